@@ -2,15 +2,78 @@ package config
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// AccessTokenKey is PEM encoded key. The first key of Config.AccessTokenKeys signs tokens,
+// the rest are only used to verify tokens signed before the rotation.
+type AccessTokenKey struct {
+	ID  string
+	PEM []byte
+}
+
+// OIDCProvider is the client registered at an OpenID Connect provider, Name identifies the provider in the API.
+type OIDCProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// RateLimit allows Limit requests per Period.
+type RateLimit struct {
+	Limit  int
+	Period time.Duration
+}
+
 type Config struct {
-	AppEnv            string
-	ServiceURL        string
-	AccessTokenSecret []byte
-	PGConnect         string
-	KafkaURL          string
+	AppEnv                       string
+	ServiceURL                   string
+	TrustedProxies               []string
+	AccessTokenKeys              []AccessTokenKey
+	ActivationCodeTTL            time.Duration
+	ActivationCodeResendInterval time.Duration
+	PasswordResetTokenTTL        time.Duration
+	MagicLinkTokenTTL            time.Duration
+	EmailChangeCodeTTL           time.Duration
+	EmailChangeUndoWindow        time.Duration
+	PasswordHashMemory           int
+	PasswordHashIterations       int
+	PasswordHashParallelism      int
+	PasswordMinLength            int
+	PasswordMinCharClasses       int
+	PasswordBreachedList         []byte
+	RegistrationMode             string
+	RegistrationAllowedDomains   []string
+	DisposableEmailDomainList    []byte
+	RefreshTokenTTL              time.Duration
+	RefreshTokenMaxLifetime      time.Duration
+	LoginAttemptStorage          string
+	LoginAccountBackoffAfter     int
+	LoginAccountLockoutAfter     int
+	LoginIPBackoffAfter          int
+	LoginIPLockoutAfter          int
+	LoginBackoffBaseDelay        time.Duration
+	LoginLockoutDuration         time.Duration
+	TwoFactorIssuer              string
+	TwoFactorChallengeTTL        time.Duration
+	OIDCProviders                []OIDCProvider
+	OIDCStateTTL                 time.Duration
+	WebAuthnRPID                 string
+	WebAuthnRPName               string
+	WebAuthnOrigin               string
+	WebAuthnChallengeTTL         time.Duration
+	RateLimitRegister            RateLimit
+	RateLimitIP                  RateLimit
+	RateLimitEmail               RateLimit
+	PGConnect                    string
+	KafkaURL                     string
 }
 
 func Parse() *Config {
@@ -21,20 +84,53 @@ func Parse() *Config {
 	pgPass := getenv("PG_USER", "postgres")
 	pgDBName := getenv("PG_DBNAME", "postgres")
 
-	jwtSecret := os.Getenv("ACCESS_TOKEN_SECRET")
-	if jwtSecret == "" {
-		if appEnv == "PROD" {
-			panic("ACCESS_TOKEN_SECRET cannot be empty on PROD")
-		}
-		jwtSecret = "secret"
+	accessTokenKeys := getenvAccessTokenKeys("ACCESS_TOKEN_KEYS")
+	if len(accessTokenKeys) == 0 && appEnv == "PROD" {
+		panic("ACCESS_TOKEN_KEYS cannot be empty on PROD")
 	}
 
 	return &Config{
-		AppEnv:            appEnv,
-		ServiceURL:        getenv("SERVICE_PORT", ":8080"),
-		AccessTokenSecret: []byte(jwtSecret),
-		PGConnect:         fmt.Sprintf("postgres://%s:%s@%s:%s/%s", pgUser, pgPass, pgHost, pgPort, pgDBName),
-		KafkaURL:          getenv("KAFKA_URL", "127.0.0.1:9092"),
+		AppEnv:                       appEnv,
+		ServiceURL:                   getenv("SERVICE_PORT", ":8080"),
+		TrustedProxies:               getenvList("TRUSTED_PROXIES"),
+		AccessTokenKeys:              accessTokenKeys,
+		ActivationCodeTTL:            getenvDuration("ACTIVATION_CODE_TTL", 24*time.Hour),
+		ActivationCodeResendInterval: getenvDuration("ACTIVATION_CODE_RESEND_INTERVAL", time.Minute),
+		PasswordResetTokenTTL:        getenvDuration("PASSWORD_RESET_TOKEN_TTL", time.Hour),
+		MagicLinkTokenTTL:            getenvDuration("MAGIC_LINK_TOKEN_TTL", 15*time.Minute),
+		EmailChangeCodeTTL:           getenvDuration("EMAIL_CHANGE_CODE_TTL", 24*time.Hour),
+		EmailChangeUndoWindow:        getenvDuration("EMAIL_CHANGE_UNDO_WINDOW", 72*time.Hour),
+		PasswordHashMemory:           getenvIntBetween("PASSWORD_HASH_MEMORY", 64*1024, 1, math.MaxUint32),
+		PasswordHashIterations:       getenvIntBetween("PASSWORD_HASH_ITERATIONS", 3, 1, math.MaxUint32),
+		PasswordHashParallelism:      getenvIntBetween("PASSWORD_HASH_PARALLELISM", 4, 1, math.MaxUint8),
+		PasswordMinLength:            getenvInt("PASSWORD_MIN_LENGTH", 10),
+		PasswordMinCharClasses:       getenvInt("PASSWORD_MIN_CHAR_CLASSES", 2),
+		PasswordBreachedList:         getenvFile("PASSWORD_BREACHED_LIST_FILE"),
+		RegistrationMode:             getenvOneOf("REGISTRATION_MODE", "open", "invite", "domain"),
+		RegistrationAllowedDomains:   getenvList("REGISTRATION_ALLOWED_DOMAINS"),
+		DisposableEmailDomainList:    getenvFile("DISPOSABLE_EMAIL_DOMAIN_LIST_FILE"),
+		RefreshTokenTTL:              getenvDuration("REFRESH_TOKEN_TTL", 7*24*time.Hour),
+		RefreshTokenMaxLifetime:      getenvDuration("REFRESH_TOKEN_MAX_LIFETIME", 30*24*time.Hour),
+		LoginAttemptStorage:          getenvOneOf("LOGIN_ATTEMPT_STORAGE", "postgres", "memory"),
+		LoginAccountBackoffAfter:     getenvInt("LOGIN_ACCOUNT_BACKOFF_AFTER", 5),
+		LoginAccountLockoutAfter:     getenvInt("LOGIN_ACCOUNT_LOCKOUT_AFTER", 10),
+		LoginIPBackoffAfter:          getenvInt("LOGIN_IP_BACKOFF_AFTER", 20),
+		LoginIPLockoutAfter:          getenvInt("LOGIN_IP_LOCKOUT_AFTER", 100),
+		LoginBackoffBaseDelay:        getenvDuration("LOGIN_BACKOFF_BASE_DELAY", time.Second),
+		LoginLockoutDuration:         getenvDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		TwoFactorIssuer:              getenv("TWO_FACTOR_ISSUER", "Blog"),
+		TwoFactorChallengeTTL:        getenvDuration("TWO_FACTOR_CHALLENGE_TTL", 5*time.Minute),
+		OIDCProviders:                getenvOIDCProviders("OIDC_PROVIDERS"),
+		OIDCStateTTL:                 getenvDuration("OIDC_STATE_TTL", 10*time.Minute),
+		WebAuthnRPID:                 getenv("WEBAUTHN_RP_ID", "localhost"),
+		WebAuthnRPName:               getenv("WEBAUTHN_RP_NAME", "Blog"),
+		WebAuthnOrigin:               getenv("WEBAUTHN_ORIGIN", "http://localhost:8080"),
+		WebAuthnChallengeTTL:         getenvDuration("WEBAUTHN_CHALLENGE_TTL", 5*time.Minute),
+		RateLimitRegister:            getenvRateLimit("RATE_LIMIT_REGISTER", RateLimit{Limit: 5, Period: time.Hour}),
+		RateLimitIP:                  getenvRateLimit("RATE_LIMIT_IP", RateLimit{Limit: 20, Period: time.Minute}),
+		RateLimitEmail:               getenvRateLimit("RATE_LIMIT_EMAIL", RateLimit{Limit: 3, Period: time.Hour}),
+		PGConnect:                    fmt.Sprintf("postgres://%s:%s@%s:%s/%s", pgUser, pgPass, pgHost, pgPort, pgDBName),
+		KafkaURL:                     getenv("KAFKA_URL", "127.0.0.1:9092"),
 	}
 }
 
@@ -45,3 +141,155 @@ func getenv(key string, defaultValue string) string {
 	}
 	return value
 }
+
+func getenvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		panic(fmt.Sprintf("%s is not a valid duration: %v", key, err))
+	}
+	return duration
+}
+
+func getenvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		panic(fmt.Sprintf("%s is not a valid integer: %v", key, err))
+	}
+	return number
+}
+
+// getenvIntBetween reads the integer which has to be in [low, high], e.g. the password hash parameters can't be zero.
+func getenvIntBetween(key string, defaultValue, low, high int) int {
+	number := getenvInt(key, defaultValue)
+	if number < low || number > high {
+		panic(fmt.Sprintf("%s must be between %d and %d", key, low, high))
+	}
+	return number
+}
+
+// getenvFile reads the file which path is set, nil is returned if the path is not set.
+func getenvFile(key string) []byte {
+	path := os.Getenv(key)
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		panic(fmt.Sprintf("%s reading error: %v", key, err))
+	}
+	return data
+}
+
+// getenvRateLimit reads the limit set as "<limit>/<period>", e.g. "5/1h".
+func getenvRateLimit(key string, defaultValue RateLimit) RateLimit {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	limit, period, ok := strings.Cut(value, "/")
+	if !ok {
+		panic(fmt.Sprintf("%s is not a valid rate limit, expected <limit>/<period>", key))
+	}
+
+	rateLimit := RateLimit{}
+	var err error
+	if rateLimit.Limit, err = strconv.Atoi(limit); err != nil || rateLimit.Limit <= 0 {
+		panic(fmt.Sprintf("%s has invalid limit %q", key, limit))
+	}
+	if rateLimit.Period, err = time.ParseDuration(period); err != nil || rateLimit.Period <= 0 {
+		panic(fmt.Sprintf("%s has invalid period %q", key, period))
+	}
+	return rateLimit
+}
+
+// getenvList reads values listed as "<value>[,<value>...]", empty values are skipped.
+func getenvList(key string) []string {
+	var values []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if value := strings.TrimSpace(item); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getenvOneOf returns the first option by default.
+func getenvOneOf(key string, options ...string) string {
+	value := getenv(key, options[0])
+	for _, option := range options {
+		if value == option {
+			return value
+		}
+	}
+	panic(fmt.Sprintf("%s must be one of %s", key, strings.Join(options, ", ")))
+}
+
+// getenvAccessTokenKeys reads keys from files listed as "<kid>=<path>[,<kid>=<path>...]".
+func getenvAccessTokenKeys(key string) []AccessTokenKey {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	var keys []AccessTokenKey
+	for _, item := range strings.Split(value, ",") {
+		id, path, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok || id == "" || path == "" {
+			panic(fmt.Sprintf("%s has invalid item %q, expected <kid>=<path>", key, item))
+		}
+
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			panic(fmt.Sprintf("%s key %q reading error: %v", key, id, err))
+		}
+
+		keys = append(keys, AccessTokenKey{ID: id, PEM: pem})
+	}
+	return keys
+}
+
+// getenvOIDCProviders reads providers listed as "<name>[,<name>...]". Each provider is configured with
+// OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET, OIDC_<NAME>_REDIRECT_URL
+// and OIDC_<NAME>_SCOPES, which are separated with spaces.
+func getenvOIDCProviders(key string) []OIDCProvider {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	var providers []OIDCProvider
+	for _, item := range strings.Split(value, ",") {
+		name := strings.TrimSpace(item)
+		if name == "" || strings.Trim(name, "abcdefghijklmnopqrstuvwxyz0123456789_") != "" {
+			panic(fmt.Sprintf("%s has invalid name %q, expected lowercase letters, digits and underscores", key, item))
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		provider := OIDCProvider{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(getenv(prefix+"SCOPES", "openid email profile")),
+		}
+		if provider.Issuer == "" || provider.ClientID == "" || provider.RedirectURL == "" {
+			panic(fmt.Sprintf("%sISSUER, %sCLIENT_ID and %sREDIRECT_URL cannot be empty", prefix, prefix, prefix))
+		}
+
+		providers = append(providers, provider)
+	}
+	return providers
+}
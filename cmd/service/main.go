@@ -3,6 +3,8 @@ package main
 import (
 	"database/sql"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/art-es/blog/internal/auth/infra/databus_kafka"
 
@@ -11,7 +13,24 @@ import (
 	"github.com/art-es/blog/internal/common/validation"
 
 	"github.com/art-es/blog/internal/auth/domain/service/activation"
+	"github.com/art-es/blog/internal/auth/domain/service/email_change"
+	"github.com/art-es/blog/internal/auth/domain/service/invite"
+	"github.com/art-es/blog/internal/auth/domain/service/login_throttle"
+	"github.com/art-es/blog/internal/auth/domain/service/magic_link"
+	"github.com/art-es/blog/internal/auth/domain/service/notification"
+	"github.com/art-es/blog/internal/auth/domain/service/oidc_state"
 	"github.com/art-es/blog/internal/auth/domain/service/password_hash"
+	"github.com/art-es/blog/internal/auth/domain/service/password_policy"
+	"github.com/art-es/blog/internal/auth/domain/service/password_reset"
+	"github.com/art-es/blog/internal/auth/domain/service/personal_access_token"
+	"github.com/art-es/blog/internal/auth/domain/service/refresh_token"
+	"github.com/art-es/blog/internal/auth/domain/service/registration_policy"
+	"github.com/art-es/blog/internal/auth/domain/service/revocation"
+	"github.com/art-es/blog/internal/auth/domain/service/session"
+	"github.com/art-es/blog/internal/auth/domain/service/two_factor"
+	"github.com/art-es/blog/internal/auth/domain/service/webauthn"
+	"github.com/art-es/blog/internal/auth/infra/oidc_client"
+	"github.com/art-es/blog/internal/auth/infra/repository_memory"
 	"github.com/art-es/blog/internal/auth/infra/repository_pg"
 
 	"github.com/art-es/blog/internal/auth/api/endpoint/v1_user_register"
@@ -20,10 +39,52 @@ import (
 
 	"github.com/art-es/blog/cmd/service/config"
 	"github.com/art-es/blog/internal/auth/api/endpoint/v1_access_token_refresh"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_admin_user_activate"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_admin_user_get"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_admin_user_list"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_admin_user_sessions_reset"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_admin_user_suspend"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_admin_user_unban"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_audit_event_list"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_email_change"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_email_change_confirm"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_email_change_undo"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_invite_create"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_magic_link_consume"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_magic_link_send"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_oidc_authenticate"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_oidc_authorize"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_password_change"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_password_forgot"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_password_reset"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_personal_access_token_create"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_personal_access_token_list"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_personal_access_token_revoke"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_public_profile_get"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_session_list"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_session_revoke"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_two_factor_confirm"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_two_factor_disable"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_two_factor_enroll"
 	"github.com/art-es/blog/internal/auth/api/endpoint/v1_user_activate"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_user_activation_resend"
 	"github.com/art-es/blog/internal/auth/api/endpoint/v1_user_authenticate"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_user_authenticate_two_factor"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_user_logout"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_user_logout_everywhere"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_user_profile_get"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_user_profile_update"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_webauthn_login_begin"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_webauthn_login_finish"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_webauthn_registration_begin"
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_webauthn_registration_finish"
+	"github.com/art-es/blog/internal/auth/api/endpoint/well_known_jwks"
+	"github.com/art-es/blog/internal/auth/api/middleware/authenticated"
+	"github.com/art-es/blog/internal/auth/api/middleware/parse_token"
+	"github.com/art-es/blog/internal/auth/api/middleware/require_permission"
 	auth "github.com/art-es/blog/internal/auth/domain"
 	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/api/rate_limit"
 	"github.com/art-es/blog/internal/common/log"
 )
 
@@ -40,43 +101,530 @@ func run() error {
 		return fmt.Errorf("open database error: %w", err)
 	}
 
-	router := gin.Default()
-	bindAuthEndpoints(router, conf, logger, db)
+	accessTokenService, err := newAccessTokenService(conf)
+	if err != nil {
+		return fmt.Errorf("create access token service error: %w", err)
+	}
+
+	passwordPolicyService, err := password_policy.New(password_policy.Policy{
+		MinLength:      conf.PasswordMinLength,
+		MinCharClasses: conf.PasswordMinCharClasses,
+	}, conf.PasswordBreachedList)
+	if err != nil {
+		return fmt.Errorf("create password policy service error: %w", err)
+	}
+
+	registrationPolicyService, err := registration_policy.New(registration_policy.Policy{
+		Mode:           conf.RegistrationMode,
+		AllowedDomains: conf.RegistrationAllowedDomains,
+	}, conf.DisposableEmailDomainList)
+	if err != nil {
+		return fmt.Errorf("create registration policy service error: %w", err)
+	}
+
+	router, err := api.NewRouter(conf.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("create router error: %w", err)
+	}
+	bindAuthEndpoints(router, conf, logger, db, accessTokenService, passwordPolicyService, registrationPolicyService)
 
 	err = router.Run(conf.ServiceURL)
 	return fmt.Errorf("running router error: %w", err)
 }
 
-func bindAuthEndpoints(router *gin.Engine, conf *config.Config, logger log.Logger, db *sql.DB) {
+func bindAuthEndpoints(
+	router *gin.Engine,
+	conf *config.Config,
+	logger log.Logger,
+	db *sql.DB,
+	accessTokenService *access_token.Service,
+	passwordPolicyService *password_policy.Service,
+	registrationPolicyService *registration_policy.Service,
+) {
 	repository := repository_pg.New(db)
-	passwordHashService := password_hash.New()
-	activationService := activation.New(logger, databus_kafka.New(conf.KafkaURL))
-	accessTokenService := access_token.New(conf.AccessTokenSecret)
+	passwordHashService := password_hash.New(password_hash.Params{
+		Memory:      uint32(conf.PasswordHashMemory),
+		Iterations:  uint32(conf.PasswordHashIterations),
+		Parallelism: uint8(conf.PasswordHashParallelism),
+	})
+	databus := databus_kafka.New(conf.KafkaURL)
+	activationService := activation.New(logger, databus, conf.ActivationCodeTTL, conf.ActivationCodeResendInterval)
+	passwordResetService := password_reset.New(logger, databus, conf.PasswordResetTokenTTL)
+	magicLinkService := magic_link.New(logger, databus, conf.MagicLinkTokenTTL)
+	emailChangeService := email_change.New(logger, databus, conf.EmailChangeCodeTTL, conf.EmailChangeUndoWindow)
+	notificationService := notification.New(logger, databus)
+	refreshTokenService := refresh_token.New(conf.RefreshTokenTTL)
+	// a session lasts as long as its refresh token family is allowed to
+	sessionService := session.New(conf.RefreshTokenMaxLifetime)
+	revocationService := revocation.New(access_token.Lifetime, access_token.Leeway)
+	loginThrottleService := login_throttle.New(
+		newLoginAttemptRepository(conf, repository),
+		login_throttle.Policy{BackoffAfter: conf.LoginAccountBackoffAfter, LockoutAfter: conf.LoginAccountLockoutAfter},
+		login_throttle.Policy{BackoffAfter: conf.LoginIPBackoffAfter, LockoutAfter: conf.LoginIPLockoutAfter},
+		conf.LoginBackoffBaseDelay,
+		conf.LoginLockoutDuration,
+	)
+	twoFactorService := two_factor.New(conf.TwoFactorIssuer, conf.TwoFactorChallengeTTL)
+	oidcStateService := oidc_state.New(conf.OIDCStateTTL)
+	webAuthnService := webauthn.New(conf.WebAuthnRPID, conf.WebAuthnRPName, conf.WebAuthnOrigin, conf.WebAuthnChallengeTTL)
+	personalAccessTokenService := personal_access_token.New()
+	inviteService := invite.New()
+	oidcClient := newOIDCClient(conf)
 
 	validator := validation.NewValidator()
 	serverErrorHandlerFactory := api.NewServerErrorHandlerFactory(logger)
 
+	parseTokenMiddleware := parse_token.New(auth.NewAccessTokenParseCase(
+		repository,
+		accessTokenService,
+		revocationService,
+		personalAccessTokenService,
+	))
+	authenticatedMiddleware := authenticated.New()
+
+	// the limits are kept in memory, so each instance counts its own requests
+	rateLimitStore := rate_limit.NewMemoryStore()
+	registerRateLimit := rate_limit.New(
+		"register",
+		rate_limit.NewSlidingWindow(conf.RateLimitRegister.Limit, conf.RateLimitRegister.Period),
+		rate_limit.ByIP,
+		rateLimitStore,
+		logger,
+	)
+	// the IP limit is shared by the routes and allows short bursts, e.g. signing in with two-factor authentication
+	ipRateLimit := rate_limit.New(
+		"ip",
+		rate_limit.NewTokenBucket(conf.RateLimitIP.Limit, conf.RateLimitIP.Period/time.Duration(conf.RateLimitIP.Limit)),
+		rate_limit.ByIP,
+		rateLimitStore,
+		logger,
+	)
+	// the email limit keeps the mailboxes from being flooded with the emails of the service
+	emailRateLimit := rate_limit.New(
+		"email",
+		rate_limit.NewSlidingWindow(conf.RateLimitEmail.Limit, conf.RateLimitEmail.Period),
+		rate_limit.ByJSONField("email", auth.NormalizeEmail),
+		rateLimitStore,
+		logger,
+	)
+
 	v1_user_register.Bind(
 		router,
-		auth.NewUserRegisterCase(repository, passwordHashService, activationService),
+		auth.NewUserRegisterCase(
+			repository,
+			passwordPolicyService,
+			passwordHashService,
+			activationService,
+			registrationPolicyService,
+			inviteService,
+		),
 		validator,
 		serverErrorHandlerFactory,
+		registerRateLimit.Handle,
 	)
 	v1_user_activate.Bind(
 		router,
 		auth.NewUserActivateCase(repository, activationService),
 		validator,
 		serverErrorHandlerFactory,
+		ipRateLimit.Handle,
+	)
+	v1_user_activation_resend.Bind(
+		router,
+		auth.NewUserActivationResendCase(repository, activationService),
+		validator,
+		serverErrorHandlerFactory,
+		ipRateLimit.Handle,
+		emailRateLimit.Handle,
 	)
 	v1_user_authenticate.Bind(
 		router,
-		auth.NewUserAuthenticateCase(repository.User(), passwordHashService, accessTokenService),
+		auth.NewUserAuthenticateCase(
+			repository,
+			passwordHashService,
+			passwordHashService,
+			accessTokenService,
+			refreshTokenService,
+			loginThrottleService,
+			twoFactorService,
+			sessionService,
+		),
+		validator,
+		serverErrorHandlerFactory,
+		ipRateLimit.Handle,
+	)
+	v1_user_authenticate_two_factor.Bind(
+		router,
+		auth.NewUserAuthenticateTwoFactorCase(
+			repository,
+			twoFactorService,
+			accessTokenService,
+			refreshTokenService,
+			loginThrottleService,
+			sessionService,
+		),
+		validator,
+		serverErrorHandlerFactory,
+		ipRateLimit.Handle,
+	)
+	v1_oidc_authorize.Bind(
+		router,
+		auth.NewOIDCAuthorizeCase(repository, oidcStateService, oidcClient),
+		serverErrorHandlerFactory,
+	)
+	v1_oidc_authenticate.Bind(
+		router,
+		auth.NewOIDCAuthenticateCase(
+			repository,
+			oidcStateService,
+			oidcClient,
+			twoFactorService,
+			accessTokenService,
+			refreshTokenService,
+			sessionService,
+			registrationPolicyService,
+		),
+		validator,
+		serverErrorHandlerFactory,
+	)
+	v1_two_factor_enroll.Bind(
+		router,
+		auth.NewTwoFactorEnrollCase(repository, twoFactorService),
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		authenticatedMiddleware.Handle,
+	)
+	v1_two_factor_confirm.Bind(
+		router,
+		auth.NewTwoFactorConfirmCase(repository, twoFactorService),
 		validator,
 		serverErrorHandlerFactory,
+		ipRateLimit.Handle,
+		parseTokenMiddleware.Handle,
+		authenticatedMiddleware.Handle,
+	)
+	v1_two_factor_disable.Bind(
+		router,
+		auth.NewTwoFactorDisableCase(repository, twoFactorService),
+		validator,
+		serverErrorHandlerFactory,
+		ipRateLimit.Handle,
+		parseTokenMiddleware.Handle,
+		authenticatedMiddleware.Handle,
+	)
+	v1_webauthn_registration_begin.Bind(
+		router,
+		auth.NewWebAuthnRegistrationBeginCase(repository, webAuthnService),
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		authenticatedMiddleware.Handle,
+	)
+	v1_webauthn_registration_finish.Bind(
+		router,
+		auth.NewWebAuthnRegistrationFinishCase(repository, webAuthnService),
+		validator,
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		authenticatedMiddleware.Handle,
+	)
+	v1_webauthn_login_begin.Bind(
+		router,
+		auth.NewWebAuthnLoginBeginCase(repository, webAuthnService),
+		serverErrorHandlerFactory,
+		ipRateLimit.Handle,
+	)
+	v1_webauthn_login_finish.Bind(
+		router,
+		auth.NewWebAuthnLoginFinishCase(
+			repository,
+			webAuthnService,
+			twoFactorService,
+			accessTokenService,
+			refreshTokenService,
+			sessionService,
+		),
+		validator,
+		serverErrorHandlerFactory,
+		ipRateLimit.Handle,
+	)
+	v1_magic_link_send.Bind(
+		router,
+		auth.NewMagicLinkSendCase(repository, magicLinkService),
+		validator,
+		serverErrorHandlerFactory,
+		ipRateLimit.Handle,
+		emailRateLimit.Handle,
+	)
+	v1_magic_link_consume.Bind(
+		router,
+		auth.NewMagicLinkConsumeCase(
+			repository,
+			magicLinkService,
+			twoFactorService,
+			accessTokenService,
+			refreshTokenService,
+			sessionService,
+		),
+		validator,
+		serverErrorHandlerFactory,
+		ipRateLimit.Handle,
+	)
+	v1_password_forgot.Bind(
+		router,
+		auth.NewPasswordForgotCase(repository, passwordResetService),
+		validator,
+		serverErrorHandlerFactory,
+		ipRateLimit.Handle,
+		emailRateLimit.Handle,
+	)
+	v1_password_reset.Bind(
+		router,
+		auth.NewPasswordResetCase(
+			repository,
+			passwordResetService,
+			passwordPolicyService,
+			passwordHashService,
+			revocationService,
+		),
+		validator,
+		serverErrorHandlerFactory,
+		ipRateLimit.Handle,
+	)
+	v1_password_change.Bind(
+		router,
+		auth.NewPasswordChangeCase(
+			repository,
+			passwordHashService,
+			loginThrottleService,
+			passwordPolicyService,
+			passwordHashService,
+			revocationService,
+			accessTokenService,
+			refreshTokenService,
+			notificationService,
+			sessionService,
+		),
+		validator,
+		serverErrorHandlerFactory,
+		ipRateLimit.Handle,
+		parseTokenMiddleware.Handle,
+		authenticatedMiddleware.Handle,
 	)
 	v1_access_token_refresh.Bind(
 		router,
-		auth.NewAccessTokenRefreshCase(repository.User(), accessTokenService),
+		auth.NewAccessTokenRefreshCase(repository, refreshTokenService, accessTokenService),
+		validator,
+		serverErrorHandlerFactory,
+	)
+	v1_user_logout.Bind(
+		router,
+		auth.NewUserLogoutCase(repository, accessTokenService, revocationService, revocationService, refreshTokenService),
+		validator,
+		serverErrorHandlerFactory,
+	)
+	v1_user_logout_everywhere.Bind(
+		router,
+		auth.NewUserLogoutEverywhereCase(repository, accessTokenService, revocationService, revocationService),
+		serverErrorHandlerFactory,
+	)
+	v1_session_list.Bind(
+		router,
+		auth.NewSessionListCase(repository),
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		authenticatedMiddleware.Handle,
+	)
+	v1_session_revoke.Bind(
+		router,
+		auth.NewSessionRevokeCase(repository, sessionService),
+		validator,
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		authenticatedMiddleware.Handle,
+	)
+	v1_personal_access_token_create.Bind(
+		router,
+		auth.NewPersonalAccessTokenCreateCase(repository, personalAccessTokenService),
+		validator,
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		authenticatedMiddleware.Handle,
+	)
+	v1_personal_access_token_list.Bind(
+		router,
+		auth.NewPersonalAccessTokenListCase(repository),
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		authenticatedMiddleware.Handle,
+	)
+	v1_personal_access_token_revoke.Bind(
+		router,
+		auth.NewPersonalAccessTokenRevokeCase(repository),
+		validator,
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		authenticatedMiddleware.Handle,
+	)
+	v1_user_profile_get.Bind(
+		router,
+		auth.NewUserProfileGetCase(repository),
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		authenticatedMiddleware.Handle,
+	)
+	v1_user_profile_update.Bind(
+		router,
+		auth.NewUserProfileUpdateCase(repository),
+		validator,
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		authenticatedMiddleware.Handle,
+	)
+	v1_email_change.Bind(
+		router,
+		auth.NewEmailChangeCase(repository, emailChangeService),
+		validator,
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		authenticatedMiddleware.Handle,
+	)
+	v1_email_change_confirm.Bind(
+		router,
+		auth.NewEmailChangeConfirmCase(repository, emailChangeService),
+		validator,
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		authenticatedMiddleware.Handle,
+	)
+	v1_email_change_undo.Bind(
+		router,
+		auth.NewEmailChangeUndoCase(repository, emailChangeService, revocationService),
+		validator,
+		serverErrorHandlerFactory,
+	)
+	v1_public_profile_get.Bind(
+		router,
+		auth.NewPublicProfileGetCase(repository),
+		validator,
 		serverErrorHandlerFactory,
 	)
+	v1_audit_event_list.Bind(
+		router,
+		auth.NewAuditEventListCase(repository),
+		validator,
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		require_permission.New(auth.PermissionAuditRead).Handle,
+	)
+	v1_admin_user_list.Bind(
+		router,
+		auth.NewAdminUserListCase(repository),
+		validator,
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		require_permission.New(auth.PermissionUserManage).Handle,
+	)
+	v1_admin_user_get.Bind(
+		router,
+		auth.NewAdminUserGetCase(repository),
+		validator,
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		require_permission.New(auth.PermissionUserManage).Handle,
+	)
+	v1_admin_user_activate.Bind(
+		router,
+		auth.NewAdminUserActivateCase(repository),
+		validator,
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		require_permission.New(auth.PermissionUserManage).Handle,
+	)
+	v1_admin_user_suspend.Bind(
+		router,
+		auth.NewAdminUserSuspendCase(repository, revocationService),
+		validator,
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		require_permission.New(auth.PermissionUserManage).Handle,
+	)
+	v1_admin_user_unban.Bind(
+		router,
+		auth.NewAdminUserUnbanCase(repository),
+		validator,
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		require_permission.New(auth.PermissionUserManage).Handle,
+	)
+	v1_admin_user_sessions_reset.Bind(
+		router,
+		auth.NewAdminUserSessionsResetCase(repository, revocationService),
+		validator,
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		require_permission.New(auth.PermissionUserManage).Handle,
+	)
+	v1_invite_create.Bind(
+		router,
+		auth.NewInviteCreateCase(repository, inviteService),
+		validator,
+		serverErrorHandlerFactory,
+		parseTokenMiddleware.Handle,
+		require_permission.New(auth.PermissionUserManage).Handle,
+	)
+	well_known_jwks.Bind(
+		router,
+		auth.NewJSONWebKeySetGetCase(accessTokenService),
+	)
+}
+
+// newLoginAttemptRepository keeps the attempts in memory only on demand,
+// since they are not shared between instances then.
+func newLoginAttemptRepository(conf *config.Config, repository *repository_pg.Repository) auth.LoginAttemptRepository {
+	if conf.LoginAttemptStorage == "memory" {
+		return repository_memory.NewLoginAttemptRepository()
+	}
+	return repository.LoginAttempt()
+}
+
+func newOIDCClient(conf *config.Config) *oidc_client.Client {
+	configs := make([]oidc_client.ProviderConfig, 0, len(conf.OIDCProviders))
+	for _, p := range conf.OIDCProviders {
+		configs = append(configs, oidc_client.ProviderConfig{
+			Name:         p.Name,
+			Issuer:       p.Issuer,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			RedirectURL:  p.RedirectURL,
+			Scopes:       p.Scopes,
+		})
+	}
+	return oidc_client.New(&http.Client{Timeout: 10 * time.Second}, configs...)
+}
+
+// newAccessTokenService signs tokens with the first configured key.
+// A random key is generated when no keys are configured, so tokens don't survive restarts.
+func newAccessTokenService(conf *config.Config) (*access_token.Service, error) {
+	if len(conf.AccessTokenKeys) == 0 {
+		key, err := access_token.GenerateKey("local")
+		if err != nil {
+			return nil, err
+		}
+		return access_token.New(key), nil
+	}
+
+	keys := make([]*access_token.Key, 0, len(conf.AccessTokenKeys))
+	for _, k := range conf.AccessTokenKeys {
+		key, err := access_token.NewKey(k.ID, k.PEM)
+		if err != nil {
+			return nil, fmt.Errorf("key %q parsing error: %w", k.ID, err)
+		}
+		keys = append(keys, key)
+	}
+
+	if !keys[0].CanSign() {
+		return nil, fmt.Errorf("signing key %q has no private part", keys[0].ID())
+	}
+
+	return access_token.New(keys[0], keys[1:]...), nil
 }
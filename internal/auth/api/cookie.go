@@ -0,0 +1,126 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/common/api"
+)
+
+// The session cookies let browsers keep the tokens out of reach of JavaScript.
+// Since the browsers send the cookies along with the cross-site requests,
+// the requests of unsafe methods have to repeat the value of the CSRF cookie in the header.
+const (
+	accessTokenCookie  = "access_token"
+	refreshTokenCookie = "refresh_token"
+	csrfTokenCookie    = "csrf_token"
+	csrfTokenHeader    = "X-CSRF-Token"
+
+	// refreshTokenCookiePath covers the endpoints of refreshing and logging out only.
+	refreshTokenCookiePath = "/v1/auth"
+	csrfTokenSize          = 32
+)
+
+// The OIDC state cookie binds the sign-in at the identity provider to the browser, which has started it,
+// so the response of the provider can't be used to sign another browser in.
+const (
+	oidcStateCookie     = "oidc_state"
+	oidcStateCookiePath = "/v1/auth/oidc"
+)
+
+// SetSessionCookies puts the tokens into the HttpOnly cookies and issues a new CSRF token.
+func SetSessionCookies(ctx *gin.Context, accessToken, refreshToken string) error {
+	csrfToken := make([]byte, csrfTokenSize)
+	if _, err := rand.Read(csrfToken); err != nil {
+		return fmt.Errorf("csrf token generation error: %w", err)
+	}
+
+	setCookie(ctx, accessTokenCookie, accessToken, "/", true, http.SameSiteLaxMode)
+	setCookie(ctx, refreshTokenCookie, refreshToken, refreshTokenCookiePath, true, http.SameSiteStrictMode)
+	// the frontend reads the token to send it in the header
+	setCookie(ctx, csrfTokenCookie, base64.RawURLEncoding.EncodeToString(csrfToken), "/", false, http.SameSiteLaxMode)
+	return nil
+}
+
+func ClearSessionCookies(ctx *gin.Context) {
+	for _, c := range []struct{ name, path string }{
+		{accessTokenCookie, "/"},
+		{refreshTokenCookie, refreshTokenCookiePath},
+		{csrfTokenCookie, "/"},
+	} {
+		http.SetCookie(ctx.Writer, &http.Cookie{Name: c.name, Path: c.path, MaxAge: -1, Secure: true})
+	}
+}
+
+// setCookie sets the cookie living until the browser is closed.
+func setCookie(ctx *gin.Context, name, value, path string, httpOnly bool, sameSite http.SameSite) {
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		HttpOnly: httpOnly,
+		Secure:   true,
+		SameSite: sameSite,
+	})
+}
+
+// SetOIDCStateCookie keeps the state of the authorization request until the state expires.
+func SetOIDCStateCookie(ctx *gin.Context, state string, expiresAt time.Time) {
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     oidcStateCookiePath,
+		Expires:  expiresAt,
+		HttpOnly: true,
+		Secure:   true,
+		// the cookie is sent when the provider redirects back to the site
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// OIDCStateValid reports whether the state returned by the provider is issued to the browser of the request.
+func OIDCStateValid(ctx *gin.Context, state string) bool {
+	expected := cookie(ctx, oidcStateCookie)
+	return expected != "" && subtle.ConstantTimeCompare([]byte(expected), []byte(state)) == 1
+}
+
+// AccessToken returns the access token of the request and whether it's taken from the cookie,
+// the headers take precedence over the cookie.
+func AccessToken(ctx *gin.Context) (string, bool) {
+	if token := api.AccessTokenHeader(ctx); token != "" {
+		return token, false
+	}
+	token := cookie(ctx, accessTokenCookie)
+	return token, token != ""
+}
+
+func RefreshTokenCookie(ctx *gin.Context) string {
+	return cookie(ctx, refreshTokenCookie)
+}
+
+func cookie(ctx *gin.Context, name string) string {
+	value, err := ctx.Cookie(name)
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+// CSRFTokenValid reports whether the request may be authenticated by the session cookies,
+// the requests of unsafe methods must have the header matching the CSRF cookie.
+func CSRFTokenValid(ctx *gin.Context) bool {
+	switch ctx.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	expected := cookie(ctx, csrfTokenCookie)
+	actual := ctx.GetHeader(csrfTokenHeader)
+	return expected != "" && subtle.ConstantTimeCompare([]byte(expected), []byte(actual)) == 1
+}
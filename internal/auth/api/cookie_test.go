@@ -0,0 +1,167 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+)
+
+func TestSetSessionCookies(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+
+	err := auth_api.SetSessionCookies(ctx, "dummyAccessToken", "dummyRefreshToken")
+	assert.NoError(t, err)
+
+	cookies := make(map[string]*http.Cookie)
+	for _, c := range w.Result().Cookies() {
+		cookies[c.Name] = c
+	}
+
+	accessToken := cookies["access_token"]
+	assert.Equal(t, "dummyAccessToken", accessToken.Value)
+	assert.Equal(t, "/", accessToken.Path)
+	assert.True(t, accessToken.HttpOnly)
+	assert.True(t, accessToken.Secure)
+	assert.Equal(t, http.SameSiteLaxMode, accessToken.SameSite)
+
+	refreshToken := cookies["refresh_token"]
+	assert.Equal(t, "dummyRefreshToken", refreshToken.Value)
+	assert.Equal(t, "/v1/auth", refreshToken.Path)
+	assert.True(t, refreshToken.HttpOnly)
+	assert.True(t, refreshToken.Secure)
+	assert.Equal(t, http.SameSiteStrictMode, refreshToken.SameSite)
+
+	csrfToken := cookies["csrf_token"]
+	assert.Len(t, csrfToken.Value, 43)
+	assert.False(t, csrfToken.HttpOnly)
+	assert.True(t, csrfToken.Secure)
+}
+
+func TestClearSessionCookies(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+
+	auth_api.ClearSessionCookies(ctx)
+
+	cookies := w.Result().Cookies()
+	assert.Len(t, cookies, 3)
+	for _, c := range cookies {
+		assert.Equal(t, "", c.Value)
+		assert.Equal(t, -1, c.MaxAge)
+	}
+}
+
+func TestAccessToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		header        string
+		cookie        string
+		expToken      string
+		expFromCookie bool
+	}{
+		{name: "header", header: "foo", expToken: "foo"},
+		{name: "cookie", cookie: "bar", expToken: "bar", expFromCookie: true},
+		{name: "header takes precedence", header: "foo", cookie: "bar", expToken: "foo"},
+		{name: "no token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				ctx.Request.Header.Set("X-Access-Token", tt.header)
+			}
+			if tt.cookie != "" {
+				ctx.Request.AddCookie(&http.Cookie{Name: "access_token", Value: tt.cookie})
+			}
+
+			token, fromCookie := auth_api.AccessToken(ctx)
+
+			assert.Equal(t, tt.expToken, token)
+			assert.Equal(t, tt.expFromCookie, fromCookie)
+		})
+	}
+}
+
+func TestCSRFTokenValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		cookie string
+		header string
+		expRes bool
+	}{
+		{name: "safe method", method: http.MethodGet, expRes: true},
+		{name: "matching token", method: http.MethodPost, cookie: "foo", header: "foo", expRes: true},
+		{name: "mismatching token", method: http.MethodDelete, cookie: "foo", header: "bar", expRes: false},
+		{name: "no header", method: http.MethodPatch, cookie: "foo", expRes: false},
+		{name: "no cookie", method: http.MethodPost, header: "foo", expRes: false},
+		{name: "no tokens", method: http.MethodPost, expRes: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest(tt.method, "/", nil)
+			if tt.cookie != "" {
+				ctx.Request.AddCookie(&http.Cookie{Name: "csrf_token", Value: tt.cookie})
+			}
+			if tt.header != "" {
+				ctx.Request.Header.Set("X-CSRF-Token", tt.header)
+			}
+
+			assert.Equal(t, tt.expRes, auth_api.CSRFTokenValid(ctx))
+		})
+	}
+}
+
+func TestSetOIDCStateCookie(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	expiresAt := time.Now().Add(10 * time.Minute)
+
+	auth_api.SetOIDCStateCookie(ctx, "dummyState", expiresAt)
+
+	cookies := w.Result().Cookies()
+	assert.Len(t, cookies, 1)
+
+	state := cookies[0]
+	assert.Equal(t, "oidc_state", state.Name)
+	assert.Equal(t, "dummyState", state.Value)
+	assert.Equal(t, "/v1/auth/oidc", state.Path)
+	assert.WithinDuration(t, expiresAt, state.Expires, time.Second)
+	assert.True(t, state.HttpOnly)
+	assert.True(t, state.Secure)
+	assert.Equal(t, http.SameSiteLaxMode, state.SameSite)
+}
+
+func TestOIDCStateValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		cookie string
+		state  string
+		expRes bool
+	}{
+		{name: "matching state", cookie: "foo", state: "foo", expRes: true},
+		{name: "mismatching state", cookie: "foo", state: "bar", expRes: false},
+		{name: "no cookie", state: "foo", expRes: false},
+		{name: "no states", expRes: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.cookie != "" {
+				ctx.Request.AddCookie(&http.Cookie{Name: "oidc_state", Value: tt.cookie})
+			}
+
+			assert.Equal(t, tt.expRes, auth_api.OIDCStateValid(ctx, tt.state))
+		})
+	}
+}
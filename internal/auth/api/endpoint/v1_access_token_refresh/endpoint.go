@@ -9,6 +9,7 @@ import (
 
 	"github.com/art-es/blog/internal/auth/dto"
 	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
 )
 
 const (
@@ -23,10 +24,12 @@ type accessTokenRefreshCase interface {
 func Bind(
 	router *gin.Engine,
 	accessTokenRefreshCase accessTokenRefreshCase,
+	validator validation.Validator,
 	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
 ) {
 	h := handler{
 		accessTokenRefreshCase: accessTokenRefreshCase,
+		validator:              validator,
 		serverErrorHandler:     serverErrorHandlerFactory.MakeHandler(method, path),
 	}
 
@@ -1,7 +1,10 @@
 package v1_access_token_refresh
 
 import (
+	"bytes"
 	"errors"
+	"io"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
@@ -22,19 +25,26 @@ func TestEndpoint(t *testing.T) {
 
 	var (
 		accessTokenRefreshCase    = mock.NewMockaccessTokenRefreshCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
 		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
 		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
 
-		smellyAccessToken = "smelly access token"
-		freshAccessToken  = "fresh access token"
-		noError           = (error)(nil)
-		dummyError        = errors.New("dummy error")
+		smellyRefreshToken = "smelly refresh token"
+		freshRefreshToken  = "fresh refresh token"
+		freshAccessToken   = "fresh access token"
+		noError            = (error)(nil)
+		dummyError         = errors.New("dummy error")
 
+		expectedRequestInValidator = &request{
+			RefreshToken: smellyRefreshToken,
+		}
 		expectedAccessTokenRefreshIn = &dto.AccessTokenRefreshIn{
-			AccessToken: smellyAccessToken,
+			RefreshToken: smellyRefreshToken,
+			IP:           "192.0.2.1",
 		}
 		validAccessTokenRefreshOut = &dto.AccessTokenRefreshOut{
-			AccessToken: freshAccessToken,
+			AccessToken:  freshAccessToken,
+			RefreshToken: freshRefreshToken,
 		}
 		noAccessTokenRefreshOut = (*dto.AccessTokenRefreshOut)(nil)
 	)
@@ -45,34 +55,150 @@ func TestEndpoint(t *testing.T) {
 		AnyTimes()
 
 	tests := []struct {
-		name    string
-		setup   func()
-		expCode int
-		expBody string
+		name       string
+		reqBody    string
+		cookies    []*http.Cookie
+		csrfToken  string
+		setup      func()
+		expCode    int
+		expBody    string
+		expCookies []string
 	}{
 		{
 			name: "OK",
 			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
 				accessTokenRefreshCase.EXPECT().
 					Use(gomock.Any(), gomock.Eq(expectedAccessTokenRefreshIn)).
 					Return(validAccessTokenRefreshOut, noError)
 			},
 			expCode: 200,
-			expBody: `{"accessToken":"fresh access token"}`,
+			expBody: `{"accessToken":"fresh access token","refreshToken":"fresh refresh token"}`,
+		},
+		{
+			name:    "OK: cookies",
+			reqBody: `{}`,
+			cookies: []*http.Cookie{
+				{Name: "refresh_token", Value: smellyRefreshToken},
+				{Name: "csrf_token", Value: "dummyCSRFToken"},
+			},
+			csrfToken: "dummyCSRFToken",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(&request{})).
+					Return(noError)
+
+				accessTokenRefreshCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedAccessTokenRefreshIn)).
+					Return(validAccessTokenRefreshOut, noError)
+			},
+			expCode:    200,
+			expBody:    `{}`,
+			expCookies: []string{"access_token", "refresh_token", "csrf_token"},
+		},
+		{
+			name:    "Unauthorized: no refresh token",
+			reqBody: `{}`,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(&request{})).
+					Return(noError)
+			},
+			expCode: 401,
+			expBody: `{"message":"Please try to sign in again."}`,
+		},
+		{
+			name:    "Forbidden: CSRF token mismatch",
+			reqBody: `{}`,
+			cookies: []*http.Cookie{
+				{Name: "refresh_token", Value: smellyRefreshToken},
+				{Name: "csrf_token", Value: "dummyCSRFToken"},
+			},
+			csrfToken: "anotherCSRFToken",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(&request{})).
+					Return(noError)
+			},
+			expCode: 403,
+			expBody: `{"error":{"code":2023,"name":"CSRF token mismatch"},"message":"Please reload the page and try again."}`,
+		},
+		{
+			name: "Bad request: request validation failed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name: "Unauthorized: invalid refresh token",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				accessTokenRefreshCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedAccessTokenRefreshIn)).
+					Return(noAccessTokenRefreshOut, dto.ErrInvalidRefreshToken)
+			},
+			expCode: 401,
+			expBody: `{"message":"Please try to sign in again."}`,
+		},
+		{
+			name: "Unauthorized: reused refresh token",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				accessTokenRefreshCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedAccessTokenRefreshIn)).
+					Return(noAccessTokenRefreshOut, dto.ErrReusedRefreshToken)
+			},
+			expCode: 401,
+			expBody: `{"message":"Please try to sign in again."}`,
 		},
 		{
-			name: "Unauthorized: invalid access token",
+			name: "Unauthorized: user not found",
 			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
 				accessTokenRefreshCase.EXPECT().
 					Use(gomock.Any(), gomock.Eq(expectedAccessTokenRefreshIn)).
-					Return(noAccessTokenRefreshOut, dto.ErrInvalidAccessToken)
+					Return(noAccessTokenRefreshOut, dto.ErrUserNotFound)
 			},
 			expCode: 401,
 			expBody: `{"message":"Please try to sign in again."}`,
 		},
+		{
+			name: "Forbidden: user suspended",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				accessTokenRefreshCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedAccessTokenRefreshIn)).
+					Return(noAccessTokenRefreshOut, dto.ErrUserSuspended)
+			},
+			expCode: 403,
+			expBody: `{"error":{"code":2028,"name":"User suspended"},"message":"Your account is suspended."}`,
+		},
 		{
 			name: "Internal server error: unexpected error in use case",
 			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
 				accessTokenRefreshCase.EXPECT().
 					Use(gomock.Any(), gomock.Eq(expectedAccessTokenRefreshIn)).
 					Return(noAccessTokenRefreshOut, dummyError)
@@ -92,17 +218,35 @@ func TestEndpoint(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
 
+			rBody := `{"refreshToken":"smelly refresh token"}`
+			if tt.reqBody != "" {
+				rBody = tt.reqBody
+			}
+			r := httptest.NewRequest(method, path, io.NopCloser(bytes.NewBufferString(rBody)))
+			for _, c := range tt.cookies {
+				r.AddCookie(c)
+			}
+			if tt.csrfToken != "" {
+				r.Header.Set("X-CSRF-Token", tt.csrfToken)
+			}
 			w := httptest.NewRecorder()
-			r := httptest.NewRequest(method, path, nil)
-			r.Header.Set("X-Access-Token", "smelly access token")
 
 			router := gin.New()
-			Bind(router, accessTokenRefreshCase, serverErrorHandlerFactory)
+			Bind(router, accessTokenRefreshCase, validator, serverErrorHandlerFactory)
 
 			router.ServeHTTP(w, r)
 
 			assert.Equal(t, tt.expCode, w.Code)
 			assert.JSONEq(t, tt.expBody, w.Body.String())
+			assert.Equal(t, tt.expCookies, cookieNames(w))
 		})
 	}
 }
+
+func cookieNames(w *httptest.ResponseRecorder) []string {
+	var names []string
+	for _, c := range w.Result().Cookies() {
+		names = append(names, c.Name)
+	}
+	return names
+}
@@ -1,57 +1,106 @@
 package v1_access_token_refresh
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
+	auth_api "github.com/art-es/blog/internal/auth/api"
 	"github.com/art-es/blog/internal/auth/dto"
 	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
 )
 
+// request without refreshToken is served by the session cookies.
+type request struct {
+	RefreshToken string `json:"refreshToken" validate:"lte=255"`
+}
+
 type response struct {
-	AccessToken string `json:"accessToken,omitempty"`
+	AccessToken  string `json:"accessToken,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
 }
 
 type handler struct {
 	accessTokenRefreshCase accessTokenRefreshCase
+	validator              validation.Validator
 	serverErrorHandler     api.ServerErrorHandler
 }
 
 func (h *handler) handle(ctx *gin.Context) {
-	smellyAccessToken := ctx.GetHeader("X-Access-Token")
-	if smellyAccessToken == "" {
-		api.UnauthorizedResponse(ctx)
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
 		return
 	}
 
-	freshAccessToken, err := h.useCase(ctx, smellyAccessToken)
+	useCookies := req.RefreshToken == ""
+	if useCookies {
+		if req.RefreshToken = auth_api.RefreshTokenCookie(ctx); req.RefreshToken == "" {
+			api.UnauthorizedResponse(ctx)
+			return
+		}
+		if !auth_api.CSRFTokenValid(ctx) {
+			auth_api.CSRFTokenMismatchResponse(ctx)
+			return
+		}
+	}
+
+	out, err := h.useCase(ctx, ctx.ClientIP(), ctx.Request.UserAgent(), req)
 	if err != nil {
 		switch err {
-		case dto.ErrInvalidAccessToken:
+		case dto.ErrInvalidRefreshToken, dto.ErrReusedRefreshToken, dto.ErrUserNotFound:
 			api.UnauthorizedResponse(ctx)
+		case dto.ErrUserSuspended:
+			auth_api.UserSuspendedResponse(ctx)
 		default:
 			h.serverErrorHandler.Handle(ctx, err)
 		}
 		return
 	}
 
-	okResponse(ctx, freshAccessToken)
+	if useCookies {
+		h.cookieResponse(ctx, out)
+		return
+	}
+
+	okResponse(ctx, out)
 }
 
-func (h *handler) useCase(ctx *gin.Context, accessToken string) (string, error) {
-	in := dto.AccessTokenRefreshIn{
-		AccessToken: accessToken,
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
 	}
 
-	out, err := h.accessTokenRefreshCase.Use(ctx, &in)
-	if err != nil {
-		return "", err
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, clientIP, userAgent string, req *request) (*dto.AccessTokenRefreshOut, error) {
+	in := dto.AccessTokenRefreshIn{
+		RefreshToken: req.RefreshToken,
+		IP:           clientIP,
+		UserAgent:    userAgent,
 	}
 
-	return out.AccessToken, nil
+	return h.accessTokenRefreshCase.Use(ctx, &in)
 }
 
-func okResponse(ctx *gin.Context, accessToken string) {
-	ctx.JSON(http.StatusOK, &response{AccessToken: accessToken})
+func okResponse(ctx *gin.Context, result *dto.AccessTokenRefreshOut) {
+	ctx.JSON(http.StatusOK, &response{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+	})
+}
+
+func (h *handler) cookieResponse(ctx *gin.Context, result *dto.AccessTokenRefreshOut) {
+	if err := auth_api.SetSessionCookies(ctx, result.AccessToken, result.RefreshToken); err != nil {
+		h.serverErrorHandler.Handle(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &response{})
 }
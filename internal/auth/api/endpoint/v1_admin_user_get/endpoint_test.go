@@ -0,0 +1,171 @@
+package v1_admin_user_get
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_admin_user_get/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		adminUserGetCase          = mock.NewMockadminUserGetCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		until      = time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+		createdAt  = time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			ID: 2,
+		}
+		expectedAdminUserGetIn = &dto.AdminUserGetIn{
+			UserID: 2,
+		}
+		validAdminUserGetOut = &dto.AdminUserGetOut{
+			User:  dto.AdminUser{ID: 2, Name: "John", Email: "john@example.com", Active: true},
+			Roles: []string{"reader"},
+		}
+		noAdminUserGetOut = (*dto.AdminUserGetOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		userID  string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name:   "OK",
+			userID: "2",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				adminUserGetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedAdminUserGetIn)).
+					Return(validAdminUserGetOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"id":2,"name":"John","email":"john@example.com","active":true,"roles":["reader"]}`,
+		},
+		{
+			name:   "OK: user suspended",
+			userID: "2",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				adminUserGetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedAdminUserGetIn)).
+					Return(&dto.AdminUserGetOut{
+						User:  dto.AdminUser{ID: 2, Name: "John", Email: "john@example.com", Active: true},
+						Roles: []string{"reader"},
+						Suspension: &dto.UserSuspension{
+							Reason:      "spam",
+							Until:       &until,
+							SuspendedBy: 1,
+							CreatedAt:   createdAt,
+						},
+					}, noError)
+			},
+			expCode: 200,
+			expBody: `{"id":2,"name":"John","email":"john@example.com","active":true,"roles":["reader"],
+				"suspension":{"reason":"spam","until":"2024-03-08T00:00:00Z","suspendedBy":1,"createdAt":"2024-03-01T12:30:00Z"}}`,
+		},
+		{
+			name:    "Bad request: malformed user ID",
+			userID:  "john",
+			setup:   func() {},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"strconv.ParseInt: parsing \"john\": invalid syntax"}`,
+		},
+		{
+			name:   "Bad request: request validation failed",
+			userID: "2",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name:   "Not found: user not found",
+			userID: "2",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				adminUserGetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedAdminUserGetIn)).
+					Return(noAdminUserGetOut, dto.ErrUserNotFound)
+			},
+			expCode: 404,
+			expBody: `{"error":{"code":2027,"name":"User not found"},"message":"User not found."}`,
+		},
+		{
+			name:   "Internal server error: unexpected error in use case",
+			userID: "2",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				adminUserGetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedAdminUserGetIn)).
+					Return(noAdminUserGetOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			r := httptest.NewRequest(method, "/v1/admin/users/"+tt.userID, nil)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, adminUserGetCase, validator, serverErrorHandlerFactory)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
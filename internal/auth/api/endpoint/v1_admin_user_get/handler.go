@@ -0,0 +1,105 @@
+package v1_admin_user_get
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	ID int64 `uri:"id" validate:"gt=0"`
+}
+
+type response struct {
+	ID     int64    `json:"id"`
+	Name   string   `json:"name"`
+	Email  string   `json:"email"`
+	Active bool     `json:"active"`
+	Roles  []string `json:"roles"`
+	// Suspension is omitted if the user is not suspended at the moment.
+	Suspension *suspension `json:"suspension,omitempty"`
+}
+
+type suspension struct {
+	Reason string `json:"reason"`
+	// Until is omitted if the user is banned.
+	Until       *time.Time `json:"until,omitempty"`
+	SuspendedBy int64      `json:"suspendedBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+type handler struct {
+	adminUserGetCase   adminUserGetCase
+	validator          validation.Validator
+	serverErrorHandler api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	out, err := h.useCase(ctx, req)
+	if err != nil {
+		switch err {
+		case dto.ErrUserNotFound:
+			auth_api.UserNotFoundResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	// unlike the body, malformed path parameters cannot be left to the validator
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		return nil, err
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, req *request) (*dto.AdminUserGetOut, error) {
+	in := dto.AdminUserGetIn{
+		UserID: req.ID,
+	}
+
+	return h.adminUserGetCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.AdminUserGetOut) {
+	res := &response{
+		ID:     out.User.ID,
+		Name:   out.User.Name,
+		Email:  out.User.Email,
+		Active: out.User.Active,
+		Roles:  out.Roles,
+	}
+	if out.Suspension != nil {
+		res.Suspension = &suspension{
+			Reason:      out.Suspension.Reason,
+			Until:       out.Suspension.Until,
+			SuspendedBy: out.Suspension.SuspendedBy,
+			CreatedAt:   out.Suspension.CreatedAt,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
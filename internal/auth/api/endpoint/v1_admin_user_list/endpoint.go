@@ -0,0 +1,40 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_admin_user_list
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+const (
+	method = http.MethodGet
+	path   = "/v1/admin/users"
+)
+
+type adminUserListCase interface {
+	Use(ctx context.Context, in *dto.AdminUserListIn) (*dto.AdminUserListOut, error)
+}
+
+// Bind registers the endpoint behind the middlewares,
+// which must pass only the users allowed to manage the users.
+func Bind(
+	router *gin.Engine,
+	adminUserListCase adminUserListCase,
+	validator validation.Validator,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
+) {
+	h := handler{
+		adminUserListCase:  adminUserListCase,
+		validator:          validator,
+		serverErrorHandler: serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, append(middlewares, h.handle)...)
+}
@@ -0,0 +1,154 @@
+package v1_admin_user_list
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_admin_user_list/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		adminUserListCase         = mock.NewMockadminUserListCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		query                      = "?query=john&before=10&limit=2"
+		expectedRequestInValidator = &request{
+			Query:  "john",
+			Before: 10,
+			Limit:  2,
+		}
+		expectedAdminUserListIn = &dto.AdminUserListIn{
+			Query:  "john",
+			Before: 10,
+			Limit:  2,
+		}
+		validAdminUserListOut = &dto.AdminUserListOut{
+			Users: []dto.AdminUser{
+				{ID: 9, Name: "John", Email: "john@example.com", Active: true},
+				{ID: 7, Name: "Johnny", Email: "johnny@example.com", Active: false},
+			},
+			NextBefore: 7,
+		}
+		noAdminUserListOut = (*dto.AdminUserListOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		query   string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name:  "OK",
+			query: query,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				adminUserListCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedAdminUserListIn)).
+					Return(validAdminUserListOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"users":[
+				{"id":9,"name":"John","email":"john@example.com","active":true},
+				{"id":7,"name":"Johnny","email":"johnny@example.com","active":false}
+			],"nextBefore":7}`,
+		},
+		{
+			name:  "OK: last page",
+			query: "",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(&request{})).
+					Return(noError)
+
+				adminUserListCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(&dto.AdminUserListIn{})).
+					Return(&dto.AdminUserListOut{}, noError)
+			},
+			expCode: 200,
+			expBody: `{"users":[]}`,
+		},
+		{
+			name:    "Bad request: malformed query",
+			query:   "?limit=ten",
+			setup:   func() {},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"strconv.ParseInt: parsing \"ten\": invalid syntax"}`,
+		},
+		{
+			name:  "Bad request: request validation failed",
+			query: query,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name:  "Internal server error: unexpected error in use case",
+			query: query,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				adminUserListCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedAdminUserListIn)).
+					Return(noAdminUserListOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			r := httptest.NewRequest(method, path+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, adminUserListCase, validator, serverErrorHandlerFactory)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package v1_admin_user_list
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	Query  string `form:"query" validate:"lte=255"`
+	Before int64  `form:"before" validate:"gte=0"`
+	Limit  int    `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type response struct {
+	Users []user `json:"users"`
+	// NextBefore is omitted on the last page.
+	NextBefore int64 `json:"nextBefore,omitempty"`
+}
+
+type user struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Active bool   `json:"active"`
+}
+
+type handler struct {
+	adminUserListCase  adminUserListCase
+	validator          validation.Validator
+	serverErrorHandler api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	out, err := h.useCase(ctx, req)
+	if err != nil {
+		h.serverErrorHandler.Handle(ctx, err)
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	// unlike the body, malformed query parameters cannot be left to the validator
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		return nil, err
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, req *request) (*dto.AdminUserListOut, error) {
+	in := dto.AdminUserListIn{
+		Query:  req.Query,
+		Before: req.Before,
+		Limit:  req.Limit,
+	}
+
+	return h.adminUserListCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.AdminUserListOut) {
+	res := &response{
+		Users:      make([]user, 0, len(out.Users)),
+		NextBefore: out.NextBefore,
+	}
+	for _, u := range out.Users {
+		res.Users = append(res.Users, user{
+			ID:     u.ID,
+			Name:   u.Name,
+			Email:  u.Email,
+			Active: u.Active,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,148 @@
+package v1_admin_user_sessions_reset
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_admin_user_sessions_reset/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		adminUserSessionsResetCase = mock.NewMockadminUserSessionsResetCase(ctrl)
+		validator                  = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory  = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler         = mock_api.NewMockServerErrorHandler(ctrl)
+
+		adminID    = int64(1)
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			ID: 2,
+		}
+		expectedAdminUserActionIn = &dto.AdminUserActionIn{
+			AdminID:   adminID,
+			UserID:    2,
+			IP:        "192.0.2.1",
+			UserAgent: "Mozilla/5.0",
+		}
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		userID  string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name:   "OK",
+			userID: "2",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				adminUserSessionsResetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedAdminUserActionIn)).
+					Return(noError)
+			},
+			expCode: 200,
+			expBody: `{"message":"Sessions of the user have been ended."}`,
+		},
+		{
+			name:    "Bad request: malformed user ID",
+			userID:  "john",
+			setup:   func() {},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"strconv.ParseInt: parsing \"john\": invalid syntax"}`,
+		},
+		{
+			name:   "Bad request: request validation failed",
+			userID: "2",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name:   "Not found: user not found",
+			userID: "2",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				adminUserSessionsResetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedAdminUserActionIn)).
+					Return(dto.ErrUserNotFound)
+			},
+			expCode: 404,
+			expBody: `{"error":{"code":2027,"name":"User not found"},"message":"User not found."}`,
+		},
+		{
+			name:   "Internal server error: unexpected error in use case",
+			userID: "2",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				adminUserSessionsResetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedAdminUserActionIn)).
+					Return(dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, adminID)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			r := httptest.NewRequest(method, "/v1/admin/users/"+tt.userID+"/sessions", nil)
+			r.Header.Set("User-Agent", "Mozilla/5.0")
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, adminUserSessionsResetCase, validator, serverErrorHandlerFactory, authenticate)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package v1_admin_user_sessions_reset
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	ID int64 `uri:"id" validate:"gt=0"`
+}
+
+type response struct {
+	Message string `json:"message"`
+}
+
+type handler struct {
+	adminUserSessionsResetCase adminUserSessionsResetCase
+	validator                  validation.Validator
+	serverErrorHandler         api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	if err = h.useCase(ctx, api.GetUserID(ctx), ctx.ClientIP(), ctx.Request.UserAgent(), req); err != nil {
+		switch err {
+		case dto.ErrUserNotFound:
+			auth_api.UserNotFoundResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	// unlike the body, malformed path parameters cannot be left to the validator
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		return nil, err
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, adminID int64, ip, userAgent string, req *request) error {
+	in := dto.AdminUserActionIn{
+		AdminID:   adminID,
+		UserID:    req.ID,
+		IP:        ip,
+		UserAgent: userAgent,
+	}
+
+	return h.adminUserSessionsResetCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context) {
+	const message = "Sessions of the user have been ended."
+	ctx.JSON(http.StatusOK, &response{Message: message})
+}
@@ -0,0 +1,87 @@
+package v1_admin_user_suspend
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	ID     int64  `uri:"id" json:"-" validate:"gt=0"`
+	Reason string `json:"reason" validate:"required,lte=255"`
+	// Until is omitted to ban the user.
+	Until *time.Time `json:"until" validate:"omitempty,gt"`
+}
+
+type response struct {
+	Message string `json:"message"`
+}
+
+type handler struct {
+	adminUserSuspendCase adminUserSuspendCase
+	validator            validation.Validator
+	serverErrorHandler   api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	if err = h.useCase(ctx, api.GetUserID(ctx), ctx.ClientIP(), ctx.Request.UserAgent(), req); err != nil {
+		switch err {
+		case dto.ErrUserNotFound:
+			auth_api.UserNotFoundResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx, req.Until)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	// unlike the body, malformed path parameters cannot be left to the validator
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		return nil, err
+	}
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, adminID int64, ip, userAgent string, req *request) error {
+	in := dto.AdminUserSuspendIn{
+		AdminID:   adminID,
+		UserID:    req.ID,
+		Reason:    req.Reason,
+		Until:     req.Until,
+		IP:        ip,
+		UserAgent: userAgent,
+	}
+
+	return h.adminUserSuspendCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, until *time.Time) {
+	message := "User has been banned."
+	if until != nil {
+		message = "User has been suspended."
+	}
+	ctx.JSON(http.StatusOK, &response{Message: message})
+}
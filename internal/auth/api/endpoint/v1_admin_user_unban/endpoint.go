@@ -0,0 +1,41 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_admin_user_unban
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+const (
+	method = http.MethodDelete
+	path   = "/v1/admin/users/:id/suspension"
+)
+
+type adminUserUnbanCase interface {
+	Use(ctx context.Context, in *dto.AdminUserActionIn) error
+}
+
+// Bind registers the endpoint behind the middlewares,
+// which must set ID of the authenticated user to the context
+// and pass only the users allowed to manage the users.
+func Bind(
+	router *gin.Engine,
+	adminUserUnbanCase adminUserUnbanCase,
+	validator validation.Validator,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
+) {
+	h := handler{
+		adminUserUnbanCase: adminUserUnbanCase,
+		validator:          validator,
+		serverErrorHandler: serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, append(middlewares, h.handle)...)
+}
@@ -0,0 +1,40 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_audit_event_list
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+const (
+	method = http.MethodGet
+	path   = "/v1/admin/audit-events"
+)
+
+type auditEventListCase interface {
+	Use(ctx context.Context, in *dto.AuditEventListIn) (*dto.AuditEventListOut, error)
+}
+
+// Bind registers the endpoint behind the middlewares,
+// which must pass only the users allowed to read the audit log.
+func Bind(
+	router *gin.Engine,
+	auditEventListCase auditEventListCase,
+	validator validation.Validator,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
+) {
+	h := handler{
+		auditEventListCase: auditEventListCase,
+		validator:          validator,
+		serverErrorHandler: serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, append(middlewares, h.handle)...)
+}
@@ -0,0 +1,183 @@
+package v1_audit_event_list
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_audit_event_list/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		auditEventListCase        = mock.NewMockauditEventListCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		from       = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		createdAt  = time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		query                      = "?actorId=1&targetId=2&outcome=failure&from=2024-03-01T00:00:00Z&before=10&limit=2"
+		expectedRequestInValidator = &request{
+			ActorID:  1,
+			TargetID: 2,
+			Outcome:  "failure",
+			From:     &from,
+			Before:   10,
+			Limit:    2,
+		}
+		expectedAuditEventListIn = &dto.AuditEventListIn{
+			ActorID:  1,
+			TargetID: 2,
+			Outcome:  "failure",
+			From:     &from,
+			Before:   10,
+			Limit:    2,
+		}
+		validAuditEventListOut = &dto.AuditEventListOut{
+			Events: []dto.AuditEvent{
+				{
+					ID:        9,
+					Type:      "user.login",
+					ActorID:   1,
+					TargetID:  2,
+					IP:        "192.0.2.1",
+					UserAgent: "Mozilla/5.0",
+					Outcome:   "failure",
+					Details:   "incorrect password",
+					CreatedAt: createdAt,
+				},
+				{
+					ID:        7,
+					Type:      "user.login",
+					ActorID:   1,
+					TargetID:  2,
+					IP:        "192.0.2.1",
+					UserAgent: "Mozilla/5.0",
+					Outcome:   "failure",
+					Details:   "incorrect password",
+					CreatedAt: createdAt.Add(-time.Hour),
+				},
+			},
+			NextBefore: 7,
+		}
+		noAuditEventListOut = (*dto.AuditEventListOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		query   string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name:  "OK",
+			query: query,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				auditEventListCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedAuditEventListIn)).
+					Return(validAuditEventListOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"events":[
+				{"id":9,"type":"user.login","actorId":1,"targetId":2,"ip":"192.0.2.1","userAgent":"Mozilla/5.0","outcome":"failure","details":"incorrect password","createdAt":"2024-03-01T12:30:00Z"},
+				{"id":7,"type":"user.login","actorId":1,"targetId":2,"ip":"192.0.2.1","userAgent":"Mozilla/5.0","outcome":"failure","details":"incorrect password","createdAt":"2024-03-01T11:30:00Z"}
+			],"nextBefore":7}`,
+		},
+		{
+			name:  "OK: last page",
+			query: "",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(&request{})).
+					Return(noError)
+
+				auditEventListCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(&dto.AuditEventListIn{})).
+					Return(&dto.AuditEventListOut{}, noError)
+			},
+			expCode: 200,
+			expBody: `{"events":[]}`,
+		},
+		{
+			name:    "Bad request: malformed query",
+			query:   "?limit=ten",
+			setup:   func() {},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"strconv.ParseInt: parsing \"ten\": invalid syntax"}`,
+		},
+		{
+			name:  "Bad request: request validation failed",
+			query: query,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name:  "Internal server error: unexpected error in use case",
+			query: query,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				auditEventListCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedAuditEventListIn)).
+					Return(noAuditEventListOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			r := httptest.NewRequest(method, path+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, auditEventListCase, validator, serverErrorHandlerFactory)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
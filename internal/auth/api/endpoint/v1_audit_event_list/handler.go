@@ -0,0 +1,115 @@
+package v1_audit_event_list
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	ActorID  int64      `form:"actorId" validate:"gte=0"`
+	TargetID int64      `form:"targetId" validate:"gte=0"`
+	Type     string     `form:"type" validate:"lte=64"`
+	Outcome  string     `form:"outcome" validate:"omitempty,oneof=success failure"`
+	From     *time.Time `form:"from"`
+	To       *time.Time `form:"to"`
+	Before   int64      `form:"before" validate:"gte=0"`
+	Limit    int        `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type response struct {
+	Events []event `json:"events"`
+	// NextBefore is omitted on the last page.
+	NextBefore int64 `json:"nextBefore,omitempty"`
+}
+
+type event struct {
+	ID        int64     `json:"id"`
+	Type      string    `json:"type"`
+	ActorID   int64     `json:"actorId"`
+	TargetID  int64     `json:"targetId,omitempty"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"userAgent"`
+	Outcome   string    `json:"outcome"`
+	Details   string    `json:"details"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type handler struct {
+	auditEventListCase auditEventListCase
+	validator          validation.Validator
+	serverErrorHandler api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	out, err := h.useCase(ctx, req)
+	if err != nil {
+		h.serverErrorHandler.Handle(ctx, err)
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	// unlike the body, malformed query parameters cannot be left to the validator
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		return nil, err
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, req *request) (*dto.AuditEventListOut, error) {
+	in := dto.AuditEventListIn{
+		ActorID:  req.ActorID,
+		TargetID: req.TargetID,
+		Type:     req.Type,
+		Outcome:  req.Outcome,
+		From:     req.From,
+		To:       req.To,
+		Before:   req.Before,
+		Limit:    req.Limit,
+	}
+
+	return h.auditEventListCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.AuditEventListOut) {
+	res := &response{
+		Events:     make([]event, 0, len(out.Events)),
+		NextBefore: out.NextBefore,
+	}
+	for _, e := range out.Events {
+		res.Events = append(res.Events, event{
+			ID:        e.ID,
+			Type:      e.Type,
+			ActorID:   e.ActorID,
+			TargetID:  e.TargetID,
+			IP:        e.IP,
+			UserAgent: e.UserAgent,
+			Outcome:   e.Outcome,
+			Details:   e.Details,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
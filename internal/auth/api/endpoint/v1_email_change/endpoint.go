@@ -0,0 +1,40 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_email_change
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+const (
+	method = http.MethodPost
+	path   = "/v1/users/me/email"
+)
+
+type emailChangeCase interface {
+	Use(ctx context.Context, in *dto.EmailChangeIn) error
+}
+
+// Bind registers the endpoint behind the middlewares,
+// which must set ID of the authenticated user to the context.
+func Bind(
+	router *gin.Engine,
+	emailChangeCase emailChangeCase,
+	validator validation.Validator,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
+) {
+	h := handler{
+		emailChangeCase:    emailChangeCase,
+		validator:          validator,
+		serverErrorHandler: serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, append(middlewares, h.handle)...)
+}
@@ -0,0 +1,76 @@
+package v1_email_change
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	Email string `json:"email" validate:"required,email,lte=255"`
+}
+
+type response struct {
+	Message string `json:"message,omitempty"`
+}
+
+type handler struct {
+	emailChangeCase    emailChangeCase
+	validator          validation.Validator
+	serverErrorHandler api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	if err = h.useCase(ctx, api.GetUserID(ctx), ctx.ClientIP(), ctx.Request.UserAgent(), req); err != nil {
+		switch err {
+		case dto.ErrEmailIsBusy:
+			auth_api.BusyEmailResponse(ctx)
+		case dto.ErrUserNotFound:
+			api.UnauthorizedResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, userID int64, clientIP, userAgent string, req *request) error {
+	in := dto.EmailChangeIn{
+		UserID:    userID,
+		NewEmail:  req.Email,
+		IP:        clientIP,
+		UserAgent: userAgent,
+	}
+
+	return h.emailChangeCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context) {
+	const message = "Please check the new email for the confirmation code."
+	ctx.JSON(http.StatusOK, &response{Message: message})
+}
@@ -0,0 +1,40 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_email_change_confirm
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+const (
+	method = http.MethodPost
+	path   = "/v1/users/me/email/confirm"
+)
+
+type emailChangeConfirmCase interface {
+	Use(ctx context.Context, in *dto.EmailChangeConfirmIn) error
+}
+
+// Bind registers the endpoint behind the middlewares,
+// which must set ID of the authenticated user to the context.
+func Bind(
+	router *gin.Engine,
+	emailChangeConfirmCase emailChangeConfirmCase,
+	validator validation.Validator,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
+) {
+	h := handler{
+		emailChangeConfirmCase: emailChangeConfirmCase,
+		validator:              validator,
+		serverErrorHandler:     serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, append(middlewares, h.handle)...)
+}
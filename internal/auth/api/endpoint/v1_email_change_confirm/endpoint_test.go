@@ -0,0 +1,169 @@
+package v1_email_change_confirm
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_email_change_confirm/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		emailChangeConfirmCase    = mock.NewMockemailChangeConfirmCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		userID     = int64(1)
+		code       = "dummyCode"
+		userAgent  = "Mozilla/5.0"
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			Code: code,
+		}
+		expectedEmailChangeConfirmIn = &dto.EmailChangeConfirmIn{
+			UserID:    userID,
+			Code:      code,
+			IP:        "192.0.2.1",
+			UserAgent: userAgent,
+		}
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				emailChangeConfirmCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedEmailChangeConfirmIn)).
+					Return(noError)
+			},
+			expCode: 200,
+			expBody: `{"message":"Your email has been changed."}`,
+		},
+		{
+			name: "Bad request: request validation failed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name: "Bad request: invalid email change code",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				emailChangeConfirmCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedEmailChangeConfirmIn)).
+					Return(dto.ErrInvalidEmailChangeCode)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2025,"name":"Invalid email change code"},"message":"Confirmation code is invalid or expired. Please request the email change again."}`,
+		},
+		{
+			name: "Bad request: busy email",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				emailChangeConfirmCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedEmailChangeConfirmIn)).
+					Return(dto.ErrEmailIsBusy)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2001,"name":"Busy email"},"message":"User with this email already exists."}`,
+		},
+		{
+			name: "Unauthorized: user not found",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				emailChangeConfirmCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedEmailChangeConfirmIn)).
+					Return(dto.ErrUserNotFound)
+			},
+			expCode: 401,
+			expBody: `{"message":"Please try to sign in again."}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				emailChangeConfirmCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedEmailChangeConfirmIn)).
+					Return(dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, userID)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			rBody := `{"code":"dummyCode"}`
+			r := httptest.NewRequest(method, path, io.NopCloser(bytes.NewBufferString(rBody)))
+			r.Header.Set("User-Agent", userAgent)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, emailChangeConfirmCase, validator, serverErrorHandlerFactory, authenticate)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
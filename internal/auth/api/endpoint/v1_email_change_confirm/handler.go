@@ -0,0 +1,78 @@
+package v1_email_change_confirm
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	Code string `json:"code" validate:"required,lte=255"`
+}
+
+type response struct {
+	Message string `json:"message,omitempty"`
+}
+
+type handler struct {
+	emailChangeConfirmCase emailChangeConfirmCase
+	validator              validation.Validator
+	serverErrorHandler     api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	if err = h.useCase(ctx, api.GetUserID(ctx), ctx.ClientIP(), ctx.Request.UserAgent(), req); err != nil {
+		switch err {
+		case dto.ErrInvalidEmailChangeCode:
+			auth_api.InvalidEmailChangeCodeResponse(ctx)
+		case dto.ErrEmailIsBusy:
+			auth_api.BusyEmailResponse(ctx)
+		case dto.ErrUserNotFound:
+			api.UnauthorizedResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, userID int64, clientIP, userAgent string, req *request) error {
+	in := dto.EmailChangeConfirmIn{
+		UserID:    userID,
+		Code:      req.Code,
+		IP:        clientIP,
+		UserAgent: userAgent,
+	}
+
+	return h.emailChangeConfirmCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context) {
+	const message = "Your email has been changed."
+	ctx.JSON(http.StatusOK, &response{Message: message})
+}
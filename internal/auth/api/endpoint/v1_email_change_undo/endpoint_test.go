@@ -0,0 +1,146 @@
+package v1_email_change_undo
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_email_change_undo/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		emailChangeUndoCase       = mock.NewMockemailChangeUndoCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		token      = "dummy undo token"
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			Token: token,
+		}
+		expectedEmailChangeUndoIn = &dto.EmailChangeUndoIn{
+			Token: token,
+			IP:    "192.0.2.1",
+		}
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				emailChangeUndoCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedEmailChangeUndoIn)).
+					Return(noError)
+			},
+			expCode: 200,
+			expBody: `{"message":"Your email has been restored. Please sign in again and change your password."}`,
+		},
+		{
+			name: "Bad request: request validation failed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name: "Bad request: invalid email change undo token",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				emailChangeUndoCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedEmailChangeUndoIn)).
+					Return(dto.ErrInvalidEmailChangeUndoToken)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2026,"name":"Invalid email change undo token"},"message":"Undo link is invalid or expired."}`,
+		},
+		{
+			name: "Bad request: busy email",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				emailChangeUndoCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedEmailChangeUndoIn)).
+					Return(dto.ErrEmailIsBusy)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2001,"name":"Busy email"},"message":"User with this email already exists."}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				emailChangeUndoCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedEmailChangeUndoIn)).
+					Return(dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			rBody := `{"token":"dummy undo token"}`
+			r := httptest.NewRequest(method, path, io.NopCloser(bytes.NewBufferString(rBody)))
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, emailChangeUndoCase, validator, serverErrorHandlerFactory)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
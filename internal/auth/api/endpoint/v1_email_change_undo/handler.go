@@ -0,0 +1,75 @@
+package v1_email_change_undo
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	Token string `json:"token" validate:"required,lte=255"`
+}
+
+type response struct {
+	Message string `json:"message,omitempty"`
+}
+
+type handler struct {
+	emailChangeUndoCase emailChangeUndoCase
+	validator           validation.Validator
+	serverErrorHandler  api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	if err = h.useCase(ctx, ctx.ClientIP(), ctx.Request.UserAgent(), req); err != nil {
+		switch err {
+		case dto.ErrInvalidEmailChangeUndoToken:
+			auth_api.InvalidEmailChangeUndoTokenResponse(ctx)
+		case dto.ErrEmailIsBusy:
+			auth_api.BusyEmailResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, clientIP, userAgent string, req *request) error {
+	in := dto.EmailChangeUndoIn{
+		Token:     req.Token,
+		IP:        clientIP,
+		UserAgent: userAgent,
+	}
+
+	return h.emailChangeUndoCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context) {
+	const message = "Your email has been restored. Please sign in again and change your password."
+	ctx.JSON(http.StatusOK, &response{Message: message})
+}
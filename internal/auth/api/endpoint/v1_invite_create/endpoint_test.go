@@ -0,0 +1,161 @@
+package v1_invite_create
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_invite_create/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		inviteCreateCase          = mock.NewMockinviteCreateCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		adminID    = int64(1)
+		expiresAt  = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		inviteBody = `{"maxUses":10,"skipActivation":true,"expiresAt":"2030-01-01T00:00:00Z"}`
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			MaxUses:        10,
+			SkipActivation: true,
+			ExpiresAt:      expiresAt,
+		}
+		expectedInviteCreateIn = &dto.InviteCreateIn{
+			AdminID:        adminID,
+			MaxUses:        10,
+			SkipActivation: true,
+			ExpiresAt:      expiresAt,
+			IP:             "192.0.2.1",
+			UserAgent:      "Mozilla/5.0",
+		}
+		validInviteCreateOut = &dto.InviteCreateOut{
+			ID:   5,
+			Code: "dummyCode",
+		}
+		noInviteCreateOut = (*dto.InviteCreateOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		body    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			body: inviteBody,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				inviteCreateCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedInviteCreateIn)).
+					Return(validInviteCreateOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"id":5,"code":"dummyCode"}`,
+		},
+		{
+			name: "OK: single-use by default",
+			body: `{"expiresAt":"2030-01-01T00:00:00Z"}`,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(&request{ExpiresAt: expiresAt})).
+					Return(noError)
+
+				inviteCreateCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(&dto.InviteCreateIn{
+						AdminID:   adminID,
+						MaxUses:   1,
+						ExpiresAt: expiresAt,
+						IP:        "192.0.2.1",
+						UserAgent: "Mozilla/5.0",
+					})).
+					Return(validInviteCreateOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"id":5,"code":"dummyCode"}`,
+		},
+		{
+			name: "Bad request: request validation failed",
+			body: inviteBody,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			body: inviteBody,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				inviteCreateCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedInviteCreateIn)).
+					Return(noInviteCreateOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, adminID)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			r := httptest.NewRequest(method, path, io.NopCloser(bytes.NewBufferString(tt.body)))
+			r.Header.Set("User-Agent", "Mozilla/5.0")
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, inviteCreateCase, validator, serverErrorHandlerFactory, authenticate)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package v1_invite_create
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	// MaxUses is omitted for the single-use invites.
+	MaxUses        int       `json:"maxUses" validate:"omitempty,min=1,max=1000"`
+	SkipActivation bool      `json:"skipActivation"`
+	ExpiresAt      time.Time `json:"expiresAt" validate:"required,gt"`
+}
+
+type response struct {
+	ID   int64  `json:"id"`
+	Code string `json:"code"`
+}
+
+type handler struct {
+	inviteCreateCase   inviteCreateCase
+	validator          validation.Validator
+	serverErrorHandler api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	out, err := h.useCase(ctx, api.GetUserID(ctx), ctx.ClientIP(), ctx.Request.UserAgent(), req)
+	if err != nil {
+		h.serverErrorHandler.Handle(ctx, err)
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(
+	ctx context.Context,
+	adminID int64,
+	ip, userAgent string,
+	req *request,
+) (*dto.InviteCreateOut, error) {
+	in := dto.InviteCreateIn{
+		AdminID:        adminID,
+		MaxUses:        req.MaxUses,
+		SkipActivation: req.SkipActivation,
+		ExpiresAt:      req.ExpiresAt,
+		IP:             ip,
+		UserAgent:      userAgent,
+	}
+	if in.MaxUses == 0 {
+		in.MaxUses = 1
+	}
+
+	return h.inviteCreateCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.InviteCreateOut) {
+	ctx.JSON(http.StatusOK, &response{
+		ID:   out.ID,
+		Code: out.Code,
+	})
+}
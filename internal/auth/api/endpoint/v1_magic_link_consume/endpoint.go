@@ -0,0 +1,39 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_magic_link_consume
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+const (
+	method = http.MethodPost
+	path   = "/v1/auth/magic-link/consume"
+)
+
+type magicLinkConsumeCase interface {
+	Use(ctx context.Context, in *dto.MagicLinkConsumeIn) (*dto.UserAuthenticateOut, error)
+}
+
+// Bind registers the endpoint behind the middlewares, e.g. the rate limits.
+func Bind(
+	router *gin.Engine,
+	magicLinkConsumeCase magicLinkConsumeCase,
+	validator validation.Validator,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
+) {
+	h := handler{
+		magicLinkConsumeCase: magicLinkConsumeCase,
+		validator:            validator,
+		serverErrorHandler:   serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, append(middlewares, h.handle)...)
+}
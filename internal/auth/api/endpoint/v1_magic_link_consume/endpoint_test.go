@@ -0,0 +1,177 @@
+package v1_magic_link_consume
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_magic_link_consume/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		magicLinkConsumeCase      = mock.NewMockmagicLinkConsumeCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		token      = "dummyToken"
+		userAgent  = "Mozilla/5.0 (X11; Linux x86_64)"
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			Token: token,
+		}
+		expectedMagicLinkConsumeIn = &dto.MagicLinkConsumeIn{
+			Token:     token,
+			IP:        "192.0.2.1",
+			UserAgent: userAgent,
+		}
+		validUserAuthenticateOut = &dto.UserAuthenticateOut{
+			AccessToken:  "fresh access token",
+			RefreshToken: "fresh refresh token",
+		}
+		noUserAuthenticateOut = (*dto.UserAuthenticateOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	expectUseCase := func(out *dto.UserAuthenticateOut, err error) {
+		validator.EXPECT().
+			Struct(gomock.Eq(expectedRequestInValidator)).
+			Return(noError)
+
+		magicLinkConsumeCase.EXPECT().
+			Use(gomock.Any(), gomock.Eq(expectedMagicLinkConsumeIn)).
+			Return(out, err)
+	}
+
+	tests := []struct {
+		name       string
+		reqBody    string
+		setup      func()
+		expCode    int
+		expBody    string
+		expCookies []string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				expectUseCase(validUserAuthenticateOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"accessToken":"fresh access token","refreshToken":"fresh refresh token"}`,
+		},
+		{
+			name:    "OK: cookies",
+			reqBody: `{"token":"dummyToken","useCookies":true}`,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(&request{Token: token, UseCookies: true})).
+					Return(noError)
+
+				magicLinkConsumeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedMagicLinkConsumeIn)).
+					Return(validUserAuthenticateOut, noError)
+			},
+			expCode:    200,
+			expBody:    `{}`,
+			expCookies: []string{"access_token", "refresh_token", "csrf_token"},
+		},
+		{
+			name: "OK: two-factor required",
+			setup: func() {
+				expectUseCase(&dto.UserAuthenticateOut{TwoFactorToken: "dummyTwoFactorToken"}, noError)
+			},
+			expCode: 200,
+			expBody: `{"twoFactorToken":"dummyTwoFactorToken"}`,
+		},
+		{
+			name: "Bad request: request validation failed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name: "Bad request: invalid token",
+			setup: func() {
+				expectUseCase(noUserAuthenticateOut, dto.ErrInvalidMagicLinkToken)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2024,"name":"Invalid magic link token"},"message":"Login link is invalid or expired. Please request a new one."}`,
+		},
+		{
+			name: "Forbidden: user suspended",
+			setup: func() {
+				expectUseCase(noUserAuthenticateOut, dto.ErrUserSuspended)
+			},
+			expCode: 403,
+			expBody: `{"error":{"code":2028,"name":"User suspended"},"message":"Your account is suspended."}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				expectUseCase(noUserAuthenticateOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			rBody := `{"token":"dummyToken"}`
+			if tt.reqBody != "" {
+				rBody = tt.reqBody
+			}
+			r := httptest.NewRequest(method, path, io.NopCloser(bytes.NewBufferString(rBody)))
+			r.Header.Set("User-Agent", userAgent)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, magicLinkConsumeCase, validator, serverErrorHandlerFactory)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+			assert.Equal(t, tt.expCookies, cookieNames(w))
+		})
+	}
+}
+
+func cookieNames(w *httptest.ResponseRecorder) []string {
+	var names []string
+	for _, c := range w.Result().Cookies() {
+		names = append(names, c.Name)
+	}
+	return names
+}
@@ -0,0 +1,98 @@
+package v1_magic_link_consume
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+// request with useCookies has the tokens set to the session cookies instead of the response body.
+type request struct {
+	Token      string `json:"token" validate:"required,lte=255"`
+	UseCookies bool   `json:"useCookies"`
+}
+
+// response has only twoFactorToken if the user has two-factor authentication enabled.
+type response struct {
+	AccessToken    string `json:"accessToken,omitempty"`
+	RefreshToken   string `json:"refreshToken,omitempty"`
+	TwoFactorToken string `json:"twoFactorToken,omitempty"`
+}
+
+type handler struct {
+	magicLinkConsumeCase magicLinkConsumeCase
+	validator            validation.Validator
+	serverErrorHandler   api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	out, err := h.useCase(ctx, ctx.ClientIP(), ctx.Request.UserAgent(), req)
+	if err != nil {
+		switch err {
+		case dto.ErrInvalidMagicLinkToken:
+			auth_api.InvalidMagicLinkTokenResponse(ctx)
+		case dto.ErrUserSuspended:
+			auth_api.UserSuspendedResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	if req.UseCookies && out.AccessToken != "" {
+		h.cookieResponse(ctx, out)
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, clientIP, userAgent string, req *request) (*dto.UserAuthenticateOut, error) {
+	in := dto.MagicLinkConsumeIn{
+		Token:     req.Token,
+		IP:        clientIP,
+		UserAgent: userAgent,
+	}
+
+	return h.magicLinkConsumeCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, result *dto.UserAuthenticateOut) {
+	ctx.JSON(http.StatusOK, &response{
+		AccessToken:    result.AccessToken,
+		RefreshToken:   result.RefreshToken,
+		TwoFactorToken: result.TwoFactorToken,
+	})
+}
+
+func (h *handler) cookieResponse(ctx *gin.Context, result *dto.UserAuthenticateOut) {
+	if err := auth_api.SetSessionCookies(ctx, result.AccessToken, result.RefreshToken); err != nil {
+		h.serverErrorHandler.Handle(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &response{})
+}
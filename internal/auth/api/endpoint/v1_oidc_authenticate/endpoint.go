@@ -0,0 +1,37 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_oidc_authenticate
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+const (
+	method = http.MethodPost
+	path   = "/v1/auth/oidc/:provider/authenticate"
+)
+
+type oidcAuthenticateCase interface {
+	Use(ctx context.Context, in *dto.OIDCAuthenticateIn) (*dto.UserAuthenticateOut, error)
+}
+
+func Bind(
+	router *gin.Engine,
+	oidcAuthenticateCase oidcAuthenticateCase,
+	validator validation.Validator,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+) {
+	h := handler{
+		oidcAuthenticateCase: oidcAuthenticateCase,
+		validator:            validator,
+		serverErrorHandler:   serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, h.handle)
+}
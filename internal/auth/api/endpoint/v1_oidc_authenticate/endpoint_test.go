@@ -0,0 +1,269 @@
+package v1_oidc_authenticate
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_oidc_authenticate/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		oidcAuthenticateCase      = mock.NewMockoidcAuthenticateCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		code       = "dummyCode"
+		state      = "dummyState"
+		userAgent  = "Mozilla/5.0 (X11; Linux x86_64)"
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			Code:  code,
+			State: state,
+		}
+		expectedOIDCAuthenticateIn = &dto.OIDCAuthenticateIn{
+			Provider:  "example",
+			Code:      code,
+			State:     state,
+			IP:        "192.0.2.1",
+			UserAgent: userAgent,
+		}
+		validUserAuthenticateOut = &dto.UserAuthenticateOut{
+			AccessToken:  "fresh access token",
+			RefreshToken: "fresh refresh token",
+		}
+		noUserAuthenticateOut = (*dto.UserAuthenticateOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	expectUseCase := func(out *dto.UserAuthenticateOut, err error) {
+		validator.EXPECT().
+			Struct(gomock.Eq(expectedRequestInValidator)).
+			Return(noError)
+
+		oidcAuthenticateCase.EXPECT().
+			Use(gomock.Any(), gomock.Eq(expectedOIDCAuthenticateIn)).
+			Return(out, err)
+	}
+
+	tests := []struct {
+		name          string
+		reqBody       string
+		stateCookie   string
+		noStateCookie bool
+		setup         func()
+		expCode       int
+		expBody       string
+		expCookies    []string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				expectUseCase(validUserAuthenticateOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"accessToken":"fresh access token","refreshToken":"fresh refresh token"}`,
+		},
+		{
+			name:    "OK: cookies",
+			reqBody: `{"code":"dummyCode","state":"dummyState","useCookies":true}`,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(&request{Code: code, State: state, UseCookies: true})).
+					Return(noError)
+
+				oidcAuthenticateCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedOIDCAuthenticateIn)).
+					Return(validUserAuthenticateOut, noError)
+			},
+			expCode:    200,
+			expBody:    `{}`,
+			expCookies: []string{"access_token", "refresh_token", "csrf_token"},
+		},
+		{
+			name: "OK: two-factor required",
+			setup: func() {
+				expectUseCase(&dto.UserAuthenticateOut{TwoFactorToken: "dummyTwoFactorToken"}, noError)
+			},
+			expCode: 200,
+			expBody: `{"twoFactorToken":"dummyTwoFactorToken"}`,
+		},
+		{
+			name: "Bad request: request validation failed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name:        "Bad request: state cookie mismatch",
+			stateCookie: "anotherState",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2013,"name":"Invalid OIDC state"},"message":"Sign-in session is invalid or expired. Please try again."}`,
+		},
+		{
+			name:          "Bad request: no state cookie",
+			noStateCookie: true,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2013,"name":"Invalid OIDC state"},"message":"Sign-in session is invalid or expired. Please try again."}`,
+		},
+		{
+			name: "Bad request: invalid state",
+			setup: func() {
+				expectUseCase(noUserAuthenticateOut, dto.ErrInvalidOIDCState)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2013,"name":"Invalid OIDC state"},"message":"Sign-in session is invalid or expired. Please try again."}`,
+		},
+		{
+			name: "Bad request: authentication failed",
+			setup: func() {
+				expectUseCase(noUserAuthenticateOut, dto.ErrOIDCAuthenticationFailed)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2014,"name":"OIDC authentication failed"},"message":"Identity provider has not confirmed the sign-in. Please try again."}`,
+		},
+		{
+			name: "Bad request: linked user not found",
+			setup: func() {
+				expectUseCase(noUserAuthenticateOut, dto.ErrUserNotFound)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2014,"name":"OIDC authentication failed"},"message":"Identity provider has not confirmed the sign-in. Please try again."}`,
+		},
+		{
+			name: "Bad request: email not verified",
+			setup: func() {
+				expectUseCase(noUserAuthenticateOut, dto.ErrExternalEmailNotVerified)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2015,"name":"External email not verified"},"message":"Please verify your email at the identity provider first."}`,
+		},
+		{
+			name: "Forbidden: invite required",
+			setup: func() {
+				expectUseCase(noUserAuthenticateOut, dto.ErrInviteRequired)
+			},
+			expCode: 403,
+			expBody: `{"error":{"code":2029,"name":"Invite required"},"message":"Registration is available by invite only."}`,
+		},
+		{
+			name: "Forbidden: user suspended",
+			setup: func() {
+				expectUseCase(noUserAuthenticateOut, dto.ErrUserSuspended)
+			},
+			expCode: 403,
+			expBody: `{"error":{"code":2028,"name":"User suspended"},"message":"Your account is suspended."}`,
+		},
+		{
+			name: "Bad request: email domain not allowed",
+			setup: func() {
+				expectUseCase(noUserAuthenticateOut, dto.ErrEmailDomainNotAllowed)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2031,"name":"Email domain not allowed"},"message":"Registration with this email domain is not allowed."}`,
+		},
+		{
+			name: "Bad request: disposable email",
+			setup: func() {
+				expectUseCase(noUserAuthenticateOut, dto.ErrDisposableEmail)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2032,"name":"Disposable email"},"message":"Disposable email addresses are not allowed."}`,
+		},
+		{
+			name: "Not found: provider not found",
+			setup: func() {
+				expectUseCase(noUserAuthenticateOut, dto.ErrOIDCProviderNotFound)
+			},
+			expCode: 404,
+			expBody: `{"error":{"code":2012,"name":"Identity provider not found"},"message":"Sign-in with this provider is not supported."}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				expectUseCase(noUserAuthenticateOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			rBody := `{"code":"dummyCode","state":"dummyState"}`
+			if tt.reqBody != "" {
+				rBody = tt.reqBody
+			}
+			r := httptest.NewRequest(method, "/v1/auth/oidc/example/authenticate", io.NopCloser(bytes.NewBufferString(rBody)))
+			r.Header.Set("User-Agent", userAgent)
+			if !tt.noStateCookie {
+				stateCookie := state
+				if tt.stateCookie != "" {
+					stateCookie = tt.stateCookie
+				}
+				r.AddCookie(&http.Cookie{Name: "oidc_state", Value: stateCookie})
+			}
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, oidcAuthenticateCase, validator, serverErrorHandlerFactory)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+			assert.Equal(t, tt.expCookies, cookieNames(w))
+		})
+	}
+}
+
+func cookieNames(w *httptest.ResponseRecorder) []string {
+	var names []string
+	for _, c := range w.Result().Cookies() {
+		names = append(names, c.Name)
+	}
+	return names
+}
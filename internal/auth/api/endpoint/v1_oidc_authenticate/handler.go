@@ -0,0 +1,118 @@
+package v1_oidc_authenticate
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+// request with useCookies has the tokens set to the session cookies instead of the response body.
+type request struct {
+	Code       string `json:"code" validate:"required,lte=2048"`
+	State      string `json:"state" validate:"required,lte=255"`
+	UseCookies bool   `json:"useCookies"`
+}
+
+// response has only twoFactorToken if the user has two-factor authentication enabled.
+type response struct {
+	AccessToken    string `json:"accessToken,omitempty"`
+	RefreshToken   string `json:"refreshToken,omitempty"`
+	TwoFactorToken string `json:"twoFactorToken,omitempty"`
+}
+
+type handler struct {
+	oidcAuthenticateCase oidcAuthenticateCase
+	validator            validation.Validator
+	serverErrorHandler   api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	if !auth_api.OIDCStateValid(ctx, req.State) {
+		auth_api.InvalidOIDCStateResponse(ctx)
+		return
+	}
+
+	out, err := h.useCase(ctx, ctx.Param("provider"), ctx.ClientIP(), ctx.Request.UserAgent(), req)
+	if err != nil {
+		switch err {
+		case dto.ErrOIDCProviderNotFound:
+			auth_api.IdentityProviderNotFoundResponse(ctx)
+		case dto.ErrInvalidOIDCState:
+			auth_api.InvalidOIDCStateResponse(ctx)
+		case dto.ErrOIDCAuthenticationFailed, dto.ErrUserNotFound:
+			auth_api.OIDCAuthenticationFailedResponse(ctx)
+		case dto.ErrExternalEmailNotVerified:
+			auth_api.ExternalEmailNotVerifiedResponse(ctx)
+		case dto.ErrInviteRequired:
+			auth_api.InviteRequiredResponse(ctx)
+		case dto.ErrUserSuspended:
+			auth_api.UserSuspendedResponse(ctx)
+		case dto.ErrEmailDomainNotAllowed:
+			auth_api.EmailDomainNotAllowedResponse(ctx)
+		case dto.ErrDisposableEmail:
+			auth_api.DisposableEmailResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	if req.UseCookies && out.AccessToken != "" {
+		h.cookieResponse(ctx, out)
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, provider, clientIP, userAgent string, req *request) (*dto.UserAuthenticateOut, error) {
+	in := dto.OIDCAuthenticateIn{
+		Provider:  provider,
+		Code:      req.Code,
+		State:     req.State,
+		IP:        clientIP,
+		UserAgent: userAgent,
+	}
+
+	return h.oidcAuthenticateCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, result *dto.UserAuthenticateOut) {
+	ctx.JSON(http.StatusOK, &response{
+		AccessToken:    result.AccessToken,
+		RefreshToken:   result.RefreshToken,
+		TwoFactorToken: result.TwoFactorToken,
+	})
+}
+
+func (h *handler) cookieResponse(ctx *gin.Context, result *dto.UserAuthenticateOut) {
+	if err := auth_api.SetSessionCookies(ctx, result.AccessToken, result.RefreshToken); err != nil {
+		h.serverErrorHandler.Handle(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &response{})
+}
@@ -0,0 +1,34 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_oidc_authorize
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+const (
+	method = http.MethodPost
+	path   = "/v1/auth/oidc/:provider/authorize"
+)
+
+type oidcAuthorizeCase interface {
+	Use(ctx context.Context, in *dto.OIDCAuthorizeIn) (*dto.OIDCAuthorizeOut, error)
+}
+
+func Bind(
+	router *gin.Engine,
+	oidcAuthorizeCase oidcAuthorizeCase,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+) {
+	h := handler{
+		oidcAuthorizeCase:  oidcAuthorizeCase,
+		serverErrorHandler: serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, h.handle)
+}
@@ -0,0 +1,119 @@
+package v1_oidc_authorize
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_oidc_authorize/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		oidcAuthorizeCase         = mock.NewMockoidcAuthorizeCase(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedOIDCAuthorizeIn = &dto.OIDCAuthorizeIn{
+			Provider: "example",
+		}
+		validOIDCAuthorizeOut = &dto.OIDCAuthorizeOut{
+			AuthorizationURL: "https://id.example.com/authorize?state=dummyState",
+			State:            "dummyState",
+			StateExpiresAt:   time.Now().Add(10 * time.Minute),
+		}
+		noOIDCAuthorizeOut = (*dto.OIDCAuthorizeOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name           string
+		setup          func()
+		expCode        int
+		expBody        string
+		expStateCookie string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				oidcAuthorizeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedOIDCAuthorizeIn)).
+					Return(validOIDCAuthorizeOut, noError)
+			},
+			expCode:        200,
+			expBody:        `{"authorizationUrl":"https://id.example.com/authorize?state=dummyState"}`,
+			expStateCookie: "dummyState",
+		},
+		{
+			name: "Not found: provider not found",
+			setup: func() {
+				oidcAuthorizeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedOIDCAuthorizeIn)).
+					Return(noOIDCAuthorizeOut, dto.ErrOIDCProviderNotFound)
+			},
+			expCode: 404,
+			expBody: `{"error":{"code":2012,"name":"Identity provider not found"},"message":"Sign-in with this provider is not supported."}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				oidcAuthorizeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedOIDCAuthorizeIn)).
+					Return(noOIDCAuthorizeOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			r := httptest.NewRequest(method, "/v1/auth/oidc/example/authorize", nil)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, oidcAuthorizeCase, serverErrorHandlerFactory)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+			assert.Equal(t, tt.expStateCookie, stateCookie(w))
+		})
+	}
+}
+
+func stateCookie(w *httptest.ResponseRecorder) string {
+	for _, c := range w.Result().Cookies() {
+		if c.Name == "oidc_state" {
+			return c.Value
+		}
+	}
+	return ""
+}
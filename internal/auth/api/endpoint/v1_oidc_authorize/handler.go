@@ -0,0 +1,51 @@
+package v1_oidc_authorize
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+type response struct {
+	AuthorizationURL string `json:"authorizationUrl"`
+}
+
+type handler struct {
+	oidcAuthorizeCase  oidcAuthorizeCase
+	serverErrorHandler api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	out, err := h.useCase(ctx, ctx.Param("provider"))
+	if err != nil {
+		switch err {
+		case dto.ErrOIDCProviderNotFound:
+			auth_api.IdentityProviderNotFoundResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) useCase(ctx context.Context, provider string) (*dto.OIDCAuthorizeOut, error) {
+	in := dto.OIDCAuthorizeIn{
+		Provider: provider,
+	}
+
+	return h.oidcAuthorizeCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.OIDCAuthorizeOut) {
+	auth_api.SetOIDCStateCookie(ctx, out.State, out.StateExpiresAt)
+	ctx.JSON(http.StatusOK, &response{
+		AuthorizationURL: out.AuthorizationURL,
+	})
+}
@@ -0,0 +1,227 @@
+package v1_password_change
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_password_change/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		passwordChangeCase        = mock.NewMockpasswordChangeCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		userID          = int64(1)
+		currentPassword = "Qwerty123!"
+		newPassword     = "Qwerty456!"
+		userAgent       = "Mozilla/5.0 (X11; Linux x86_64)"
+		noError         = (error)(nil)
+		dummyError      = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			CurrentPassword: currentPassword,
+			NewPassword:     newPassword,
+		}
+		expectedPasswordChangeIn = &dto.PasswordChangeIn{
+			UserID:          userID,
+			CurrentPassword: currentPassword,
+			NewPassword:     newPassword,
+			IP:              "192.0.2.1",
+			UserAgent:       userAgent,
+		}
+		validPasswordChangeOut = &dto.PasswordChangeOut{
+			AccessToken:  "fresh access token",
+			RefreshToken: "fresh refresh token",
+		}
+		noPasswordChangeOut = (*dto.PasswordChangeOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name          string
+		cookies       []*http.Cookie
+		setup         func()
+		expCode       int
+		expBody       string
+		expCookies    []string
+		expRetryAfter string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				passwordChangeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPasswordChangeIn)).
+					Return(validPasswordChangeOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"accessToken":"fresh access token","refreshToken":"fresh refresh token"}`,
+		},
+		{
+			name:    "OK: cookies",
+			cookies: []*http.Cookie{{Name: "access_token", Value: "dummy access token"}},
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				passwordChangeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPasswordChangeIn)).
+					Return(validPasswordChangeOut, noError)
+			},
+			expCode:    200,
+			expBody:    `{}`,
+			expCookies: []string{"access_token", "refresh_token", "csrf_token"},
+		},
+		{
+			name: "Bad request: request validation failed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name: "Bad request: incorrect password",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				passwordChangeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPasswordChangeIn)).
+					Return(noPasswordChangeOut, dto.ErrIncorrectPassword)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2005,"name":"Incorrect password"},"message":"Current password is incorrect."}`,
+		},
+		{
+			name: "Too many requests: current password guessing throttled",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				passwordChangeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPasswordChangeIn)).
+					Return(noPasswordChangeOut, &dto.LoginThrottledError{RetryAfter: time.Minute})
+			},
+			expCode:       429,
+			expBody:       `{"error":{"code":2007,"name":"Too many login attempts"},"message":"Too many failed login attempts. Please try again later."}`,
+			expRetryAfter: "60",
+		},
+		{
+			name: "Unauthorized: user not found",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				passwordChangeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPasswordChangeIn)).
+					Return(noPasswordChangeOut, dto.ErrUserNotFound)
+			},
+			expCode: 401,
+			expBody: `{"message":"Please try to sign in again."}`,
+		},
+		{
+			name: "Bad request: password policy violated",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				policyErr := &dto.PasswordPolicyError{Violations: []string{dto.PasswordRuleMinLength, dto.PasswordRuleNotBreached}}
+				passwordChangeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPasswordChangeIn)).
+					Return(noPasswordChangeOut, policyErr)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2020,"name":"Password policy violated"},"message":"Password doesn't meet the requirements.","violations":["min_length","not_breached"]}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				passwordChangeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPasswordChangeIn)).
+					Return(noPasswordChangeOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, userID)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			rBody := `{"currentPassword":"Qwerty123!","newPassword":"Qwerty456!"}`
+			r := httptest.NewRequest(method, path, io.NopCloser(bytes.NewBufferString(rBody)))
+			r.Header.Set("User-Agent", userAgent)
+			for _, c := range tt.cookies {
+				r.AddCookie(c)
+			}
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, passwordChangeCase, validator, serverErrorHandlerFactory, authenticate)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+			assert.Equal(t, tt.expCookies, cookieNames(w))
+			assert.Equal(t, tt.expRetryAfter, w.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func cookieNames(w *httptest.ResponseRecorder) []string {
+	var names []string
+	for _, c := range w.Result().Cookies() {
+		names = append(names, c.Name)
+	}
+	return names
+}
@@ -0,0 +1,107 @@
+package v1_password_change
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	CurrentPassword string `json:"currentPassword" validate:"required,lte=128"`
+	NewPassword     string `json:"newPassword" validate:"required,lte=128,nefield=CurrentPassword"`
+}
+
+// response has no tokens if the user is authenticated by the session cookies,
+// the cookies are renewed instead.
+type response struct {
+	AccessToken  string `json:"accessToken,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+}
+
+type handler struct {
+	passwordChangeCase passwordChangeCase
+	validator          validation.Validator
+	serverErrorHandler api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	out, err := h.useCase(ctx, api.GetUserID(ctx), ctx.ClientIP(), ctx.Request.UserAgent(), req)
+	if err != nil {
+		if throttledErr, ok := err.(*dto.LoginThrottledError); ok {
+			auth_api.LoginThrottledResponse(ctx, throttledErr.RetryAfter)
+			return
+		}
+		if policyErr, ok := err.(*dto.PasswordPolicyError); ok {
+			auth_api.PasswordPolicyViolatedResponse(ctx, policyErr.Violations)
+			return
+		}
+
+		switch err {
+		case dto.ErrIncorrectPassword:
+			auth_api.IncorrectPasswordResponse(ctx)
+		case dto.ErrUserNotFound:
+			api.UnauthorizedResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	if _, fromCookie := auth_api.AccessToken(ctx); fromCookie {
+		h.cookieResponse(ctx, out)
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, userID int64, clientIP, userAgent string, req *request) (*dto.PasswordChangeOut, error) {
+	in := dto.PasswordChangeIn{
+		UserID:          userID,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+		IP:              clientIP,
+		UserAgent:       userAgent,
+	}
+
+	return h.passwordChangeCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.PasswordChangeOut) {
+	ctx.JSON(http.StatusOK, &response{
+		AccessToken:  out.AccessToken,
+		RefreshToken: out.RefreshToken,
+	})
+}
+
+func (h *handler) cookieResponse(ctx *gin.Context, out *dto.PasswordChangeOut) {
+	if err := auth_api.SetSessionCookies(ctx, out.AccessToken, out.RefreshToken); err != nil {
+		h.serverErrorHandler.Handle(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &response{})
+}
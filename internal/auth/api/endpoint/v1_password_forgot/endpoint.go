@@ -0,0 +1,39 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_password_forgot
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+const (
+	method = http.MethodPost
+	path   = "/v1/auth/password/forgot"
+)
+
+type passwordForgotCase interface {
+	Use(ctx context.Context, in *dto.PasswordForgotIn) error
+}
+
+// Bind registers the endpoint behind the middlewares, e.g. the rate limits.
+func Bind(
+	router *gin.Engine,
+	passwordForgotCase passwordForgotCase,
+	validator validation.Validator,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
+) {
+	h := handler{
+		passwordForgotCase: passwordForgotCase,
+		validator:          validator,
+		serverErrorHandler: serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, append(middlewares, h.handle)...)
+}
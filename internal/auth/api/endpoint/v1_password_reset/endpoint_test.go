@@ -0,0 +1,150 @@
+package v1_password_reset
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_password_reset/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		passwordResetCase         = mock.NewMockpasswordResetCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		token      = "dummy reset token"
+		password   = "Qwerty123!"
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			Token:    token,
+			Password: password,
+		}
+		expectedPasswordResetIn = &dto.PasswordResetIn{
+			Token:    token,
+			Password: password,
+			IP:       "192.0.2.1",
+		}
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				passwordResetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPasswordResetIn)).
+					Return(noError)
+			},
+			expCode: 200,
+			expBody: `{"message":"Your password has been reset. Please sign in again."}`,
+		},
+		{
+			name: "Bad request: request validation failed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name: "Bad request: invalid password reset token",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				passwordResetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPasswordResetIn)).
+					Return(dto.ErrInvalidPasswordResetToken)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2004,"name":"Invalid password reset token"},"message":"Password reset link is invalid or expired. Please request a new one."}`,
+		},
+		{
+			name: "Bad request: password policy violated",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				policyErr := &dto.PasswordPolicyError{Violations: []string{dto.PasswordRuleMinLength, dto.PasswordRuleNotBreached}}
+				passwordResetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPasswordResetIn)).
+					Return(policyErr)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2020,"name":"Password policy violated"},"message":"Password doesn't meet the requirements.","violations":["min_length","not_breached"]}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				passwordResetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPasswordResetIn)).
+					Return(dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			rBody := `{"token":"dummy reset token","password":"Qwerty123!"}`
+			r := httptest.NewRequest(method, path, io.NopCloser(bytes.NewBufferString(rBody)))
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, passwordResetCase, validator, serverErrorHandlerFactory)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
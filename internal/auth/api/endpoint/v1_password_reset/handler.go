@@ -0,0 +1,80 @@
+package v1_password_reset
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	Token    string `json:"token" validate:"required,lte=255"`
+	Password string `json:"password" validate:"required,lte=128"`
+}
+
+type response struct {
+	Message string `json:"message,omitempty"`
+}
+
+type handler struct {
+	passwordResetCase  passwordResetCase
+	validator          validation.Validator
+	serverErrorHandler api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	if err = h.useCase(ctx, ctx.ClientIP(), ctx.Request.UserAgent(), req); err != nil {
+		if policyErr, ok := err.(*dto.PasswordPolicyError); ok {
+			auth_api.PasswordPolicyViolatedResponse(ctx, policyErr.Violations)
+			return
+		}
+
+		switch err {
+		case dto.ErrInvalidPasswordResetToken:
+			auth_api.InvalidPasswordResetTokenResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, clientIP, userAgent string, req *request) error {
+	in := dto.PasswordResetIn{
+		Token:     req.Token,
+		Password:  req.Password,
+		IP:        clientIP,
+		UserAgent: userAgent,
+	}
+
+	return h.passwordResetCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context) {
+	const message = "Your password has been reset. Please sign in again."
+	ctx.JSON(http.StatusOK, &response{Message: message})
+}
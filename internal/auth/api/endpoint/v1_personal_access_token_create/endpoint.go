@@ -0,0 +1,40 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_personal_access_token_create
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+const (
+	method = http.MethodPost
+	path   = "/v1/auth/tokens"
+)
+
+type personalAccessTokenCreateCase interface {
+	Use(ctx context.Context, in *dto.PersonalAccessTokenCreateIn) (*dto.PersonalAccessTokenCreateOut, error)
+}
+
+// Bind registers the endpoint behind the middlewares,
+// which must set ID and the permissions of the authenticated user to the context.
+func Bind(
+	router *gin.Engine,
+	personalAccessTokenCreateCase personalAccessTokenCreateCase,
+	validator validation.Validator,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
+) {
+	h := handler{
+		personalAccessTokenCreateCase: personalAccessTokenCreateCase,
+		validator:                     validator,
+		serverErrorHandler:            serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, append(middlewares, h.handle)...)
+}
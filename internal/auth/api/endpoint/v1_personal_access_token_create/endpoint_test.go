@@ -0,0 +1,184 @@
+package v1_personal_access_token_create
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_personal_access_token_create/mock"
+	"github.com/art-es/blog/internal/auth/api/middleware/authenticated"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		personalAccessTokenCreateCase = mock.NewMockpersonalAccessTokenCreateCase(ctrl)
+		validator                     = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory     = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler            = mock_api.NewMockServerErrorHandler(ctrl)
+
+		userID      = int64(1)
+		permissions = []string{"post:read", "post:write"}
+		expiresAt   = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		noError     = (error)(nil)
+		dummyError  = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			Name:      "CI",
+			Scopes:    []string{"post:write"},
+			ExpiresAt: &expiresAt,
+		}
+		expectedPersonalAccessTokenCreateIn = &dto.PersonalAccessTokenCreateIn{
+			UserID:      userID,
+			Permissions: permissions,
+			Name:        "CI",
+			Scopes:      []string{"post:write"},
+			ExpiresAt:   &expiresAt,
+		}
+		validPersonalAccessTokenCreateOut = &dto.PersonalAccessTokenCreateOut{
+			ID:    "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b",
+			Token: "bpat_dummyToken",
+		}
+		noPersonalAccessTokenCreateOut = (*dto.PersonalAccessTokenCreateOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				personalAccessTokenCreateCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPersonalAccessTokenCreateIn)).
+					Return(validPersonalAccessTokenCreateOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"id":"8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b","token":"bpat_dummyToken"}`,
+		},
+		{
+			name: "Bad request: request validation failed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name: "Forbidden: scope not granted",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				personalAccessTokenCreateCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPersonalAccessTokenCreateIn)).
+					Return(noPersonalAccessTokenCreateOut, dto.ErrScopeNotGranted)
+			},
+			expCode: 403,
+			expBody: `{"error":{"code":2018,"name":"Scope not granted"},"message":"Token scopes must be among your permissions."}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				personalAccessTokenCreateCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPersonalAccessTokenCreateIn)).
+					Return(noPersonalAccessTokenCreateOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, userID)
+		api.SetPermissions(ctx, permissions)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			rBody := `{"name":"CI","scopes":["post:write"],"expiresAt":"2030-01-01T00:00:00Z"}`
+			r := httptest.NewRequest(method, path, io.NopCloser(bytes.NewBufferString(rBody)))
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, personalAccessTokenCreateCase, validator, serverErrorHandlerFactory, authenticate)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
+
+// TestEndpoint_personalAccessToken checks the account can't be managed with a personal access token.
+func TestEndpoint_personalAccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		personalAccessTokenCreateCase = mock.NewMockpersonalAccessTokenCreateCase(ctrl)
+		validator                     = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory     = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(mock_api.NewMockServerErrorHandler(ctrl))
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, 1)
+		api.SetPersonalAccessToken(ctx, true)
+	}
+
+	r := httptest.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+
+	router := gin.New()
+	Bind(router, personalAccessTokenCreateCase, validator, serverErrorHandlerFactory, authenticate, authenticated.New().Handle)
+
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, 403, w.Code)
+	assert.JSONEq(t, `{"error":{"code":2035,"name":"Personal access token not allowed"},"message":"Please sign in to manage your account, personal access tokens can't be used for this action."}`, w.Body.String())
+}
@@ -0,0 +1,88 @@
+package v1_personal_access_token_create
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1,unique,dive,required"`
+	// ExpiresAt is omitted for the tokens which never expire.
+	ExpiresAt *time.Time `json:"expiresAt" validate:"omitempty,gt"`
+}
+
+type response struct {
+	ID    string `json:"id"`
+	Token string `json:"token"`
+}
+
+type handler struct {
+	personalAccessTokenCreateCase personalAccessTokenCreateCase
+	validator                     validation.Validator
+	serverErrorHandler            api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	out, err := h.useCase(ctx, api.GetUserID(ctx), api.GetPermissions(ctx), req)
+	if err != nil {
+		switch err {
+		case dto.ErrScopeNotGranted:
+			auth_api.ScopeNotGrantedResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(
+	ctx context.Context,
+	userID int64,
+	permissions []string,
+	req *request,
+) (*dto.PersonalAccessTokenCreateOut, error) {
+	in := dto.PersonalAccessTokenCreateIn{
+		UserID:      userID,
+		Permissions: permissions,
+		Name:        req.Name,
+		Scopes:      req.Scopes,
+		ExpiresAt:   req.ExpiresAt,
+	}
+
+	return h.personalAccessTokenCreateCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.PersonalAccessTokenCreateOut) {
+	ctx.JSON(http.StatusOK, &response{
+		ID:    out.ID,
+		Token: out.Token,
+	})
+}
@@ -0,0 +1,37 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_personal_access_token_list
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+const (
+	method = http.MethodGet
+	path   = "/v1/auth/tokens"
+)
+
+type personalAccessTokenListCase interface {
+	Use(ctx context.Context, in *dto.PersonalAccessTokenListIn) (*dto.PersonalAccessTokenListOut, error)
+}
+
+// Bind registers the endpoint behind the middlewares,
+// which must set ID of the authenticated user to the context.
+func Bind(
+	router *gin.Engine,
+	personalAccessTokenListCase personalAccessTokenListCase,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
+) {
+	h := handler{
+		personalAccessTokenListCase: personalAccessTokenListCase,
+		serverErrorHandler:          serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, append(middlewares, h.handle)...)
+}
@@ -0,0 +1,130 @@
+package v1_personal_access_token_list
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_personal_access_token_list/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		personalAccessTokenListCase = mock.NewMockpersonalAccessTokenListCase(ctrl)
+		serverErrorHandlerFactory   = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler          = mock_api.NewMockServerErrorHandler(ctrl)
+
+		userID     = int64(1)
+		createdAt  = time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+		expiresAt  = createdAt.Add(30 * 24 * time.Hour)
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedPersonalAccessTokenListIn = &dto.PersonalAccessTokenListIn{
+			UserID: userID,
+		}
+		validPersonalAccessTokenListOut = &dto.PersonalAccessTokenListOut{
+			Tokens: []dto.PersonalAccessToken{
+				{
+					ID:        "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b",
+					Name:      "CI",
+					Scopes:    []string{"post:write"},
+					CreatedAt: createdAt,
+					ExpiresAt: &expiresAt,
+				},
+				{
+					ID:        "1c9d4e2b-3a5f-4b6c-8d7e-9f0a1b2c3d4e",
+					Name:      "Backup",
+					Scopes:    []string{"post:read"},
+					CreatedAt: createdAt.Add(-time.Hour),
+				},
+			},
+		}
+		noPersonalAccessTokenListOut = (*dto.PersonalAccessTokenListOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				personalAccessTokenListCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPersonalAccessTokenListIn)).
+					Return(validPersonalAccessTokenListOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"tokens":[
+				{"id":"8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b","name":"CI","scopes":["post:write"],"createdAt":"2024-03-01T12:30:00Z","expiresAt":"2024-03-31T12:30:00Z"},
+				{"id":"1c9d4e2b-3a5f-4b6c-8d7e-9f0a1b2c3d4e","name":"Backup","scopes":["post:read"],"createdAt":"2024-03-01T11:30:00Z","expiresAt":null}
+			]}`,
+		},
+		{
+			name: "OK: no tokens",
+			setup: func() {
+				personalAccessTokenListCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPersonalAccessTokenListIn)).
+					Return(&dto.PersonalAccessTokenListOut{}, noError)
+			},
+			expCode: 200,
+			expBody: `{"tokens":[]}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				personalAccessTokenListCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPersonalAccessTokenListIn)).
+					Return(noPersonalAccessTokenListOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, userID)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			r := httptest.NewRequest(method, path, nil)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, personalAccessTokenListCase, serverErrorHandlerFactory, authenticate)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package v1_personal_access_token_list
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+type response struct {
+	Tokens []token `json:"tokens"`
+}
+
+type token struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+type handler struct {
+	personalAccessTokenListCase personalAccessTokenListCase
+	serverErrorHandler          api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	out, err := h.useCase(ctx, api.GetUserID(ctx))
+	if err != nil {
+		h.serverErrorHandler.Handle(ctx, err)
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) useCase(ctx context.Context, userID int64) (*dto.PersonalAccessTokenListOut, error) {
+	in := dto.PersonalAccessTokenListIn{
+		UserID: userID,
+	}
+
+	return h.personalAccessTokenListCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.PersonalAccessTokenListOut) {
+	res := &response{Tokens: make([]token, 0, len(out.Tokens))}
+	for _, t := range out.Tokens {
+		res.Tokens = append(res.Tokens, token{
+			ID:        t.ID,
+			Name:      t.Name,
+			Scopes:    t.Scopes,
+			CreatedAt: t.CreatedAt,
+			ExpiresAt: t.ExpiresAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,40 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_personal_access_token_revoke
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+const (
+	method = http.MethodDelete
+	path   = "/v1/auth/tokens/:id"
+)
+
+type personalAccessTokenRevokeCase interface {
+	Use(ctx context.Context, in *dto.PersonalAccessTokenRevokeIn) error
+}
+
+// Bind registers the endpoint behind the middlewares,
+// which must set ID of the authenticated user to the context.
+func Bind(
+	router *gin.Engine,
+	personalAccessTokenRevokeCase personalAccessTokenRevokeCase,
+	validator validation.Validator,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
+) {
+	h := handler{
+		personalAccessTokenRevokeCase: personalAccessTokenRevokeCase,
+		validator:                     validator,
+		serverErrorHandler:            serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, append(middlewares, h.handle)...)
+}
@@ -0,0 +1,134 @@
+package v1_personal_access_token_revoke
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_personal_access_token_revoke/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		personalAccessTokenRevokeCase = mock.NewMockpersonalAccessTokenRevokeCase(ctrl)
+		validator                     = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory     = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler            = mock_api.NewMockServerErrorHandler(ctrl)
+
+		userID     = int64(1)
+		tokenID    = "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b"
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			ID: tokenID,
+		}
+		expectedPersonalAccessTokenRevokeIn = &dto.PersonalAccessTokenRevokeIn{
+			UserID: userID,
+			ID:     tokenID,
+		}
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				personalAccessTokenRevokeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPersonalAccessTokenRevokeIn)).
+					Return(noError)
+			},
+			expCode: 200,
+			expBody: `{"message":"Personal access token has been revoked."}`,
+		},
+		{
+			name: "Bad request: request validation failed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name: "Not found: personal access token not found",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				personalAccessTokenRevokeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPersonalAccessTokenRevokeIn)).
+					Return(dto.ErrPersonalAccessTokenNotFound)
+			},
+			expCode: 404,
+			expBody: `{"error":{"code":2019,"name":"Personal access token not found"},"message":"Personal access token is not found or has already been revoked."}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				personalAccessTokenRevokeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPersonalAccessTokenRevokeIn)).
+					Return(dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, userID)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			r := httptest.NewRequest(method, "/v1/auth/tokens/"+tokenID, nil)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, personalAccessTokenRevokeCase, validator, serverErrorHandlerFactory, authenticate)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package v1_personal_access_token_revoke
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	ID string `uri:"id" validate:"required,uuid"`
+}
+
+type response struct {
+	Message string `json:"message"`
+}
+
+type handler struct {
+	personalAccessTokenRevokeCase personalAccessTokenRevokeCase
+	validator                     validation.Validator
+	serverErrorHandler            api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	if err = h.useCase(ctx, api.GetUserID(ctx), req); err != nil {
+		switch err {
+		case dto.ErrPersonalAccessTokenNotFound:
+			auth_api.PersonalAccessTokenNotFoundResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindUri(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, userID int64, req *request) error {
+	in := dto.PersonalAccessTokenRevokeIn{
+		UserID: userID,
+		ID:     req.ID,
+	}
+
+	return h.personalAccessTokenRevokeCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context) {
+	const message = "Personal access token has been revoked."
+	ctx.JSON(http.StatusOK, &response{Message: message})
+}
@@ -0,0 +1,37 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_public_profile_get
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+const (
+	method = http.MethodGet
+	path   = "/v1/users/:handle"
+)
+
+type publicProfileGetCase interface {
+	Use(ctx context.Context, in *dto.PublicProfileGetIn) (*dto.PublicProfileGetOut, error)
+}
+
+func Bind(
+	router *gin.Engine,
+	publicProfileGetCase publicProfileGetCase,
+	validator validation.Validator,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+) {
+	h := handler{
+		publicProfileGetCase: publicProfileGetCase,
+		validator:            validator,
+		serverErrorHandler:   serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, h.handle)
+}
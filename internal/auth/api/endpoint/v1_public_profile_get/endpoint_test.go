@@ -0,0 +1,138 @@
+package v1_public_profile_get
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_public_profile_get/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		publicProfileGetCase      = mock.NewMockpublicProfileGetCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		handle     = "ivanov"
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			Handle: handle,
+		}
+		expectedPublicProfileGetIn = &dto.PublicProfileGetIn{
+			Handle: handle,
+		}
+		validPublicProfileGetOut = &dto.PublicProfileGetOut{
+			Profile: dto.PublicProfile{
+				Handle:      handle,
+				DisplayName: "Ivan",
+				Bio:         "Writer",
+				Website:     "https://ivanov.example.com",
+				AvatarURL:   "https://cdn.example.com/ivanov.png",
+			},
+		}
+		noPublicProfileGetOut = (*dto.PublicProfileGetOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				publicProfileGetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPublicProfileGetIn)).
+					Return(validPublicProfileGetOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"handle":"ivanov","displayName":"Ivan","bio":"Writer","website":"https://ivanov.example.com","avatarUrl":"https://cdn.example.com/ivanov.png"}`,
+		},
+		{
+			name: "Bad request: request validation failed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name: "Not found: profile not found",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				publicProfileGetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPublicProfileGetIn)).
+					Return(noPublicProfileGetOut, dto.ErrProfileNotFound)
+			},
+			expCode: 404,
+			expBody: `{"error":{"code":2022,"name":"Profile not found"},"message":"Profile is not found."}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				publicProfileGetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedPublicProfileGetIn)).
+					Return(noPublicProfileGetOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			r := httptest.NewRequest(method, "/v1/users/"+handle, nil)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, publicProfileGetCase, validator, serverErrorHandlerFactory)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
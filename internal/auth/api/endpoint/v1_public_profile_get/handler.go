@@ -0,0 +1,81 @@
+package v1_public_profile_get
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	Handle string `uri:"handle" validate:"required,max=30"`
+}
+
+type response struct {
+	Handle      string `json:"handle"`
+	DisplayName string `json:"displayName"`
+	Bio         string `json:"bio"`
+	Website     string `json:"website"`
+	AvatarURL   string `json:"avatarUrl"`
+}
+
+type handler struct {
+	publicProfileGetCase publicProfileGetCase
+	validator            validation.Validator
+	serverErrorHandler   api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	out, err := h.useCase(ctx, req)
+	if err != nil {
+		switch err {
+		case dto.ErrProfileNotFound:
+			auth_api.ProfileNotFoundResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindUri(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, req *request) (*dto.PublicProfileGetOut, error) {
+	in := dto.PublicProfileGetIn{
+		Handle: req.Handle,
+	}
+
+	return h.publicProfileGetCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.PublicProfileGetOut) {
+	ctx.JSON(http.StatusOK, &response{
+		Handle:      out.Profile.Handle,
+		DisplayName: out.Profile.DisplayName,
+		Bio:         out.Profile.Bio,
+		Website:     out.Profile.Website,
+		AvatarURL:   out.Profile.AvatarURL,
+	})
+}
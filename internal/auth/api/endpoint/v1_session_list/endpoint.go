@@ -0,0 +1,37 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_session_list
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+const (
+	method = http.MethodGet
+	path   = "/v1/auth/sessions"
+)
+
+type sessionListCase interface {
+	Use(ctx context.Context, in *dto.SessionListIn) (*dto.SessionListOut, error)
+}
+
+// Bind registers the endpoint behind the middlewares,
+// which must set ID of the authenticated user and the session to the context.
+func Bind(
+	router *gin.Engine,
+	sessionListCase sessionListCase,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
+) {
+	h := handler{
+		sessionListCase:    sessionListCase,
+		serverErrorHandler: serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, append(middlewares, h.handle)...)
+}
@@ -0,0 +1,157 @@
+package v1_session_list
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_session_list/mock"
+	"github.com/art-es/blog/internal/auth/api/middleware/authenticated"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		sessionListCase           = mock.NewMocksessionListCase(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		userID     = int64(1)
+		sessionID  = "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b"
+		usedAt     = time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedSessionListIn = &dto.SessionListIn{
+			UserID:    userID,
+			SessionID: sessionID,
+		}
+		validSessionListOut = &dto.SessionListOut{
+			Sessions: []dto.Session{
+				{
+					ID:         sessionID,
+					UserAgent:  "Mozilla/5.0 (X11; Linux x86_64)",
+					IP:         "192.0.2.1",
+					CreatedAt:  usedAt.Add(-time.Hour),
+					LastUsedAt: usedAt,
+					Current:    true,
+				},
+			},
+		}
+		noSessionListOut = (*dto.SessionListOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				sessionListCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedSessionListIn)).
+					Return(validSessionListOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"sessions":[{"id":"8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b","userAgent":"Mozilla/5.0 (X11; Linux x86_64)","ip":"192.0.2.1","createdAt":"2024-03-01T11:30:00Z","lastUsedAt":"2024-03-01T12:30:00Z","current":true}]}`,
+		},
+		{
+			name: "OK: no sessions",
+			setup: func() {
+				sessionListCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedSessionListIn)).
+					Return(&dto.SessionListOut{}, noError)
+			},
+			expCode: 200,
+			expBody: `{"sessions":[]}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				sessionListCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedSessionListIn)).
+					Return(noSessionListOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, userID)
+		api.SetSessionID(ctx, sessionID)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			r := httptest.NewRequest(method, path, nil)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, sessionListCase, serverErrorHandlerFactory, authenticate)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
+
+// TestEndpoint_personalAccessToken checks the account can't be managed with a personal access token.
+func TestEndpoint_personalAccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		sessionListCase           = mock.NewMocksessionListCase(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(mock_api.NewMockServerErrorHandler(ctrl))
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, 1)
+		api.SetPersonalAccessToken(ctx, true)
+	}
+
+	r := httptest.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+
+	router := gin.New()
+	Bind(router, sessionListCase, serverErrorHandlerFactory, authenticate, authenticated.New().Handle)
+
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, 403, w.Code)
+	assert.JSONEq(t, `{"error":{"code":2035,"name":"Personal access token not allowed"},"message":"Please sign in to manage your account, personal access tokens can't be used for this action."}`, w.Body.String())
+}
@@ -0,0 +1,65 @@
+package v1_session_list
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+type response struct {
+	Sessions []session `json:"sessions"`
+}
+
+type session struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"userAgent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	Current    bool      `json:"current"`
+}
+
+type handler struct {
+	sessionListCase    sessionListCase
+	serverErrorHandler api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	out, err := h.useCase(ctx, api.GetUserID(ctx), api.GetSessionID(ctx))
+	if err != nil {
+		h.serverErrorHandler.Handle(ctx, err)
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) useCase(ctx context.Context, userID int64, sessionID string) (*dto.SessionListOut, error) {
+	in := dto.SessionListIn{
+		UserID:    userID,
+		SessionID: sessionID,
+	}
+
+	return h.sessionListCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.SessionListOut) {
+	res := &response{Sessions: make([]session, 0, len(out.Sessions))}
+	for _, s := range out.Sessions {
+		res.Sessions = append(res.Sessions, session{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			Current:    s.Current,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,168 @@
+package v1_session_revoke
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_session_revoke/mock"
+	"github.com/art-es/blog/internal/auth/api/middleware/authenticated"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		sessionRevokeCase         = mock.NewMocksessionRevokeCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		userID     = int64(1)
+		sessionID  = "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b"
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			ID: sessionID,
+		}
+		expectedSessionRevokeIn = &dto.SessionRevokeIn{
+			UserID: userID,
+			ID:     sessionID,
+		}
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				sessionRevokeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedSessionRevokeIn)).
+					Return(noError)
+			},
+			expCode: 200,
+			expBody: `{"message":"Session has been ended."}`,
+		},
+		{
+			name: "Bad request: request validation failed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name: "Not found: session not found",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				sessionRevokeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedSessionRevokeIn)).
+					Return(dto.ErrSessionNotFound)
+			},
+			expCode: 404,
+			expBody: `{"error":{"code":2016,"name":"Session not found"},"message":"Session is not found or has already ended."}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				sessionRevokeCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedSessionRevokeIn)).
+					Return(dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, userID)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			r := httptest.NewRequest(method, "/v1/auth/sessions/"+sessionID, nil)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, sessionRevokeCase, validator, serverErrorHandlerFactory, authenticate)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
+
+// TestEndpoint_personalAccessToken checks the account can't be managed with a personal access token.
+func TestEndpoint_personalAccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		sessionRevokeCase         = mock.NewMocksessionRevokeCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(mock_api.NewMockServerErrorHandler(ctrl))
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, 1)
+		api.SetPersonalAccessToken(ctx, true)
+	}
+
+	r := httptest.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+
+	router := gin.New()
+	Bind(router, sessionRevokeCase, validator, serverErrorHandlerFactory, authenticate, authenticated.New().Handle)
+
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, 403, w.Code)
+	assert.JSONEq(t, `{"error":{"code":2035,"name":"Personal access token not allowed"},"message":"Please sign in to manage your account, personal access tokens can't be used for this action."}`, w.Body.String())
+}
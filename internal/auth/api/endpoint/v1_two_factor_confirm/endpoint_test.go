@@ -0,0 +1,203 @@
+package v1_two_factor_confirm
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_two_factor_confirm/mock"
+	"github.com/art-es/blog/internal/auth/api/middleware/authenticated"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		twoFactorConfirmCase      = mock.NewMocktwoFactorConfirmCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		userID     = int64(1)
+		code       = "123456"
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			Code: code,
+		}
+		expectedTwoFactorConfirmIn = &dto.TwoFactorConfirmIn{
+			UserID: userID,
+			Code:   code,
+		}
+		validTwoFactorConfirmOut = &dto.TwoFactorConfirmOut{
+			RecoveryCodes: []string{"abcde-fghij", "klmno-pqrst"},
+		}
+		noTwoFactorConfirmOut = (*dto.TwoFactorConfirmOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				twoFactorConfirmCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedTwoFactorConfirmIn)).
+					Return(validTwoFactorConfirmOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"recoveryCodes":["abcde-fghij","klmno-pqrst"]}`,
+		},
+		{
+			name: "Bad request: request validation failed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name: "Bad request: invalid code",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				twoFactorConfirmCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedTwoFactorConfirmIn)).
+					Return(noTwoFactorConfirmOut, dto.ErrInvalidTwoFactorCode)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2008,"name":"Invalid two-factor code"},"message":"Authentication code is invalid."}`,
+		},
+		{
+			name: "Bad request: two-factor not enrolled",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				twoFactorConfirmCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedTwoFactorConfirmIn)).
+					Return(noTwoFactorConfirmOut, dto.ErrTwoFactorNotEnabled)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2011,"name":"Two-factor authentication not enabled"},"message":"Two-factor authentication is not enabled. Please set it up first."}`,
+		},
+		{
+			name: "Bad request: two-factor already enabled",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				twoFactorConfirmCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedTwoFactorConfirmIn)).
+					Return(noTwoFactorConfirmOut, dto.ErrTwoFactorAlreadyEnabled)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2010,"name":"Two-factor authentication already enabled"},"message":"Two-factor authentication is already enabled. Disable it first to set up a new authenticator."}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				twoFactorConfirmCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedTwoFactorConfirmIn)).
+					Return(noTwoFactorConfirmOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, userID)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			rBody := `{"code":"123456"}`
+			r := httptest.NewRequest(method, path, io.NopCloser(bytes.NewBufferString(rBody)))
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, twoFactorConfirmCase, validator, serverErrorHandlerFactory, authenticate)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
+
+// TestEndpoint_personalAccessToken checks the account can't be managed with a personal access token.
+func TestEndpoint_personalAccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		twoFactorConfirmCase      = mock.NewMocktwoFactorConfirmCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(mock_api.NewMockServerErrorHandler(ctrl))
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, 1)
+		api.SetPersonalAccessToken(ctx, true)
+	}
+
+	r := httptest.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+
+	router := gin.New()
+	Bind(router, twoFactorConfirmCase, validator, serverErrorHandlerFactory, authenticate, authenticated.New().Handle)
+
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, 403, w.Code)
+	assert.JSONEq(t, `{"error":{"code":2035,"name":"Personal access token not allowed"},"message":"Please sign in to manage your account, personal access tokens can't be used for this action."}`, w.Body.String())
+}
@@ -0,0 +1,78 @@
+package v1_two_factor_confirm
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	Code string `json:"code" validate:"required,numeric,len=6"`
+}
+
+type response struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type handler struct {
+	twoFactorConfirmCase twoFactorConfirmCase
+	validator            validation.Validator
+	serverErrorHandler   api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	out, err := h.useCase(ctx, api.GetUserID(ctx), req)
+	if err != nil {
+		switch err {
+		case dto.ErrInvalidTwoFactorCode:
+			auth_api.InvalidTwoFactorCodeResponse(ctx)
+		case dto.ErrTwoFactorNotEnabled:
+			auth_api.TwoFactorNotEnabledResponse(ctx)
+		case dto.ErrTwoFactorAlreadyEnabled:
+			auth_api.TwoFactorAlreadyEnabledResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, userID int64, req *request) (*dto.TwoFactorConfirmOut, error) {
+	in := dto.TwoFactorConfirmIn{
+		UserID: userID,
+		Code:   req.Code,
+	}
+
+	return h.twoFactorConfirmCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.TwoFactorConfirmOut) {
+	ctx.JSON(http.StatusOK, &response{
+		RecoveryCodes: out.RecoveryCodes,
+	})
+}
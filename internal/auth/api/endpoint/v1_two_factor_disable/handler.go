@@ -0,0 +1,77 @@
+package v1_two_factor_disable
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+// request accepts either the code or a recovery code.
+type request struct {
+	Code string `json:"code" validate:"required,lte=20"`
+}
+
+type response struct {
+	Message string `json:"message"`
+}
+
+type handler struct {
+	twoFactorDisableCase twoFactorDisableCase
+	validator            validation.Validator
+	serverErrorHandler   api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	if err = h.useCase(ctx, api.GetUserID(ctx), req); err != nil {
+		switch err {
+		case dto.ErrInvalidTwoFactorCode:
+			auth_api.InvalidTwoFactorCodeResponse(ctx)
+		case dto.ErrTwoFactorNotEnabled:
+			auth_api.TwoFactorNotEnabledResponse(ctx)
+		case dto.ErrTooManyTwoFactorAttempts:
+			auth_api.TooManyTwoFactorAttemptsResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, userID int64, req *request) error {
+	in := dto.TwoFactorDisableIn{
+		UserID: userID,
+		Code:   req.Code,
+	}
+
+	return h.twoFactorDisableCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context) {
+	const message = "Two-factor authentication has been disabled."
+	ctx.JSON(http.StatusOK, &response{Message: message})
+}
@@ -0,0 +1,37 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_two_factor_enroll
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+const (
+	method = http.MethodPost
+	path   = "/v1/auth/user/2fa/enroll"
+)
+
+type twoFactorEnrollCase interface {
+	Use(ctx context.Context, in *dto.TwoFactorEnrollIn) (*dto.TwoFactorEnrollOut, error)
+}
+
+// Bind registers the endpoint behind the middlewares,
+// which must set ID of the authenticated user to the context.
+func Bind(
+	router *gin.Engine,
+	twoFactorEnrollCase twoFactorEnrollCase,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
+) {
+	h := handler{
+		twoFactorEnrollCase: twoFactorEnrollCase,
+		serverErrorHandler:  serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, append(middlewares, h.handle)...)
+}
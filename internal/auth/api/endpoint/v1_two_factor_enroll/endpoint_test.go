@@ -0,0 +1,154 @@
+package v1_two_factor_enroll
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_two_factor_enroll/mock"
+	"github.com/art-es/blog/internal/auth/api/middleware/authenticated"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		twoFactorEnrollCase       = mock.NewMocktwoFactorEnrollCase(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		userID     = int64(1)
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedTwoFactorEnrollIn = &dto.TwoFactorEnrollIn{
+			UserID: userID,
+		}
+		validTwoFactorEnrollOut = &dto.TwoFactorEnrollOut{
+			Secret: "JBSWY3DPEHPK3PXP",
+			URI:    "otpauth://totp/Blog:i.ivanov@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Blog",
+		}
+		noTwoFactorEnrollOut = (*dto.TwoFactorEnrollOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				twoFactorEnrollCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedTwoFactorEnrollIn)).
+					Return(validTwoFactorEnrollOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"secret":"JBSWY3DPEHPK3PXP","uri":"otpauth://totp/Blog:i.ivanov@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Blog"}`,
+		},
+		{
+			name: "Bad request: two-factor already enabled",
+			setup: func() {
+				twoFactorEnrollCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedTwoFactorEnrollIn)).
+					Return(noTwoFactorEnrollOut, dto.ErrTwoFactorAlreadyEnabled)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2010,"name":"Two-factor authentication already enabled"},"message":"Two-factor authentication is already enabled. Disable it first to set up a new authenticator."}`,
+		},
+		{
+			name: "Unauthorized: user not found",
+			setup: func() {
+				twoFactorEnrollCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedTwoFactorEnrollIn)).
+					Return(noTwoFactorEnrollOut, dto.ErrUserNotFound)
+			},
+			expCode: 401,
+			expBody: `{"message":"Please try to sign in again."}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				twoFactorEnrollCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedTwoFactorEnrollIn)).
+					Return(noTwoFactorEnrollOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, userID)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			r := httptest.NewRequest(method, path, nil)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, twoFactorEnrollCase, serverErrorHandlerFactory, authenticate)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
+
+// TestEndpoint_personalAccessToken checks the account can't be managed with a personal access token.
+func TestEndpoint_personalAccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		twoFactorEnrollCase       = mock.NewMocktwoFactorEnrollCase(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(mock_api.NewMockServerErrorHandler(ctrl))
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, 1)
+		api.SetPersonalAccessToken(ctx, true)
+	}
+
+	r := httptest.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+
+	router := gin.New()
+	Bind(router, twoFactorEnrollCase, serverErrorHandlerFactory, authenticate, authenticated.New().Handle)
+
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, 403, w.Code)
+	assert.JSONEq(t, `{"error":{"code":2035,"name":"Personal access token not allowed"},"message":"Please sign in to manage your account, personal access tokens can't be used for this action."}`, w.Body.String())
+}
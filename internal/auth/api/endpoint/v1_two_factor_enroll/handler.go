@@ -0,0 +1,54 @@
+package v1_two_factor_enroll
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+type response struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type handler struct {
+	twoFactorEnrollCase twoFactorEnrollCase
+	serverErrorHandler  api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	out, err := h.useCase(ctx, api.GetUserID(ctx))
+	if err != nil {
+		switch err {
+		case dto.ErrTwoFactorAlreadyEnabled:
+			auth_api.TwoFactorAlreadyEnabledResponse(ctx)
+		case dto.ErrUserNotFound:
+			api.UnauthorizedResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) useCase(ctx context.Context, userID int64) (*dto.TwoFactorEnrollOut, error) {
+	in := dto.TwoFactorEnrollIn{
+		UserID: userID,
+	}
+
+	return h.twoFactorEnrollCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.TwoFactorEnrollOut) {
+	ctx.JSON(http.StatusOK, &response{
+		Secret: out.Secret,
+		URI:    out.URI,
+	})
+}
@@ -21,11 +21,13 @@ type userActivateCase interface {
 	Use(ctx context.Context, in *dto.UserActivateIn) error
 }
 
+// Bind registers the endpoint behind the middlewares, e.g. the rate limits.
 func Bind(
 	router *gin.Engine,
 	userActivateCase userActivateCase,
 	validator validation.Validator,
 	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
 ) {
 	h := handler{
 		userActivateCase:   userActivateCase,
@@ -33,5 +35,5 @@ func Bind(
 		serverErrorHandler: serverErrorHandlerFactory.MakeHandler(method, path),
 	}
 
-	router.Handle(method, path, h.handle)
+	router.Handle(method, path, append(middlewares, h.handle)...)
 }
@@ -37,6 +37,7 @@ func TestEndpoint(t *testing.T) {
 		}
 		expectedUserActivateIn = &dto.UserActivateIn{
 			Code: code,
+			IP:   "192.0.2.1",
 		}
 	)
 
@@ -87,7 +88,7 @@ func TestEndpoint(t *testing.T) {
 					Return(dto.ErrExpiredUserActivationCode)
 			},
 			expCode: 400,
-			expBody: `{"error":{"code":2002,"name":"Expired user activation code"},"message":"Activate code is expired. Please pass the registration process again."}`,
+			expBody: `{"error":{"code":2002,"name":"Expired user activation code"},"message":"Activation code is expired. Please request a new one."}`,
 		},
 		{
 			name: "Not found: user activation code not found",
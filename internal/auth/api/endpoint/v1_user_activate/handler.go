@@ -33,7 +33,7 @@ func (h *handler) handle(ctx *gin.Context) {
 		return
 	}
 
-	if err = h.useCase(ctx, req); err != nil {
+	if err = h.useCase(ctx, ctx.ClientIP(), ctx.Request.UserAgent(), req); err != nil {
 		switch err {
 		case dto.ErrUserActivationCodeNotFound, dto.ErrUserNotFound:
 			notFoundResponse(ctx)
@@ -59,9 +59,11 @@ func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
 	return &req, nil
 }
 
-func (h *handler) useCase(ctx context.Context, req *request) error {
+func (h *handler) useCase(ctx context.Context, clientIP, userAgent string, req *request) error {
 	in := dto.UserActivateIn{
-		Code: req.Code,
+		Code:      req.Code,
+		IP:        clientIP,
+		UserAgent: userAgent,
 	}
 
 	return h.userActivateCase.Use(ctx, &in)
@@ -0,0 +1,39 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_user_activation_resend
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+const (
+	method = http.MethodPost
+	path   = "/v1/auth/user/activation/resend"
+)
+
+type userActivationResendCase interface {
+	Use(ctx context.Context, in *dto.UserActivationResendIn) error
+}
+
+// Bind registers the endpoint behind the middlewares, e.g. the rate limits.
+func Bind(
+	router *gin.Engine,
+	userActivationResendCase userActivationResendCase,
+	validator validation.Validator,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
+) {
+	h := handler{
+		userActivationResendCase: userActivationResendCase,
+		validator:                validator,
+		serverErrorHandler:       serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, append(middlewares, h.handle)...)
+}
@@ -0,0 +1,65 @@
+package v1_user_activation_resend
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+type request struct {
+	Email string `json:"email" validate:"required,email,lte=255"`
+}
+
+type response struct {
+	Message string `json:"message,omitempty"`
+}
+
+type handler struct {
+	userActivationResendCase userActivationResendCase
+	validator                validation.Validator
+	serverErrorHandler       api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	if err = h.useCase(ctx, req); err != nil {
+		h.serverErrorHandler.Handle(ctx, err)
+		return
+	}
+
+	okResponse(ctx)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, req *request) error {
+	in := dto.UserActivationResendIn{
+		Email: req.Email,
+	}
+
+	return h.userActivationResendCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context) {
+	const message = "If your account is waiting for activation, please check your email for a new activation code."
+	ctx.JSON(http.StatusOK, &response{Message: message})
+}
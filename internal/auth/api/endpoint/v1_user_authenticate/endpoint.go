@@ -21,11 +21,13 @@ type userAuthenticateCase interface {
 	Use(ctx context.Context, in *dto.UserAuthenticateIn) (*dto.UserAuthenticateOut, error)
 }
 
+// Bind registers the endpoint behind the middlewares, e.g. the rate limits.
 func Bind(
 	router *gin.Engine,
 	userAuthenticateCase userAuthenticateCase,
 	validator validation.Validator,
 	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
 ) {
 	h := handler{
 		userAuthenticateCase: userAuthenticateCase,
@@ -33,5 +35,5 @@ func Bind(
 		serverErrorHandler:   serverErrorHandlerFactory.MakeHandler(method, path),
 	}
 
-	router.Handle(method, path, h.handle)
+	router.Handle(method, path, append(middlewares, h.handle)...)
 }
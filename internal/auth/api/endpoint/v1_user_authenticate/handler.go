@@ -12,13 +12,18 @@ import (
 	"github.com/art-es/blog/internal/common/validation"
 )
 
+// request with useCookies has the tokens set to the session cookies instead of the response body.
 type request struct {
-	Email    string `json:"email" validate:"required,email,lte=255"`
-	Password string `json:"password" validate:"required,lte=70"`
+	Email      string `json:"email" validate:"required,email,lte=255"`
+	Password   string `json:"password" validate:"required,lte=128"`
+	UseCookies bool   `json:"useCookies"`
 }
 
+// response has only twoFactorToken if the user has two-factor authentication enabled.
 type response struct {
-	AccessToken string `json:"accessToken,omitempty"`
+	AccessToken    string `json:"accessToken,omitempty"`
+	RefreshToken   string `json:"refreshToken,omitempty"`
+	TwoFactorToken string `json:"twoFactorToken,omitempty"`
 }
 
 type handler struct {
@@ -34,17 +39,31 @@ func (h *handler) handle(ctx *gin.Context) {
 		return
 	}
 
-	out, err := h.useCase(ctx, req)
+	out, err := h.useCase(ctx, ctx.ClientIP(), ctx.Request.UserAgent(), req)
 	if err != nil {
+		if throttledErr, ok := err.(*dto.LoginThrottledError); ok {
+			auth_api.LoginThrottledResponse(ctx, throttledErr.RetryAfter)
+			return
+		}
+
 		switch err {
 		case dto.ErrUserNotFound, dto.ErrIncorrectPassword:
 			auth_api.IncorrectUserCredentialsResponse(ctx)
+		case dto.ErrUserNotActivated:
+			auth_api.UserNotActivatedResponse(ctx)
+		case dto.ErrUserSuspended:
+			auth_api.UserSuspendedResponse(ctx)
 		default:
 			h.serverErrorHandler.Handle(ctx, err)
 		}
 		return
 	}
 
+	if req.UseCookies && out.AccessToken != "" {
+		h.cookieResponse(ctx, out)
+		return
+	}
+
 	okResponse(ctx, out)
 }
 
@@ -59,15 +78,30 @@ func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
 	return &req, nil
 }
 
-func (h *handler) useCase(ctx context.Context, req *request) (*dto.UserAuthenticateOut, error) {
+func (h *handler) useCase(ctx context.Context, clientIP, userAgent string, req *request) (*dto.UserAuthenticateOut, error) {
 	in := dto.UserAuthenticateIn{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:     req.Email,
+		Password:  req.Password,
+		IP:        clientIP,
+		UserAgent: userAgent,
 	}
 
 	return h.userAuthenticateCase.Use(ctx, &in)
 }
 
 func okResponse(ctx *gin.Context, result *dto.UserAuthenticateOut) {
-	ctx.JSON(http.StatusOK, &response{AccessToken: result.AccessToken})
+	ctx.JSON(http.StatusOK, &response{
+		AccessToken:    result.AccessToken,
+		RefreshToken:   result.RefreshToken,
+		TwoFactorToken: result.TwoFactorToken,
+	})
+}
+
+func (h *handler) cookieResponse(ctx *gin.Context, result *dto.UserAuthenticateOut) {
+	if err := auth_api.SetSessionCookies(ctx, result.AccessToken, result.RefreshToken); err != nil {
+		h.serverErrorHandler.Handle(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &response{})
 }
@@ -0,0 +1,219 @@
+package v1_user_authenticate_two_factor
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_user_authenticate_two_factor/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		userAuthenticateTwoFactorCase = mock.NewMockuserAuthenticateTwoFactorCase(ctrl)
+		validator                     = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory     = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler            = mock_api.NewMockServerErrorHandler(ctrl)
+
+		twoFactorToken = "dummyTwoFactorToken"
+		code           = "123456"
+		userAgent      = "Mozilla/5.0 (X11; Linux x86_64)"
+		noError        = (error)(nil)
+		dummyError     = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			TwoFactorToken: twoFactorToken,
+			Code:           code,
+		}
+		expectedUserAuthenticateTwoFactorIn = &dto.UserAuthenticateTwoFactorIn{
+			TwoFactorToken: twoFactorToken,
+			Code:           code,
+			IP:             "192.0.2.1",
+			UserAgent:      userAgent,
+		}
+		validUserAuthenticateOut = &dto.UserAuthenticateOut{
+			AccessToken:  "fresh access token",
+			RefreshToken: "fresh refresh token",
+		}
+		noUserAuthenticateOut = (*dto.UserAuthenticateOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name          string
+		reqBody       string
+		setup         func()
+		expCode       int
+		expBody       string
+		expCookies    []string
+		expRetryAfter string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userAuthenticateTwoFactorCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserAuthenticateTwoFactorIn)).
+					Return(validUserAuthenticateOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"accessToken":"fresh access token","refreshToken":"fresh refresh token"}`,
+		},
+		{
+			name:    "OK: cookies",
+			reqBody: `{"twoFactorToken":"dummyTwoFactorToken","code":"123456","useCookies":true}`,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(&request{TwoFactorToken: twoFactorToken, Code: code, UseCookies: true})).
+					Return(noError)
+
+				userAuthenticateTwoFactorCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserAuthenticateTwoFactorIn)).
+					Return(validUserAuthenticateOut, noError)
+			},
+			expCode:    200,
+			expBody:    `{}`,
+			expCookies: []string{"access_token", "refresh_token", "csrf_token"},
+		},
+		{
+			name: "Bad request: request validation failed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name: "Bad request: invalid code",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userAuthenticateTwoFactorCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserAuthenticateTwoFactorIn)).
+					Return(noUserAuthenticateOut, dto.ErrInvalidTwoFactorCode)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2008,"name":"Invalid two-factor code"},"message":"Authentication code is invalid."}`,
+		},
+		{
+			name: "Bad request: invalid two-factor token",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userAuthenticateTwoFactorCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserAuthenticateTwoFactorIn)).
+					Return(noUserAuthenticateOut, dto.ErrInvalidTwoFactorToken)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2009,"name":"Invalid two-factor token"},"message":"Sign-in session is invalid or expired. Please sign in again."}`,
+		},
+		{
+			name: "Forbidden: user suspended",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userAuthenticateTwoFactorCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserAuthenticateTwoFactorIn)).
+					Return(noUserAuthenticateOut, dto.ErrUserSuspended)
+			},
+			expCode: 403,
+			expBody: `{"error":{"code":2028,"name":"User suspended"},"message":"Your account is suspended."}`,
+		},
+		{
+			name: "Too many requests: login throttled",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userAuthenticateTwoFactorCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserAuthenticateTwoFactorIn)).
+					Return(noUserAuthenticateOut, &dto.LoginThrottledError{RetryAfter: time.Minute})
+			},
+			expCode:       429,
+			expBody:       `{"error":{"code":2007,"name":"Too many login attempts"},"message":"Too many failed login attempts. Please try again later."}`,
+			expRetryAfter: "60",
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userAuthenticateTwoFactorCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserAuthenticateTwoFactorIn)).
+					Return(noUserAuthenticateOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			rBody := `{"twoFactorToken":"dummyTwoFactorToken","code":"123456"}`
+			if tt.reqBody != "" {
+				rBody = tt.reqBody
+			}
+			r := httptest.NewRequest(method, path, io.NopCloser(bytes.NewBufferString(rBody)))
+			r.Header.Set("User-Agent", userAgent)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, userAuthenticateTwoFactorCase, validator, serverErrorHandlerFactory)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+			assert.Equal(t, tt.expCookies, cookieNames(w))
+			assert.Equal(t, tt.expRetryAfter, w.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func cookieNames(w *httptest.ResponseRecorder) []string {
+	var names []string
+	for _, c := range w.Result().Cookies() {
+		names = append(names, c.Name)
+	}
+	return names
+}
@@ -0,0 +1,105 @@
+package v1_user_authenticate_two_factor
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+// request accepts either the code or a recovery code,
+// with useCookies the tokens are set to the session cookies instead of the response body.
+type request struct {
+	TwoFactorToken string `json:"twoFactorToken" validate:"required"`
+	Code           string `json:"code" validate:"required,lte=20"`
+	UseCookies     bool   `json:"useCookies"`
+}
+
+type response struct {
+	AccessToken  string `json:"accessToken,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+}
+
+type handler struct {
+	userAuthenticateTwoFactorCase userAuthenticateTwoFactorCase
+	validator                     validation.Validator
+	serverErrorHandler            api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	out, err := h.useCase(ctx, ctx.ClientIP(), ctx.Request.UserAgent(), req)
+	if err != nil {
+		if throttledErr, ok := err.(*dto.LoginThrottledError); ok {
+			auth_api.LoginThrottledResponse(ctx, throttledErr.RetryAfter)
+			return
+		}
+
+		switch err {
+		case dto.ErrInvalidTwoFactorCode:
+			auth_api.InvalidTwoFactorCodeResponse(ctx)
+		case dto.ErrInvalidTwoFactorToken:
+			auth_api.InvalidTwoFactorTokenResponse(ctx)
+		case dto.ErrUserSuspended:
+			auth_api.UserSuspendedResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	if req.UseCookies {
+		h.cookieResponse(ctx, out)
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, clientIP, userAgent string, req *request) (*dto.UserAuthenticateOut, error) {
+	in := dto.UserAuthenticateTwoFactorIn{
+		TwoFactorToken: req.TwoFactorToken,
+		Code:           req.Code,
+		IP:             clientIP,
+		UserAgent:      userAgent,
+	}
+
+	return h.userAuthenticateTwoFactorCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.UserAuthenticateOut) {
+	ctx.JSON(http.StatusOK, &response{
+		AccessToken:  out.AccessToken,
+		RefreshToken: out.RefreshToken,
+	})
+}
+
+func (h *handler) cookieResponse(ctx *gin.Context, out *dto.UserAuthenticateOut) {
+	if err := auth_api.SetSessionCookies(ctx, out.AccessToken, out.RefreshToken); err != nil {
+		h.serverErrorHandler.Handle(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &response{})
+}
@@ -0,0 +1,201 @@
+package v1_user_logout
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_user_logout/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		userLogoutCase            = mock.NewMockuserLogoutCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		accessToken  = "dummy access token"
+		refreshToken = "dummy refresh token"
+		noError      = (error)(nil)
+		dummyError   = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			RefreshToken: refreshToken,
+		}
+		expectedUserLogoutIn = &dto.UserLogoutIn{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+		}
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name        string
+		accessToken string
+		reqBody     string
+		cookies     []*http.Cookie
+		csrfToken   string
+		setup       func()
+		expCode     int
+		expBody     string
+		expCookies  []string
+	}{
+		{
+			name:        "OK",
+			accessToken: accessToken,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userLogoutCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserLogoutIn)).
+					Return(noError)
+			},
+			expCode: 200,
+			expBody: `{"message":"You have been logged out."}`,
+		},
+		{
+			name:    "OK: cookies",
+			reqBody: `{}`,
+			cookies: []*http.Cookie{
+				{Name: "access_token", Value: accessToken},
+				{Name: "refresh_token", Value: refreshToken},
+				{Name: "csrf_token", Value: "dummyCSRFToken"},
+			},
+			csrfToken: "dummyCSRFToken",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(&request{})).
+					Return(noError)
+
+				userLogoutCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserLogoutIn)).
+					Return(noError)
+			},
+			expCode:    200,
+			expBody:    `{"message":"You have been logged out."}`,
+			expCookies: []string{"access_token", "refresh_token", "csrf_token"},
+		},
+		{
+			name: "Forbidden: CSRF token mismatch",
+			cookies: []*http.Cookie{
+				{Name: "access_token", Value: accessToken},
+				{Name: "csrf_token", Value: "dummyCSRFToken"},
+			},
+			setup:   func() {},
+			expCode: 403,
+			expBody: `{"error":{"code":2023,"name":"CSRF token mismatch"},"message":"Please reload the page and try again."}`,
+		},
+		{
+			name:        "Unauthorized: access token not specified",
+			accessToken: "",
+			setup:       func() {},
+			expCode:     401,
+			expBody:     `{"message":"Please try to sign in again."}`,
+		},
+		{
+			name:        "Bad request: request validation failed",
+			accessToken: accessToken,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name:        "Unauthorized: invalid access token",
+			accessToken: accessToken,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userLogoutCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserLogoutIn)).
+					Return(dto.ErrInvalidAccessToken)
+			},
+			expCode: 401,
+			expBody: `{"message":"Please try to sign in again."}`,
+		},
+		{
+			name:        "Internal server error: unexpected error in use case",
+			accessToken: accessToken,
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userLogoutCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserLogoutIn)).
+					Return(dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			rBody := `{"refreshToken":"dummy refresh token"}`
+			if tt.reqBody != "" {
+				rBody = tt.reqBody
+			}
+			r := httptest.NewRequest(method, path, io.NopCloser(bytes.NewBufferString(rBody)))
+			r.Header.Set("X-Access-Token", tt.accessToken)
+			for _, c := range tt.cookies {
+				r.AddCookie(c)
+			}
+			if tt.csrfToken != "" {
+				r.Header.Set("X-CSRF-Token", tt.csrfToken)
+			}
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, userLogoutCase, validator, serverErrorHandlerFactory)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+			assert.Equal(t, tt.expCookies, cookieNames(w))
+		})
+	}
+}
+
+func cookieNames(w *httptest.ResponseRecorder) []string {
+	var names []string
+	for _, c := range w.Result().Cookies() {
+		names = append(names, c.Name)
+	}
+	return names
+}
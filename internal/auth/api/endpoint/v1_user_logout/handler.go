@@ -0,0 +1,90 @@
+package v1_user_logout
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+// request without refreshToken has the one of the session cookies revoked.
+type request struct {
+	RefreshToken string `json:"refreshToken" validate:"lte=255"`
+}
+
+type response struct {
+	Message string `json:"message,omitempty"`
+}
+
+type handler struct {
+	userLogoutCase     userLogoutCase
+	validator          validation.Validator
+	serverErrorHandler api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	accessToken, fromCookie := auth_api.AccessToken(ctx)
+	if accessToken == "" {
+		api.UnauthorizedResponse(ctx)
+		return
+	}
+	if fromCookie && !auth_api.CSRFTokenValid(ctx) {
+		auth_api.CSRFTokenMismatchResponse(ctx)
+		return
+	}
+
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+	if req.RefreshToken == "" && fromCookie {
+		req.RefreshToken = auth_api.RefreshTokenCookie(ctx)
+	}
+
+	if err = h.useCase(ctx, accessToken, req); err != nil {
+		switch err {
+		case dto.ErrInvalidAccessToken:
+			api.UnauthorizedResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	if fromCookie {
+		auth_api.ClearSessionCookies(ctx)
+	}
+
+	okResponse(ctx)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, accessToken string, req *request) error {
+	in := dto.UserLogoutIn{
+		AccessToken:  accessToken,
+		RefreshToken: req.RefreshToken,
+	}
+
+	return h.userLogoutCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context) {
+	const message = "You have been logged out."
+	ctx.JSON(http.StatusOK, &response{Message: message})
+}
@@ -0,0 +1,34 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_user_logout_everywhere
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+const (
+	method = http.MethodPost
+	path   = "/v1/auth/logout/everywhere"
+)
+
+type userLogoutEverywhereCase interface {
+	Use(ctx context.Context, in *dto.UserLogoutEverywhereIn) error
+}
+
+func Bind(
+	router *gin.Engine,
+	userLogoutEverywhereCase userLogoutEverywhereCase,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+) {
+	h := handler{
+		userLogoutEverywhereCase: userLogoutEverywhereCase,
+		serverErrorHandler:       serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, h.handle)
+}
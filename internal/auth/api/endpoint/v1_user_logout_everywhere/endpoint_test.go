@@ -0,0 +1,160 @@
+package v1_user_logout_everywhere
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_user_logout_everywhere/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		userLogoutEverywhereCase  = mock.NewMockuserLogoutEverywhereCase(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		accessToken = "dummy access token"
+		noError     = (error)(nil)
+		dummyError  = errors.New("dummy error")
+
+		expectedUserLogoutEverywhereIn = &dto.UserLogoutEverywhereIn{
+			AccessToken: accessToken,
+		}
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name        string
+		accessToken string
+		cookies     []*http.Cookie
+		csrfToken   string
+		setup       func()
+		expCode     int
+		expBody     string
+		expCookies  []string
+	}{
+		{
+			name:        "OK",
+			accessToken: accessToken,
+			setup: func() {
+				userLogoutEverywhereCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserLogoutEverywhereIn)).
+					Return(noError)
+			},
+			expCode: 200,
+			expBody: `{"message":"You have been logged out on all devices."}`,
+		},
+		{
+			name: "OK: cookies",
+			cookies: []*http.Cookie{
+				{Name: "access_token", Value: accessToken},
+				{Name: "csrf_token", Value: "dummyCSRFToken"},
+			},
+			csrfToken: "dummyCSRFToken",
+			setup: func() {
+				userLogoutEverywhereCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserLogoutEverywhereIn)).
+					Return(noError)
+			},
+			expCode:    200,
+			expBody:    `{"message":"You have been logged out on all devices."}`,
+			expCookies: []string{"access_token", "refresh_token", "csrf_token"},
+		},
+		{
+			name: "Forbidden: CSRF token mismatch",
+			cookies: []*http.Cookie{
+				{Name: "access_token", Value: accessToken},
+				{Name: "csrf_token", Value: "dummyCSRFToken"},
+			},
+			csrfToken: "anotherCSRFToken",
+			setup:     func() {},
+			expCode:   403,
+			expBody:   `{"error":{"code":2023,"name":"CSRF token mismatch"},"message":"Please reload the page and try again."}`,
+		},
+		{
+			name:        "Unauthorized: access token not specified",
+			accessToken: "",
+			setup:       func() {},
+			expCode:     401,
+			expBody:     `{"message":"Please try to sign in again."}`,
+		},
+		{
+			name:        "Unauthorized: invalid access token",
+			accessToken: accessToken,
+			setup: func() {
+				userLogoutEverywhereCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserLogoutEverywhereIn)).
+					Return(dto.ErrInvalidAccessToken)
+			},
+			expCode: 401,
+			expBody: `{"message":"Please try to sign in again."}`,
+		},
+		{
+			name:        "Internal server error: unexpected error in use case",
+			accessToken: accessToken,
+			setup: func() {
+				userLogoutEverywhereCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserLogoutEverywhereIn)).
+					Return(dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(method, path, nil)
+			r.Header.Set("X-Access-Token", tt.accessToken)
+			for _, c := range tt.cookies {
+				r.AddCookie(c)
+			}
+			if tt.csrfToken != "" {
+				r.Header.Set("X-CSRF-Token", tt.csrfToken)
+			}
+
+			router := gin.New()
+			Bind(router, userLogoutEverywhereCase, serverErrorHandlerFactory)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+			assert.Equal(t, tt.expCookies, cookieNames(w))
+		})
+	}
+}
+
+func cookieNames(w *httptest.ResponseRecorder) []string {
+	var names []string
+	for _, c := range w.Result().Cookies() {
+		names = append(names, c.Name)
+	}
+	return names
+}
@@ -0,0 +1,62 @@
+package v1_user_logout_everywhere
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+type response struct {
+	Message string `json:"message,omitempty"`
+}
+
+type handler struct {
+	userLogoutEverywhereCase userLogoutEverywhereCase
+	serverErrorHandler       api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	accessToken, fromCookie := auth_api.AccessToken(ctx)
+	if accessToken == "" {
+		api.UnauthorizedResponse(ctx)
+		return
+	}
+	if fromCookie && !auth_api.CSRFTokenValid(ctx) {
+		auth_api.CSRFTokenMismatchResponse(ctx)
+		return
+	}
+
+	if err := h.useCase(ctx, accessToken); err != nil {
+		switch err {
+		case dto.ErrInvalidAccessToken:
+			api.UnauthorizedResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	if fromCookie {
+		auth_api.ClearSessionCookies(ctx)
+	}
+
+	okResponse(ctx)
+}
+
+func (h *handler) useCase(ctx context.Context, accessToken string) error {
+	in := dto.UserLogoutEverywhereIn{
+		AccessToken: accessToken,
+	}
+
+	return h.userLogoutEverywhereCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context) {
+	const message = "You have been logged out on all devices."
+	ctx.JSON(http.StatusOK, &response{Message: message})
+}
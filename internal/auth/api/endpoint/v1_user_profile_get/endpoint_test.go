@@ -0,0 +1,119 @@
+package v1_user_profile_get
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_user_profile_get/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		userProfileGetCase        = mock.NewMockuserProfileGetCase(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		userID     = int64(1)
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedUserProfileGetIn = &dto.UserProfileGetIn{
+			UserID: userID,
+		}
+		validUserProfileGetOut = &dto.UserProfileGetOut{
+			Profile: dto.UserProfile{
+				ID:          userID,
+				Name:        "Ivan Ivanov",
+				Email:       "i.ivanov@example.com",
+				Handle:      "ivanov",
+				DisplayName: "Ivan",
+				Bio:         "Writer",
+				Website:     "https://ivanov.example.com",
+				AvatarURL:   "https://cdn.example.com/ivanov.png",
+			},
+		}
+		noUserProfileGetOut = (*dto.UserProfileGetOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				userProfileGetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserProfileGetIn)).
+					Return(validUserProfileGetOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"id":1,"name":"Ivan Ivanov","email":"i.ivanov@example.com","handle":"ivanov","displayName":"Ivan","bio":"Writer","website":"https://ivanov.example.com","avatarUrl":"https://cdn.example.com/ivanov.png"}`,
+		},
+		{
+			name: "Unauthorized: user not found",
+			setup: func() {
+				userProfileGetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserProfileGetIn)).
+					Return(noUserProfileGetOut, dto.ErrUserNotFound)
+			},
+			expCode: 401,
+			expBody: `{"message":"Please try to sign in again."}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				userProfileGetCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserProfileGetIn)).
+					Return(noUserProfileGetOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, userID)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			r := httptest.NewRequest(method, path, nil)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, userProfileGetCase, serverErrorHandlerFactory, authenticate)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package v1_user_profile_get
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+type response struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Handle      string `json:"handle"`
+	DisplayName string `json:"displayName"`
+	Bio         string `json:"bio"`
+	Website     string `json:"website"`
+	AvatarURL   string `json:"avatarUrl"`
+}
+
+type handler struct {
+	userProfileGetCase userProfileGetCase
+	serverErrorHandler api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	out, err := h.useCase(ctx, api.GetUserID(ctx))
+	if err != nil {
+		switch err {
+		case dto.ErrUserNotFound:
+			api.UnauthorizedResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) useCase(ctx context.Context, userID int64) (*dto.UserProfileGetOut, error) {
+	in := dto.UserProfileGetIn{
+		UserID: userID,
+	}
+
+	return h.userProfileGetCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.UserProfileGetOut) {
+	ctx.JSON(http.StatusOK, &response{
+		ID:          out.Profile.ID,
+		Name:        out.Profile.Name,
+		Email:       out.Profile.Email,
+		Handle:      out.Profile.Handle,
+		DisplayName: out.Profile.DisplayName,
+		Bio:         out.Profile.Bio,
+		Website:     out.Profile.Website,
+		AvatarURL:   out.Profile.AvatarURL,
+	})
+}
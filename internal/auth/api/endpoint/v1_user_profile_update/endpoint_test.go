@@ -0,0 +1,164 @@
+package v1_user_profile_update
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_user_profile_update/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		userProfileUpdateCase     = mock.NewMockuserProfileUpdateCase(ctrl)
+		validator                 = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		userID     = int64(1)
+		handle     = "ivanov"
+		bio        = ""
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			Handle: &handle,
+			Bio:    &bio,
+		}
+		expectedUserProfileUpdateIn = &dto.UserProfileUpdateIn{
+			UserID: userID,
+			Handle: &handle,
+			Bio:    &bio,
+		}
+		validUserProfileUpdateOut = &dto.UserProfileUpdateOut{
+			Profile: dto.UserProfile{
+				ID:          userID,
+				Name:        "Ivan Ivanov",
+				Email:       "i.ivanov@example.com",
+				Handle:      handle,
+				DisplayName: "Ivan",
+			},
+		}
+		noUserProfileUpdateOut = (*dto.UserProfileUpdateOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userProfileUpdateCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserProfileUpdateIn)).
+					Return(validUserProfileUpdateOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"id":1,"name":"Ivan Ivanov","email":"i.ivanov@example.com","handle":"ivanov","displayName":"Ivan","bio":"","website":"","avatarUrl":""}`,
+		},
+		{
+			name: "Bad request: request validation failed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name: "Bad request: busy handle",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userProfileUpdateCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserProfileUpdateIn)).
+					Return(noUserProfileUpdateOut, dto.ErrHandleIsBusy)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2021,"name":"Busy handle"},"message":"This handle is already taken."}`,
+		},
+		{
+			name: "Unauthorized: user not found",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userProfileUpdateCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserProfileUpdateIn)).
+					Return(noUserProfileUpdateOut, dto.ErrUserNotFound)
+			},
+			expCode: 401,
+			expBody: `{"message":"Please try to sign in again."}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userProfileUpdateCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserProfileUpdateIn)).
+					Return(noUserProfileUpdateOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, userID)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			rBody := `{"handle":"ivanov","bio":""}`
+			r := httptest.NewRequest(method, path, io.NopCloser(bytes.NewBufferString(rBody)))
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, userProfileUpdateCase, validator, serverErrorHandlerFactory, authenticate)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package v1_user_profile_update
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+// request has only the fields to change, an empty value clears the field.
+// The handle can be changed, but not cleared.
+type request struct {
+	Handle      *string `json:"handle" validate:"omitempty,min=3,max=30,lowercase,alphanum"`
+	DisplayName *string `json:"displayName" validate:"omitempty,max=100"`
+	Bio         *string `json:"bio" validate:"omitempty,max=1000"`
+	Website     *string `json:"website" validate:"omitempty,max=255,eq=|http_url"`
+	AvatarURL   *string `json:"avatarUrl" validate:"omitempty,max=2048,eq=|http_url"`
+}
+
+type response struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Handle      string `json:"handle"`
+	DisplayName string `json:"displayName"`
+	Bio         string `json:"bio"`
+	Website     string `json:"website"`
+	AvatarURL   string `json:"avatarUrl"`
+}
+
+type handler struct {
+	userProfileUpdateCase userProfileUpdateCase
+	validator             validation.Validator
+	serverErrorHandler    api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	out, err := h.useCase(ctx, api.GetUserID(ctx), req)
+	if err != nil {
+		switch err {
+		case dto.ErrHandleIsBusy:
+			auth_api.BusyHandleResponse(ctx)
+		case dto.ErrUserNotFound:
+			api.UnauthorizedResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, userID int64, req *request) (*dto.UserProfileUpdateOut, error) {
+	in := dto.UserProfileUpdateIn{
+		UserID:      userID,
+		Handle:      req.Handle,
+		DisplayName: req.DisplayName,
+		Bio:         req.Bio,
+		Website:     req.Website,
+		AvatarURL:   req.AvatarURL,
+	}
+
+	return h.userProfileUpdateCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.UserProfileUpdateOut) {
+	ctx.JSON(http.StatusOK, &response{
+		ID:          out.Profile.ID,
+		Name:        out.Profile.Name,
+		Email:       out.Profile.Email,
+		Handle:      out.Profile.Handle,
+		DisplayName: out.Profile.DisplayName,
+		Bio:         out.Profile.Bio,
+		Website:     out.Profile.Website,
+		AvatarURL:   out.Profile.AvatarURL,
+	})
+}
@@ -18,14 +18,16 @@ const (
 )
 
 type userRegisterCase interface {
-	Use(ctx context.Context, in *dto.UserRegisterIn) error
+	Use(ctx context.Context, in *dto.UserRegisterIn) (*dto.UserRegisterOut, error)
 }
 
+// Bind registers the endpoint behind the middlewares, e.g. the rate limits.
 func Bind(
 	router *gin.Engine,
 	userRegisterCase userRegisterCase,
 	validator validation.Validator,
 	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
 ) {
 	h := handler{
 		userRegisterCase:   userRegisterCase,
@@ -33,5 +35,5 @@ func Bind(
 		serverErrorHandler: serverErrorHandlerFactory.MakeHandler(method, path),
 	}
 
-	router.Handle(method, path, h.handle)
+	router.Handle(method, path, append(middlewares, h.handle)...)
 }
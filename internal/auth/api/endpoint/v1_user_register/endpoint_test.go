@@ -31,18 +31,23 @@ func TestEndpoint(t *testing.T) {
 		name       = "Ivan Ivanov"
 		email      = "i.ivanov@example.com"
 		password   = "Qwerty123!"
+		inviteCode = "invite-code"
 		noError    = (error)(nil)
+		noOut      = (*dto.UserRegisterOut)(nil)
 		dummyError = errors.New("dummy error")
 
 		expectedRequestInValidator = &request{
-			Name:     name,
-			Email:    email,
-			Password: password,
+			Name:       name,
+			Email:      email,
+			Password:   password,
+			InviteCode: inviteCode,
 		}
 		expectedUserRegisterIn = &dto.UserRegisterIn{
-			Name:     name,
-			Email:    email,
-			Password: password,
+			Name:       name,
+			Email:      email,
+			Password:   password,
+			InviteCode: inviteCode,
+			IP:         "192.0.2.1",
 		}
 	)
 
@@ -66,11 +71,25 @@ func TestEndpoint(t *testing.T) {
 
 				userRegisterCase.EXPECT().
 					Use(gomock.Any(), gomock.Eq(expectedUserRegisterIn)).
-					Return(noError)
+					Return(&dto.UserRegisterOut{}, noError)
 			},
 			expCode: 200,
 			expBody: `{"message":"Please check your email to activate your account."}`,
 		},
+		{
+			name: "OK: activated by invite",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userRegisterCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserRegisterIn)).
+					Return(&dto.UserRegisterOut{Activated: true}, noError)
+			},
+			expCode: 200,
+			expBody: `{"message":"Your account is ready, please sign in."}`,
+		},
 		{
 			name: "Bad request: request validation failed",
 			setup: func() {
@@ -90,11 +109,82 @@ func TestEndpoint(t *testing.T) {
 
 				userRegisterCase.EXPECT().
 					Use(gomock.Any(), gomock.Eq(expectedUserRegisterIn)).
-					Return(dto.ErrEmailIsBusy)
+					Return(noOut, dto.ErrEmailIsBusy)
 			},
 			expCode: 400,
 			expBody: `{"error":{"code":2001,"name":"Busy email"},"message":"User with this email already exists."}`,
 		},
+		{
+			name: "Forbidden: invite required",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userRegisterCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserRegisterIn)).
+					Return(noOut, dto.ErrInviteRequired)
+			},
+			expCode: 403,
+			expBody: `{"error":{"code":2029,"name":"Invite required"},"message":"Registration is available by invite only."}`,
+		},
+		{
+			name: "Bad request: invalid invite",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userRegisterCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserRegisterIn)).
+					Return(noOut, dto.ErrInvalidInvite)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2030,"name":"Invalid invite"},"message":"Invite is invalid, expired or used up."}`,
+		},
+		{
+			name: "Bad request: email domain not allowed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userRegisterCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserRegisterIn)).
+					Return(noOut, dto.ErrEmailDomainNotAllowed)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2031,"name":"Email domain not allowed"},"message":"Registration with this email domain is not allowed."}`,
+		},
+		{
+			name: "Bad request: disposable email",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				userRegisterCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserRegisterIn)).
+					Return(noOut, dto.ErrDisposableEmail)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2032,"name":"Disposable email"},"message":"Disposable email addresses are not allowed."}`,
+		},
+		{
+			name: "Bad request: password policy violated",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(noError)
+
+				policyErr := &dto.PasswordPolicyError{Violations: []string{dto.PasswordRuleMinLength, dto.PasswordRuleNotBreached}}
+				userRegisterCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedUserRegisterIn)).
+					Return(noOut, policyErr)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2020,"name":"Password policy violated"},"message":"Password doesn't meet the requirements.","violations":["min_length","not_breached"]}`,
+		},
 		{
 			name: "Internal server error: unexpected error in use case",
 			setup: func() {
@@ -104,7 +194,7 @@ func TestEndpoint(t *testing.T) {
 
 				userRegisterCase.EXPECT().
 					Use(gomock.Any(), gomock.Eq(expectedUserRegisterIn)).
-					Return(dummyError)
+					Return(noOut, dummyError)
 
 				serverErrorHandler.EXPECT().
 					Handle(gomock.Any(), gomock.Eq(dummyError)).
@@ -121,7 +211,7 @@ func TestEndpoint(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
 
-			rBody := `{"name":"Ivan Ivanov","email":"i.ivanov@example.com","password":"Qwerty123!"}`
+			rBody := `{"name":"Ivan Ivanov","email":"i.ivanov@example.com","password":"Qwerty123!","inviteCode":"invite-code"}`
 			r := httptest.NewRequest(method, path, io.NopCloser(bytes.NewBufferString(rBody)))
 			w := httptest.NewRecorder()
 
@@ -15,7 +15,9 @@ import (
 type request struct {
 	Name     string `json:"name" validate:"required,lte=255"`
 	Email    string `json:"email" validate:"required,email,lte=255"`
-	Password string `json:"password" validate:"required,lte=70"`
+	Password string `json:"password" validate:"required,lte=128"`
+	// InviteCode is required when the registration is invite-only.
+	InviteCode string `json:"inviteCode" validate:"lte=64"`
 }
 
 type response struct {
@@ -35,17 +37,31 @@ func (h *handler) handle(ctx *gin.Context) {
 		return
 	}
 
-	if err = h.useCase(ctx, req); err != nil {
+	out, err := h.useCase(ctx, ctx.ClientIP(), ctx.Request.UserAgent(), req)
+	if err != nil {
+		if policyErr, ok := err.(*dto.PasswordPolicyError); ok {
+			auth_api.PasswordPolicyViolatedResponse(ctx, policyErr.Violations)
+			return
+		}
+
 		switch err {
 		case dto.ErrEmailIsBusy:
 			auth_api.BusyEmailResponse(ctx)
+		case dto.ErrInviteRequired:
+			auth_api.InviteRequiredResponse(ctx)
+		case dto.ErrInvalidInvite:
+			auth_api.InvalidInviteResponse(ctx)
+		case dto.ErrEmailDomainNotAllowed:
+			auth_api.EmailDomainNotAllowedResponse(ctx)
+		case dto.ErrDisposableEmail:
+			auth_api.DisposableEmailResponse(ctx)
 		default:
 			h.serverErrorHandler.Handle(ctx, err)
 		}
 		return
 	}
 
-	okResponse(ctx)
+	okResponse(ctx, out.Activated)
 }
 
 func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
@@ -59,17 +75,23 @@ func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
 	return &req, nil
 }
 
-func (h *handler) useCase(ctx context.Context, req *request) error {
+func (h *handler) useCase(ctx context.Context, clientIP, userAgent string, req *request) (*dto.UserRegisterOut, error) {
 	in := dto.UserRegisterIn{
-		Name:     req.Name,
-		Email:    req.Email,
-		Password: req.Password,
+		Name:       req.Name,
+		Email:      req.Email,
+		Password:   req.Password,
+		InviteCode: req.InviteCode,
+		IP:         clientIP,
+		UserAgent:  userAgent,
 	}
 
 	return h.userRegisterCase.Use(ctx, &in)
 }
 
-func okResponse(ctx *gin.Context) {
-	const message = "Please check your email to activate your account."
+func okResponse(ctx *gin.Context, activated bool) {
+	message := "Please check your email to activate your account."
+	if activated {
+		message = "Your account is ready, please sign in."
+	}
 	ctx.JSON(http.StatusOK, &response{Message: message})
 }
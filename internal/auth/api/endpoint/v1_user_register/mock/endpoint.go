@@ -36,11 +36,12 @@ func (m *MockuserRegisterCase) EXPECT() *MockuserRegisterCaseMockRecorder {
 }
 
 // Use mocks base method.
-func (m *MockuserRegisterCase) Use(ctx context.Context, in *dto.UserRegisterIn) error {
+func (m *MockuserRegisterCase) Use(ctx context.Context, in *dto.UserRegisterIn) (*dto.UserRegisterOut, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Use", ctx, in)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*dto.UserRegisterOut)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Use indicates an expected call of Use.
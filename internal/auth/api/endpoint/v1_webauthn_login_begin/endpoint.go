@@ -0,0 +1,36 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_webauthn_login_begin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+const (
+	method = http.MethodPost
+	path   = "/v1/auth/passkey/login/begin"
+)
+
+type webAuthnLoginBeginCase interface {
+	Use(ctx context.Context) (*dto.WebAuthnLoginBeginOut, error)
+}
+
+// Bind registers the endpoint behind the middlewares, e.g. the rate limits.
+func Bind(
+	router *gin.Engine,
+	webAuthnLoginBeginCase webAuthnLoginBeginCase,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
+) {
+	h := handler{
+		webAuthnLoginBeginCase: webAuthnLoginBeginCase,
+		serverErrorHandler:     serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, append(middlewares, h.handle)...)
+}
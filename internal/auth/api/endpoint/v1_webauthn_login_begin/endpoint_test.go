@@ -0,0 +1,94 @@
+package v1_webauthn_login_begin
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_webauthn_login_begin/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		webAuthnLoginBeginCase    = mock.NewMockwebAuthnLoginBeginCase(ctrl)
+		serverErrorHandlerFactory = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler        = mock_api.NewMockServerErrorHandler(ctrl)
+
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		validWebAuthnLoginBeginOut = &dto.WebAuthnLoginBeginOut{
+			Challenge: "dummyChallenge",
+			RPID:      "example.com",
+			Timeout:   5 * time.Minute,
+		}
+		noWebAuthnLoginBeginOut = (*dto.WebAuthnLoginBeginOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				webAuthnLoginBeginCase.EXPECT().
+					Use(gomock.Any()).
+					Return(validWebAuthnLoginBeginOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"challenge":"dummyChallenge","rpId":"example.com","timeout":300000,"userVerification":"preferred"}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				webAuthnLoginBeginCase.EXPECT().
+					Use(gomock.Any()).
+					Return(noWebAuthnLoginBeginOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			r := httptest.NewRequest(method, path, nil)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, webAuthnLoginBeginCase, serverErrorHandlerFactory)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
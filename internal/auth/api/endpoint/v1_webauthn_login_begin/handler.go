@@ -0,0 +1,43 @@
+package v1_webauthn_login_begin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+// response is PublicKeyCredentialRequestOptionsJSON, so it can be passed to
+// PublicKeyCredential.parseRequestOptionsFromJSON() as is.
+type response struct {
+	Challenge        string `json:"challenge"`
+	RPID             string `json:"rpId"`
+	Timeout          int64  `json:"timeout"`
+	UserVerification string `json:"userVerification"`
+}
+
+type handler struct {
+	webAuthnLoginBeginCase webAuthnLoginBeginCase
+	serverErrorHandler     api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	out, err := h.webAuthnLoginBeginCase.Use(ctx)
+	if err != nil {
+		h.serverErrorHandler.Handle(ctx, err)
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func okResponse(ctx *gin.Context, out *dto.WebAuthnLoginBeginOut) {
+	ctx.JSON(http.StatusOK, &response{
+		Challenge:        out.Challenge,
+		RPID:             out.RPID,
+		Timeout:          out.Timeout.Milliseconds(),
+		UserVerification: "preferred",
+	})
+}
@@ -0,0 +1,108 @@
+package v1_webauthn_login_finish
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+// request carries the response of navigator.credentials.get(), the binary values are base64url encoded.
+// With useCookies the tokens are set to the session cookies instead of the response body.
+type request struct {
+	CredentialID      string `json:"credentialId" validate:"required,lte=1400"`
+	ClientDataJSON    string `json:"clientDataJSON" validate:"required,lte=4096"`
+	AuthenticatorData string `json:"authenticatorData" validate:"required,lte=4096"`
+	Signature         string `json:"signature" validate:"required,lte=1024"`
+	UserHandle        string `json:"userHandle" validate:"lte=128"`
+	UseCookies        bool   `json:"useCookies"`
+}
+
+// response has only twoFactorToken if the authenticator hasn't verified the user
+// and the user has two-factor authentication enabled.
+type response struct {
+	AccessToken    string `json:"accessToken,omitempty"`
+	RefreshToken   string `json:"refreshToken,omitempty"`
+	TwoFactorToken string `json:"twoFactorToken,omitempty"`
+}
+
+type handler struct {
+	webAuthnLoginFinishCase webAuthnLoginFinishCase
+	validator               validation.Validator
+	serverErrorHandler      api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	out, err := h.useCase(ctx, ctx.ClientIP(), ctx.Request.UserAgent(), req)
+	if err != nil {
+		switch err {
+		case dto.ErrInvalidWebAuthnResponse:
+			auth_api.InvalidWebAuthnResponseResponse(ctx)
+		case dto.ErrUserSuspended:
+			auth_api.UserSuspendedResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	if req.UseCookies && out.AccessToken != "" {
+		h.cookieResponse(ctx, out)
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(ctx context.Context, clientIP, userAgent string, req *request) (*dto.UserAuthenticateOut, error) {
+	in := dto.WebAuthnLoginFinishIn{
+		CredentialID:      req.CredentialID,
+		ClientDataJSON:    req.ClientDataJSON,
+		AuthenticatorData: req.AuthenticatorData,
+		Signature:         req.Signature,
+		UserHandle:        req.UserHandle,
+		IP:                clientIP,
+		UserAgent:         userAgent,
+	}
+
+	return h.webAuthnLoginFinishCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, result *dto.UserAuthenticateOut) {
+	ctx.JSON(http.StatusOK, &response{
+		AccessToken:    result.AccessToken,
+		RefreshToken:   result.RefreshToken,
+		TwoFactorToken: result.TwoFactorToken,
+	})
+}
+
+func (h *handler) cookieResponse(ctx *gin.Context, result *dto.UserAuthenticateOut) {
+	if err := auth_api.SetSessionCookies(ctx, result.AccessToken, result.RefreshToken); err != nil {
+		h.serverErrorHandler.Handle(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &response{})
+}
@@ -0,0 +1,161 @@
+package v1_webauthn_registration_begin
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_webauthn_registration_begin/mock"
+	"github.com/art-es/blog/internal/auth/api/middleware/authenticated"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		webAuthnRegistrationBeginCase = mock.NewMockwebAuthnRegistrationBeginCase(ctrl)
+		serverErrorHandlerFactory     = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler            = mock_api.NewMockServerErrorHandler(ctrl)
+
+		userID     = int64(1)
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedWebAuthnRegistrationBeginIn = &dto.WebAuthnRegistrationBeginIn{
+			UserID: userID,
+		}
+		validWebAuthnRegistrationBeginOut = &dto.WebAuthnRegistrationBeginOut{
+			Challenge:            "dummyChallenge",
+			RPID:                 "example.com",
+			RPName:               "Blog",
+			UserHandle:           "MQ",
+			UserName:             "i.ivanov@example.com",
+			UserDisplayName:      "Ivan",
+			Algorithms:           []int{-7, -257},
+			ExcludeCredentialIDs: []string{"dummyCredentialID"},
+			Timeout:              5 * time.Minute,
+		}
+		noWebAuthnRegistrationBeginOut = (*dto.WebAuthnRegistrationBeginOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				webAuthnRegistrationBeginCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedWebAuthnRegistrationBeginIn)).
+					Return(validWebAuthnRegistrationBeginOut, noError)
+			},
+			expCode: 200,
+			expBody: `{
+				"challenge":"dummyChallenge",
+				"rp":{"id":"example.com","name":"Blog"},
+				"user":{"id":"MQ","name":"i.ivanov@example.com","displayName":"Ivan"},
+				"pubKeyCredParams":[{"type":"public-key","alg":-7},{"type":"public-key","alg":-257}],
+				"excludeCredentials":[{"type":"public-key","id":"dummyCredentialID"}],
+				"timeout":300000,
+				"attestation":"none",
+				"authenticatorSelection":{"residentKey":"required","userVerification":"preferred"}
+			}`,
+		},
+		{
+			name: "Unauthorized: user not found",
+			setup: func() {
+				webAuthnRegistrationBeginCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedWebAuthnRegistrationBeginIn)).
+					Return(noWebAuthnRegistrationBeginOut, dto.ErrUserNotFound)
+			},
+			expCode: 401,
+			expBody: `{"message":"Please try to sign in again."}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				webAuthnRegistrationBeginCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(expectedWebAuthnRegistrationBeginIn)).
+					Return(noWebAuthnRegistrationBeginOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, userID)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			r := httptest.NewRequest(method, path, nil)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, webAuthnRegistrationBeginCase, serverErrorHandlerFactory, authenticate)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
+
+// TestEndpoint_personalAccessToken checks the account can't be managed with a personal access token.
+func TestEndpoint_personalAccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		webAuthnRegistrationBeginCase = mock.NewMockwebAuthnRegistrationBeginCase(ctrl)
+		serverErrorHandlerFactory     = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(mock_api.NewMockServerErrorHandler(ctrl))
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, 1)
+		api.SetPersonalAccessToken(ctx, true)
+	}
+
+	r := httptest.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+
+	router := gin.New()
+	Bind(router, webAuthnRegistrationBeginCase, serverErrorHandlerFactory, authenticate, authenticated.New().Handle)
+
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, 403, w.Code)
+	assert.JSONEq(t, `{"error":{"code":2035,"name":"Personal access token not allowed"},"message":"Please sign in to manage your account, personal access tokens can't be used for this action."}`, w.Body.String())
+}
@@ -0,0 +1,114 @@
+package v1_webauthn_registration_begin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+const publicKeyCredentialType = "public-key"
+
+// response is PublicKeyCredentialCreationOptionsJSON, so it can be passed to
+// PublicKeyCredential.parseCreationOptionsFromJSON() as is.
+type response struct {
+	Challenge              string                 `json:"challenge"`
+	RP                     relyingParty           `json:"rp"`
+	User                   user                   `json:"user"`
+	PubKeyCredParams       []credentialParameters `json:"pubKeyCredParams"`
+	ExcludeCredentials     []credentialDescriptor `json:"excludeCredentials"`
+	Timeout                int64                  `json:"timeout"`
+	Attestation            string                 `json:"attestation"`
+	AuthenticatorSelection authenticatorSelection `json:"authenticatorSelection"`
+}
+
+type relyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type user struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type credentialParameters struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type credentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// authenticatorSelection requires the passkey to be discoverable, so the login doesn't ask for the email.
+type authenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+type handler struct {
+	webAuthnRegistrationBeginCase webAuthnRegistrationBeginCase
+	serverErrorHandler            api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	out, err := h.useCase(ctx, api.GetUserID(ctx))
+	if err != nil {
+		switch err {
+		case dto.ErrUserNotFound:
+			api.UnauthorizedResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) useCase(ctx context.Context, userID int64) (*dto.WebAuthnRegistrationBeginOut, error) {
+	in := dto.WebAuthnRegistrationBeginIn{
+		UserID: userID,
+	}
+
+	return h.webAuthnRegistrationBeginCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.WebAuthnRegistrationBeginOut) {
+	params := make([]credentialParameters, 0, len(out.Algorithms))
+	for _, alg := range out.Algorithms {
+		params = append(params, credentialParameters{Type: publicKeyCredentialType, Alg: alg})
+	}
+
+	excludeCredentials := make([]credentialDescriptor, 0, len(out.ExcludeCredentialIDs))
+	for _, id := range out.ExcludeCredentialIDs {
+		excludeCredentials = append(excludeCredentials, credentialDescriptor{Type: publicKeyCredentialType, ID: id})
+	}
+
+	ctx.JSON(http.StatusOK, &response{
+		Challenge: out.Challenge,
+		RP: relyingParty{
+			ID:   out.RPID,
+			Name: out.RPName,
+		},
+		User: user{
+			ID:          out.UserHandle,
+			Name:        out.UserName,
+			DisplayName: out.UserDisplayName,
+		},
+		PubKeyCredParams:   params,
+		ExcludeCredentials: excludeCredentials,
+		Timeout:            out.Timeout.Milliseconds(),
+		Attestation:        "none",
+		AuthenticatorSelection: authenticatorSelection{
+			ResidentKey:      "required",
+			UserVerification: "preferred",
+		},
+	})
+}
@@ -0,0 +1,40 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package v1_webauthn_registration_finish
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+const (
+	method = http.MethodPost
+	path   = "/v1/auth/user/passkeys/registration/finish"
+)
+
+type webAuthnRegistrationFinishCase interface {
+	Use(ctx context.Context, in *dto.WebAuthnRegistrationFinishIn) (*dto.WebAuthnRegistrationFinishOut, error)
+}
+
+// Bind registers the endpoint behind the middlewares,
+// which must set ID of the authenticated user to the context.
+func Bind(
+	router *gin.Engine,
+	webAuthnRegistrationFinishCase webAuthnRegistrationFinishCase,
+	validator validation.Validator,
+	serverErrorHandlerFactory api.ServerErrorHandlerFactory,
+	middlewares ...gin.HandlerFunc,
+) {
+	h := handler{
+		webAuthnRegistrationFinishCase: webAuthnRegistrationFinishCase,
+		validator:                      validator,
+		serverErrorHandler:             serverErrorHandlerFactory.MakeHandler(method, path),
+	}
+
+	router.Handle(method, path, append(middlewares, h.handle)...)
+}
@@ -0,0 +1,182 @@
+package v1_webauthn_registration_finish
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/v1_webauthn_registration_finish/mock"
+	"github.com/art-es/blog/internal/auth/api/middleware/authenticated"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	mock_api "github.com/art-es/blog/internal/common/api/mock"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		webAuthnRegistrationFinishCase = mock.NewMockwebAuthnRegistrationFinishCase(ctrl)
+		validator                      = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory      = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+		serverErrorHandler             = mock_api.NewMockServerErrorHandler(ctrl)
+
+		userID     = int64(1)
+		userAgent  = "Mozilla/5.0 (X11; Linux x86_64)"
+		noError    = (error)(nil)
+		dummyError = errors.New("dummy error")
+
+		expectedRequestInValidator = &request{
+			Name:              "MacBook",
+			ClientDataJSON:    "dummyClientDataJSON",
+			AttestationObject: "dummyAttestationObject",
+		}
+		expectedWebAuthnRegistrationFinishIn = &dto.WebAuthnRegistrationFinishIn{
+			UserID:            userID,
+			Name:              "MacBook",
+			ClientDataJSON:    "dummyClientDataJSON",
+			AttestationObject: "dummyAttestationObject",
+			IP:                "192.0.2.1",
+			UserAgent:         userAgent,
+		}
+		validWebAuthnRegistrationFinishOut = &dto.WebAuthnRegistrationFinishOut{
+			CredentialID: "dummyCredentialID",
+		}
+		noWebAuthnRegistrationFinishOut = (*dto.WebAuthnRegistrationFinishOut)(nil)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(serverErrorHandler).
+		AnyTimes()
+
+	expectUseCase := func(out *dto.WebAuthnRegistrationFinishOut, err error) {
+		validator.EXPECT().
+			Struct(gomock.Eq(expectedRequestInValidator)).
+			Return(noError)
+
+		webAuthnRegistrationFinishCase.EXPECT().
+			Use(gomock.Any(), gomock.Eq(expectedWebAuthnRegistrationFinishIn)).
+			Return(out, err)
+	}
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				expectUseCase(validWebAuthnRegistrationFinishOut, noError)
+			},
+			expCode: 200,
+			expBody: `{"id":"dummyCredentialID"}`,
+		},
+		{
+			name: "Bad request: request validation failed",
+			setup: func() {
+				validator.EXPECT().
+					Struct(gomock.Eq(expectedRequestInValidator)).
+					Return(dummyError)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":1001,"name":"Request validation failed"},"message":"dummy error"}`,
+		},
+		{
+			name: "Bad request: invalid response",
+			setup: func() {
+				expectUseCase(noWebAuthnRegistrationFinishOut, dto.ErrInvalidWebAuthnResponse)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2033,"name":"Invalid WebAuthn response"},"message":"Passkey verification failed. Please try again."}`,
+		},
+		{
+			name: "Bad request: credential exists",
+			setup: func() {
+				expectUseCase(noWebAuthnRegistrationFinishOut, dto.ErrWebAuthnCredentialExists)
+			},
+			expCode: 400,
+			expBody: `{"error":{"code":2034,"name":"WebAuthn credential exists"},"message":"This passkey is already registered."}`,
+		},
+		{
+			name: "Internal server error: unexpected error in use case",
+			setup: func() {
+				expectUseCase(noWebAuthnRegistrationFinishOut, dummyError)
+
+				serverErrorHandler.EXPECT().
+					Handle(gomock.Any(), gomock.Eq(dummyError)).
+					DoAndReturn(func(ctx *gin.Context, _ error) {
+						api.InternalServerErrorResponse(ctx)
+					})
+			},
+			expCode: 500,
+			expBody: `{"message":"Something went wrong, please try again later."}`,
+		},
+	}
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, userID)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			rBody := `{"name":"MacBook","clientDataJSON":"dummyClientDataJSON","attestationObject":"dummyAttestationObject"}`
+			r := httptest.NewRequest(method, path, io.NopCloser(bytes.NewBufferString(rBody)))
+			r.Header.Set("User-Agent", userAgent)
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			Bind(router, webAuthnRegistrationFinishCase, validator, serverErrorHandlerFactory, authenticate)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
+
+// TestEndpoint_personalAccessToken checks the account can't be managed with a personal access token.
+func TestEndpoint_personalAccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		webAuthnRegistrationFinishCase = mock.NewMockwebAuthnRegistrationFinishCase(ctrl)
+		validator                      = mock_api.NewMockValidator(ctrl)
+		serverErrorHandlerFactory      = mock_api.NewMockServerErrorHandlerFactory(ctrl)
+	)
+
+	serverErrorHandlerFactory.EXPECT().
+		MakeHandler(gomock.Eq(method), gomock.Eq(path)).
+		Return(mock_api.NewMockServerErrorHandler(ctrl))
+
+	authenticate := func(ctx *gin.Context) {
+		api.SetUserID(ctx, 1)
+		api.SetPersonalAccessToken(ctx, true)
+	}
+
+	r := httptest.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+
+	router := gin.New()
+	Bind(router, webAuthnRegistrationFinishCase, validator, serverErrorHandlerFactory, authenticate, authenticated.New().Handle)
+
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, 403, w.Code)
+	assert.JSONEq(t, `{"error":{"code":2035,"name":"Personal access token not allowed"},"message":"Please sign in to manage your account, personal access tokens can't be used for this action."}`, w.Body.String())
+}
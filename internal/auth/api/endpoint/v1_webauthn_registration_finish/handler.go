@@ -0,0 +1,88 @@
+package v1_webauthn_registration_finish
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/validation"
+)
+
+// request carries the response of navigator.credentials.create(), the binary values are base64url encoded.
+type request struct {
+	Name              string `json:"name" validate:"required,max=100"`
+	ClientDataJSON    string `json:"clientDataJSON" validate:"required,lte=4096"`
+	AttestationObject string `json:"attestationObject" validate:"required,lte=16384"`
+}
+
+type response struct {
+	ID string `json:"id"`
+}
+
+type handler struct {
+	webAuthnRegistrationFinishCase webAuthnRegistrationFinishCase
+	validator                      validation.Validator
+	serverErrorHandler             api.ServerErrorHandler
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		api.RequestValidationFailedResponse(ctx, err)
+		return
+	}
+
+	out, err := h.useCase(ctx, api.GetUserID(ctx), ctx.ClientIP(), ctx.Request.UserAgent(), req)
+	if err != nil {
+		switch err {
+		case dto.ErrInvalidWebAuthnResponse:
+			auth_api.InvalidWebAuthnResponseResponse(ctx)
+		case dto.ErrWebAuthnCredentialExists:
+			auth_api.WebAuthnCredentialExistsResponse(ctx)
+		default:
+			h.serverErrorHandler.Handle(ctx, err)
+		}
+		return
+	}
+
+	okResponse(ctx, out)
+}
+
+func (h *handler) parseRequest(ctx *gin.Context) (*request, error) {
+	var req request
+	ctx.ShouldBindJSON(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *handler) useCase(
+	ctx context.Context,
+	userID int64,
+	clientIP, userAgent string,
+	req *request,
+) (*dto.WebAuthnRegistrationFinishOut, error) {
+	in := dto.WebAuthnRegistrationFinishIn{
+		UserID:            userID,
+		Name:              req.Name,
+		ClientDataJSON:    req.ClientDataJSON,
+		AttestationObject: req.AttestationObject,
+		IP:                clientIP,
+		UserAgent:         userAgent,
+	}
+
+	return h.webAuthnRegistrationFinishCase.Use(ctx, &in)
+}
+
+func okResponse(ctx *gin.Context, out *dto.WebAuthnRegistrationFinishOut) {
+	ctx.JSON(http.StatusOK, &response{
+		ID: out.CredentialID,
+	})
+}
@@ -0,0 +1,31 @@
+//go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
+package well_known_jwks
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const (
+	method = http.MethodGet
+	path   = "/.well-known/jwks.json"
+)
+
+type jsonWebKeySetGetCase interface {
+	Use(ctx context.Context) *dto.JSONWebKeySetGetOut
+}
+
+func Bind(
+	router *gin.Engine,
+	jsonWebKeySetGetCase jsonWebKeySetGetCase,
+) {
+	h := handler{
+		jsonWebKeySetGetCase: jsonWebKeySetGetCase,
+	}
+
+	router.Handle(method, path, h.handle)
+}
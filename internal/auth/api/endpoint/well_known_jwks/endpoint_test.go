@@ -0,0 +1,75 @@
+package well_known_jwks
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/endpoint/well_known_jwks/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jsonWebKeySetGetCase := mock.NewMockjsonWebKeySetGetCase(ctrl)
+
+	tests := []struct {
+		name    string
+		setup   func()
+		expCode int
+		expBody string
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				jsonWebKeySetGetCase.EXPECT().
+					Use(gomock.Any()).
+					Return(&dto.JSONWebKeySetGetOut{
+						Keys: []dto.JSONWebKey{
+							{KeyType: "OKP", KeyID: "current", Use: "sig", Algorithm: "EdDSA", Curve: "Ed25519", X: "dummyX"},
+							{KeyType: "RSA", KeyID: "previous", Use: "sig", Algorithm: "RS256", Modulus: "dummyN", Exponent: "AQAB"},
+						},
+					})
+			},
+			expCode: 200,
+			expBody: `{"keys":[` +
+				`{"kty":"OKP","kid":"current","use":"sig","alg":"EdDSA","crv":"Ed25519","x":"dummyX"},` +
+				`{"kty":"RSA","kid":"previous","use":"sig","alg":"RS256","n":"dummyN","e":"AQAB"}` +
+				`]}`,
+		},
+		{
+			name: "OK: empty key set",
+			setup: func() {
+				jsonWebKeySetGetCase.EXPECT().
+					Use(gomock.Any()).
+					Return(&dto.JSONWebKeySetGetOut{})
+			},
+			expCode: 200,
+			expBody: `{"keys":[]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(method, path, nil)
+
+			router := gin.New()
+			Bind(router, jsonWebKeySetGetCase)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.Equal(t, cacheControl, w.Header().Get("Cache-Control"))
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
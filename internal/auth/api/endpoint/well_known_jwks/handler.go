@@ -0,0 +1,58 @@
+package well_known_jwks
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+// cacheControl lets verifiers cache keys between rotations.
+const cacheControl = "public, max-age=300"
+
+// response is JSON Web Key Set
+// https://datatracker.ietf.org/doc/html/rfc7517#section-5
+type response struct {
+	Keys []key `json:"keys"`
+}
+
+type key struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Modulus   string `json:"n,omitempty"`
+	Exponent  string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+type handler struct {
+	jsonWebKeySetGetCase jsonWebKeySetGetCase
+}
+
+func (h *handler) handle(ctx *gin.Context) {
+	out := h.jsonWebKeySetGetCase.Use(ctx)
+
+	okResponse(ctx, out)
+}
+
+func okResponse(ctx *gin.Context, out *dto.JSONWebKeySetGetOut) {
+	keys := make([]key, 0, len(out.Keys))
+	for _, k := range out.Keys {
+		keys = append(keys, key{
+			KeyType:   k.KeyType,
+			KeyID:     k.KeyID,
+			Use:       k.Use,
+			Algorithm: k.Algorithm,
+			Modulus:   k.Modulus,
+			Exponent:  k.Exponent,
+			Curve:     k.Curve,
+			X:         k.X,
+		})
+	}
+
+	ctx.Header("Cache-Control", cacheControl)
+	ctx.JSON(http.StatusOK, &response{Keys: keys})
+}
@@ -4,18 +4,27 @@ import (
 	"github.com/art-es/blog/internal/common/api"
 
 	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
 )
 
 type Middleware struct{}
 
+// New creates the middleware passing only the users signed in with a session.
+// Personal access tokens are rejected, since the routes behind it manage the account,
+// e.g. register passkeys or create more tokens, and the scopes of a token never grant that.
 func New() *Middleware {
 	return &Middleware{}
 }
 
 func (m *Middleware) Handle(ctx *gin.Context) {
-	switch api.GetUserID(ctx) {
-	case 0:
+	switch {
+	case api.GetUserID(ctx) == 0:
 		api.UnauthorizedResponse(ctx)
+		ctx.Abort()
+	case api.IsPersonalAccessToken(ctx):
+		auth_api.PersonalAccessTokenNotAllowedResponse(ctx)
+		ctx.Abort()
 	default:
 		ctx.Next()
 	}
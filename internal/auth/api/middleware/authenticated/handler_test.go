@@ -0,0 +1,73 @@
+package authenticated_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/middleware/authenticated"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+func Test(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	middleware := authenticated.New().Handle
+
+	tests := []struct {
+		name                string
+		userID              int64
+		personalAccessToken bool
+		expCode             int
+		expBody             string
+	}{
+		{
+			name:    "authenticated",
+			userID:  1,
+			expCode: 200,
+			expBody: `{"message":"OK"}`,
+		},
+		{
+			name:                "personal access token",
+			userID:              1,
+			personalAccessToken: true,
+			expCode:             403,
+			expBody:             `{"error":{"code":2035,"name":"Personal access token not allowed"},"message":"Please sign in to manage your account, personal access tokens can't be used for this action."}`,
+		},
+		{
+			name:    "not authenticated",
+			userID:  0,
+			expCode: 401,
+			expBody: `{"message":"Please try to sign in again."}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const method = http.MethodGet
+			const path = "/"
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(method, path, nil)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.userID != 0 {
+					api.SetUserID(c, tt.userID)
+					api.SetPersonalAccessToken(c, tt.personalAccessToken)
+				}
+			})
+			router.Use(middleware)
+			router.Handle(method, path, func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"message": "OK"})
+			})
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
@@ -3,11 +3,13 @@ package parse_token
 
 import (
 	"context"
+	"errors"
 
 	"github.com/art-es/blog/internal/common/api"
 
 	"github.com/gin-gonic/gin"
 
+	auth_api "github.com/art-es/blog/internal/auth/api"
 	"github.com/art-es/blog/internal/auth/dto"
 )
 
@@ -26,23 +28,36 @@ func New(accessTokenParseCase accessTokenParseCase) *Middleware {
 }
 
 func (m *Middleware) Handle(ctx *gin.Context) {
-	if accessToken := api.AccessTokenHeader(ctx); accessToken != "" {
-		if userID, ok := m.useCase(ctx, accessToken); ok {
-			api.SetUserID(ctx, userID)
+	accessToken, fromCookie := auth_api.AccessToken(ctx)
+	if fromCookie && !auth_api.CSRFTokenValid(ctx) {
+		auth_api.CSRFTokenMismatchResponse(ctx)
+		ctx.Abort()
+		return
+	}
+
+	if accessToken != "" {
+		out, err := m.useCase(ctx, accessToken)
+		switch {
+		case err == nil:
+			api.SetUserID(ctx, out.UserID)
+			api.SetSessionID(ctx, out.SessionID)
+			api.SetRoles(ctx, out.Roles)
+			api.SetPermissions(ctx, out.Permissions)
+			api.SetPersonalAccessToken(ctx, out.PersonalAccessToken)
+		case errors.Is(err, dto.ErrUserSuspended):
+			auth_api.UserSuspendedResponse(ctx)
+			ctx.Abort()
+			return
 		}
 	}
 
 	ctx.Next()
 }
 
-func (m *Middleware) useCase(ctx context.Context, accessToken string) (int64, bool) {
+func (m *Middleware) useCase(ctx context.Context, accessToken string) (*dto.ParseTokenOut, error) {
 	in := dto.AccessTokenParseIn{
 		AccessToken: accessToken,
 	}
 
-	if out, err := m.accessTokenParseCase.Use(ctx, &in); err == nil {
-		return out.UserID, true
-	}
-
-	return 0, false
+	return m.accessTokenParseCase.Use(ctx, &in)
 }
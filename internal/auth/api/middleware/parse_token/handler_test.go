@@ -20,34 +20,118 @@ import (
 func Test(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	usecase := mock.NewMockusecase(ctrl)
+	accessTokenParseCase := mock.NewMockaccessTokenParseCase(ctrl)
 
 	gin.SetMode(gin.TestMode)
-	middleware := parse_token.New(usecase).Handle
+	middleware := parse_token.New(accessTokenParseCase).Handle
 
 	tests := []struct {
 		name    string
+		method  string
 		setup   func(r *http.Request)
 		handler func(c *gin.Context)
+		expCode int
 	}{
 		{
 			name: "token parsed",
+			setup: func(r *http.Request) {
+				r.Header.Set("X-Access-Token", "foo")
+
+				accessTokenParseCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(&dto.AccessTokenParseIn{AccessToken: "foo"})).
+					Return(&dto.ParseTokenOut{
+						UserID:      1,
+						SessionID:   "dummySessionID",
+						Roles:       []string{"author"},
+						Permissions: []string{"post:read", "post:write"},
+					}, nil)
+			},
+			handler: func(c *gin.Context) {
+				assert.Equal(t, int64(1), api.GetUserID(c))
+				assert.Equal(t, "dummySessionID", api.GetSessionID(c))
+				assert.Equal(t, []string{"author"}, api.GetRoles(c))
+				assert.Equal(t, []string{"post:read", "post:write"}, api.GetPermissions(c))
+				assert.False(t, api.IsPersonalAccessToken(c))
+			},
+		},
+		{
+			name: "personal access token parsed",
+			setup: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer bpat_foo")
+
+				accessTokenParseCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(&dto.AccessTokenParseIn{AccessToken: "bpat_foo"})).
+					Return(&dto.ParseTokenOut{
+						UserID:              1,
+						Permissions:         []string{"post:read"},
+						PersonalAccessToken: true,
+					}, nil)
+			},
+			handler: func(c *gin.Context) {
+				assert.Equal(t, int64(1), api.GetUserID(c))
+				assert.Equal(t, []string{"post:read"}, api.GetPermissions(c))
+				assert.True(t, api.IsPersonalAccessToken(c))
+			},
+		},
+		{
+			name: "bearer token parsed",
 			setup: func(r *http.Request) {
 				r.Header.Set("Authorization", "Bearer foo")
 
-				usecase.EXPECT().
-					Do(gomock.Any(), gomock.Eq(&dto.AccessTokenParseIn{AccessToken: "foo"})).
+				accessTokenParseCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(&dto.AccessTokenParseIn{AccessToken: "foo"})).
 					Return(&dto.ParseTokenOut{UserID: 1}, nil)
 			},
 			handler: func(c *gin.Context) {
-				userID := api.GetUserID(c)
-				assert.Equal(t, int64(1), userID)
+				assert.Equal(t, int64(1), api.GetUserID(c))
+			},
+		},
+		{
+			name:   "cookie token parsed",
+			method: http.MethodPost,
+			setup: func(r *http.Request) {
+				r.AddCookie(&http.Cookie{Name: "access_token", Value: "foo"})
+				r.AddCookie(&http.Cookie{Name: "csrf_token", Value: "bar"})
+				r.Header.Set("X-CSRF-Token", "bar")
+
+				accessTokenParseCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(&dto.AccessTokenParseIn{AccessToken: "foo"})).
+					Return(&dto.ParseTokenOut{UserID: 1}, nil)
+			},
+			handler: func(c *gin.Context) {
+				assert.Equal(t, int64(1), api.GetUserID(c))
 			},
 		},
+		{
+			name: "cookie token parsed without CSRF token on safe method",
+			setup: func(r *http.Request) {
+				r.AddCookie(&http.Cookie{Name: "access_token", Value: "foo"})
+
+				accessTokenParseCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(&dto.AccessTokenParseIn{AccessToken: "foo"})).
+					Return(&dto.ParseTokenOut{UserID: 1}, nil)
+			},
+			handler: func(c *gin.Context) {
+				assert.Equal(t, int64(1), api.GetUserID(c))
+			},
+		},
+		{
+			name:   "CSRF token mismatch",
+			method: http.MethodPost,
+			setup: func(r *http.Request) {
+				r.AddCookie(&http.Cookie{Name: "access_token", Value: "foo"})
+				r.AddCookie(&http.Cookie{Name: "csrf_token", Value: "bar"})
+				r.Header.Set("X-CSRF-Token", "baz")
+			},
+			handler: func(c *gin.Context) {
+				t.Error("handler must not be called")
+			},
+			expCode: http.StatusForbidden,
+		},
 		{
 			name: "token not specified",
 			setup: func(r *http.Request) {
-				r.Header.Del("Authorization")
+				r.Header.Del("X-Access-Token")
 			},
 			handler: func(c *gin.Context) {
 				userID := api.GetUserID(c)
@@ -57,10 +141,10 @@ func Test(t *testing.T) {
 		{
 			name: "token parsing error",
 			setup: func(r *http.Request) {
-				r.Header.Set("Authorization", "Bearer foo")
+				r.Header.Set("X-Access-Token", "foo")
 
-				usecase.EXPECT().
-					Do(gomock.Any(), gomock.Eq(&dto.AccessTokenParseIn{AccessToken: "foo"})).
+				accessTokenParseCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(&dto.AccessTokenParseIn{AccessToken: "foo"})).
 					Return(nil, errors.New("dummy error"))
 			},
 			handler: func(c *gin.Context) {
@@ -68,12 +152,29 @@ func Test(t *testing.T) {
 				assert.Equal(t, int64(0), userID)
 			},
 		},
+		{
+			name: "user suspended",
+			setup: func(r *http.Request) {
+				r.Header.Set("X-Access-Token", "foo")
+
+				accessTokenParseCase.EXPECT().
+					Use(gomock.Any(), gomock.Eq(&dto.AccessTokenParseIn{AccessToken: "foo"})).
+					Return(nil, dto.ErrUserSuspended)
+			},
+			handler: func(c *gin.Context) {
+				t.Error("handler must not be called")
+			},
+			expCode: http.StatusForbidden,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			const method = http.MethodGet
 			const path = "/"
+			method := http.MethodGet
+			if tt.method != "" {
+				method = tt.method
+			}
 
 			w := httptest.NewRecorder()
 			r := httptest.NewRequest(method, path, nil)
@@ -83,6 +184,10 @@ func Test(t *testing.T) {
 			router.Use(middleware)
 			router.Handle(method, path, tt.handler)
 			router.ServeHTTP(w, r)
+
+			if tt.expCode != 0 {
+				assert.Equal(t, tt.expCode, w.Code)
+			}
 		})
 	}
 }
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: endpoint.go
+// Source: handler.go
 
 // Package mock is a generated GoMock package.
 package mock
@@ -12,31 +12,31 @@ import (
 	gomock "github.com/golang/mock/gomock"
 )
 
-// Mockusecase is a mock of usecase interface.
-type Mockusecase struct {
+// MockaccessTokenParseCase is a mock of accessTokenParseCase interface.
+type MockaccessTokenParseCase struct {
 	ctrl     *gomock.Controller
-	recorder *MockusecaseMockRecorder
+	recorder *MockaccessTokenParseCaseMockRecorder
 }
 
-// MockusecaseMockRecorder is the mock recorder for Mockusecase.
-type MockusecaseMockRecorder struct {
-	mock *Mockusecase
+// MockaccessTokenParseCaseMockRecorder is the mock recorder for MockaccessTokenParseCase.
+type MockaccessTokenParseCaseMockRecorder struct {
+	mock *MockaccessTokenParseCase
 }
 
-// NewMockusecase creates a new mock instance.
-func NewMockusecase(ctrl *gomock.Controller) *Mockusecase {
-	mock := &Mockusecase{ctrl: ctrl}
-	mock.recorder = &MockusecaseMockRecorder{mock}
+// NewMockaccessTokenParseCase creates a new mock instance.
+func NewMockaccessTokenParseCase(ctrl *gomock.Controller) *MockaccessTokenParseCase {
+	mock := &MockaccessTokenParseCase{ctrl: ctrl}
+	mock.recorder = &MockaccessTokenParseCaseMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockusecase) EXPECT() *MockusecaseMockRecorder {
+func (m *MockaccessTokenParseCase) EXPECT() *MockaccessTokenParseCaseMockRecorder {
 	return m.recorder
 }
 
-// Do mocks base method.
-func (m *Mockusecase) Use(ctx context.Context, in *dto.AccessTokenParseIn) (*dto.ParseTokenOut, error) {
+// Use mocks base method.
+func (m *MockaccessTokenParseCase) Use(ctx context.Context, in *dto.AccessTokenParseIn) (*dto.ParseTokenOut, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Use", ctx, in)
 	ret0, _ := ret[0].(*dto.ParseTokenOut)
@@ -44,8 +44,8 @@ func (m *Mockusecase) Use(ctx context.Context, in *dto.AccessTokenParseIn) (*dto
 	return ret0, ret1
 }
 
-// Do indicates an expected call of Do.
-func (mr *MockusecaseMockRecorder) Do(ctx, in interface{}) *gomock.Call {
+// Use indicates an expected call of Use.
+func (mr *MockaccessTokenParseCaseMockRecorder) Use(ctx, in interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Use", reflect.TypeOf((*Mockusecase)(nil).Use), ctx, in)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Use", reflect.TypeOf((*MockaccessTokenParseCase)(nil).Use), ctx, in)
 }
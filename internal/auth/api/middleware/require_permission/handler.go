@@ -0,0 +1,33 @@
+package require_permission
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auth_api "github.com/art-es/blog/internal/auth/api"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+type Middleware struct {
+	permission string
+}
+
+// New creates the middleware passing only the users granted the permission.
+// It must follow the parse_token middleware, which puts the permissions into the context.
+func New(permission string) *Middleware {
+	return &Middleware{
+		permission: permission,
+	}
+}
+
+func (m *Middleware) Handle(ctx *gin.Context) {
+	switch {
+	case api.GetUserID(ctx) == 0:
+		api.UnauthorizedResponse(ctx)
+		ctx.Abort()
+	case !api.HasPermission(ctx, m.permission):
+		auth_api.PermissionDeniedResponse(ctx)
+		ctx.Abort()
+	default:
+		ctx.Next()
+	}
+}
@@ -0,0 +1,87 @@
+package require_permission_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/api/middleware/require_permission"
+	"github.com/art-es/blog/internal/common/api"
+)
+
+func Test(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	middleware := require_permission.New("user:manage").Handle
+
+	tests := []struct {
+		name        string
+		userID      int64
+		permissions []string
+		expCode     int
+		expBody     string
+	}{
+		{
+			name:        "permission granted",
+			userID:      1,
+			permissions: []string{"post:read", "user:manage"},
+			expCode:     200,
+			expBody:     `{"message":"OK"}`,
+		},
+		{
+			name:        "permission not granted",
+			userID:      1,
+			permissions: []string{"post:read"},
+			expCode:     403,
+			expBody: `{
+				"error":{"code":2017,"name":"Permission denied"},
+				"message":"You don't have permission to perform this action."
+			}`,
+		},
+		{
+			name:        "no permissions",
+			userID:      1,
+			permissions: nil,
+			expCode:     403,
+			expBody: `{
+				"error":{"code":2017,"name":"Permission denied"},
+				"message":"You don't have permission to perform this action."
+			}`,
+		},
+		{
+			name:    "not authenticated",
+			userID:  0,
+			expCode: 401,
+			expBody: `{"message":"Please try to sign in again."}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const method = http.MethodGet
+			const path = "/"
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(method, path, nil)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.userID != 0 {
+					api.SetUserID(c, tt.userID)
+					api.SetPermissions(c, tt.permissions)
+				}
+			})
+			router.Use(middleware)
+			router.Handle(method, path, func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"message": "OK"})
+			})
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expCode, w.Code)
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
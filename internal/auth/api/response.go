@@ -2,6 +2,8 @@ package api
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -24,7 +26,7 @@ func ExpiredUserActivationCodeResponse(ctx *gin.Context) {
 			Code: 2002,
 			Name: "Expired user activation code",
 		},
-		Message: "Activate code is expired. Please pass the registration process again.",
+		Message: "Activation code is expired. Please request a new one.",
 	})
 }
 
@@ -37,3 +39,345 @@ func IncorrectUserCredentialsResponse(ctx *gin.Context) {
 		Message: "Email or password is incorrect.",
 	})
 }
+
+func InvalidPasswordResetTokenResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2004,
+			Name: "Invalid password reset token",
+		},
+		Message: "Password reset link is invalid or expired. Please request a new one.",
+	})
+}
+
+func IncorrectPasswordResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2005,
+			Name: "Incorrect password",
+		},
+		Message: "Current password is incorrect.",
+	})
+}
+
+func UserNotActivatedResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusForbidden, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2006,
+			Name: "Account not activated",
+		},
+		Message: "Please activate your account using the code from the email.",
+	})
+}
+
+// LoginThrottledResponse sets Retry-After header in seconds.
+func LoginThrottledResponse(ctx *gin.Context, retryAfter time.Duration) {
+	seconds := int64((retryAfter + time.Second - 1) / time.Second)
+	ctx.Header("Retry-After", strconv.FormatInt(seconds, 10))
+	ctx.JSON(http.StatusTooManyRequests, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2007,
+			Name: "Too many login attempts",
+		},
+		Message: "Too many failed login attempts. Please try again later.",
+	})
+}
+
+func InvalidTwoFactorCodeResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2008,
+			Name: "Invalid two-factor code",
+		},
+		Message: "Authentication code is invalid.",
+	})
+}
+
+func InvalidTwoFactorTokenResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2009,
+			Name: "Invalid two-factor token",
+		},
+		Message: "Sign-in session is invalid or expired. Please sign in again.",
+	})
+}
+
+func TwoFactorAlreadyEnabledResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2010,
+			Name: "Two-factor authentication already enabled",
+		},
+		Message: "Two-factor authentication is already enabled. Disable it first to set up a new authenticator.",
+	})
+}
+
+func TwoFactorNotEnabledResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2011,
+			Name: "Two-factor authentication not enabled",
+		},
+		Message: "Two-factor authentication is not enabled. Please set it up first.",
+	})
+}
+
+func IdentityProviderNotFoundResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusNotFound, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2012,
+			Name: "Identity provider not found",
+		},
+		Message: "Sign-in with this provider is not supported.",
+	})
+}
+
+func InvalidOIDCStateResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2013,
+			Name: "Invalid OIDC state",
+		},
+		Message: "Sign-in session is invalid or expired. Please try again.",
+	})
+}
+
+func OIDCAuthenticationFailedResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2014,
+			Name: "OIDC authentication failed",
+		},
+		Message: "Identity provider has not confirmed the sign-in. Please try again.",
+	})
+}
+
+func ExternalEmailNotVerifiedResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2015,
+			Name: "External email not verified",
+		},
+		Message: "Please verify your email at the identity provider first.",
+	})
+}
+
+func SessionNotFoundResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusNotFound, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2016,
+			Name: "Session not found",
+		},
+		Message: "Session is not found or has already ended.",
+	})
+}
+
+func PermissionDeniedResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusForbidden, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2017,
+			Name: "Permission denied",
+		},
+		Message: "You don't have permission to perform this action.",
+	})
+}
+
+func ScopeNotGrantedResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusForbidden, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2018,
+			Name: "Scope not granted",
+		},
+		Message: "Token scopes must be among your permissions.",
+	})
+}
+
+func PersonalAccessTokenNotFoundResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusNotFound, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2019,
+			Name: "Personal access token not found",
+		},
+		Message: "Personal access token is not found or has already been revoked.",
+	})
+}
+
+type passwordPolicyViolatedResponse struct {
+	api.ErrorResponse
+	Violations []string `json:"violations"`
+}
+
+// PasswordPolicyViolatedResponse lists the violated rules of the password policy.
+func PasswordPolicyViolatedResponse(ctx *gin.Context, violations []string) {
+	ctx.JSON(http.StatusBadRequest, &passwordPolicyViolatedResponse{
+		ErrorResponse: api.ErrorResponse{
+			Error: &api.Error{
+				Code: 2020,
+				Name: "Password policy violated",
+			},
+			Message: "Password doesn't meet the requirements.",
+		},
+		Violations: violations,
+	})
+}
+
+func BusyHandleResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2021,
+			Name: "Busy handle",
+		},
+		Message: "This handle is already taken.",
+	})
+}
+
+func ProfileNotFoundResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusNotFound, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2022,
+			Name: "Profile not found",
+		},
+		Message: "Profile is not found.",
+	})
+}
+
+func CSRFTokenMismatchResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusForbidden, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2023,
+			Name: "CSRF token mismatch",
+		},
+		Message: "Please reload the page and try again.",
+	})
+}
+
+func InvalidMagicLinkTokenResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2024,
+			Name: "Invalid magic link token",
+		},
+		Message: "Login link is invalid or expired. Please request a new one.",
+	})
+}
+
+func InvalidEmailChangeCodeResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2025,
+			Name: "Invalid email change code",
+		},
+		Message: "Confirmation code is invalid or expired. Please request the email change again.",
+	})
+}
+
+func InvalidEmailChangeUndoTokenResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2026,
+			Name: "Invalid email change undo token",
+		},
+		Message: "Undo link is invalid or expired.",
+	})
+}
+
+func UserNotFoundResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusNotFound, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2027,
+			Name: "User not found",
+		},
+		Message: "User not found.",
+	})
+}
+
+func UserSuspendedResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusForbidden, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2028,
+			Name: "User suspended",
+		},
+		Message: "Your account is suspended.",
+	})
+}
+
+func InviteRequiredResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusForbidden, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2029,
+			Name: "Invite required",
+		},
+		Message: "Registration is available by invite only.",
+	})
+}
+
+func InvalidInviteResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2030,
+			Name: "Invalid invite",
+		},
+		Message: "Invite is invalid, expired or used up.",
+	})
+}
+
+func EmailDomainNotAllowedResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2031,
+			Name: "Email domain not allowed",
+		},
+		Message: "Registration with this email domain is not allowed.",
+	})
+}
+
+func DisposableEmailResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2032,
+			Name: "Disposable email",
+		},
+		Message: "Disposable email addresses are not allowed.",
+	})
+}
+
+func InvalidWebAuthnResponseResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2033,
+			Name: "Invalid WebAuthn response",
+		},
+		Message: "Passkey verification failed. Please try again.",
+	})
+}
+
+func WebAuthnCredentialExistsResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusBadRequest, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2034,
+			Name: "WebAuthn credential exists",
+		},
+		Message: "This passkey is already registered.",
+	})
+}
+
+func PersonalAccessTokenNotAllowedResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusForbidden, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2035,
+			Name: "Personal access token not allowed",
+		},
+		Message: "Please sign in to manage your account, personal access tokens can't be used for this action.",
+	})
+}
+
+func TooManyTwoFactorAttemptsResponse(ctx *gin.Context) {
+	ctx.JSON(http.StatusTooManyRequests, &api.ErrorResponse{
+		Error: &api.Error{
+			Code: 2036,
+			Name: "Too many two-factor attempts",
+		},
+		Message: "Too many incorrect codes. Please sign in again with two-factor authentication to continue.",
+	})
+}
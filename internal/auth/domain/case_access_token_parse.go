@@ -1,8 +1,10 @@
+//go:generate mockgen -source=case_access_token_parse.go -destination=mock/case_access_token_parse.go -package=mock
 package domain
 
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/art-es/blog/internal/auth/dto"
 )
@@ -11,30 +13,144 @@ type accessTokenParser interface {
 	ParseAndValidate(token string) (*AccessTokenObject, error)
 }
 
+type accessTokenRevocationChecker interface {
+	IsRevoked(ctx context.Context, object *AccessTokenObject, repository AccessTokenRevocationRepository) (bool, error)
+}
+
+type personalAccessTokenParser interface {
+	IsPersonalAccessToken(token string) bool
+	Parse(ctx context.Context, token string, repository PersonalAccessTokenRepository) (*PersonalAccessToken, error)
+}
+
 type AccessTokenParseCase struct {
-	userRepository    UserRepository
-	accessTokenParser accessTokenParser
+	repository                   Repository
+	accessTokenParser            accessTokenParser
+	accessTokenRevocationChecker accessTokenRevocationChecker
+	personalAccessTokenParser    personalAccessTokenParser
 }
 
 func NewAccessTokenParseCase(
-	userRepository UserRepository,
+	repository Repository,
 	accessTokenParser accessTokenParser,
+	accessTokenRevocationChecker accessTokenRevocationChecker,
+	personalAccessTokenParser personalAccessTokenParser,
 ) *AccessTokenParseCase {
 	return &AccessTokenParseCase{
-		userRepository:    userRepository,
-		accessTokenParser: accessTokenParser,
+		repository:                   repository,
+		accessTokenParser:            accessTokenParser,
+		accessTokenRevocationChecker: accessTokenRevocationChecker,
+		personalAccessTokenParser:    personalAccessTokenParser,
 	}
 }
 
 func (u *AccessTokenParseCase) Use(ctx context.Context, in *dto.AccessTokenParseIn) (*dto.ParseTokenOut, error) {
-	tokenObject, err := u.accessTokenParser.ParseAndValidate(in.AccessToken)
+	if u.personalAccessTokenParser.IsPersonalAccessToken(in.AccessToken) {
+		return u.usePersonalAccessToken(ctx, in.AccessToken)
+	}
+
+	tokenObject, err := validateAccessToken(ctx, in.AccessToken, u.accessTokenParser, u.accessTokenRevocationChecker, u.repository)
 	if err != nil {
-		return nil, fmt.Errorf("access token validation error: %w", err)
+		return nil, err
+	}
+
+	if err = checkUserExistence(u.repository.User(), ctx, tokenObject.UserID); err != nil {
+		return nil, err
 	}
 
-	if err = checkUserExistence(u.userRepository, ctx, tokenObject.UserID); err != nil {
+	if err = checkUserSuspension(ctx, u.repository.Suspension(), tokenObject.UserID); err != nil {
 		return nil, err
 	}
 
-	return &dto.ParseTokenOut{UserID: tokenObject.UserID}, nil
+	return &dto.ParseTokenOut{
+		UserID:      tokenObject.UserID,
+		SessionID:   tokenObject.SessionID,
+		Roles:       tokenObject.Roles,
+		Permissions: tokenObject.Permissions,
+	}, nil
+}
+
+// usePersonalAccessToken grants the scopes of the token which are still granted to the user.
+// The token is marked, so it can't be used to manage the account like the access token of a session.
+func (u *AccessTokenParseCase) usePersonalAccessToken(ctx context.Context, token string) (*dto.ParseTokenOut, error) {
+	object, err := u.personalAccessTokenParser.Parse(ctx, token, u.repository.PersonalAccessToken())
+	if err != nil {
+		if err == dto.ErrInvalidAccessToken {
+			return nil, err
+		}
+		return nil, fmt.Errorf("personal access token parsing error: %w", err)
+	}
+
+	if err = checkUserExistence(u.repository.User(), ctx, object.UserID); err != nil {
+		return nil, err
+	}
+
+	if err = checkUserSuspension(ctx, u.repository.Suspension(), object.UserID); err != nil {
+		return nil, err
+	}
+
+	access, err := u.repository.Role().GetUserAccess(ctx, object.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("user access getting error: %w", err)
+	}
+
+	permissions := make([]string, 0, len(object.Scopes))
+	for _, scope := range object.Scopes {
+		if contains(access.Permissions, scope) {
+			permissions = append(permissions, scope)
+		}
+	}
+
+	return &dto.ParseTokenOut{
+		UserID:              object.UserID,
+		Permissions:         permissions,
+		PersonalAccessToken: true,
+	}, nil
+}
+
+// checkUserSuspension returns dto.ErrUserSuspended if the user is suspended at the moment.
+func checkUserSuspension(ctx context.Context, repository SuspensionRepository, userID int64) error {
+	suspension, err := repository.Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user suspension getting error: %w", err)
+	}
+	if suspension != nil && suspension.IsActive(time.Now()) {
+		return dto.ErrUserSuspended
+	}
+	return nil
+}
+
+func validateAccessToken(
+	ctx context.Context,
+	token string,
+	parser accessTokenParser,
+	revocationChecker accessTokenRevocationChecker,
+	repository Repository,
+) (*AccessTokenObject, error) {
+	object, err := parser.ParseAndValidate(token)
+	if err != nil {
+		return nil, dto.ErrInvalidAccessToken
+	}
+
+	revoked, err := revocationChecker.IsRevoked(ctx, object, repository.AccessTokenRevocation())
+	if err != nil {
+		return nil, fmt.Errorf("access token revocation checking error: %w", err)
+	}
+	if revoked {
+		return nil, dto.ErrInvalidAccessToken
+	}
+
+	// the tokens issued before sessions have no session
+	if object.SessionID == "" {
+		return object, nil
+	}
+
+	session, err := repository.Session().Get(ctx, object.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("session getting error: %w", err)
+	}
+	if session == nil || session.UserID != object.UserID || !session.IsActive(time.Now()) {
+		return nil, dto.ErrInvalidAccessToken
+	}
+
+	return object, nil
 }
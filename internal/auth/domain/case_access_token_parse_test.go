@@ -0,0 +1,500 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestAccessTokenParseUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository                   = mock.NewMockRepository(ctrl)
+		accessTokenParser            = mock.NewMockaccessTokenParser(ctrl)
+		accessTokenRevocationChecker = mock.NewMockaccessTokenRevocationChecker(ctrl)
+		personalAccessTokenParser    = mock.NewMockpersonalAccessTokenParser(ctrl)
+	)
+
+	var (
+		ctx                = context.Background()
+		userID             = int64(1)
+		token              = "dummyAccessToken"
+		sessionID          = "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b"
+		tokenObject        = &domain.AccessTokenObject{UserID: userID, ID: "dummyTokenID"}
+		sessionTokenObject = &domain.AccessTokenObject{
+			UserID:      userID,
+			SessionID:   sessionID,
+			Roles:       []string{domain.RoleAuthor},
+			Permissions: []string{domain.PermissionPostWrite},
+			ID:          "dummyTokenID",
+		}
+		accessTokenRevocationRepository = mock.NewMockAccessTokenRevocationRepository(ctrl)
+		in                              = &dto.AccessTokenParseIn{AccessToken: token}
+		noError                         = ""
+	)
+
+	personalAccessTokenParser.EXPECT().
+		IsPersonalAccessToken(gomock.Eq(token)).
+		Return(false).
+		AnyTimes()
+
+	expectRevocationCheck := func(revoked bool, err error) {
+		repository.EXPECT().
+			AccessTokenRevocation().
+			Return(accessTokenRevocationRepository)
+
+		accessTokenRevocationChecker.EXPECT().
+			IsRevoked(gomock.Eq(ctx), gomock.Any(), gomock.Eq(accessTokenRevocationRepository)).
+			Return(revoked, err)
+	}
+
+	expectSessionGetting := func(session *domain.Session, err error) {
+		repository.EXPECT().
+			Session().
+			DoAndReturn(func() domain.SessionRepository {
+				r := mock.NewMockSessionRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(sessionID)).
+					Return(session, err)
+				return r
+			})
+	}
+
+	activeSession := func() *domain.Session {
+		return &domain.Session{ID: sessionID, UserID: userID, ExpiresAt: time.Now().Add(time.Hour)}
+	}
+
+	expectUserExistence := func(exists bool, err error) {
+		repository.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					Exists(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(exists, err)
+				return r
+			})
+	}
+
+	expectSuspension := func(suspension *domain.Suspension, err error) {
+		repository.EXPECT().
+			Suspension().
+			DoAndReturn(func() domain.SuspensionRepository {
+				r := mock.NewMockSuspensionRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(suspension, err)
+				return r
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.ParseTokenOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(token)).
+					Return(tokenObject, nil)
+
+				expectRevocationCheck(false, nil)
+				expectUserExistence(true, nil)
+				expectSuspension(nil, nil)
+			},
+			expOut: &dto.ParseTokenOut{UserID: userID},
+			expErr: noError,
+		},
+		{
+			name: "happy path with session",
+			setup: func() {
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(token)).
+					Return(sessionTokenObject, nil)
+
+				expectRevocationCheck(false, nil)
+				expectSessionGetting(activeSession(), nil)
+				expectUserExistence(true, nil)
+				expectSuspension(nil, nil)
+			},
+			expOut: &dto.ParseTokenOut{
+				UserID:      userID,
+				SessionID:   sessionID,
+				Roles:       []string{domain.RoleAuthor},
+				Permissions: []string{domain.PermissionPostWrite},
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on parsing access token",
+			setup: func() {
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(token)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: dto.ErrInvalidAccessToken.Error(),
+		},
+		{
+			name: "error on checking revocation",
+			setup: func() {
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(token)).
+					Return(tokenObject, nil)
+
+				expectRevocationCheck(false, errors.New("dummy error"))
+			},
+			expErr: "access token revocation checking error: dummy error",
+		},
+		{
+			name: "access token revoked",
+			setup: func() {
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(token)).
+					Return(tokenObject, nil)
+
+				expectRevocationCheck(true, nil)
+			},
+			expErr: dto.ErrInvalidAccessToken.Error(),
+		},
+		{
+			name: "error on getting session",
+			setup: func() {
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(token)).
+					Return(sessionTokenObject, nil)
+
+				expectRevocationCheck(false, nil)
+				expectSessionGetting(nil, errors.New("dummy error"))
+			},
+			expErr: "session getting error: dummy error",
+		},
+		{
+			name: "session not found",
+			setup: func() {
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(token)).
+					Return(sessionTokenObject, nil)
+
+				expectRevocationCheck(false, nil)
+				expectSessionGetting(nil, nil)
+			},
+			expErr: dto.ErrInvalidAccessToken.Error(),
+		},
+		{
+			name: "session revoked",
+			setup: func() {
+				session := activeSession()
+				session.Revoked = true
+
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(token)).
+					Return(sessionTokenObject, nil)
+
+				expectRevocationCheck(false, nil)
+				expectSessionGetting(session, nil)
+			},
+			expErr: dto.ErrInvalidAccessToken.Error(),
+		},
+		{
+			name: "session expired",
+			setup: func() {
+				session := activeSession()
+				session.ExpiresAt = time.Now().Add(-time.Minute)
+
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(token)).
+					Return(sessionTokenObject, nil)
+
+				expectRevocationCheck(false, nil)
+				expectSessionGetting(session, nil)
+			},
+			expErr: dto.ErrInvalidAccessToken.Error(),
+		},
+		{
+			name: "session of another user",
+			setup: func() {
+				session := activeSession()
+				session.UserID = userID + 1
+
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(token)).
+					Return(sessionTokenObject, nil)
+
+				expectRevocationCheck(false, nil)
+				expectSessionGetting(session, nil)
+			},
+			expErr: dto.ErrInvalidAccessToken.Error(),
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(token)).
+					Return(tokenObject, nil)
+
+				expectRevocationCheck(false, nil)
+				expectUserExistence(false, nil)
+			},
+			expErr: dto.ErrUserNotFound.Error(),
+		},
+		{
+			name: "happy path: suspension is over",
+			setup: func() {
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(token)).
+					Return(tokenObject, nil)
+
+				expectRevocationCheck(false, nil)
+				expectUserExistence(true, nil)
+				expectSuspension(&domain.Suspension{UserID: userID, Until: time.Now().Add(-time.Minute)}, nil)
+			},
+			expOut: &dto.ParseTokenOut{UserID: userID},
+			expErr: noError,
+		},
+		{
+			name: "user suspended",
+			setup: func() {
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(token)).
+					Return(tokenObject, nil)
+
+				expectRevocationCheck(false, nil)
+				expectUserExistence(true, nil)
+				expectSuspension(&domain.Suspension{UserID: userID, Until: time.Now().Add(time.Hour)}, nil)
+			},
+			expErr: dto.ErrUserSuspended.Error(),
+		},
+		{
+			name: "user banned",
+			setup: func() {
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(token)).
+					Return(tokenObject, nil)
+
+				expectRevocationCheck(false, nil)
+				expectUserExistence(true, nil)
+				expectSuspension(&domain.Suspension{UserID: userID}, nil)
+			},
+			expErr: dto.ErrUserSuspended.Error(),
+		},
+		{
+			name: "error on getting suspension",
+			setup: func() {
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(token)).
+					Return(tokenObject, nil)
+
+				expectRevocationCheck(false, nil)
+				expectUserExistence(true, nil)
+				expectSuspension(nil, errors.New("dummy error"))
+			},
+			expErr: "user suspension getting error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+
+			u := domain.NewAccessTokenParseCase(repository, accessTokenParser, accessTokenRevocationChecker, personalAccessTokenParser)
+			out, err := u.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
+
+func TestAccessTokenParseUsecase_personalAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository                   = mock.NewMockRepository(ctrl)
+		accessTokenParser            = mock.NewMockaccessTokenParser(ctrl)
+		accessTokenRevocationChecker = mock.NewMockaccessTokenRevocationChecker(ctrl)
+		personalAccessTokenParser    = mock.NewMockpersonalAccessTokenParser(ctrl)
+	)
+
+	var (
+		ctx                           = context.Background()
+		userID                        = int64(1)
+		token                         = "bpat_dummyToken"
+		personalAccessTokenRepository = mock.NewMockPersonalAccessTokenRepository(ctrl)
+		personalAccessToken           = &domain.PersonalAccessToken{
+			UserID: userID,
+			Scopes: []string{domain.PermissionPostRead, domain.PermissionPostWrite},
+		}
+		in      = &dto.AccessTokenParseIn{AccessToken: token}
+		noError = ""
+	)
+
+	personalAccessTokenParser.EXPECT().
+		IsPersonalAccessToken(gomock.Eq(token)).
+		Return(true).
+		AnyTimes()
+
+	expectParsing := func(object *domain.PersonalAccessToken, err error) {
+		repository.EXPECT().
+			PersonalAccessToken().
+			Return(personalAccessTokenRepository)
+
+		personalAccessTokenParser.EXPECT().
+			Parse(gomock.Eq(ctx), gomock.Eq(token), gomock.Eq(personalAccessTokenRepository)).
+			Return(object, err)
+	}
+
+	expectUserExistence := func(exists bool, err error) {
+		repository.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					Exists(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(exists, err)
+				return r
+			})
+	}
+
+	expectSuspension := func(suspension *domain.Suspension, err error) {
+		repository.EXPECT().
+			Suspension().
+			DoAndReturn(func() domain.SuspensionRepository {
+				r := mock.NewMockSuspensionRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(suspension, err)
+				return r
+			})
+	}
+
+	expectUserAccess := func(access *domain.UserAccess, err error) {
+		repository.EXPECT().
+			Role().
+			DoAndReturn(func() domain.RoleRepository {
+				r := mock.NewMockRoleRepository(ctrl)
+				r.EXPECT().
+					GetUserAccess(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(access, err)
+				return r
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.ParseTokenOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				expectParsing(personalAccessToken, nil)
+				expectUserExistence(true, nil)
+				expectSuspension(nil, nil)
+				expectUserAccess(&domain.UserAccess{
+					Roles:       []string{domain.RoleAuthor},
+					Permissions: []string{domain.PermissionPostRead, domain.PermissionPostWrite, domain.PermissionCommentWrite},
+				}, nil)
+			},
+			expOut: &dto.ParseTokenOut{
+				UserID:              userID,
+				Permissions:         []string{domain.PermissionPostRead, domain.PermissionPostWrite},
+				PersonalAccessToken: true,
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: scope is no longer granted to the user",
+			setup: func() {
+				expectParsing(personalAccessToken, nil)
+				expectUserExistence(true, nil)
+				expectSuspension(nil, nil)
+				expectUserAccess(&domain.UserAccess{
+					Roles:       []string{domain.RoleReader},
+					Permissions: []string{domain.PermissionPostRead, domain.PermissionCommentWrite},
+				}, nil)
+			},
+			expOut: &dto.ParseTokenOut{
+				UserID:              userID,
+				Permissions:         []string{domain.PermissionPostRead},
+				PersonalAccessToken: true,
+			},
+			expErr: noError,
+		},
+		{
+			name: "invalid token",
+			setup: func() {
+				expectParsing(nil, dto.ErrInvalidAccessToken)
+			},
+			expErr: dto.ErrInvalidAccessToken.Error(),
+		},
+		{
+			name: "error on parsing token",
+			setup: func() {
+				expectParsing(nil, errors.New("dummy error"))
+			},
+			expErr: "personal access token parsing error: dummy error",
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				expectParsing(personalAccessToken, nil)
+				expectUserExistence(false, nil)
+			},
+			expErr: dto.ErrUserNotFound.Error(),
+		},
+		{
+			name: "user suspended",
+			setup: func() {
+				expectParsing(personalAccessToken, nil)
+				expectUserExistence(true, nil)
+				expectSuspension(&domain.Suspension{UserID: userID}, nil)
+			},
+			expErr: dto.ErrUserSuspended.Error(),
+		},
+		{
+			name: "error on getting user access",
+			setup: func() {
+				expectParsing(personalAccessToken, nil)
+				expectUserExistence(true, nil)
+				expectSuspension(nil, nil)
+				expectUserAccess(nil, errors.New("dummy error"))
+			},
+			expErr: "user access getting error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewAccessTokenParseCase(repository, accessTokenParser, accessTokenRevocationChecker, personalAccessTokenParser)
+			out, err := u.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
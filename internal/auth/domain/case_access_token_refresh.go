@@ -1,61 +1,100 @@
-//go:generate mockgen -source=case_refresh_access_token.go -destination=mock/case_refresh_access_token.go -package=mock
+//go:generate mockgen -source=case_access_token_refresh.go -destination=mock/case_access_token_refresh.go -package=mock
 package domain
 
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/art-es/blog/internal/auth/dto"
 )
 
-type accessTokenRefresher interface {
-	Parse(token string) (*AccessTokenObject, error)
-	Refresh(object *AccessTokenObject)
-	Sign(object *AccessTokenObject) (string, error)
+type refreshTokenRotator interface {
+	Rotate(ctx context.Context, token string, repository RefreshTokenRepository) (*RefreshToken, string, error)
 }
 
 type AccessTokenRefreshCase struct {
-	userRepository       UserRepository
-	accessTokenRefresher accessTokenRefresher
+	repository          Repository
+	refreshTokenRotator refreshTokenRotator
+	accessTokenIssuer   accessTokenIssuer
 }
 
 func NewAccessTokenRefreshCase(
-	userRepository UserRepository,
-	accessTokenRefresher accessTokenRefresher,
+	repository Repository,
+	refreshTokenRotator refreshTokenRotator,
+	accessTokenIssuer accessTokenIssuer,
 ) *AccessTokenRefreshCase {
 	return &AccessTokenRefreshCase{
-		userRepository:       userRepository,
-		accessTokenRefresher: accessTokenRefresher,
+		repository:          repository,
+		refreshTokenRotator: refreshTokenRotator,
+		accessTokenIssuer:   accessTokenIssuer,
 	}
 }
 
 func (c *AccessTokenRefreshCase) Use(ctx context.Context, in *dto.AccessTokenRefreshIn) (*dto.AccessTokenRefreshOut, error) {
-	tokenObject, err := c.accessTokenRefresher.Parse(in.AccessToken)
+	tx, err := c.repository.BeginTx(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("access token parsing error: %w", err)
+		return nil, fmt.Errorf("tx beginning error: %w", err)
 	}
 
-	if err = checkUserExistence(c.userRepository, ctx, tokenObject.UserID); err != nil {
+	out, err := c.useInTx(ctx, in, tx)
+	if err != nil && err != dto.ErrReusedRefreshToken {
+		tx.Rollback()
 		return nil, err
 	}
 
-	token, err := refreshAccessToken(c.accessTokenRefresher, tokenObject)
+	// on reuse the revocation of the token family has to be kept
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, fmt.Errorf("tx committing error: %w", commitErr)
+	}
+
+	return out, err
+}
+
+func (c *AccessTokenRefreshCase) useInTx(ctx context.Context, in *dto.AccessTokenRefreshIn, tx TxCommitter) (*dto.AccessTokenRefreshOut, error) {
+	rotated, refreshToken, err := c.refreshTokenRotator.Rotate(ctx, in.RefreshToken, tx.RefreshToken())
 	if err != nil {
+		switch err {
+		case dto.ErrInvalidRefreshToken, dto.ErrReusedRefreshToken:
+			return nil, err
+		default:
+			return nil, fmt.Errorf("refresh token rotation error: %w", err)
+		}
+	}
+
+	if err = checkUserExistence(tx.User(), ctx, rotated.UserID); err != nil {
 		return nil, err
 	}
 
-	return &dto.AccessTokenRefreshOut{AccessToken: token}, nil
-}
+	if err = checkUserSuspension(ctx, tx.Suspension(), rotated.UserID); err != nil {
+		return nil, err
+	}
 
-func refreshAccessToken(refresher accessTokenRefresher, object *AccessTokenObject) (string, error) {
-	refresher.Refresh(object)
+	// the token family is the session
+	if err = tx.Session().Touch(ctx, rotated.FamilyID, time.Now()); err != nil {
+		return nil, fmt.Errorf("session touching error: %w", err)
+	}
 
-	token, err := refresher.Sign(object)
+	accessToken, err := newAccessToken(ctx, c.accessTokenIssuer, rotated.UserID, rotated.FamilyID, tx.Role())
 	if err != nil {
-		return "", fmt.Errorf("signing access token object error: %w", err)
+		return nil, fmt.Errorf("access token creation error: %w", err)
+	}
+
+	err = addAuditEvent(ctx, tx.AuditLog(), &AuditEvent{
+		Type:      AuditEventTokenRefreshed,
+		ActorID:   rotated.UserID,
+		IP:        in.IP,
+		UserAgent: in.UserAgent,
+		Outcome:   AuditOutcomeSuccess,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return token, nil
+	return &dto.AccessTokenRefreshOut{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
 }
 
 func checkUserExistence(userRepository UserRepository, ctx context.Context, userID int64) error {
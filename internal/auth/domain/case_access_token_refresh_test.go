@@ -18,24 +18,102 @@ func TestRefreshTokenUsecase(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	var (
-		userRepository       = mock.NewMockUserRepository(ctrl)
-		accessTokenRefresher = mock.NewMockaccessTokenRefresher(ctrl)
+		repository          = mock.NewMockRepository(ctrl)
+		refreshTokenRotator = mock.NewMockrefreshTokenRotator(ctrl)
+		accessTokenIssuer   = mock.NewMockaccessTokenIssuer(ctrl)
 	)
 
 	var (
-		ctx             = context.Background()
-		userID          = int64(1)
-		token           = "dummyAccessToken"
-		refreshedToken  = "dummyRefreshedAccessToken"
-		in              = &dto.AccessTokenRefreshIn{AccessToken: token}
-		now             = time.Now()
-		refreshedObject = &domain.AccessTokenObject{ExpirationTime: now.Add(time.Minute), UserID: userID}
-		objectFactory   = func() *domain.AccessTokenObject {
-			return &domain.AccessTokenObject{ExpirationTime: now, UserID: userID}
-		}
-		noError = ""
+		ctx                    = context.Background()
+		userID                 = int64(1)
+		sessionID              = "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b"
+		rotated                = &domain.RefreshToken{FamilyID: sessionID, UserID: userID}
+		refreshToken           = "dummyRefreshToken"
+		rotatedRefreshToken    = "dummyRotatedRefreshToken"
+		accessToken            = "dummyAccessToken"
+		access                 = &domain.UserAccess{Roles: []string{domain.RoleReader}}
+		accessTokenObject      = &domain.AccessTokenObject{UserID: userID, SessionID: sessionID, Roles: access.Roles}
+		refreshTokenRepository = mock.NewMockRefreshTokenRepository(ctrl)
+		in                     = &dto.AccessTokenRefreshIn{RefreshToken: refreshToken, IP: "192.0.2.1", UserAgent: "dummyUserAgent"}
+		noError                = ""
 	)
 
+	expectRotation := func(tx *mock.MockTxCommitter, rotated *domain.RefreshToken, token string, err error) {
+		tx.EXPECT().
+			RefreshToken().
+			Return(refreshTokenRepository)
+
+		refreshTokenRotator.EXPECT().
+			Rotate(gomock.Eq(ctx), gomock.Eq(refreshToken), gomock.Eq(refreshTokenRepository)).
+			Return(rotated, token, err)
+	}
+
+	expectUserExistence := func(tx *mock.MockTxCommitter, exists bool, err error) {
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					Exists(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(exists, err)
+				return r
+			})
+	}
+
+	expectSuspension := func(tx *mock.MockTxCommitter, suspension *domain.Suspension, err error) {
+		tx.EXPECT().
+			Suspension().
+			DoAndReturn(func() domain.SuspensionRepository {
+				r := mock.NewMockSuspensionRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(suspension, err)
+				return r
+			})
+	}
+
+	expectSessionTouch := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			Session().
+			DoAndReturn(func() domain.SessionRepository {
+				r := mock.NewMockSessionRepository(ctrl)
+				r.EXPECT().
+					Touch(gomock.Eq(ctx), gomock.Eq(sessionID), gomock.Any()).
+					Return(err)
+				return r
+			})
+	}
+
+	expectUserAccess := func(tx *mock.MockTxCommitter, access *domain.UserAccess, err error) {
+		tx.EXPECT().
+			Role().
+			DoAndReturn(func() domain.RoleRepository {
+				r := mock.NewMockRoleRepository(ctrl)
+				r.EXPECT().
+					GetUserAccess(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(access, err)
+				return r
+			})
+	}
+
+	expectAuditEventAdding := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      domain.AuditEventTokenRefreshed,
+						ActorID:   userID,
+						IP:        in.IP,
+						UserAgent: in.UserAgent,
+						Outcome:   domain.AuditOutcomeSuccess,
+					})).
+					Return(err)
+				return r
+			})
+	}
+
 	tests := []struct {
 		name   string
 		setup  func()
@@ -45,92 +123,300 @@ func TestRefreshTokenUsecase(t *testing.T) {
 		{
 			name: "happy path",
 			setup: func() {
-				object := objectFactory()
+				tx := mock.NewMockTxCommitter(ctrl)
 
-				accessTokenRefresher.EXPECT().
-					Parse(gomock.Eq(token)).
-					Return(object, nil)
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
 
-				userRepository.EXPECT().
-					Exists(gomock.Eq(ctx), gomock.Eq(userID)).
-					Return(true, nil)
+				expectRotation(tx, rotated, rotatedRefreshToken, nil)
+				expectUserExistence(tx, true, nil)
+				expectSuspension(tx, nil, nil)
+				expectSessionTouch(tx, nil)
+				expectUserAccess(tx, access, nil)
+
+				accessTokenIssuer.EXPECT().
+					NewObject(gomock.Eq(userID), gomock.Eq(sessionID), gomock.Eq(access)).
+					Return(accessTokenObject)
+
+				accessTokenIssuer.EXPECT().
+					Sign(gomock.Eq(accessTokenObject)).
+					Return(accessToken, nil)
 
-				accessTokenRefresher.EXPECT().
-					Refresh(gomock.Eq(object)).
-					DoAndReturn(func(o *domain.AccessTokenObject) {
-						o.ExpirationTime = refreshedObject.ExpirationTime
-					})
+				expectAuditEventAdding(tx, nil)
 
-				accessTokenRefresher.EXPECT().
-					Sign(gomock.Eq(refreshedObject)).
-					Return(refreshedToken, nil)
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.AccessTokenRefreshOut{
+				AccessToken:  accessToken,
+				RefreshToken: rotatedRefreshToken,
 			},
-			expOut: &dto.AccessTokenRefreshOut{AccessToken: refreshedToken},
 			expErr: noError,
 		},
 		{
-			name: "error on parsing access token",
+			name: "error on beginning tx",
 			setup: func() {
-				accessTokenRefresher.EXPECT().
-					Parse(gomock.Eq(token)).
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
 					Return(nil, errors.New("dummy error"))
 			},
-			expErr: "access token parsing error: dummy error",
+			expErr: "tx beginning error: dummy error",
 		},
 		{
-			name: "error on getting auth",
+			name: "invalid refresh token",
 			setup: func() {
-				object := objectFactory()
+				tx := mock.NewMockTxCommitter(ctrl)
 
-				accessTokenRefresher.EXPECT().
-					Parse(gomock.Eq(token)).
-					Return(object, nil)
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
 
-				userRepository.EXPECT().
-					Exists(gomock.Eq(ctx), gomock.Eq(userID)).
-					Return(false, errors.New("dummy error"))
+				expectRotation(tx, nil, "", dto.ErrInvalidRefreshToken)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInvalidRefreshToken.Error(),
+		},
+		{
+			name: "reused refresh token",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectRotation(tx, nil, "", dto.ErrReusedRefreshToken)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: dto.ErrReusedRefreshToken.Error(),
+		},
+		{
+			name: "error on committing tx after reused refresh token",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectRotation(tx, nil, "", dto.ErrReusedRefreshToken)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+		{
+			name: "error on rotating refresh token",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectRotation(tx, nil, "", errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "refresh token rotation error: dummy error",
+		},
+		{
+			name: "error on checking user existence",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectRotation(tx, rotated, rotatedRefreshToken, nil)
+				expectUserExistence(tx, false, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
 			},
 			expErr: "user checking existence in repository error: dummy error",
 		},
 		{
-			name: "auth not found",
+			name: "user not found",
 			setup: func() {
-				object := objectFactory()
+				tx := mock.NewMockTxCommitter(ctrl)
 
-				accessTokenRefresher.EXPECT().
-					Parse(gomock.Eq(token)).
-					Return(object, nil)
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
 
-				userRepository.EXPECT().
-					Exists(gomock.Eq(ctx), gomock.Eq(userID)).
-					Return(false, nil)
+				expectRotation(tx, rotated, rotatedRefreshToken, nil)
+				expectUserExistence(tx, false, nil)
+
+				tx.EXPECT().Rollback()
 			},
 			expErr: dto.ErrUserNotFound.Error(),
 		},
 		{
-			name: "error on refreshing access token",
+			name: "error on getting user suspension",
 			setup: func() {
-				object := objectFactory()
+				tx := mock.NewMockTxCommitter(ctrl)
 
-				accessTokenRefresher.EXPECT().
-					Parse(gomock.Eq(token)).
-					Return(object, nil)
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
 
-				userRepository.EXPECT().
-					Exists(gomock.Eq(ctx), gomock.Eq(userID)).
-					Return(true, nil)
+				expectRotation(tx, rotated, rotatedRefreshToken, nil)
+				expectUserExistence(tx, true, nil)
+				expectSuspension(tx, nil, errors.New("dummy error"))
 
-				accessTokenRefresher.EXPECT().
-					Refresh(gomock.Eq(object)).
-					DoAndReturn(func(o *domain.AccessTokenObject) {
-						o.ExpirationTime = refreshedObject.ExpirationTime
-					})
+				tx.EXPECT().Rollback()
+			},
+			expErr: "user suspension getting error: dummy error",
+		},
+		{
+			name: "user suspended",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectRotation(tx, rotated, rotatedRefreshToken, nil)
+				expectUserExistence(tx, true, nil)
+				expectSuspension(tx, &domain.Suspension{UserID: userID, Until: time.Now().Add(time.Hour)}, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrUserSuspended.Error(),
+		},
+		{
+			name: "error on touching session",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectRotation(tx, rotated, rotatedRefreshToken, nil)
+				expectUserExistence(tx, true, nil)
+				expectSuspension(tx, nil, nil)
+				expectSessionTouch(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "session touching error: dummy error",
+		},
+		{
+			name: "error on getting user access",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectRotation(tx, rotated, rotatedRefreshToken, nil)
+				expectUserExistence(tx, true, nil)
+				expectSuspension(tx, nil, nil)
+				expectSessionTouch(tx, nil)
+				expectUserAccess(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "access token creation error: user access getting error: dummy error",
+		},
+		{
+			name: "error on creating access token",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
 
-				accessTokenRefresher.EXPECT().
-					Sign(gomock.Eq(refreshedObject)).
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectRotation(tx, rotated, rotatedRefreshToken, nil)
+				expectUserExistence(tx, true, nil)
+				expectSuspension(tx, nil, nil)
+				expectSessionTouch(tx, nil)
+				expectUserAccess(tx, access, nil)
+
+				accessTokenIssuer.EXPECT().
+					NewObject(gomock.Eq(userID), gomock.Eq(sessionID), gomock.Eq(access)).
+					Return(accessTokenObject)
+
+				accessTokenIssuer.EXPECT().
+					Sign(gomock.Eq(accessTokenObject)).
 					Return("", errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
 			},
-			expErr: "signing access token object error: dummy error",
+			expErr: "access token creation error: dummy error",
+		},
+		{
+			name: "error on adding audit event",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectRotation(tx, rotated, rotatedRefreshToken, nil)
+				expectUserExistence(tx, true, nil)
+				expectSuspension(tx, nil, nil)
+				expectSessionTouch(tx, nil)
+				expectUserAccess(tx, access, nil)
+
+				accessTokenIssuer.EXPECT().
+					NewObject(gomock.Eq(userID), gomock.Eq(sessionID), gomock.Eq(access)).
+					Return(accessTokenObject)
+
+				accessTokenIssuer.EXPECT().
+					Sign(gomock.Eq(accessTokenObject)).
+					Return(accessToken, nil)
+
+				expectAuditEventAdding(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectRotation(tx, rotated, rotatedRefreshToken, nil)
+				expectUserExistence(tx, true, nil)
+				expectSuspension(tx, nil, nil)
+				expectSessionTouch(tx, nil)
+				expectUserAccess(tx, access, nil)
+
+				accessTokenIssuer.EXPECT().
+					NewObject(gomock.Eq(userID), gomock.Eq(sessionID), gomock.Eq(access)).
+					Return(accessTokenObject)
+
+				accessTokenIssuer.EXPECT().
+					Sign(gomock.Eq(accessTokenObject)).
+					Return(accessToken, nil)
+
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
 		},
 	}
 	for _, tt := range tests {
@@ -139,7 +425,7 @@ func TestRefreshTokenUsecase(t *testing.T) {
 				tt.setup()
 			}
 
-			u := domain.NewAccessTokenRefreshCase(userRepository, accessTokenRefresher)
+			u := domain.NewAccessTokenRefreshCase(repository, refreshTokenRotator, accessTokenIssuer)
 			out, err := u.Use(ctx, in)
 
 			assert.Equal(t, tt.expOut, out)
@@ -151,5 +437,4 @@ func TestRefreshTokenUsecase(t *testing.T) {
 			}
 		})
 	}
-
 }
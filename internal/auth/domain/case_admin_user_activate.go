@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type AdminUserActivateCase struct {
+	repository Repository
+}
+
+func NewAdminUserActivateCase(repository Repository) *AdminUserActivateCase {
+	return &AdminUserActivateCase{
+		repository: repository,
+	}
+}
+
+// Use activates the user without the activation code, the pending codes are dropped.
+// Activating an active user changes nothing.
+func (c *AdminUserActivateCase) Use(ctx context.Context, in *dto.AdminUserActionIn) error {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	if err = c.useInTx(ctx, in, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *AdminUserActivateCase) useInTx(ctx context.Context, in *dto.AdminUserActionIn, tx TxCommitter) error {
+	user, err := tx.User().Get(ctx, in.UserID)
+	if err != nil {
+		return fmt.Errorf("auth getting error: %w", err)
+	}
+	if user == nil {
+		return dto.ErrUserNotFound
+	}
+	if user.Active {
+		return nil
+	}
+
+	if _, err = tx.User().Activate(ctx, user.ID); err != nil {
+		return fmt.Errorf("auth activating error: %w", err)
+	}
+
+	if err = tx.ActivationCode().RemoveCodes(ctx, user.ID); err != nil {
+		return fmt.Errorf("activation codes removing error: %w", err)
+	}
+
+	return addAuditEvent(ctx, tx.AuditLog(), &AuditEvent{
+		Type:      AuditEventUserActivated,
+		ActorID:   in.AdminID,
+		TargetID:  user.ID,
+		IP:        in.IP,
+		UserAgent: in.UserAgent,
+		Outcome:   AuditOutcomeSuccess,
+	})
+}
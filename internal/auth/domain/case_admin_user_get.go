@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type AdminUserGetCase struct {
+	repository Repository
+}
+
+func NewAdminUserGetCase(repository Repository) *AdminUserGetCase {
+	return &AdminUserGetCase{
+		repository: repository,
+	}
+}
+
+// Use returns the user with the roles and the suspension in effect.
+func (c *AdminUserGetCase) Use(ctx context.Context, in *dto.AdminUserGetIn) (*dto.AdminUserGetOut, error) {
+	user, err := c.repository.User().Get(ctx, in.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("auth getting error: %w", err)
+	}
+	if user == nil {
+		return nil, dto.ErrUserNotFound
+	}
+
+	access, err := c.repository.Role().GetUserAccess(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("user access getting error: %w", err)
+	}
+
+	suspension, err := c.repository.Suspension().Get(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("user suspension getting error: %w", err)
+	}
+
+	out := &dto.AdminUserGetOut{
+		User:  newAdminUser(user),
+		Roles: access.Roles,
+	}
+	if suspension != nil && suspension.IsActive(time.Now()) {
+		out.Suspension = &dto.UserSuspension{
+			Reason:      suspension.Reason,
+			SuspendedBy: suspension.SuspendedBy,
+			CreatedAt:   suspension.CreatedAt,
+		}
+		if !suspension.Until.IsZero() {
+			out.Suspension.Until = &suspension.Until
+		}
+	}
+
+	return out, nil
+}
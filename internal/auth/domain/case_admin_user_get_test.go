@@ -0,0 +1,199 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestAdminUserGetCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository           = mock.NewMockRepository(ctrl)
+		userRepository       = mock.NewMockUserRepository(ctrl)
+		roleRepository       = mock.NewMockRoleRepository(ctrl)
+		suspensionRepository = mock.NewMockSuspensionRepository(ctrl)
+	)
+
+	var (
+		ctx       = context.Background()
+		userID    = int64(2)
+		adminID   = int64(1)
+		now       = time.Now()
+		until     = now.Add(time.Hour)
+		user      = &domain.User{ID: userID, Name: "dummyName", Email: "dummyEmail@example.com", Active: true}
+		access    = &domain.UserAccess{Roles: []string{domain.RoleReader}, Permissions: []string{domain.PermissionPostRead}}
+		outUser   = dto.AdminUser{ID: userID, Name: "dummyName", Email: "dummyEmail@example.com", Active: true}
+		in        = &dto.AdminUserGetIn{UserID: userID}
+		noError   = ""
+		noOut     = (*dto.AdminUserGetOut)(nil)
+		createdAt = now.Add(-time.Hour)
+	)
+
+	expectUser := func(user *domain.User, err error) {
+		repository.EXPECT().
+			User().
+			Return(userRepository)
+
+		userRepository.EXPECT().
+			Get(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(user, err)
+	}
+
+	expectAccess := func(access *domain.UserAccess, err error) {
+		repository.EXPECT().
+			Role().
+			Return(roleRepository)
+
+		roleRepository.EXPECT().
+			GetUserAccess(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(access, err)
+	}
+
+	expectSuspension := func(suspension *domain.Suspension, err error) {
+		repository.EXPECT().
+			Suspension().
+			Return(suspensionRepository)
+
+		suspensionRepository.EXPECT().
+			Get(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(suspension, err)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.AdminUserGetOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				expectUser(user, nil)
+				expectAccess(access, nil)
+				expectSuspension(nil, nil)
+			},
+			expOut: &dto.AdminUserGetOut{
+				User:  outUser,
+				Roles: []string{domain.RoleReader},
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: user suspended",
+			setup: func() {
+				expectUser(user, nil)
+				expectAccess(access, nil)
+				expectSuspension(&domain.Suspension{
+					UserID:      userID,
+					Reason:      "spam",
+					Until:       until,
+					SuspendedBy: adminID,
+					CreatedAt:   createdAt,
+				}, nil)
+			},
+			expOut: &dto.AdminUserGetOut{
+				User:  outUser,
+				Roles: []string{domain.RoleReader},
+				Suspension: &dto.UserSuspension{
+					Reason:      "spam",
+					Until:       &until,
+					SuspendedBy: adminID,
+					CreatedAt:   createdAt,
+				},
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: user banned",
+			setup: func() {
+				expectUser(user, nil)
+				expectAccess(access, nil)
+				expectSuspension(&domain.Suspension{UserID: userID, Reason: "spam", SuspendedBy: adminID, CreatedAt: createdAt}, nil)
+			},
+			expOut: &dto.AdminUserGetOut{
+				User:  outUser,
+				Roles: []string{domain.RoleReader},
+				Suspension: &dto.UserSuspension{
+					Reason:      "spam",
+					SuspendedBy: adminID,
+					CreatedAt:   createdAt,
+				},
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: suspension is over",
+			setup: func() {
+				expectUser(user, nil)
+				expectAccess(access, nil)
+				expectSuspension(&domain.Suspension{UserID: userID, Until: now.Add(-time.Minute)}, nil)
+			},
+			expOut: &dto.AdminUserGetOut{
+				User:  outUser,
+				Roles: []string{domain.RoleReader},
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on getting user",
+			setup: func() {
+				expectUser(nil, errors.New("dummy error"))
+			},
+			expOut: noOut,
+			expErr: "auth getting error: dummy error",
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				expectUser(nil, nil)
+			},
+			expOut: noOut,
+			expErr: dto.ErrUserNotFound.Error(),
+		},
+		{
+			name: "error on getting user access",
+			setup: func() {
+				expectUser(user, nil)
+				expectAccess(nil, errors.New("dummy error"))
+			},
+			expOut: noOut,
+			expErr: "user access getting error: dummy error",
+		},
+		{
+			name: "error on getting suspension",
+			setup: func() {
+				expectUser(user, nil)
+				expectAccess(access, nil)
+				expectSuspension(nil, errors.New("dummy error"))
+			},
+			expOut: noOut,
+			expErr: "user suspension getting error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewAdminUserGetCase(repository)
+			out, err := u.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const defaultAdminUserListLimit = 50
+
+type AdminUserListCase struct {
+	repository Repository
+}
+
+func NewAdminUserListCase(repository Repository) *AdminUserListCase {
+	return &AdminUserListCase{
+		repository: repository,
+	}
+}
+
+// Use returns a page of the found users, recent users first.
+func (c *AdminUserListCase) Use(ctx context.Context, in *dto.AdminUserListIn) (*dto.AdminUserListOut, error) {
+	limit := in.Limit
+	if limit <= 0 {
+		limit = defaultAdminUserListLimit
+	}
+
+	// one more user is requested to know whether the next page exists
+	users, err := c.repository.User().Find(ctx, &UserFilter{
+		Query:    in.Query,
+		BeforeID: in.Before,
+		Limit:    limit + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("users finding error: %w", err)
+	}
+
+	out := &dto.AdminUserListOut{}
+	if len(users) > limit {
+		users = users[:limit]
+		out.NextBefore = users[limit-1].ID
+	}
+
+	out.Users = make([]dto.AdminUser, 0, len(users))
+	for _, user := range users {
+		out.Users = append(out.Users, newAdminUser(user))
+	}
+
+	return out, nil
+}
+
+func newAdminUser(user *User) dto.AdminUser {
+	return dto.AdminUser{
+		ID:     user.ID,
+		Name:   user.Name,
+		Email:  user.Email,
+		Active: user.Active,
+	}
+}
@@ -0,0 +1,113 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestAdminUserListCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository     = mock.NewMockRepository(ctrl)
+		userRepository = mock.NewMockUserRepository(ctrl)
+	)
+
+	var (
+		ctx     = context.Background()
+		noError = ""
+	)
+
+	userFactory := func(id int64) *domain.User {
+		return &domain.User{ID: id, Name: "dummyName", Email: "dummyEmail@example.com", PasswordHash: "dummyPasswordHash", Active: true}
+	}
+
+	outUserFactory := func(id int64) dto.AdminUser {
+		return dto.AdminUser{ID: id, Name: "dummyName", Email: "dummyEmail@example.com", Active: true}
+	}
+
+	expectFinding := func(filter *domain.UserFilter, users []*domain.User, err error) {
+		repository.EXPECT().
+			User().
+			Return(userRepository)
+
+		userRepository.EXPECT().
+			Find(gomock.Eq(ctx), gomock.Eq(filter)).
+			Return(users, err)
+	}
+
+	tests := []struct {
+		name   string
+		in     *dto.AdminUserListIn
+		setup  func()
+		expOut *dto.AdminUserListOut
+		expErr string
+	}{
+		{
+			name: "happy path: last page",
+			in:   &dto.AdminUserListIn{Query: "dummy", Before: 10, Limit: 3},
+			setup: func() {
+				expectFinding(&domain.UserFilter{Query: "dummy", BeforeID: 10, Limit: 4},
+					[]*domain.User{userFactory(9), userFactory(7)}, nil)
+			},
+			expOut: &dto.AdminUserListOut{
+				Users: []dto.AdminUser{outUserFactory(9), outUserFactory(7)},
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: next page exists",
+			in:   &dto.AdminUserListIn{Limit: 2},
+			setup: func() {
+				expectFinding(&domain.UserFilter{Limit: 3},
+					[]*domain.User{userFactory(9), userFactory(7), userFactory(6)}, nil)
+			},
+			expOut: &dto.AdminUserListOut{
+				Users:      []dto.AdminUser{outUserFactory(9), outUserFactory(7)},
+				NextBefore: 7,
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: default limit",
+			in:   &dto.AdminUserListIn{},
+			setup: func() {
+				expectFinding(&domain.UserFilter{Limit: 51}, nil, nil)
+			},
+			expOut: &dto.AdminUserListOut{Users: []dto.AdminUser{}},
+			expErr: noError,
+		},
+		{
+			name: "error on finding users",
+			in:   &dto.AdminUserListIn{},
+			setup: func() {
+				expectFinding(&domain.UserFilter{Limit: 51}, nil, errors.New("dummy error"))
+			},
+			expErr: "users finding error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewAdminUserListCase(repository)
+			out, err := u.Use(ctx, tt.in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
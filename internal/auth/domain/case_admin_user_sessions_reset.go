@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type AdminUserSessionsResetCase struct {
+	repository        Repository
+	userTokensRevoker userTokensRevoker
+}
+
+func NewAdminUserSessionsResetCase(
+	repository Repository,
+	userTokensRevoker userTokensRevoker,
+) *AdminUserSessionsResetCase {
+	return &AdminUserSessionsResetCase{
+		repository:        repository,
+		userTokensRevoker: userTokensRevoker,
+	}
+}
+
+// Use ends all the sessions of the user and revokes the tokens issued to the user so far.
+func (c *AdminUserSessionsResetCase) Use(ctx context.Context, in *dto.AdminUserActionIn) error {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	if err = c.useInTx(ctx, in, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *AdminUserSessionsResetCase) useInTx(ctx context.Context, in *dto.AdminUserActionIn, tx TxCommitter) error {
+	if err := checkUserExistence(tx.User(), ctx, in.UserID); err != nil {
+		return err
+	}
+
+	if err := c.userTokensRevoker.RevokeUser(ctx, in.UserID, tx); err != nil {
+		return fmt.Errorf("user tokens revoking error: %w", err)
+	}
+
+	return addAuditEvent(ctx, tx.AuditLog(), &AuditEvent{
+		Type:      AuditEventUserSessionsReset,
+		ActorID:   in.AdminID,
+		TargetID:  in.UserID,
+		IP:        in.IP,
+		UserAgent: in.UserAgent,
+		Outcome:   AuditOutcomeSuccess,
+	})
+}
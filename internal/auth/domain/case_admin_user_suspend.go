@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type AdminUserSuspendCase struct {
+	repository        Repository
+	userTokensRevoker userTokensRevoker
+}
+
+func NewAdminUserSuspendCase(
+	repository Repository,
+	userTokensRevoker userTokensRevoker,
+) *AdminUserSuspendCase {
+	return &AdminUserSuspendCase{
+		repository:        repository,
+		userTokensRevoker: userTokensRevoker,
+	}
+}
+
+// Use suspends the user until the given time or bans the user if the time is not given.
+// The sessions of the user are ended, so the user is signed out right away.
+func (c *AdminUserSuspendCase) Use(ctx context.Context, in *dto.AdminUserSuspendIn) error {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	if err = c.useInTx(ctx, in, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *AdminUserSuspendCase) useInTx(ctx context.Context, in *dto.AdminUserSuspendIn, tx TxCommitter) error {
+	user, err := tx.User().Get(ctx, in.UserID)
+	if err != nil {
+		return fmt.Errorf("auth getting error: %w", err)
+	}
+	if user == nil {
+		return dto.ErrUserNotFound
+	}
+
+	suspension := &Suspension{
+		UserID:      user.ID,
+		Reason:      in.Reason,
+		SuspendedBy: in.AdminID,
+	}
+	eventType := AuditEventUserBanned
+	if in.Until != nil {
+		suspension.Until = *in.Until
+		eventType = AuditEventUserSuspended
+	}
+
+	if err = tx.Suspension().Save(ctx, suspension); err != nil {
+		return fmt.Errorf("user suspension saving error: %w", err)
+	}
+
+	if err = c.userTokensRevoker.RevokeUser(ctx, user.ID, tx); err != nil {
+		return fmt.Errorf("user tokens revoking error: %w", err)
+	}
+
+	return addAuditEvent(ctx, tx.AuditLog(), &AuditEvent{
+		Type:      eventType,
+		ActorID:   in.AdminID,
+		TargetID:  user.ID,
+		IP:        in.IP,
+		UserAgent: in.UserAgent,
+		Outcome:   AuditOutcomeSuccess,
+		Details:   in.Reason,
+	})
+}
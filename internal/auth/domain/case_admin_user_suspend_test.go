@@ -0,0 +1,288 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestAdminUserSuspendCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository        = mock.NewMockRepository(ctrl)
+		userTokensRevoker = mock.NewMockuserTokensRevoker(ctrl)
+	)
+
+	var (
+		ctx     = context.Background()
+		adminID = int64(1)
+		userID  = int64(2)
+		until   = time.Now().Add(24 * time.Hour)
+		in      = &dto.AdminUserSuspendIn{
+			AdminID:   adminID,
+			UserID:    userID,
+			Reason:    "spam",
+			Until:     &until,
+			IP:        "192.0.2.1",
+			UserAgent: "dummyUserAgent",
+		}
+		banIn   = &dto.AdminUserSuspendIn{AdminID: adminID, UserID: userID, Reason: "spam", IP: "192.0.2.1", UserAgent: "dummyUserAgent"}
+		noError = ""
+	)
+
+	expectUser := func(tx *mock.MockTxCommitter, user *domain.User, err error) {
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(user, err)
+				return r
+			})
+	}
+
+	expectSuspensionSaving := func(tx *mock.MockTxCommitter, suspension *domain.Suspension, err error) {
+		tx.EXPECT().
+			Suspension().
+			DoAndReturn(func() domain.SuspensionRepository {
+				r := mock.NewMockSuspensionRepository(ctrl)
+				r.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Eq(suspension)).
+					Return(err)
+				return r
+			})
+	}
+
+	expectAuditEventAdding := func(tx *mock.MockTxCommitter, eventType string, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      eventType,
+						ActorID:   adminID,
+						TargetID:  userID,
+						IP:        in.IP,
+						UserAgent: in.UserAgent,
+						Outcome:   domain.AuditOutcomeSuccess,
+						Details:   "spam",
+					})).
+					Return(err)
+				return r
+			})
+	}
+
+	suspension := &domain.Suspension{UserID: userID, Reason: "spam", Until: until, SuspendedBy: adminID}
+	ban := &domain.Suspension{UserID: userID, Reason: "spam", SuspendedBy: adminID}
+
+	tests := []struct {
+		name   string
+		in     *dto.AdminUserSuspendIn
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path: suspension",
+			in:   in,
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, &domain.User{ID: userID}, nil)
+				expectSuspensionSaving(tx, suspension, nil)
+
+				userTokensRevoker.EXPECT().
+					RevokeUser(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(tx)).
+					Return(nil)
+
+				expectAuditEventAdding(tx, domain.AuditEventUserSuspended, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: ban",
+			in:   banIn,
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, &domain.User{ID: userID}, nil)
+				expectSuspensionSaving(tx, ban, nil)
+
+				userTokensRevoker.EXPECT().
+					RevokeUser(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(tx)).
+					Return(nil)
+
+				expectAuditEventAdding(tx, domain.AuditEventUserBanned, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			in:   in,
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "error on getting user",
+			in:   in,
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth getting error: dummy error",
+		},
+		{
+			name: "user not found",
+			in:   in,
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, nil, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrUserNotFound.Error(),
+		},
+		{
+			name: "error on saving suspension",
+			in:   in,
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, &domain.User{ID: userID}, nil)
+				expectSuspensionSaving(tx, suspension, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "user suspension saving error: dummy error",
+		},
+		{
+			name: "error on revoking user tokens",
+			in:   in,
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, &domain.User{ID: userID}, nil)
+				expectSuspensionSaving(tx, suspension, nil)
+
+				userTokensRevoker.EXPECT().
+					RevokeUser(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(tx)).
+					Return(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "user tokens revoking error: dummy error",
+		},
+		{
+			name: "error on adding audit event",
+			in:   in,
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, &domain.User{ID: userID}, nil)
+				expectSuspensionSaving(tx, suspension, nil)
+
+				userTokensRevoker.EXPECT().
+					RevokeUser(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(tx)).
+					Return(nil)
+
+				expectAuditEventAdding(tx, domain.AuditEventUserSuspended, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			in:   in,
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, &domain.User{ID: userID}, nil)
+				expectSuspensionSaving(tx, suspension, nil)
+
+				userTokensRevoker.EXPECT().
+					RevokeUser(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(tx)).
+					Return(nil)
+
+				expectAuditEventAdding(tx, domain.AuditEventUserSuspended, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewAdminUserSuspendCase(repository, userTokensRevoker)
+			err := u.Use(ctx, tt.in)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
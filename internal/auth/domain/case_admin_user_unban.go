@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type AdminUserUnbanCase struct {
+	repository Repository
+}
+
+func NewAdminUserUnbanCase(repository Repository) *AdminUserUnbanCase {
+	return &AdminUserUnbanCase{
+		repository: repository,
+	}
+}
+
+// Use lifts the suspension or the ban of the user.
+func (c *AdminUserUnbanCase) Use(ctx context.Context, in *dto.AdminUserActionIn) error {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	if err = c.useInTx(ctx, in, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *AdminUserUnbanCase) useInTx(ctx context.Context, in *dto.AdminUserActionIn, tx TxCommitter) error {
+	user, err := tx.User().Get(ctx, in.UserID)
+	if err != nil {
+		return fmt.Errorf("auth getting error: %w", err)
+	}
+	if user == nil {
+		return dto.ErrUserNotFound
+	}
+
+	if err = tx.Suspension().Remove(ctx, user.ID); err != nil {
+		return fmt.Errorf("user suspension removing error: %w", err)
+	}
+
+	return addAuditEvent(ctx, tx.AuditLog(), &AuditEvent{
+		Type:      AuditEventUserUnbanned,
+		ActorID:   in.AdminID,
+		TargetID:  user.ID,
+		IP:        in.IP,
+		UserAgent: in.UserAgent,
+		Outcome:   AuditOutcomeSuccess,
+	})
+}
@@ -0,0 +1,204 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestAdminUserUnbanCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository = mock.NewMockRepository(ctrl)
+	)
+
+	var (
+		ctx     = context.Background()
+		adminID = int64(1)
+		userID  = int64(2)
+		in      = &dto.AdminUserActionIn{AdminID: adminID, UserID: userID, IP: "192.0.2.1", UserAgent: "dummyUserAgent"}
+		noError = ""
+	)
+
+	expectUser := func(tx *mock.MockTxCommitter, user *domain.User, err error) {
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(user, err)
+				return r
+			})
+	}
+
+	expectSuspensionRemoving := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			Suspension().
+			DoAndReturn(func() domain.SuspensionRepository {
+				r := mock.NewMockSuspensionRepository(ctrl)
+				r.EXPECT().
+					Remove(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(err)
+				return r
+			})
+	}
+
+	expectAuditEventAdding := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      domain.AuditEventUserUnbanned,
+						ActorID:   adminID,
+						TargetID:  userID,
+						IP:        in.IP,
+						UserAgent: in.UserAgent,
+						Outcome:   domain.AuditOutcomeSuccess,
+					})).
+					Return(err)
+				return r
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, &domain.User{ID: userID}, nil)
+				expectSuspensionRemoving(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "error on getting user",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth getting error: dummy error",
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, nil, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrUserNotFound.Error(),
+		},
+		{
+			name: "error on removing suspension",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, &domain.User{ID: userID}, nil)
+				expectSuspensionRemoving(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "user suspension removing error: dummy error",
+		},
+		{
+			name: "error on adding audit event",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, &domain.User{ID: userID}, nil)
+				expectSuspensionRemoving(tx, nil)
+				expectAuditEventAdding(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, &domain.User{ID: userID}, nil)
+				expectSuspensionRemoving(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewAdminUserUnbanCase(repository)
+			err := u.Use(ctx, in)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const defaultAuditEventListLimit = 50
+
+type AuditEventListCase struct {
+	repository Repository
+}
+
+func NewAuditEventListCase(repository Repository) *AuditEventListCase {
+	return &AuditEventListCase{
+		repository: repository,
+	}
+}
+
+// Use returns a page of the audit log, recent events first.
+func (c *AuditEventListCase) Use(ctx context.Context, in *dto.AuditEventListIn) (*dto.AuditEventListOut, error) {
+	limit := in.Limit
+	if limit <= 0 {
+		limit = defaultAuditEventListLimit
+	}
+
+	// one more event is requested to know whether the next page exists
+	events, err := c.repository.AuditLog().Find(ctx, &AuditEventFilter{
+		ActorID:  in.ActorID,
+		TargetID: in.TargetID,
+		Type:     in.Type,
+		Outcome:  in.Outcome,
+		From:     in.From,
+		To:       in.To,
+		BeforeID: in.Before,
+		Limit:    limit + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("audit events finding error: %w", err)
+	}
+
+	out := &dto.AuditEventListOut{}
+	if len(events) > limit {
+		events = events[:limit]
+		out.NextBefore = events[limit-1].ID
+	}
+
+	out.Events = make([]dto.AuditEvent, 0, len(events))
+	for _, event := range events {
+		out.Events = append(out.Events, dto.AuditEvent{
+			ID:        event.ID,
+			Type:      event.Type,
+			ActorID:   event.ActorID,
+			TargetID:  event.TargetID,
+			IP:        event.IP,
+			UserAgent: event.UserAgent,
+			Outcome:   event.Outcome,
+			Details:   event.Details,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+
+	return out, nil
+}
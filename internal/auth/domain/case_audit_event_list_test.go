@@ -0,0 +1,149 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestAuditEventListCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository         = mock.NewMockRepository(ctrl)
+		auditLogRepository = mock.NewMockAuditLogRepository(ctrl)
+	)
+
+	var (
+		ctx     = context.Background()
+		now     = time.Now()
+		from    = now.Add(-24 * time.Hour)
+		noError = ""
+	)
+
+	eventFactory := func(id int64) *domain.AuditEvent {
+		return &domain.AuditEvent{
+			ID:        id,
+			Type:      domain.AuditEventUserLogin,
+			ActorID:   1,
+			IP:        "192.0.2.1",
+			UserAgent: "Mozilla/5.0",
+			Outcome:   domain.AuditOutcomeFailure,
+			Details:   "incorrect password",
+			CreatedAt: now,
+		}
+	}
+
+	outEventFactory := func(id int64) dto.AuditEvent {
+		return dto.AuditEvent{
+			ID:        id,
+			Type:      domain.AuditEventUserLogin,
+			ActorID:   1,
+			IP:        "192.0.2.1",
+			UserAgent: "Mozilla/5.0",
+			Outcome:   domain.AuditOutcomeFailure,
+			Details:   "incorrect password",
+			CreatedAt: now,
+		}
+	}
+
+	expectFinding := func(filter *domain.AuditEventFilter, events []*domain.AuditEvent, err error) {
+		repository.EXPECT().
+			AuditLog().
+			Return(auditLogRepository)
+
+		auditLogRepository.EXPECT().
+			Find(gomock.Eq(ctx), gomock.Eq(filter)).
+			Return(events, err)
+	}
+
+	tests := []struct {
+		name   string
+		in     *dto.AuditEventListIn
+		setup  func()
+		expOut *dto.AuditEventListOut
+		expErr string
+	}{
+		{
+			name: "happy path: last page",
+			in: &dto.AuditEventListIn{
+				ActorID: 1,
+				Type:    domain.AuditEventUserLogin,
+				Outcome: domain.AuditOutcomeFailure,
+				From:    &from,
+				To:      &now,
+				Before:  10,
+				Limit:   3,
+			},
+			setup: func() {
+				expectFinding(&domain.AuditEventFilter{
+					ActorID:  1,
+					Type:     domain.AuditEventUserLogin,
+					Outcome:  domain.AuditOutcomeFailure,
+					From:     &from,
+					To:       &now,
+					BeforeID: 10,
+					Limit:    4,
+				}, []*domain.AuditEvent{eventFactory(9), eventFactory(7)}, nil)
+			},
+			expOut: &dto.AuditEventListOut{
+				Events: []dto.AuditEvent{outEventFactory(9), outEventFactory(7)},
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: next page exists",
+			in:   &dto.AuditEventListIn{Limit: 2},
+			setup: func() {
+				expectFinding(&domain.AuditEventFilter{Limit: 3},
+					[]*domain.AuditEvent{eventFactory(9), eventFactory(7), eventFactory(6)}, nil)
+			},
+			expOut: &dto.AuditEventListOut{
+				Events:     []dto.AuditEvent{outEventFactory(9), outEventFactory(7)},
+				NextBefore: 7,
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: default limit",
+			in:   &dto.AuditEventListIn{},
+			setup: func() {
+				expectFinding(&domain.AuditEventFilter{Limit: 51}, nil, nil)
+			},
+			expOut: &dto.AuditEventListOut{Events: []dto.AuditEvent{}},
+			expErr: noError,
+		},
+		{
+			name: "error on finding events",
+			in:   &dto.AuditEventListIn{},
+			setup: func() {
+				expectFinding(&domain.AuditEventFilter{Limit: 51}, nil, errors.New("dummy error"))
+			},
+			expErr: "audit events finding error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewAuditEventListCase(repository)
+			out, err := u.Use(ctx, tt.in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
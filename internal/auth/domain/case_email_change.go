@@ -0,0 +1,89 @@
+//go:generate mockgen -source=case_email_change.go -destination=mock/case_email_change.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type emailChangeCodeSender interface {
+	SendCode(ctx context.Context, user *User, newEmail string, tx TxCommitter) error
+}
+
+type EmailChangeCase struct {
+	repository            Repository
+	emailChangeCodeSender emailChangeCodeSender
+}
+
+func NewEmailChangeCase(
+	repository Repository,
+	emailChangeCodeSender emailChangeCodeSender,
+) *EmailChangeCase {
+	return &EmailChangeCase{
+		repository:            repository,
+		emailChangeCodeSender: emailChangeCodeSender,
+	}
+}
+
+// Use starts the email change, the email is not changed until the code sent to the new email is confirmed.
+func (c *EmailChangeCase) Use(ctx context.Context, in *dto.EmailChangeIn) error {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	if err = c.useInTx(ctx, in, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *EmailChangeCase) useInTx(ctx context.Context, in *dto.EmailChangeIn, tx TxCommitter) error {
+	user, err := tx.User().Get(ctx, in.UserID)
+	if err != nil {
+		return fmt.Errorf("auth getting error: %w", err)
+	}
+	if user == nil {
+		return dto.ErrUserNotFound
+	}
+
+	newEmail := NormalizeEmail(in.NewEmail)
+
+	if err = checkEmailOwner(ctx, tx.User(), newEmail, user.ID); err != nil {
+		return err
+	}
+
+	if err = c.emailChangeCodeSender.SendCode(ctx, user, newEmail, tx); err != nil {
+		return fmt.Errorf("email change code sending error: %w", err)
+	}
+
+	return addAuditEvent(ctx, tx.AuditLog(), &AuditEvent{
+		Type:      AuditEventEmailChangeRequested,
+		ActorID:   user.ID,
+		IP:        in.IP,
+		UserAgent: in.UserAgent,
+		Outcome:   AuditOutcomeSuccess,
+		Details:   newEmail,
+	})
+}
+
+// checkEmailOwner returns dto.ErrEmailIsBusy if the email belongs to another user.
+// The emails match case-insensitively, so the user can change the case of the own email.
+func checkEmailOwner(ctx context.Context, repository UserRepository, email string, userID int64) error {
+	owner, err := repository.GetByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("email existence checking error: %w", err)
+	}
+	if owner != nil && owner.ID != userID {
+		return dto.ErrEmailIsBusy
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+//go:generate mockgen -source=case_email_change_confirm.go -destination=mock/case_email_change_confirm.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/repository"
+)
+
+type emailChangeConfirmer interface {
+	CheckCode(ctx context.Context, userID int64, code string, tx TxCommitter) (*EmailChange, error)
+	IssueUndo(ctx context.Context, change *EmailChange, tx TxCommitter) error
+}
+
+type EmailChangeConfirmCase struct {
+	repository           Repository
+	emailChangeConfirmer emailChangeConfirmer
+}
+
+func NewEmailChangeConfirmCase(
+	repository Repository,
+	emailChangeConfirmer emailChangeConfirmer,
+) *EmailChangeConfirmCase {
+	return &EmailChangeConfirmCase{
+		repository:           repository,
+		emailChangeConfirmer: emailChangeConfirmer,
+	}
+}
+
+// Use sets the new email of the change confirmed by the code.
+// The old email gets the token to undo the change.
+func (c *EmailChangeConfirmCase) Use(ctx context.Context, in *dto.EmailChangeConfirmIn) error {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	if err = c.useInTx(ctx, in, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *EmailChangeConfirmCase) useInTx(ctx context.Context, in *dto.EmailChangeConfirmIn, tx TxCommitter) error {
+	change, err := c.emailChangeConfirmer.CheckCode(ctx, in.UserID, in.Code, tx)
+	if err != nil {
+		if err == dto.ErrInvalidEmailChangeCode {
+			return err
+		}
+		return fmt.Errorf("email change code checking error: %w", err)
+	}
+
+	// the email could be taken since the change was requested
+	if err = checkEmailOwner(ctx, tx.User(), change.NewEmail, in.UserID); err != nil {
+		return err
+	}
+
+	user, err := tx.User().Get(ctx, in.UserID)
+	if err != nil {
+		return fmt.Errorf("auth getting error: %w", err)
+	}
+	if user == nil {
+		return dto.ErrUserNotFound
+	}
+
+	user.Email = change.NewEmail
+	if err = tx.User().Save(ctx, user); err != nil {
+		if err == repository.ErrUniqueViolation {
+			return dto.ErrEmailIsBusy
+		}
+		return fmt.Errorf("auth saving error: %w", err)
+	}
+
+	if err = c.emailChangeConfirmer.IssueUndo(ctx, change, tx); err != nil {
+		return fmt.Errorf("email change undo issuing error: %w", err)
+	}
+
+	return addAuditEvent(ctx, tx.AuditLog(), &AuditEvent{
+		Type:      AuditEventEmailChanged,
+		ActorID:   user.ID,
+		IP:        in.IP,
+		UserAgent: in.UserAgent,
+		Outcome:   AuditOutcomeSuccess,
+		Details:   change.NewEmail,
+	})
+}
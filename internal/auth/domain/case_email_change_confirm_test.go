@@ -0,0 +1,333 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	common_repository "github.com/art-es/blog/internal/common/repository"
+)
+
+func TestEmailChangeConfirmCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository           = mock.NewMockRepository(ctrl)
+		emailChangeConfirmer = mock.NewMockemailChangeConfirmer(ctrl)
+	)
+
+	var (
+		ctx       = context.Background()
+		userID    = int64(1)
+		code      = "dummyCode"
+		oldEmail  = "old@example.com"
+		newEmail  = "new@example.com"
+		ip        = "192.0.2.1"
+		userAgent = "Mozilla/5.0"
+		change    = &domain.EmailChange{ID: 2, UserID: userID, OldEmail: oldEmail, NewEmail: newEmail}
+		in        = &dto.EmailChangeConfirmIn{
+			UserID:    userID,
+			Code:      code,
+			IP:        ip,
+			UserAgent: userAgent,
+		}
+		noError = ""
+	)
+
+	userFactory := func() *domain.User {
+		return &domain.User{ID: userID, Email: oldEmail, Active: true}
+	}
+
+	changedUserFactory := func() *domain.User {
+		user := userFactory()
+		user.Email = newEmail
+		return user
+	}
+
+	expectBeginning := func() *mock.MockTxCommitter {
+		tx := mock.NewMockTxCommitter(ctrl)
+
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+
+		return tx
+	}
+
+	expectChecking := func(tx *mock.MockTxCommitter, change *domain.EmailChange, err error) {
+		emailChangeConfirmer.EXPECT().
+			CheckCode(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(code), gomock.Eq(tx)).
+			Return(change, err)
+	}
+
+	expectOwner := func(tx *mock.MockTxCommitter, owner *domain.User, err error) {
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(newEmail)).
+					Return(owner, err)
+				return r
+			})
+	}
+
+	expectGetting := func(tx *mock.MockTxCommitter, user *domain.User, err error) {
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(user, err)
+				return r
+			})
+	}
+
+	expectSaving := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Eq(changedUserFactory())).
+					Return(err)
+				return r
+			})
+	}
+
+	expectIssuing := func(tx *mock.MockTxCommitter, err error) {
+		emailChangeConfirmer.EXPECT().
+			IssueUndo(gomock.Eq(ctx), gomock.Eq(change), gomock.Eq(tx)).
+			Return(err)
+	}
+
+	expectAuditEventAdding := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      domain.AuditEventEmailChanged,
+						ActorID:   userID,
+						IP:        ip,
+						UserAgent: userAgent,
+						Outcome:   domain.AuditOutcomeSuccess,
+						Details:   newEmail,
+					})).
+					Return(err)
+				return r
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				tx := expectBeginning()
+				expectChecking(tx, change, nil)
+				expectOwner(tx, nil, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectSaving(tx, nil)
+				expectIssuing(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: own email in another case",
+			setup: func() {
+				tx := expectBeginning()
+				expectChecking(tx, change, nil)
+				// the email matches the own one case-insensitively
+				expectOwner(tx, userFactory(), nil)
+				expectGetting(tx, userFactory(), nil)
+				expectSaving(tx, nil)
+				expectIssuing(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "invalid code",
+			setup: func() {
+				tx := expectBeginning()
+				expectChecking(tx, nil, dto.ErrInvalidEmailChangeCode)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInvalidEmailChangeCode.Error(),
+		},
+		{
+			name: "error on checking code",
+			setup: func() {
+				tx := expectBeginning()
+				expectChecking(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "email change code checking error: dummy error",
+		},
+		{
+			name: "error on checking email existence",
+			setup: func() {
+				tx := expectBeginning()
+				expectChecking(tx, change, nil)
+				expectOwner(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "email existence checking error: dummy error",
+		},
+		{
+			name: "email taken since the change was requested",
+			setup: func() {
+				tx := expectBeginning()
+				expectChecking(tx, change, nil)
+				expectOwner(tx, &domain.User{ID: 2}, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrEmailIsBusy.Error(),
+		},
+		{
+			name: "error on getting user",
+			setup: func() {
+				tx := expectBeginning()
+				expectChecking(tx, change, nil)
+				expectOwner(tx, nil, nil)
+				expectGetting(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth getting error: dummy error",
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				tx := expectBeginning()
+				expectChecking(tx, change, nil)
+				expectOwner(tx, nil, nil)
+				expectGetting(tx, nil, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrUserNotFound.Error(),
+		},
+		{
+			name: "email taken concurrently",
+			setup: func() {
+				tx := expectBeginning()
+				expectChecking(tx, change, nil)
+				expectOwner(tx, nil, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectSaving(tx, common_repository.ErrUniqueViolation)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrEmailIsBusy.Error(),
+		},
+		{
+			name: "error on saving user",
+			setup: func() {
+				tx := expectBeginning()
+				expectChecking(tx, change, nil)
+				expectOwner(tx, nil, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectSaving(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth saving error: dummy error",
+		},
+		{
+			name: "error on issuing undo token",
+			setup: func() {
+				tx := expectBeginning()
+				expectChecking(tx, change, nil)
+				expectOwner(tx, nil, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectSaving(tx, nil)
+				expectIssuing(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "email change undo issuing error: dummy error",
+		},
+		{
+			name: "error on adding audit event",
+			setup: func() {
+				tx := expectBeginning()
+				expectChecking(tx, change, nil)
+				expectOwner(tx, nil, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectSaving(tx, nil)
+				expectIssuing(tx, nil)
+				expectAuditEventAdding(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				tx := expectBeginning()
+				expectChecking(tx, change, nil)
+				expectOwner(tx, nil, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectSaving(tx, nil)
+				expectIssuing(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewEmailChangeConfirmCase(repository, emailChangeConfirmer)
+			err := u.Use(ctx, in)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
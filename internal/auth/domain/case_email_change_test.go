@@ -0,0 +1,242 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestEmailChangeCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository            = mock.NewMockRepository(ctrl)
+		emailChangeCodeSender = mock.NewMockemailChangeCodeSender(ctrl)
+	)
+
+	var (
+		ctx       = context.Background()
+		userID    = int64(1)
+		newEmail  = "new@example.com"
+		ip        = "192.0.2.1"
+		userAgent = "Mozilla/5.0"
+		user      = &domain.User{ID: userID, Email: "old@example.com", Active: true}
+		in        = &dto.EmailChangeIn{
+			UserID:    userID,
+			NewEmail:  " new@EXAMPLE.com",
+			IP:        ip,
+			UserAgent: userAgent,
+		}
+		noError = ""
+	)
+
+	expectBeginning := func() *mock.MockTxCommitter {
+		tx := mock.NewMockTxCommitter(ctrl)
+
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+
+		return tx
+	}
+
+	expectGetting := func(tx *mock.MockTxCommitter, user *domain.User, err error) {
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(user, err)
+				return r
+			})
+	}
+
+	expectOwner := func(tx *mock.MockTxCommitter, owner *domain.User, err error) {
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(newEmail)).
+					Return(owner, err)
+				return r
+			})
+	}
+
+	expectSending := func(tx *mock.MockTxCommitter, err error) {
+		emailChangeCodeSender.EXPECT().
+			SendCode(gomock.Eq(ctx), gomock.Eq(user), gomock.Eq(newEmail), gomock.Eq(tx)).
+			Return(err)
+	}
+
+	expectAuditEventAdding := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      domain.AuditEventEmailChangeRequested,
+						ActorID:   userID,
+						IP:        ip,
+						UserAgent: userAgent,
+						Outcome:   domain.AuditOutcomeSuccess,
+						Details:   newEmail,
+					})).
+					Return(err)
+				return r
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, user, nil)
+				expectOwner(tx, nil, nil)
+				expectSending(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: own email in another case",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, user, nil)
+				// the email matches the own one case-insensitively
+				expectOwner(tx, user, nil)
+				expectSending(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "error on getting user",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth getting error: dummy error",
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, nil, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrUserNotFound.Error(),
+		},
+		{
+			name: "error on checking email existence",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, user, nil)
+				expectOwner(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "email existence checking error: dummy error",
+		},
+		{
+			name: "busy email",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, user, nil)
+				expectOwner(tx, &domain.User{ID: 2}, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrEmailIsBusy.Error(),
+		},
+		{
+			name: "error on sending code",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, user, nil)
+				expectOwner(tx, nil, nil)
+				expectSending(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "email change code sending error: dummy error",
+		},
+		{
+			name: "error on adding audit event",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, user, nil)
+				expectOwner(tx, nil, nil)
+				expectSending(tx, nil)
+				expectAuditEventAdding(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, user, nil)
+				expectOwner(tx, nil, nil)
+				expectSending(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewEmailChangeCase(repository, emailChangeCodeSender)
+			err := u.Use(ctx, in)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+//go:generate mockgen -source=case_email_change_undo.go -destination=mock/case_email_change_undo.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/repository"
+)
+
+type emailChangeUndoTaker interface {
+	TakeUndo(ctx context.Context, token string, tx TxCommitter) (*EmailChange, error)
+}
+
+type EmailChangeUndoCase struct {
+	repository           Repository
+	emailChangeUndoTaker emailChangeUndoTaker
+	userTokensRevoker    userTokensRevoker
+}
+
+func NewEmailChangeUndoCase(
+	repository Repository,
+	emailChangeUndoTaker emailChangeUndoTaker,
+	userTokensRevoker userTokensRevoker,
+) *EmailChangeUndoCase {
+	return &EmailChangeUndoCase{
+		repository:           repository,
+		emailChangeUndoTaker: emailChangeUndoTaker,
+		userTokensRevoker:    userTokensRevoker,
+	}
+}
+
+// Use restores the old email of the change and revokes all the user tokens,
+// since the change could be made by someone who took over the account.
+func (c *EmailChangeUndoCase) Use(ctx context.Context, in *dto.EmailChangeUndoIn) error {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	if err = c.useInTx(ctx, in, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *EmailChangeUndoCase) useInTx(ctx context.Context, in *dto.EmailChangeUndoIn, tx TxCommitter) error {
+	change, err := c.emailChangeUndoTaker.TakeUndo(ctx, in.Token, tx)
+	if err != nil {
+		if err == dto.ErrInvalidEmailChangeUndoToken {
+			return err
+		}
+		return fmt.Errorf("email change undo taking error: %w", err)
+	}
+
+	user, err := tx.User().Get(ctx, change.UserID)
+	if err != nil {
+		return fmt.Errorf("auth getting error: %w", err)
+	}
+	if user == nil {
+		return dto.ErrInvalidEmailChangeUndoToken
+	}
+
+	if user.Email != change.OldEmail {
+		if err = checkEmailOwner(ctx, tx.User(), change.OldEmail, user.ID); err != nil {
+			return err
+		}
+
+		user.Email = change.OldEmail
+		if err = tx.User().Save(ctx, user); err != nil {
+			if err == repository.ErrUniqueViolation {
+				return dto.ErrEmailIsBusy
+			}
+			return fmt.Errorf("auth saving error: %w", err)
+		}
+	}
+
+	if err = tx.EmailChange().RemoveUserChanges(ctx, user.ID); err != nil {
+		return fmt.Errorf("email changes removing error: %w", err)
+	}
+
+	if err = c.userTokensRevoker.RevokeUser(ctx, user.ID, tx); err != nil {
+		return fmt.Errorf("user tokens revoking error: %w", err)
+	}
+
+	return addAuditEvent(ctx, tx.AuditLog(), &AuditEvent{
+		Type:      AuditEventEmailChangeUndone,
+		ActorID:   user.ID,
+		IP:        in.IP,
+		UserAgent: in.UserAgent,
+		Outcome:   AuditOutcomeSuccess,
+		Details:   change.OldEmail,
+	})
+}
@@ -0,0 +1,380 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	common_repository "github.com/art-es/blog/internal/common/repository"
+)
+
+func TestEmailChangeUndoCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository           = mock.NewMockRepository(ctrl)
+		emailChangeUndoTaker = mock.NewMockemailChangeUndoTaker(ctrl)
+		userTokensRevoker    = mock.NewMockuserTokensRevoker(ctrl)
+	)
+
+	var (
+		ctx       = context.Background()
+		userID    = int64(1)
+		token     = "dummyToken"
+		oldEmail  = "old@example.com"
+		newEmail  = "new@example.com"
+		ip        = "192.0.2.1"
+		userAgent = "Mozilla/5.0"
+		change    = &domain.EmailChange{ID: 2, UserID: userID, OldEmail: oldEmail, NewEmail: newEmail}
+		in        = &dto.EmailChangeUndoIn{
+			Token:     token,
+			IP:        ip,
+			UserAgent: userAgent,
+		}
+		noError = ""
+	)
+
+	userFactory := func() *domain.User {
+		return &domain.User{ID: userID, Email: newEmail, Active: true}
+	}
+
+	restoredUserFactory := func() *domain.User {
+		user := userFactory()
+		user.Email = oldEmail
+		return user
+	}
+
+	expectBeginning := func() *mock.MockTxCommitter {
+		tx := mock.NewMockTxCommitter(ctrl)
+
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+
+		return tx
+	}
+
+	expectTaking := func(tx *mock.MockTxCommitter, change *domain.EmailChange, err error) {
+		emailChangeUndoTaker.EXPECT().
+			TakeUndo(gomock.Eq(ctx), gomock.Eq(token), gomock.Eq(tx)).
+			Return(change, err)
+	}
+
+	expectGetting := func(tx *mock.MockTxCommitter, user *domain.User, err error) {
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(user, err)
+				return r
+			})
+	}
+
+	expectOwner := func(tx *mock.MockTxCommitter, owner *domain.User, err error) {
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(oldEmail)).
+					Return(owner, err)
+				return r
+			})
+	}
+
+	expectSaving := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Eq(restoredUserFactory())).
+					Return(err)
+				return r
+			})
+	}
+
+	expectRemoving := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			EmailChange().
+			DoAndReturn(func() domain.EmailChangeRepository {
+				r := mock.NewMockEmailChangeRepository(ctrl)
+				r.EXPECT().
+					RemoveUserChanges(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(err)
+				return r
+			})
+	}
+
+	expectRevoking := func(tx *mock.MockTxCommitter, err error) {
+		userTokensRevoker.EXPECT().
+			RevokeUser(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(tx)).
+			Return(err)
+	}
+
+	expectAuditEventAdding := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      domain.AuditEventEmailChangeUndone,
+						ActorID:   userID,
+						IP:        ip,
+						UserAgent: userAgent,
+						Outcome:   domain.AuditOutcomeSuccess,
+						Details:   oldEmail,
+					})).
+					Return(err)
+				return r
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				tx := expectBeginning()
+				expectTaking(tx, change, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectOwner(tx, nil, nil)
+				expectSaving(tx, nil)
+				expectRemoving(tx, nil)
+				expectRevoking(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: old email differs in case only",
+			setup: func() {
+				tx := expectBeginning()
+				expectTaking(tx, change, nil)
+				expectGetting(tx, userFactory(), nil)
+				// the old email matches the current one case-insensitively
+				expectOwner(tx, userFactory(), nil)
+				expectSaving(tx, nil)
+				expectRemoving(tx, nil)
+				expectRevoking(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: old email is set already",
+			setup: func() {
+				tx := expectBeginning()
+				expectTaking(tx, change, nil)
+				expectGetting(tx, restoredUserFactory(), nil)
+				expectRemoving(tx, nil)
+				expectRevoking(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "invalid undo token",
+			setup: func() {
+				tx := expectBeginning()
+				expectTaking(tx, nil, dto.ErrInvalidEmailChangeUndoToken)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInvalidEmailChangeUndoToken.Error(),
+		},
+		{
+			name: "error on taking undo token",
+			setup: func() {
+				tx := expectBeginning()
+				expectTaking(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "email change undo taking error: dummy error",
+		},
+		{
+			name: "error on getting user",
+			setup: func() {
+				tx := expectBeginning()
+				expectTaking(tx, change, nil)
+				expectGetting(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth getting error: dummy error",
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				tx := expectBeginning()
+				expectTaking(tx, change, nil)
+				expectGetting(tx, nil, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInvalidEmailChangeUndoToken.Error(),
+		},
+		{
+			name: "error on checking email existence",
+			setup: func() {
+				tx := expectBeginning()
+				expectTaking(tx, change, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectOwner(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "email existence checking error: dummy error",
+		},
+		{
+			name: "old email taken by another user",
+			setup: func() {
+				tx := expectBeginning()
+				expectTaking(tx, change, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectOwner(tx, &domain.User{ID: 2}, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrEmailIsBusy.Error(),
+		},
+		{
+			name: "old email taken concurrently",
+			setup: func() {
+				tx := expectBeginning()
+				expectTaking(tx, change, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectOwner(tx, nil, nil)
+				expectSaving(tx, common_repository.ErrUniqueViolation)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrEmailIsBusy.Error(),
+		},
+		{
+			name: "error on saving user",
+			setup: func() {
+				tx := expectBeginning()
+				expectTaking(tx, change, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectOwner(tx, nil, nil)
+				expectSaving(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth saving error: dummy error",
+		},
+		{
+			name: "error on removing email changes",
+			setup: func() {
+				tx := expectBeginning()
+				expectTaking(tx, change, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectOwner(tx, nil, nil)
+				expectSaving(tx, nil)
+				expectRemoving(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "email changes removing error: dummy error",
+		},
+		{
+			name: "error on revoking user tokens",
+			setup: func() {
+				tx := expectBeginning()
+				expectTaking(tx, change, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectOwner(tx, nil, nil)
+				expectSaving(tx, nil)
+				expectRemoving(tx, nil)
+				expectRevoking(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "user tokens revoking error: dummy error",
+		},
+		{
+			name: "error on adding audit event",
+			setup: func() {
+				tx := expectBeginning()
+				expectTaking(tx, change, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectOwner(tx, nil, nil)
+				expectSaving(tx, nil)
+				expectRemoving(tx, nil)
+				expectRevoking(tx, nil)
+				expectAuditEventAdding(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				tx := expectBeginning()
+				expectTaking(tx, change, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectOwner(tx, nil, nil)
+				expectSaving(tx, nil)
+				expectRemoving(tx, nil)
+				expectRevoking(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewEmailChangeUndoCase(repository, emailChangeUndoTaker, userTokensRevoker)
+			err := u.Use(ctx, in)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
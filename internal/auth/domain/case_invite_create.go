@@ -0,0 +1,77 @@
+//go:generate mockgen -source=case_invite_create.go -destination=mock/case_invite_create.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type inviteIssuer interface {
+	Issue(ctx context.Context, invite *Invite, repository InviteRepository) (string, error)
+}
+
+type InviteCreateCase struct {
+	repository   Repository
+	inviteIssuer inviteIssuer
+}
+
+func NewInviteCreateCase(repository Repository, inviteService inviteIssuer) *InviteCreateCase {
+	return &InviteCreateCase{
+		repository:   repository,
+		inviteIssuer: inviteService,
+	}
+}
+
+// Use creates the invite of the admin, its code is returned only here.
+func (c *InviteCreateCase) Use(ctx context.Context, in *dto.InviteCreateIn) (*dto.InviteCreateOut, error) {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	out, err := c.useInTx(ctx, in, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return out, nil
+}
+
+func (c *InviteCreateCase) useInTx(ctx context.Context, in *dto.InviteCreateIn, tx TxCommitter) (*dto.InviteCreateOut, error) {
+	invite := &Invite{
+		MaxUses:        in.MaxUses,
+		SkipActivation: in.SkipActivation,
+		ExpiresAt:      in.ExpiresAt,
+		CreatedBy:      in.AdminID,
+	}
+
+	code, err := c.inviteIssuer.Issue(ctx, invite, tx.Invite())
+	if err != nil {
+		return nil, fmt.Errorf("invite issuing error: %w", err)
+	}
+
+	err = addAuditEvent(ctx, tx.AuditLog(), &AuditEvent{
+		Type:      AuditEventInviteCreated,
+		ActorID:   in.AdminID,
+		IP:        in.IP,
+		UserAgent: in.UserAgent,
+		Outcome:   AuditOutcomeSuccess,
+		Details:   strconv.FormatInt(invite.ID, 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.InviteCreateOut{
+		ID:   invite.ID,
+		Code: code,
+	}, nil
+}
@@ -0,0 +1,189 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestInviteCreateCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository       = mock.NewMockRepository(ctrl)
+		inviteRepository = mock.NewMockInviteRepository(ctrl)
+		inviteIssuer     = mock.NewMockinviteIssuer(ctrl)
+	)
+
+	var (
+		ctx       = context.Background()
+		adminID   = int64(1)
+		inviteID  = int64(3)
+		code      = "dummyCode"
+		expiresAt = time.Now().Add(7 * 24 * time.Hour)
+		in        = &dto.InviteCreateIn{
+			AdminID:        adminID,
+			MaxUses:        5,
+			SkipActivation: true,
+			ExpiresAt:      expiresAt,
+			IP:             "192.0.2.1",
+			UserAgent:      "dummyUserAgent",
+		}
+		invite = &domain.Invite{
+			MaxUses:        5,
+			SkipActivation: true,
+			ExpiresAt:      expiresAt,
+			CreatedBy:      adminID,
+		}
+		noError = ""
+		noOut   = (*dto.InviteCreateOut)(nil)
+	)
+
+	expectIssuing := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			Invite().
+			Return(inviteRepository)
+
+		inviteIssuer.EXPECT().
+			Issue(gomock.Eq(ctx), gomock.Eq(invite), gomock.Eq(inviteRepository)).
+			DoAndReturn(func(_ context.Context, invite *domain.Invite, _ domain.InviteRepository) (string, error) {
+				if err != nil {
+					return "", err
+				}
+				invite.ID = inviteID
+				return code, nil
+			})
+	}
+
+	expectAuditEventAdding := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      domain.AuditEventInviteCreated,
+						ActorID:   adminID,
+						IP:        in.IP,
+						UserAgent: in.UserAgent,
+						Outcome:   domain.AuditOutcomeSuccess,
+						Details:   "3",
+					})).
+					Return(err)
+				return r
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.InviteCreateOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectIssuing(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.InviteCreateOut{ID: inviteID, Code: code},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expOut: noOut,
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "error on issuing invite",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectIssuing(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expOut: noOut,
+			expErr: "invite issuing error: dummy error",
+		},
+		{
+			name: "error on adding audit event",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectIssuing(tx, nil)
+				expectAuditEventAdding(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expOut: noOut,
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectIssuing(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expOut: noOut,
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewInviteCreateCase(repository, inviteIssuer)
+			out, err := u.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+//go:generate mockgen -source=case_json_web_key_set_get.go -destination=mock/case_json_web_key_set_get.go -package=mock
+package domain
+
+import (
+	"context"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type jsonWebKeySetProvider interface {
+	JSONWebKeySet() []dto.JSONWebKey
+}
+
+type JSONWebKeySetGetCase struct {
+	jsonWebKeySetProvider jsonWebKeySetProvider
+}
+
+func NewJSONWebKeySetGetCase(jsonWebKeySetProvider jsonWebKeySetProvider) *JSONWebKeySetGetCase {
+	return &JSONWebKeySetGetCase{
+		jsonWebKeySetProvider: jsonWebKeySetProvider,
+	}
+}
+
+func (c *JSONWebKeySetGetCase) Use(_ context.Context) *dto.JSONWebKeySetGetOut {
+	return &dto.JSONWebKeySetGetOut{
+		Keys: c.jsonWebKeySetProvider.JSONWebKeySet(),
+	}
+}
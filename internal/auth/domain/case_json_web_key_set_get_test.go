@@ -0,0 +1,31 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestJSONWebKeySetGetCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jsonWebKeySetProvider := mock.NewMockjsonWebKeySetProvider(ctrl)
+
+	keys := []dto.JSONWebKey{
+		{KeyType: "OKP", KeyID: "current", Use: "sig", Algorithm: "EdDSA", Curve: "Ed25519", X: "dummyX"},
+		{KeyType: "RSA", KeyID: "previous", Use: "sig", Algorithm: "RS256", Modulus: "dummyN", Exponent: "AQAB"},
+	}
+
+	jsonWebKeySetProvider.EXPECT().
+		JSONWebKeySet().
+		Return(keys)
+
+	out := domain.NewJSONWebKeySetGetCase(jsonWebKeySetProvider).Use(context.Background())
+	assert.Equal(t, &dto.JSONWebKeySetGetOut{Keys: keys}, out)
+}
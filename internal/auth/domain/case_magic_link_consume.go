@@ -0,0 +1,144 @@
+//go:generate mockgen -source=case_magic_link_consume.go -destination=mock/case_magic_link_consume.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type magicLinkConsumer interface {
+	ConsumeToken(ctx context.Context, token string, tx TxCommitter) (*MagicLinkToken, error)
+}
+
+type MagicLinkConsumeCase struct {
+	repository               Repository
+	magicLinkConsumer        magicLinkConsumer
+	twoFactorChallengeIssuer twoFactorChallengeIssuer
+	accessTokenIssuer        accessTokenIssuer
+	refreshTokenIssuer       refreshTokenIssuer
+	sessionStarter           sessionStarter
+}
+
+func NewMagicLinkConsumeCase(
+	repository Repository,
+	magicLinkService magicLinkConsumer,
+	twoFactorService twoFactorChallengeIssuer,
+	accessTokenService accessTokenIssuer,
+	refreshTokenService refreshTokenIssuer,
+	sessionService sessionStarter,
+) *MagicLinkConsumeCase {
+	return &MagicLinkConsumeCase{
+		repository:               repository,
+		magicLinkConsumer:        magicLinkService,
+		twoFactorChallengeIssuer: twoFactorService,
+		accessTokenIssuer:        accessTokenService,
+		refreshTokenIssuer:       refreshTokenService,
+		sessionStarter:           sessionService,
+	}
+}
+
+// Use signs in the user the link is sent to, the user is activated if it's not yet.
+// Like UserAuthenticateCase, only the two-factor token is returned if the user has two-factor authentication enabled.
+func (c *MagicLinkConsumeCase) Use(ctx context.Context, in *dto.MagicLinkConsumeIn) (*dto.UserAuthenticateOut, error) {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	out, err := c.useInTx(ctx, in, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return out, nil
+}
+
+func (c *MagicLinkConsumeCase) useInTx(ctx context.Context, in *dto.MagicLinkConsumeIn, tx TxCommitter) (*dto.UserAuthenticateOut, error) {
+	link, err := c.magicLinkConsumer.ConsumeToken(ctx, in.Token, tx)
+	if err != nil {
+		if err == dto.ErrInvalidMagicLinkToken {
+			return nil, err
+		}
+		return nil, fmt.Errorf("magic link token consuming error: %w", err)
+	}
+
+	user, err := tx.User().Get(ctx, link.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("auth getting error: %w", err)
+	}
+	// the link doesn't prove owning the email the user has switched to
+	if user == nil || user.Email != link.Email {
+		return nil, dto.ErrInvalidMagicLinkToken
+	}
+
+	if !user.Active {
+		if err = c.activate(ctx, user, in, tx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = checkUserSuspension(ctx, tx.Suspension(), user.ID); err != nil {
+		return nil, err
+	}
+
+	twoFactorEnabled, err := c.twoFactorChallengeIssuer.IsEnabled(ctx, user.ID, tx.TwoFactor())
+	if err != nil {
+		return nil, fmt.Errorf("two-factor checking error: %w", err)
+	}
+	if twoFactorEnabled {
+		token, err := c.twoFactorChallengeIssuer.IssueChallenge(ctx, user.ID, tx.TwoFactorChallenge())
+		if err != nil {
+			return nil, fmt.Errorf("two-factor challenge issuing error: %w", err)
+		}
+		return &dto.UserAuthenticateOut{TwoFactorToken: token}, nil
+	}
+
+	accessToken, refreshToken, err := startSession(ctx, c.sessionStarter, c.accessTokenIssuer, c.refreshTokenIssuer,
+		user.ID, in.UserAgent, in.IP, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = recordLogin(ctx, tx, user.ID, in.IP, in.UserAgent, nil); err != nil {
+		return nil, err
+	}
+
+	return &dto.UserAuthenticateOut{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// activate activates the user, since following the link proves owning the email.
+// Like OIDCAuthenticateCase, the password is dropped, since it was set by someone who hasn't proven owning the email.
+func (c *MagicLinkConsumeCase) activate(ctx context.Context, user *User, in *dto.MagicLinkConsumeIn, tx TxCommitter) error {
+	if _, err := tx.User().Activate(ctx, user.ID); err != nil {
+		return fmt.Errorf("auth activating error: %w", err)
+	}
+
+	user.Active = true
+	user.PasswordHash = ""
+	if err := tx.User().Save(ctx, user); err != nil {
+		return fmt.Errorf("auth saving error: %w", err)
+	}
+
+	// the pending codes would let the activation be resent
+	if err := tx.ActivationCode().RemoveCodes(ctx, user.ID); err != nil {
+		return fmt.Errorf("activation codes removing error: %w", err)
+	}
+
+	return addAuditEvent(ctx, tx.AuditLog(), &AuditEvent{
+		Type:      AuditEventUserActivated,
+		ActorID:   user.ID,
+		IP:        in.IP,
+		UserAgent: in.UserAgent,
+		Outcome:   AuditOutcomeSuccess,
+	})
+}
@@ -0,0 +1,452 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestMagicLinkConsumeCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository                   = mock.NewMockRepository(ctrl)
+		userRepository               = mock.NewMockUserRepository(ctrl)
+		activationCodeRepository     = mock.NewMockActivationCodeRepository(ctrl)
+		twoFactorRepository          = mock.NewMockTwoFactorRepository(ctrl)
+		twoFactorChallengeRepository = mock.NewMockTwoFactorChallengeRepository(ctrl)
+		refreshTokenRepository       = mock.NewMockRefreshTokenRepository(ctrl)
+		sessionRepository            = mock.NewMockSessionRepository(ctrl)
+		roleRepository               = mock.NewMockRoleRepository(ctrl)
+		access                       = &domain.UserAccess{Roles: []string{domain.RoleReader}, Permissions: []string{domain.PermissionPostRead}}
+		magicLinkConsumer            = mock.NewMockmagicLinkConsumer(ctrl)
+		twoFactorChallengeIssuer     = mock.NewMocktwoFactorChallengeIssuer(ctrl)
+		accessTokenIssuer            = mock.NewMockaccessTokenIssuer(ctrl)
+		refreshTokenIssuer           = mock.NewMockrefreshTokenIssuer(ctrl)
+		sessionStarter               = mock.NewMocksessionStarter(ctrl)
+	)
+
+	var (
+		ctx               = context.Background()
+		token             = "dummyToken"
+		userID            = int64(1)
+		email             = "i.ivanov@example.com"
+		accessToken       = "dummyAccessToken"
+		refreshToken      = "dummyRefreshToken"
+		twoFactorToken    = "dummyTwoFactorToken"
+		ip                = "192.0.2.1"
+		userAgent         = "Mozilla/5.0"
+		session           = &domain.Session{ID: "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b", UserID: userID}
+		accessTokenObject = &domain.AccessTokenObject{UserID: userID, SessionID: session.ID}
+		link              = &domain.MagicLinkToken{UserID: userID, Email: email, ExpiresAt: time.Now().Add(time.Minute)}
+		in                = &dto.MagicLinkConsumeIn{Token: token, IP: ip, UserAgent: userAgent}
+		noError           = ""
+	)
+
+	userFactory := func() *domain.User {
+		return &domain.User{ID: userID, Name: "Ivan", Email: email, PasswordHash: "dummyPasswordHash", Active: true}
+	}
+
+	expectBeginning := func() *mock.MockTxCommitter {
+		tx := mock.NewMockTxCommitter(ctrl)
+
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+
+		tx.EXPECT().
+			User().
+			Return(userRepository).
+			AnyTimes()
+
+		return tx
+	}
+
+	expectConsuming := func(tx *mock.MockTxCommitter, link *domain.MagicLinkToken, err error) {
+		magicLinkConsumer.EXPECT().
+			ConsumeToken(gomock.Eq(ctx), gomock.Eq(token), gomock.Eq(tx)).
+			Return(link, err)
+	}
+
+	expectUser := func(tx *mock.MockTxCommitter, user *domain.User, err error) {
+		expectConsuming(tx, link, nil)
+
+		userRepository.EXPECT().
+			Get(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(user, err)
+	}
+
+	expectAuditEventAdding := func(tx *mock.MockTxCommitter, eventType string, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      eventType,
+						ActorID:   userID,
+						IP:        ip,
+						UserAgent: userAgent,
+						Outcome:   domain.AuditOutcomeSuccess,
+					})).
+					Return(err)
+				return r
+			})
+	}
+
+	expectActivating := func(tx *mock.MockTxCommitter, removeErr error) {
+		user := userFactory()
+		user.Active = false
+		expectUser(tx, user, nil)
+
+		userRepository.EXPECT().
+			Activate(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(true, nil)
+
+		activated := userFactory()
+		activated.PasswordHash = ""
+		userRepository.EXPECT().
+			Save(gomock.Eq(ctx), gomock.Eq(activated)).
+			Return(nil)
+
+		tx.EXPECT().
+			ActivationCode().
+			Return(activationCodeRepository)
+
+		activationCodeRepository.EXPECT().
+			RemoveCodes(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(removeErr)
+	}
+
+	expectSuspensionChecking := func(tx *mock.MockTxCommitter, suspension *domain.Suspension, err error) {
+		tx.EXPECT().
+			Suspension().
+			DoAndReturn(func() domain.SuspensionRepository {
+				r := mock.NewMockSuspensionRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(suspension, err)
+				return r
+			})
+	}
+
+	expectTwoFactorChecking := func(tx *mock.MockTxCommitter, enabled bool, err error) {
+		expectSuspensionChecking(tx, nil, nil)
+
+		tx.EXPECT().
+			TwoFactor().
+			Return(twoFactorRepository)
+
+		twoFactorChallengeIssuer.EXPECT().
+			IsEnabled(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(twoFactorRepository)).
+			Return(enabled, err)
+	}
+
+	expectSession := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			Session().
+			Return(sessionRepository)
+
+		var started *domain.Session
+		if err == nil {
+			started = session
+		}
+
+		sessionStarter.EXPECT().
+			Start(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+			Return(started, err)
+	}
+
+	expectTokensIssuing := func(tx *mock.MockTxCommitter) {
+		expectSession(tx, nil)
+
+		tx.EXPECT().
+			Role().
+			Return(roleRepository)
+
+		roleRepository.EXPECT().
+			GetUserAccess(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(access, nil)
+
+		accessTokenIssuer.EXPECT().
+			NewObject(gomock.Eq(userID), gomock.Eq(session.ID), gomock.Eq(access)).
+			Return(accessTokenObject)
+
+		accessTokenIssuer.EXPECT().
+			Sign(gomock.Eq(accessTokenObject)).
+			Return(accessToken, nil)
+
+		tx.EXPECT().
+			RefreshToken().
+			Return(refreshTokenRepository)
+
+		refreshTokenIssuer.EXPECT().
+			Issue(gomock.Eq(ctx), gomock.Eq(session), gomock.Eq(refreshTokenRepository)).
+			Return(refreshToken, nil)
+	}
+
+	expectTokens := func(tx *mock.MockTxCommitter) {
+		expectTwoFactorChecking(tx, false, nil)
+		expectTokensIssuing(tx)
+		expectAuditEventAdding(tx, domain.AuditEventUserLogin, nil)
+
+		tx.EXPECT().
+			Commit().
+			Return(nil)
+	}
+
+	tokensOut := &dto.UserAuthenticateOut{AccessToken: accessToken, RefreshToken: refreshToken}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.UserAuthenticateOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				tx := expectBeginning()
+				expectUser(tx, userFactory(), nil)
+				expectTokens(tx)
+			},
+			expOut: tokensOut,
+			expErr: noError,
+		},
+		{
+			name: "happy path: activating the user and dropping the password",
+			setup: func() {
+				tx := expectBeginning()
+				expectActivating(tx, nil)
+				expectAuditEventAdding(tx, domain.AuditEventUserActivated, nil)
+				expectTokens(tx)
+			},
+			expOut: tokensOut,
+			expErr: noError,
+		},
+		{
+			name: "happy path: two-factor enabled",
+			setup: func() {
+				tx := expectBeginning()
+				expectUser(tx, userFactory(), nil)
+				expectTwoFactorChecking(tx, true, nil)
+
+				tx.EXPECT().
+					TwoFactorChallenge().
+					Return(twoFactorChallengeRepository)
+
+				twoFactorChallengeIssuer.EXPECT().
+					IssueChallenge(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(twoFactorChallengeRepository)).
+					Return(twoFactorToken, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.UserAuthenticateOut{TwoFactorToken: twoFactorToken},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "invalid token",
+			setup: func() {
+				tx := expectBeginning()
+				expectConsuming(tx, nil, dto.ErrInvalidMagicLinkToken)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInvalidMagicLinkToken.Error(),
+		},
+		{
+			name: "error on consuming token",
+			setup: func() {
+				tx := expectBeginning()
+				expectConsuming(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "magic link token consuming error: dummy error",
+		},
+		{
+			name: "error on getting user",
+			setup: func() {
+				tx := expectBeginning()
+				expectUser(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth getting error: dummy error",
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				tx := expectBeginning()
+				expectUser(tx, nil, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInvalidMagicLinkToken.Error(),
+		},
+		{
+			name: "email changed since sending",
+			setup: func() {
+				tx := expectBeginning()
+				user := userFactory()
+				user.Email = "ivan@example.com"
+				expectUser(tx, user, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInvalidMagicLinkToken.Error(),
+		},
+		{
+			name: "error on activating user",
+			setup: func() {
+				tx := expectBeginning()
+				user := userFactory()
+				user.Active = false
+				expectUser(tx, user, nil)
+
+				userRepository.EXPECT().
+					Activate(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(false, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth activating error: dummy error",
+		},
+		{
+			name: "error on removing activation codes",
+			setup: func() {
+				tx := expectBeginning()
+				expectActivating(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "activation codes removing error: dummy error",
+		},
+		{
+			name: "error on adding activation audit event",
+			setup: func() {
+				tx := expectBeginning()
+				expectActivating(tx, nil)
+				expectAuditEventAdding(tx, domain.AuditEventUserActivated, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "user suspended",
+			setup: func() {
+				tx := expectBeginning()
+				expectUser(tx, userFactory(), nil)
+				expectSuspensionChecking(tx, &domain.Suspension{UserID: userID}, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrUserSuspended.Error(),
+		},
+		{
+			name: "error on checking suspension",
+			setup: func() {
+				tx := expectBeginning()
+				expectUser(tx, userFactory(), nil)
+				expectSuspensionChecking(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "user suspension getting error: dummy error",
+		},
+		{
+			name: "error on checking two-factor",
+			setup: func() {
+				tx := expectBeginning()
+				expectUser(tx, userFactory(), nil)
+				expectTwoFactorChecking(tx, false, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "two-factor checking error: dummy error",
+		},
+		{
+			name: "error on starting session",
+			setup: func() {
+				tx := expectBeginning()
+				expectUser(tx, userFactory(), nil)
+				expectTwoFactorChecking(tx, false, nil)
+				expectSession(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "session starting error: dummy error",
+		},
+		{
+			name: "error on recording login",
+			setup: func() {
+				tx := expectBeginning()
+				expectUser(tx, userFactory(), nil)
+				expectTwoFactorChecking(tx, false, nil)
+				expectTokensIssuing(tx)
+				expectAuditEventAdding(tx, domain.AuditEventUserLogin, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				tx := expectBeginning()
+				expectUser(tx, userFactory(), nil)
+				expectTwoFactorChecking(tx, false, nil)
+				expectTokensIssuing(tx)
+				expectAuditEventAdding(tx, domain.AuditEventUserLogin, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewMagicLinkConsumeCase(
+				repository,
+				magicLinkConsumer,
+				twoFactorChallengeIssuer,
+				accessTokenIssuer,
+				refreshTokenIssuer,
+				sessionStarter,
+			)
+			out, err := c.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+//go:generate mockgen -source=case_magic_link_send.go -destination=mock/case_magic_link_send.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type magicLinkSender interface {
+	SendToken(ctx context.Context, user *User, tx TxCommitter) error
+}
+
+type MagicLinkSendCase struct {
+	repository      Repository
+	magicLinkSender magicLinkSender
+}
+
+func NewMagicLinkSendCase(
+	repository Repository,
+	magicLinkSender magicLinkSender,
+) *MagicLinkSendCase {
+	return &MagicLinkSendCase{
+		repository:      repository,
+		magicLinkSender: magicLinkSender,
+	}
+}
+
+// Use sends a login link to the email, the link is sent to not activated users as well.
+// Unknown emails are ignored to not disclose registered ones.
+func (c *MagicLinkSendCase) Use(ctx context.Context, in *dto.MagicLinkSendIn) error {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	if err = c.useInTx(ctx, in, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *MagicLinkSendCase) useInTx(ctx context.Context, in *dto.MagicLinkSendIn, tx TxCommitter) error {
+	user, err := tx.User().GetByEmail(ctx, NormalizeEmail(in.Email))
+	if err != nil {
+		return fmt.Errorf("auth getting by email error: %w", err)
+	}
+	if user == nil {
+		return nil
+	}
+
+	if err = c.magicLinkSender.SendToken(ctx, user, tx); err != nil {
+		return fmt.Errorf("magic link token sending error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestMagicLinkSendCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository      = mock.NewMockRepository(ctrl)
+		magicLinkSender = mock.NewMockmagicLinkSender(ctrl)
+	)
+
+	var (
+		ctx     = context.Background()
+		email   = "dummyEmail@example.com"
+		user    = &domain.User{ID: 1, Email: email, Active: true}
+		in      = &dto.MagicLinkSendIn{Email: " dummyEmail@EXAMPLE.com"}
+		noError = ""
+	)
+
+	expectUser := func(tx *mock.MockTxCommitter, user *domain.User, err error) {
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(user, err)
+				return r
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, user, nil)
+
+				magicLinkSender.EXPECT().
+					SendToken(gomock.Eq(ctx), gomock.Eq(user), gomock.Eq(tx)).
+					Return(nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "error on getting user",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth getting by email error: dummy error",
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, nil, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: user not activated",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				inactive := &domain.User{ID: 1, Email: email}
+				expectUser(tx, inactive, nil)
+
+				magicLinkSender.EXPECT().
+					SendToken(gomock.Eq(ctx), gomock.Eq(inactive), gomock.Eq(tx)).
+					Return(nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on sending token",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, user, nil)
+
+				magicLinkSender.EXPECT().
+					SendToken(gomock.Eq(ctx), gomock.Eq(user), gomock.Eq(tx)).
+					Return(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "magic link token sending error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectUser(tx, user, nil)
+
+				magicLinkSender.EXPECT().
+					SendToken(gomock.Eq(ctx), gomock.Eq(user), gomock.Eq(tx)).
+					Return(nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewMagicLinkSendCase(repository, magicLinkSender)
+			err := u.Use(ctx, in)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+//go:generate mockgen -source=case_oidc_authenticate.go -destination=mock/case_oidc_authenticate.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type oidcStateTaker interface {
+	Take(ctx context.Context, provider, state string, repository OIDCStateRepository) (*OIDCState, error)
+}
+
+type oidcCodeExchanger interface {
+	Exchange(ctx context.Context, provider, code, codeVerifier, nonce string) (*dto.OIDCIdentity, error)
+}
+
+type OIDCAuthenticateCase struct {
+	repository                Repository
+	oidcStateTaker            oidcStateTaker
+	oidcCodeExchanger         oidcCodeExchanger
+	twoFactorChallengeIssuer  twoFactorChallengeIssuer
+	accessTokenIssuer         accessTokenIssuer
+	refreshTokenIssuer        refreshTokenIssuer
+	sessionStarter            sessionStarter
+	registrationPolicyChecker registrationPolicyChecker
+}
+
+func NewOIDCAuthenticateCase(
+	repository Repository,
+	oidcStateService oidcStateTaker,
+	oidcClient oidcCodeExchanger,
+	twoFactorService twoFactorChallengeIssuer,
+	accessTokenService accessTokenIssuer,
+	refreshTokenService refreshTokenIssuer,
+	sessionService sessionStarter,
+	registrationPolicyService registrationPolicyChecker,
+) *OIDCAuthenticateCase {
+	return &OIDCAuthenticateCase{
+		repository:                repository,
+		oidcStateTaker:            oidcStateService,
+		oidcCodeExchanger:         oidcClient,
+		twoFactorChallengeIssuer:  twoFactorService,
+		accessTokenIssuer:         accessTokenService,
+		refreshTokenIssuer:        refreshTokenService,
+		sessionStarter:            sessionService,
+		registrationPolicyChecker: registrationPolicyService,
+	}
+}
+
+// Use signs in the user linked to the identity at the provider. On the first sign-in the identity is linked
+// to the user with the same email, the user is created if there is none and the registration policy allows it.
+// Like UserAuthenticateCase, only the two-factor token is returned if the user has two-factor authentication enabled.
+func (c *OIDCAuthenticateCase) Use(ctx context.Context, in *dto.OIDCAuthenticateIn) (*dto.UserAuthenticateOut, error) {
+	state, err := c.oidcStateTaker.Take(ctx, in.Provider, in.State, c.repository.OIDCState())
+	if err != nil {
+		if err == dto.ErrInvalidOIDCState {
+			return nil, err
+		}
+		return nil, fmt.Errorf("oidc state taking error: %w", err)
+	}
+
+	identity, err := c.oidcCodeExchanger.Exchange(ctx, in.Provider, in.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		switch err {
+		case dto.ErrOIDCProviderNotFound, dto.ErrOIDCAuthenticationFailed:
+			return nil, err
+		}
+		return nil, fmt.Errorf("oidc code exchanging error: %w", err)
+	}
+
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	out, err := c.useInTx(ctx, in, identity, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return out, nil
+}
+
+func (c *OIDCAuthenticateCase) useInTx(ctx context.Context, in *dto.OIDCAuthenticateIn, identity *dto.OIDCIdentity, tx TxCommitter) (*dto.UserAuthenticateOut, error) {
+	user, err := c.linkedUser(ctx, in, identity, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkUserSuspension(ctx, tx.Suspension(), user.ID); err != nil {
+		return nil, err
+	}
+
+	twoFactorEnabled, err := c.twoFactorChallengeIssuer.IsEnabled(ctx, user.ID, tx.TwoFactor())
+	if err != nil {
+		return nil, fmt.Errorf("two-factor checking error: %w", err)
+	}
+	if twoFactorEnabled {
+		token, err := c.twoFactorChallengeIssuer.IssueChallenge(ctx, user.ID, tx.TwoFactorChallenge())
+		if err != nil {
+			return nil, fmt.Errorf("two-factor challenge issuing error: %w", err)
+		}
+		return &dto.UserAuthenticateOut{TwoFactorToken: token}, nil
+	}
+
+	accessToken, refreshToken, err := startSession(ctx, c.sessionStarter, c.accessTokenIssuer, c.refreshTokenIssuer,
+		user.ID, in.UserAgent, in.IP, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = recordLogin(ctx, tx, user.ID, in.IP, in.UserAgent, nil); err != nil {
+		return nil, err
+	}
+
+	return &dto.UserAuthenticateOut{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// linkedUser returns the user linked to the identity, the identity is linked on the first sign-in.
+func (c *OIDCAuthenticateCase) linkedUser(ctx context.Context, in *dto.OIDCAuthenticateIn, identity *dto.OIDCIdentity, tx TxCommitter) (*User, error) {
+	linked, err := tx.ExternalIdentity().Get(ctx, in.Provider, identity.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("external identity getting error: %w", err)
+	}
+	if linked != nil {
+		user, err := tx.User().Get(ctx, linked.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("auth getting error: %w", err)
+		}
+		if user == nil {
+			return nil, dto.ErrUserNotFound
+		}
+		return user, nil
+	}
+
+	// an unverified email would let anyone take over the account with the same email
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, dto.ErrExternalEmailNotVerified
+	}
+
+	email := NormalizeEmail(identity.Email)
+	user, err := tx.User().GetByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("auth getting by email error: %w", err)
+	}
+
+	switch {
+	case user == nil:
+		if err = c.registrationPolicyChecker.CheckEmail(email); err != nil {
+			return nil, err
+		}
+		// the provider has no way to pass an invite
+		if c.registrationPolicyChecker.InviteRequired() {
+			return nil, dto.ErrInviteRequired
+		}
+
+		user = &User{
+			Name:   externalUserName(identity),
+			Email:  email,
+			Active: true,
+		}
+		if err = addUser(ctx, user, in.IP, in.UserAgent, tx); err != nil {
+			return nil, err
+		}
+	case !user.Active:
+		// the password is dropped, since it was set by someone who hasn't proven owning the email
+		if _, err = tx.User().Activate(ctx, user.ID); err != nil {
+			return nil, fmt.Errorf("auth activating error: %w", err)
+		}
+		user.Active = true
+		user.PasswordHash = ""
+		if err = tx.User().Save(ctx, user); err != nil {
+			return nil, fmt.Errorf("auth saving error: %w", err)
+		}
+	}
+
+	linked = &ExternalIdentity{
+		Provider: in.Provider,
+		Subject:  identity.Subject,
+		UserID:   user.ID,
+	}
+	if err = tx.ExternalIdentity().Add(ctx, linked); err != nil {
+		return nil, fmt.Errorf("external identity adding error: %w", err)
+	}
+
+	return user, nil
+}
+
+func externalUserName(identity *dto.OIDCIdentity) string {
+	if identity.Name != "" {
+		return identity.Name
+	}
+	name, _, _ := strings.Cut(identity.Email, "@")
+	return name
+}
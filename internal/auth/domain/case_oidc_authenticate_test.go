@@ -0,0 +1,820 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestOIDCAuthenticateCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository                   = mock.NewMockRepository(ctrl)
+		oidcStateRepository          = mock.NewMockOIDCStateRepository(ctrl)
+		userRepository               = mock.NewMockUserRepository(ctrl)
+		externalIdentityRepository   = mock.NewMockExternalIdentityRepository(ctrl)
+		twoFactorRepository          = mock.NewMockTwoFactorRepository(ctrl)
+		twoFactorChallengeRepository = mock.NewMockTwoFactorChallengeRepository(ctrl)
+		refreshTokenRepository       = mock.NewMockRefreshTokenRepository(ctrl)
+		sessionRepository            = mock.NewMockSessionRepository(ctrl)
+		roleRepository               = mock.NewMockRoleRepository(ctrl)
+		access                       = &domain.UserAccess{Roles: []string{domain.RoleReader}, Permissions: []string{domain.PermissionPostRead}}
+		oidcStateTaker               = mock.NewMockoidcStateTaker(ctrl)
+		oidcCodeExchanger            = mock.NewMockoidcCodeExchanger(ctrl)
+		twoFactorChallengeIssuer     = mock.NewMocktwoFactorChallengeIssuer(ctrl)
+		accessTokenIssuer            = mock.NewMockaccessTokenIssuer(ctrl)
+		refreshTokenIssuer           = mock.NewMockrefreshTokenIssuer(ctrl)
+		sessionStarter               = mock.NewMocksessionStarter(ctrl)
+		registrationPolicy           = mock.NewMockregistrationPolicyChecker(ctrl)
+	)
+
+	var (
+		ctx               = context.Background()
+		provider          = "example"
+		code              = "dummyCode"
+		state             = "dummyState"
+		userID            = int64(1)
+		email             = "i.ivanov@example.com"
+		accessToken       = "dummyAccessToken"
+		refreshToken      = "dummyRefreshToken"
+		twoFactorToken    = "dummyTwoFactorToken"
+		ip                = "192.0.2.1"
+		userAgent         = "Mozilla/5.0"
+		session           = &domain.Session{ID: "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b", UserID: userID}
+		accessTokenObject = &domain.AccessTokenObject{UserID: userID, SessionID: session.ID}
+		oidcState         = &domain.OIDCState{Provider: provider, CodeVerifier: "dummyCodeVerifier", Nonce: "dummyNonce"}
+		linkedIdentity    = &domain.ExternalIdentity{Provider: provider, Subject: "dummySubject", UserID: userID}
+		in                = &dto.OIDCAuthenticateIn{Provider: provider, Code: code, State: state, IP: ip, UserAgent: userAgent}
+		noError           = ""
+	)
+
+	expectRegistrationAllowed := func() {
+		registrationPolicy.EXPECT().
+			CheckEmail(gomock.Eq(email)).
+			Return(nil)
+
+		registrationPolicy.EXPECT().
+			InviteRequired().
+			Return(false)
+	}
+
+	identityFactory := func() *dto.OIDCIdentity {
+		return &dto.OIDCIdentity{Subject: "dummySubject", Email: email, EmailVerified: true, Name: "Ivan Ivanov"}
+	}
+
+	userFactory := func() *domain.User {
+		return &domain.User{ID: userID, Name: "Ivan", Email: email, PasswordHash: "dummyPasswordHash", Active: true}
+	}
+
+	expectTaking := func(err error) {
+		repository.EXPECT().
+			OIDCState().
+			Return(oidcStateRepository)
+
+		var out *domain.OIDCState
+		if err == nil {
+			out = oidcState
+		}
+
+		oidcStateTaker.EXPECT().
+			Take(gomock.Eq(ctx), gomock.Eq(provider), gomock.Eq(state), gomock.Eq(oidcStateRepository)).
+			Return(out, err)
+	}
+
+	expectExchanging := func(identity *dto.OIDCIdentity, err error) {
+		oidcCodeExchanger.EXPECT().
+			Exchange(gomock.Eq(ctx), gomock.Eq(provider), gomock.Eq(code), gomock.Eq("dummyCodeVerifier"), gomock.Eq("dummyNonce")).
+			Return(identity, err)
+	}
+
+	expectBeginning := func(identity *dto.OIDCIdentity) *mock.MockTxCommitter {
+		expectTaking(nil)
+		expectExchanging(identity, nil)
+
+		tx := mock.NewMockTxCommitter(ctrl)
+
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+
+		tx.EXPECT().
+			ExternalIdentity().
+			Return(externalIdentityRepository).
+			AnyTimes()
+
+		tx.EXPECT().
+			User().
+			Return(userRepository).
+			AnyTimes()
+
+		return tx
+	}
+
+	expectRoleAdding := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			Role().
+			Return(roleRepository)
+
+		roleRepository.EXPECT().
+			AddUserRole(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(domain.DefaultRole)).
+			Return(err)
+	}
+
+	expectAuditEventAdding := func(tx *mock.MockTxCommitter, eventType, details string, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      eventType,
+						ActorID:   userID,
+						IP:        ip,
+						UserAgent: userAgent,
+						Outcome:   domain.AuditOutcomeSuccess,
+						Details:   details,
+					})).
+					Return(err)
+				return r
+			})
+	}
+
+	expectRegistrationRecording := func(tx *mock.MockTxCommitter) {
+		expectAuditEventAdding(tx, domain.AuditEventUserRegistered, email, nil)
+		expectAuditEventAdding(tx, domain.AuditEventRoleGranted, domain.DefaultRole, nil)
+	}
+
+	expectIdentityGetting := func(identity *domain.ExternalIdentity, err error) {
+		externalIdentityRepository.EXPECT().
+			Get(gomock.Eq(ctx), gomock.Eq(provider), gomock.Eq("dummySubject")).
+			Return(identity, err)
+	}
+
+	expectLinking := func(err error) {
+		externalIdentityRepository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Eq(linkedIdentity)).
+			Return(err)
+	}
+
+	expectSuspensionChecking := func(tx *mock.MockTxCommitter, suspension *domain.Suspension, err error) {
+		tx.EXPECT().
+			Suspension().
+			DoAndReturn(func() domain.SuspensionRepository {
+				r := mock.NewMockSuspensionRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(suspension, err)
+				return r
+			})
+	}
+
+	expectTwoFactorChecking := func(tx *mock.MockTxCommitter, enabled bool, err error) {
+		expectSuspensionChecking(tx, nil, nil)
+
+		tx.EXPECT().
+			TwoFactor().
+			Return(twoFactorRepository)
+
+		twoFactorChallengeIssuer.EXPECT().
+			IsEnabled(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(twoFactorRepository)).
+			Return(enabled, err)
+	}
+
+	expectChallengeIssuing := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			TwoFactorChallenge().
+			Return(twoFactorChallengeRepository)
+
+		twoFactorChallengeIssuer.EXPECT().
+			IssueChallenge(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(twoFactorChallengeRepository)).
+			Return(twoFactorToken, err)
+	}
+
+	expectSession := func(tx *mock.MockTxCommitter, started *domain.Session, err error) {
+		tx.EXPECT().
+			Session().
+			Return(sessionRepository)
+
+		sessionStarter.EXPECT().
+			Start(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+			Return(started, err)
+	}
+
+	expectAccessToken := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			Role().
+			Return(roleRepository)
+
+		roleRepository.EXPECT().
+			GetUserAccess(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(access, nil)
+
+		accessTokenIssuer.EXPECT().
+			NewObject(gomock.Eq(userID), gomock.Eq(session.ID), gomock.Eq(access)).
+			Return(accessTokenObject)
+
+		accessTokenIssuer.EXPECT().
+			Sign(gomock.Eq(accessTokenObject)).
+			Return(accessToken, err)
+	}
+
+	expectRefreshToken := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			RefreshToken().
+			Return(refreshTokenRepository)
+
+		refreshTokenIssuer.EXPECT().
+			Issue(gomock.Eq(ctx), gomock.Eq(session), gomock.Eq(refreshTokenRepository)).
+			Return(refreshToken, err)
+	}
+
+	expectTokens := func(tx *mock.MockTxCommitter) {
+		expectTwoFactorChecking(tx, false, nil)
+		expectSession(tx, session, nil)
+		expectAccessToken(tx, nil)
+		expectRefreshToken(tx, nil)
+		expectAuditEventAdding(tx, domain.AuditEventUserLogin, "", nil)
+
+		tx.EXPECT().
+			Commit().
+			Return(nil)
+	}
+
+	tokensOut := &dto.UserAuthenticateOut{AccessToken: accessToken, RefreshToken: refreshToken}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.UserAuthenticateOut
+		expErr string
+	}{
+		{
+			name: "happy path: linked identity",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(linkedIdentity, nil)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(userFactory(), nil)
+
+				expectTokens(tx)
+			},
+			expOut: tokensOut,
+			expErr: noError,
+		},
+		{
+			name: "happy path: linking the user with the same email",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(nil, nil)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(userFactory(), nil)
+
+				expectLinking(nil)
+				expectTokens(tx)
+			},
+			expOut: tokensOut,
+			expErr: noError,
+		},
+		{
+			name: "happy path: activating the user with the same email and dropping the password",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(nil, nil)
+
+				user := userFactory()
+				user.Active = false
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(user, nil)
+
+				userRepository.EXPECT().
+					Activate(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(true, nil)
+
+				activated := userFactory()
+				activated.PasswordHash = ""
+				userRepository.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Eq(activated)).
+					Return(nil)
+
+				expectLinking(nil)
+				expectTokens(tx)
+			},
+			expOut: tokensOut,
+			expErr: noError,
+		},
+		{
+			name: "happy path: creating the user",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(nil, nil)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(nil, nil)
+
+				expectRegistrationAllowed()
+
+				userRepository.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Eq(&domain.User{Name: "Ivan Ivanov", Email: email, Active: true})).
+					DoAndReturn(func(_ context.Context, user *domain.User) error {
+						user.ID = userID
+						return nil
+					})
+
+				expectRoleAdding(tx, nil)
+				expectRegistrationRecording(tx)
+				expectLinking(nil)
+				expectTokens(tx)
+			},
+			expOut: tokensOut,
+			expErr: noError,
+		},
+		{
+			name: "happy path: creating the user named after the email",
+			setup: func() {
+				identity := identityFactory()
+				identity.Name = ""
+				tx := expectBeginning(identity)
+				expectIdentityGetting(nil, nil)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(nil, nil)
+
+				expectRegistrationAllowed()
+
+				userRepository.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Eq(&domain.User{Name: "i.ivanov", Email: email, Active: true})).
+					DoAndReturn(func(_ context.Context, user *domain.User) error {
+						user.ID = userID
+						return nil
+					})
+
+				expectRoleAdding(tx, nil)
+				expectRegistrationRecording(tx)
+				expectLinking(nil)
+				expectTokens(tx)
+			},
+			expOut: tokensOut,
+			expErr: noError,
+		},
+		{
+			name: "happy path: two-factor enabled",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(linkedIdentity, nil)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(userFactory(), nil)
+
+				expectTwoFactorChecking(tx, true, nil)
+				expectChallengeIssuing(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.UserAuthenticateOut{TwoFactorToken: twoFactorToken},
+			expErr: noError,
+		},
+		{
+			name: "invalid state",
+			setup: func() {
+				expectTaking(dto.ErrInvalidOIDCState)
+			},
+			expErr: dto.ErrInvalidOIDCState.Error(),
+		},
+		{
+			name: "error on taking state",
+			setup: func() {
+				expectTaking(errors.New("dummy error"))
+			},
+			expErr: "oidc state taking error: dummy error",
+		},
+		{
+			name: "authentication failed at the provider",
+			setup: func() {
+				expectTaking(nil)
+				expectExchanging(nil, dto.ErrOIDCAuthenticationFailed)
+			},
+			expErr: dto.ErrOIDCAuthenticationFailed.Error(),
+		},
+		{
+			name: "error on exchanging code",
+			setup: func() {
+				expectTaking(nil)
+				expectExchanging(nil, errors.New("dummy error"))
+			},
+			expErr: "oidc code exchanging error: dummy error",
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				expectTaking(nil)
+				expectExchanging(identityFactory(), nil)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "error on getting identity",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "external identity getting error: dummy error",
+		},
+		{
+			name: "error on getting linked user",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(linkedIdentity, nil)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth getting error: dummy error",
+		},
+		{
+			name: "linked user not found",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(linkedIdentity, nil)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(nil, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrUserNotFound.Error(),
+		},
+		{
+			name: "email not verified",
+			setup: func() {
+				identity := identityFactory()
+				identity.EmailVerified = false
+				tx := expectBeginning(identity)
+				expectIdentityGetting(nil, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrExternalEmailNotVerified.Error(),
+		},
+		{
+			name: "no email",
+			setup: func() {
+				identity := identityFactory()
+				identity.Email = ""
+				tx := expectBeginning(identity)
+				expectIdentityGetting(nil, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrExternalEmailNotVerified.Error(),
+		},
+		{
+			name: "error on getting user by email",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(nil, nil)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth getting by email error: dummy error",
+		},
+		{
+			name: "email domain not allowed",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(nil, nil)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(nil, nil)
+
+				registrationPolicy.EXPECT().
+					CheckEmail(gomock.Eq(email)).
+					Return(dto.ErrEmailDomainNotAllowed)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrEmailDomainNotAllowed.Error(),
+		},
+		{
+			name: "invite required",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(nil, nil)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(nil, nil)
+
+				registrationPolicy.EXPECT().
+					CheckEmail(gomock.Eq(email)).
+					Return(nil)
+
+				registrationPolicy.EXPECT().
+					InviteRequired().
+					Return(true)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInviteRequired.Error(),
+		},
+		{
+			name: "error on creating user",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(nil, nil)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(nil, nil)
+
+				expectRegistrationAllowed()
+
+				userRepository.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Any()).
+					Return(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth saving error: dummy error",
+		},
+		{
+			name: "error on adding user role",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(nil, nil)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(nil, nil)
+
+				expectRegistrationAllowed()
+
+				userRepository.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(_ context.Context, user *domain.User) error {
+						user.ID = userID
+						return nil
+					})
+
+				expectRoleAdding(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "user role adding error: dummy error",
+		},
+		{
+			name: "error on activating user",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(nil, nil)
+
+				user := userFactory()
+				user.Active = false
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(user, nil)
+
+				userRepository.EXPECT().
+					Activate(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(false, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth activating error: dummy error",
+		},
+		{
+			name: "error on linking identity",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(nil, nil)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(userFactory(), nil)
+
+				expectLinking(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "external identity adding error: dummy error",
+		},
+		{
+			name: "user suspended",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(linkedIdentity, nil)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(userFactory(), nil)
+
+				expectSuspensionChecking(tx, &domain.Suspension{UserID: userID}, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrUserSuspended.Error(),
+		},
+		{
+			name: "error on checking suspension",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(linkedIdentity, nil)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(userFactory(), nil)
+
+				expectSuspensionChecking(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "user suspension getting error: dummy error",
+		},
+		{
+			name: "error on checking two-factor",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(linkedIdentity, nil)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(userFactory(), nil)
+
+				expectTwoFactorChecking(tx, false, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "two-factor checking error: dummy error",
+		},
+		{
+			name: "error on issuing two-factor challenge",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(linkedIdentity, nil)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(userFactory(), nil)
+
+				expectTwoFactorChecking(tx, true, nil)
+				expectChallengeIssuing(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "two-factor challenge issuing error: dummy error",
+		},
+		{
+			name: "error on starting session",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(linkedIdentity, nil)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(userFactory(), nil)
+
+				expectTwoFactorChecking(tx, false, nil)
+				expectSession(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "session starting error: dummy error",
+		},
+		{
+			name: "error on creating access token",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(linkedIdentity, nil)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(userFactory(), nil)
+
+				expectTwoFactorChecking(tx, false, nil)
+				expectSession(tx, session, nil)
+				expectAccessToken(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "access token creation error: dummy error",
+		},
+		{
+			name: "error on creating refresh token",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(linkedIdentity, nil)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(userFactory(), nil)
+
+				expectTwoFactorChecking(tx, false, nil)
+				expectSession(tx, session, nil)
+				expectAccessToken(tx, nil)
+				expectRefreshToken(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "refresh token creation error: dummy error",
+		},
+		{
+			name: "error on recording login",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(linkedIdentity, nil)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(userFactory(), nil)
+
+				expectTwoFactorChecking(tx, false, nil)
+				expectSession(tx, session, nil)
+				expectAccessToken(tx, nil)
+				expectRefreshToken(tx, nil)
+				expectAuditEventAdding(tx, domain.AuditEventUserLogin, "", errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				tx := expectBeginning(identityFactory())
+				expectIdentityGetting(linkedIdentity, nil)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(userFactory(), nil)
+
+				expectTwoFactorChecking(tx, false, nil)
+				expectSession(tx, session, nil)
+				expectAccessToken(tx, nil)
+				expectRefreshToken(tx, nil)
+				expectAuditEventAdding(tx, domain.AuditEventUserLogin, "", nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewOIDCAuthenticateCase(
+				repository,
+				oidcStateTaker,
+				oidcCodeExchanger,
+				twoFactorChallengeIssuer,
+				accessTokenIssuer,
+				refreshTokenIssuer,
+				sessionStarter,
+				registrationPolicy,
+			)
+			out, err := c.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+//go:generate mockgen -source=case_oidc_authorize.go -destination=mock/case_oidc_authorize.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type oidcStateIssuer interface {
+	Issue(ctx context.Context, provider string, repository OIDCStateRepository) (*dto.OIDCRequest, error)
+}
+
+type oidcAuthorizer interface {
+	HasProvider(provider string) bool
+	AuthorizationURL(ctx context.Context, provider string, request *dto.OIDCRequest) (string, error)
+}
+
+type OIDCAuthorizeCase struct {
+	repository      Repository
+	oidcStateIssuer oidcStateIssuer
+	oidcAuthorizer  oidcAuthorizer
+}
+
+func NewOIDCAuthorizeCase(
+	repository Repository,
+	oidcStateService oidcStateIssuer,
+	oidcClient oidcAuthorizer,
+) *OIDCAuthorizeCase {
+	return &OIDCAuthorizeCase{
+		repository:      repository,
+		oidcStateIssuer: oidcStateService,
+		oidcAuthorizer:  oidcClient,
+	}
+}
+
+// Use returns the URL to sign in at the provider,
+// the provider redirects back with the code for OIDCAuthenticateCase.
+func (c *OIDCAuthorizeCase) Use(ctx context.Context, in *dto.OIDCAuthorizeIn) (*dto.OIDCAuthorizeOut, error) {
+	if !c.oidcAuthorizer.HasProvider(in.Provider) {
+		return nil, dto.ErrOIDCProviderNotFound
+	}
+
+	request, err := c.oidcStateIssuer.Issue(ctx, in.Provider, c.repository.OIDCState())
+	if err != nil {
+		return nil, fmt.Errorf("oidc state issuing error: %w", err)
+	}
+
+	authorizationURL, err := c.oidcAuthorizer.AuthorizationURL(ctx, in.Provider, request)
+	if err != nil {
+		return nil, fmt.Errorf("authorization url building error: %w", err)
+	}
+
+	return &dto.OIDCAuthorizeOut{
+		AuthorizationURL: authorizationURL,
+		State:            request.State,
+		StateExpiresAt:   request.ExpiresAt,
+	}, nil
+}
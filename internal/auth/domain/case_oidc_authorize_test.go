@@ -0,0 +1,121 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestOIDCAuthorizeCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository          = mock.NewMockRepository(ctrl)
+		oidcStateRepository = mock.NewMockOIDCStateRepository(ctrl)
+		oidcStateIssuer     = mock.NewMockoidcStateIssuer(ctrl)
+		oidcAuthorizer      = mock.NewMockoidcAuthorizer(ctrl)
+	)
+
+	var (
+		ctx              = context.Background()
+		provider         = "example"
+		authorizationURL = "https://id.example.com/authorize?state=dummyState"
+		expiresAt        = time.Now().Add(10 * time.Minute)
+		request          = &dto.OIDCRequest{State: "dummyState", Nonce: "dummyNonce", CodeChallenge: "dummyCodeChallenge", ExpiresAt: expiresAt}
+		in               = &dto.OIDCAuthorizeIn{Provider: provider}
+		noError          = ""
+	)
+
+	expectProvider := func(exists bool) {
+		oidcAuthorizer.EXPECT().
+			HasProvider(gomock.Eq(provider)).
+			Return(exists)
+	}
+
+	expectIssuing := func(err error) {
+		repository.EXPECT().
+			OIDCState().
+			Return(oidcStateRepository)
+
+		var out *dto.OIDCRequest
+		if err == nil {
+			out = request
+		}
+
+		oidcStateIssuer.EXPECT().
+			Issue(gomock.Eq(ctx), gomock.Eq(provider), gomock.Eq(oidcStateRepository)).
+			Return(out, err)
+	}
+
+	expectBuilding := func(err error) {
+		oidcAuthorizer.EXPECT().
+			AuthorizationURL(gomock.Eq(ctx), gomock.Eq(provider), gomock.Eq(request)).
+			Return(authorizationURL, err)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.OIDCAuthorizeOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				expectProvider(true)
+				expectIssuing(nil)
+				expectBuilding(nil)
+			},
+			expOut: &dto.OIDCAuthorizeOut{AuthorizationURL: authorizationURL, State: "dummyState", StateExpiresAt: expiresAt},
+			expErr: noError,
+		},
+		{
+			name: "provider not found",
+			setup: func() {
+				expectProvider(false)
+			},
+			expErr: dto.ErrOIDCProviderNotFound.Error(),
+		},
+		{
+			name: "error on issuing state",
+			setup: func() {
+				expectProvider(true)
+				expectIssuing(errors.New("dummy error"))
+			},
+			expErr: "oidc state issuing error: dummy error",
+		},
+		{
+			name: "error on building authorization url",
+			setup: func() {
+				expectProvider(true)
+				expectIssuing(nil)
+				expectBuilding(errors.New("dummy error"))
+			},
+			expErr: "authorization url building error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewOIDCAuthorizeCase(repository, oidcStateIssuer, oidcAuthorizer)
+			out, err := c.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
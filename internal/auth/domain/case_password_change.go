@@ -0,0 +1,148 @@
+//go:generate mockgen -source=case_password_change.go -destination=mock/case_password_change.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type passwordChangeNotifier interface {
+	NotifyPasswordChanged(ctx context.Context, user *User)
+}
+
+type PasswordChangeCase struct {
+	repository             Repository
+	passwordValidator      passwordValidator
+	loginThrottler         loginThrottler
+	passwordPolicyChecker  passwordPolicyChecker
+	passwordHashGenerator  passwordHashGenerator
+	userTokensRevoker      userTokensRevoker
+	accessTokenIssuer      accessTokenIssuer
+	refreshTokenIssuer     refreshTokenIssuer
+	passwordChangeNotifier passwordChangeNotifier
+	sessionStarter         sessionStarter
+}
+
+func NewPasswordChangeCase(
+	repository Repository,
+	passwordValidator passwordValidator,
+	loginThrottler loginThrottler,
+	passwordPolicyChecker passwordPolicyChecker,
+	passwordHashGenerator passwordHashGenerator,
+	userTokensRevoker userTokensRevoker,
+	accessTokenIssuer accessTokenIssuer,
+	refreshTokenIssuer refreshTokenIssuer,
+	passwordChangeNotifier passwordChangeNotifier,
+	sessionStarter sessionStarter,
+) *PasswordChangeCase {
+	return &PasswordChangeCase{
+		repository:             repository,
+		passwordValidator:      passwordValidator,
+		loginThrottler:         loginThrottler,
+		passwordPolicyChecker:  passwordPolicyChecker,
+		passwordHashGenerator:  passwordHashGenerator,
+		userTokensRevoker:      userTokensRevoker,
+		accessTokenIssuer:      accessTokenIssuer,
+		refreshTokenIssuer:     refreshTokenIssuer,
+		passwordChangeNotifier: passwordChangeNotifier,
+		sessionStarter:         sessionStarter,
+	}
+}
+
+// Use sets the new password and revokes all the user tokens.
+// The current client continues in a new session with the returned tokens.
+// Incorrect current passwords are throttled like failed logins, so a stolen access token can't be used to guess it.
+func (c *PasswordChangeCase) Use(ctx context.Context, in *dto.PasswordChangeIn) (*dto.PasswordChangeOut, error) {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	user, out, err := c.useInTx(ctx, in, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("tx committing error: %w", err)
+	}
+
+	c.passwordChangeNotifier.NotifyPasswordChanged(ctx, user)
+
+	return out, nil
+}
+
+func (c *PasswordChangeCase) useInTx(ctx context.Context, in *dto.PasswordChangeIn, tx TxCommitter) (*User, *dto.PasswordChangeOut, error) {
+	user, err := tx.User().Get(ctx, in.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("auth getting error: %w", err)
+	}
+	if user == nil {
+		return nil, nil, dto.ErrUserNotFound
+	}
+
+	throttleKey := userThrottleKey(user.ID)
+	if err = checkLogin(ctx, c.loginThrottler, throttleKey, in.IP); err != nil {
+		return nil, nil, err
+	}
+
+	if err = c.passwordValidator.Validate(in.CurrentPassword, user.PasswordHash); err != nil {
+		if err == dto.ErrIncorrectPassword {
+			return nil, nil, failLogin(ctx, c.loginThrottler, throttleKey, in.IP, err)
+		}
+		return nil, nil, fmt.Errorf("password validation error: %w", err)
+	}
+
+	if err = c.loginThrottler.Reset(ctx, throttleKey); err != nil {
+		return nil, nil, fmt.Errorf("login failures resetting error: %w", err)
+	}
+
+	if err = c.passwordPolicyChecker.Check(in.NewPassword, user.Name, user.Email); err != nil {
+		return nil, nil, err
+	}
+
+	if user.PasswordHash, err = c.passwordHashGenerator.Generate(in.NewPassword); err != nil {
+		return nil, nil, fmt.Errorf("password hash generation error: %w", err)
+	}
+
+	if err = tx.User().Save(ctx, user); err != nil {
+		return nil, nil, fmt.Errorf("auth saving error: %w", err)
+	}
+
+	if err = c.userTokensRevoker.RevokeUser(ctx, user.ID, tx); err != nil {
+		return nil, nil, fmt.Errorf("user tokens revoking error: %w", err)
+	}
+
+	err = addAuditEvent(ctx, tx.AuditLog(), &AuditEvent{
+		Type:      AuditEventPasswordChanged,
+		ActorID:   user.ID,
+		IP:        in.IP,
+		UserAgent: in.UserAgent,
+		Outcome:   AuditOutcomeSuccess,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accessToken, refreshToken, err := startSession(ctx, c.sessionStarter, c.accessTokenIssuer, c.refreshTokenIssuer,
+		user.ID, in.UserAgent, in.IP, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := &dto.PasswordChangeOut{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}
+	return user, out, nil
+}
+
+// userThrottleKey counts the failures by ID of the user instead of the email,
+// so guessing with a stolen access token doesn't lock the owner out of signing in.
+func userThrottleKey(userID int64) string {
+	return "user:" + strconv.FormatInt(userID, 10)
+}
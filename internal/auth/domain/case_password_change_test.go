@@ -0,0 +1,515 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestPasswordChangeCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository             = mock.NewMockRepository(ctrl)
+		passwordValidator      = mock.NewMockpasswordValidator(ctrl)
+		loginThrottler         = mock.NewMockloginThrottler(ctrl)
+		passwordPolicyChecker  = mock.NewMockpasswordPolicyChecker(ctrl)
+		passwordHashGenerator  = mock.NewMockpasswordHashGenerator(ctrl)
+		userTokensRevoker      = mock.NewMockuserTokensRevoker(ctrl)
+		accessTokenIssuer      = mock.NewMockaccessTokenIssuer(ctrl)
+		refreshTokenIssuer     = mock.NewMockrefreshTokenIssuer(ctrl)
+		passwordChangeNotifier = mock.NewMockpasswordChangeNotifier(ctrl)
+		sessionStarter         = mock.NewMocksessionStarter(ctrl)
+	)
+
+	var (
+		ctx                    = context.Background()
+		userID                 = int64(1)
+		throttleKey            = "user:1"
+		currentPassword        = "dummyCurrentPassword"
+		currentPasswordHash    = "dummyCurrentPasswordHash"
+		newPassword            = "dummyNewPassword"
+		newPasswordHash        = "dummyNewPasswordHash"
+		accessToken            = "dummyAccessToken"
+		refreshToken           = "dummyRefreshToken"
+		ip                     = "192.0.2.1"
+		userAgent              = "Mozilla/5.0"
+		session                = &domain.Session{ID: "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b", UserID: userID}
+		accessTokenObject      = &domain.AccessTokenObject{UserID: userID, SessionID: session.ID}
+		refreshTokenRepository = mock.NewMockRefreshTokenRepository(ctrl)
+		sessionRepository      = mock.NewMockSessionRepository(ctrl)
+		roleRepository         = mock.NewMockRoleRepository(ctrl)
+		access                 = &domain.UserAccess{Roles: []string{domain.RoleReader}, Permissions: []string{domain.PermissionPostRead}}
+		in                     = &dto.PasswordChangeIn{
+			UserID:          userID,
+			CurrentPassword: currentPassword,
+			NewPassword:     newPassword,
+			IP:              ip,
+			UserAgent:       userAgent,
+		}
+		noError = ""
+	)
+
+	userFactory := func() *domain.User {
+		return &domain.User{ID: userID, Name: "dummyName", Email: "dummyEmail@example.com", PasswordHash: currentPasswordHash, Active: true}
+	}
+
+	changedUserFactory := func() *domain.User {
+		user := userFactory()
+		user.PasswordHash = newPasswordHash
+		return user
+	}
+
+	expectBeginning := func() *mock.MockTxCommitter {
+		tx := mock.NewMockTxCommitter(ctrl)
+
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+
+		return tx
+	}
+
+	expectGetting := func(tx *mock.MockTxCommitter, user *domain.User, err error) {
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(user, err)
+				return r
+			})
+	}
+
+	expectThrottleChecking := func(err error) {
+		loginThrottler.EXPECT().
+			Check(gomock.Eq(ctx), gomock.Eq(throttleKey), gomock.Eq(ip)).
+			Return(err)
+	}
+
+	expectValidation := func(err error) {
+		expectThrottleChecking(nil)
+
+		passwordValidator.EXPECT().
+			Validate(gomock.Eq(currentPassword), gomock.Eq(currentPasswordHash)).
+			Return(err)
+	}
+
+	expectFailureAdding := func(err error) {
+		expectValidation(dto.ErrIncorrectPassword)
+
+		loginThrottler.EXPECT().
+			AddFailure(gomock.Eq(ctx), gomock.Eq(throttleKey), gomock.Eq(ip)).
+			Return(err)
+	}
+
+	expectValidated := func() {
+		expectValidation(nil)
+
+		loginThrottler.EXPECT().
+			Reset(gomock.Eq(ctx), gomock.Eq(throttleKey)).
+			Return(nil)
+	}
+
+	expectPolicyCheck := func(err error) {
+		passwordPolicyChecker.EXPECT().
+			Check(gomock.Eq(newPassword), gomock.Eq("dummyName"), gomock.Eq("dummyEmail@example.com")).
+			Return(err)
+	}
+
+	expectGeneration := func(err error) {
+		passwordHashGenerator.EXPECT().
+			Generate(gomock.Eq(newPassword)).
+			Return(newPasswordHash, err)
+	}
+
+	expectSaving := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Eq(changedUserFactory())).
+					Return(err)
+				return r
+			})
+	}
+
+	expectRevoking := func(tx *mock.MockTxCommitter, err error) {
+		userTokensRevoker.EXPECT().
+			RevokeUser(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(tx)).
+			Return(err)
+	}
+
+	expectAuditEventAdding := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      domain.AuditEventPasswordChanged,
+						ActorID:   userID,
+						IP:        ip,
+						UserAgent: userAgent,
+						Outcome:   domain.AuditOutcomeSuccess,
+					})).
+					Return(err)
+				return r
+			})
+	}
+
+	expectSession := func(tx *mock.MockTxCommitter, started *domain.Session, err error) {
+		tx.EXPECT().
+			Session().
+			Return(sessionRepository)
+
+		sessionStarter.EXPECT().
+			Start(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+			Return(started, err)
+	}
+
+	expectAccessToken := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			Role().
+			Return(roleRepository)
+
+		roleRepository.EXPECT().
+			GetUserAccess(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(access, nil)
+
+		accessTokenIssuer.EXPECT().
+			NewObject(gomock.Eq(userID), gomock.Eq(session.ID), gomock.Eq(access)).
+			Return(accessTokenObject)
+
+		accessTokenIssuer.EXPECT().
+			Sign(gomock.Eq(accessTokenObject)).
+			Return(accessToken, err)
+	}
+
+	expectRefreshToken := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			RefreshToken().
+			Return(refreshTokenRepository)
+
+		refreshTokenIssuer.EXPECT().
+			Issue(gomock.Eq(ctx), gomock.Eq(session), gomock.Eq(refreshTokenRepository)).
+			Return(refreshToken, err)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.PasswordChangeOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectValidated()
+				expectPolicyCheck(nil)
+				expectGeneration(nil)
+				expectSaving(tx, nil)
+				expectRevoking(tx, nil)
+				expectAuditEventAdding(tx, nil)
+				expectSession(tx, session, nil)
+				expectAccessToken(tx, nil)
+				expectRefreshToken(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+
+				passwordChangeNotifier.EXPECT().
+					NotifyPasswordChanged(gomock.Eq(ctx), gomock.Eq(changedUserFactory()))
+			},
+			expOut: &dto.PasswordChangeOut{
+				AccessToken:  accessToken,
+				RefreshToken: refreshToken,
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "error on getting user",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth getting error: dummy error",
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, nil, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrUserNotFound.Error(),
+		},
+		{
+			name: "incorrect current password",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectFailureAdding(nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrIncorrectPassword.Error(),
+		},
+		{
+			name: "current password guessing throttled",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectThrottleChecking(&dto.LoginThrottledError{RetryAfter: time.Minute})
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: (&dto.LoginThrottledError{RetryAfter: time.Minute}).Error(),
+		},
+		{
+			name: "error on checking throttling",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectThrottleChecking(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "login throttling check error: dummy error",
+		},
+		{
+			name: "error on adding login failure",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectFailureAdding(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "login failure adding error: dummy error",
+		},
+		{
+			name: "error on validating current password",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectValidation(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "password validation error: dummy error",
+		},
+		{
+			name: "error on resetting login failures",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectValidation(nil)
+
+				loginThrottler.EXPECT().
+					Reset(gomock.Eq(ctx), gomock.Eq(throttleKey)).
+					Return(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "login failures resetting error: dummy error",
+		},
+		{
+			name: "password policy violated",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectValidated()
+				expectPolicyCheck(&dto.PasswordPolicyError{Violations: []string{dto.PasswordRuleNotBreached}})
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "password policy violated: not_breached",
+		},
+		{
+			name: "error on generating password hash",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectValidated()
+				expectPolicyCheck(nil)
+				expectGeneration(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "password hash generation error: dummy error",
+		},
+		{
+			name: "error on saving user",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectValidated()
+				expectPolicyCheck(nil)
+				expectGeneration(nil)
+				expectSaving(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth saving error: dummy error",
+		},
+		{
+			name: "error on revoking user tokens",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectValidated()
+				expectPolicyCheck(nil)
+				expectGeneration(nil)
+				expectSaving(tx, nil)
+				expectRevoking(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "user tokens revoking error: dummy error",
+		},
+		{
+			name: "error on adding audit event",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectValidated()
+				expectPolicyCheck(nil)
+				expectGeneration(nil)
+				expectSaving(tx, nil)
+				expectRevoking(tx, nil)
+				expectAuditEventAdding(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "error on starting session",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectValidated()
+				expectPolicyCheck(nil)
+				expectGeneration(nil)
+				expectSaving(tx, nil)
+				expectRevoking(tx, nil)
+				expectAuditEventAdding(tx, nil)
+				expectSession(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "session starting error: dummy error",
+		},
+		{
+			name: "error on creating access token",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectValidated()
+				expectPolicyCheck(nil)
+				expectGeneration(nil)
+				expectSaving(tx, nil)
+				expectRevoking(tx, nil)
+				expectAuditEventAdding(tx, nil)
+				expectSession(tx, session, nil)
+				expectAccessToken(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "access token creation error: dummy error",
+		},
+		{
+			name: "error on creating refresh token",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectValidated()
+				expectPolicyCheck(nil)
+				expectGeneration(nil)
+				expectSaving(tx, nil)
+				expectRevoking(tx, nil)
+				expectAuditEventAdding(tx, nil)
+				expectSession(tx, session, nil)
+				expectAccessToken(tx, nil)
+				expectRefreshToken(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "refresh token creation error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(tx, userFactory(), nil)
+				expectValidated()
+				expectPolicyCheck(nil)
+				expectGeneration(nil)
+				expectSaving(tx, nil)
+				expectRevoking(tx, nil)
+				expectAuditEventAdding(tx, nil)
+				expectSession(tx, session, nil)
+				expectAccessToken(tx, nil)
+				expectRefreshToken(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewPasswordChangeCase(
+				repository,
+				passwordValidator,
+				loginThrottler,
+				passwordPolicyChecker,
+				passwordHashGenerator,
+				userTokensRevoker,
+				accessTokenIssuer,
+				refreshTokenIssuer,
+				passwordChangeNotifier,
+				sessionStarter,
+			)
+			out, err := u.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
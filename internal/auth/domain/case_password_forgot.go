@@ -0,0 +1,64 @@
+//go:generate mockgen -source=case_password_forgot.go -destination=mock/case_password_forgot.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type passwordResetTokenSender interface {
+	SendToken(ctx context.Context, user *User, tx TxCommitter) error
+}
+
+type PasswordForgotCase struct {
+	repository               Repository
+	passwordResetTokenSender passwordResetTokenSender
+}
+
+func NewPasswordForgotCase(
+	repository Repository,
+	passwordResetTokenSender passwordResetTokenSender,
+) *PasswordForgotCase {
+	return &PasswordForgotCase{
+		repository:               repository,
+		passwordResetTokenSender: passwordResetTokenSender,
+	}
+}
+
+// Use sends a password reset token to the email.
+// Unknown emails are ignored to not disclose registered ones.
+func (c *PasswordForgotCase) Use(ctx context.Context, in *dto.PasswordForgotIn) error {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	if err = c.useInTx(ctx, in, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *PasswordForgotCase) useInTx(ctx context.Context, in *dto.PasswordForgotIn, tx TxCommitter) error {
+	user, err := tx.User().GetByEmail(ctx, NormalizeEmail(in.Email))
+	if err != nil {
+		return fmt.Errorf("auth getting by email error: %w", err)
+	}
+	if user == nil {
+		return nil
+	}
+
+	if err = c.passwordResetTokenSender.SendToken(ctx, user, tx); err != nil {
+		return fmt.Errorf("password reset token sending error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+//go:generate mockgen -source=case_password_reset.go -destination=mock/case_password_reset.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type passwordResetTokenConsumer interface {
+	ConsumeToken(ctx context.Context, token string, tx TxCommitter) (int64, error)
+}
+
+type PasswordResetCase struct {
+	repository                 Repository
+	passwordResetTokenConsumer passwordResetTokenConsumer
+	passwordPolicyChecker      passwordPolicyChecker
+	passwordHashGenerator      passwordHashGenerator
+	userTokensRevoker          userTokensRevoker
+}
+
+func NewPasswordResetCase(
+	repository Repository,
+	passwordResetTokenConsumer passwordResetTokenConsumer,
+	passwordPolicyChecker passwordPolicyChecker,
+	passwordHashGenerator passwordHashGenerator,
+	userTokensRevoker userTokensRevoker,
+) *PasswordResetCase {
+	return &PasswordResetCase{
+		repository:                 repository,
+		passwordResetTokenConsumer: passwordResetTokenConsumer,
+		passwordPolicyChecker:      passwordPolicyChecker,
+		passwordHashGenerator:      passwordHashGenerator,
+		userTokensRevoker:          userTokensRevoker,
+	}
+}
+
+// Use sets the new password and logs the user out on all devices.
+func (c *PasswordResetCase) Use(ctx context.Context, in *dto.PasswordResetIn) error {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	if err = c.useInTx(ctx, in, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *PasswordResetCase) useInTx(ctx context.Context, in *dto.PasswordResetIn, tx TxCommitter) error {
+	userID, err := c.passwordResetTokenConsumer.ConsumeToken(ctx, in.Token, tx)
+	if err != nil {
+		if err == dto.ErrInvalidPasswordResetToken {
+			return err
+		}
+		return fmt.Errorf("password reset token consuming error: %w", err)
+	}
+
+	user, err := tx.User().Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("auth getting error: %w", err)
+	}
+	if user == nil {
+		return dto.ErrInvalidPasswordResetToken
+	}
+
+	// the token stays valid, since the transaction is rolled back
+	if err = c.passwordPolicyChecker.Check(in.Password, user.Name, user.Email); err != nil {
+		return err
+	}
+
+	if user.PasswordHash, err = c.passwordHashGenerator.Generate(in.Password); err != nil {
+		return fmt.Errorf("password hash generation error: %w", err)
+	}
+
+	if err = tx.User().Save(ctx, user); err != nil {
+		return fmt.Errorf("auth saving error: %w", err)
+	}
+
+	if err = c.userTokensRevoker.RevokeUser(ctx, userID, tx); err != nil {
+		return fmt.Errorf("user tokens revoking error: %w", err)
+	}
+
+	return addAuditEvent(ctx, tx.AuditLog(), &AuditEvent{
+		Type:      AuditEventPasswordReset,
+		ActorID:   userID,
+		IP:        in.IP,
+		UserAgent: in.UserAgent,
+		Outcome:   AuditOutcomeSuccess,
+	})
+}
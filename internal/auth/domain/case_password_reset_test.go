@@ -0,0 +1,329 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestPasswordResetCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository                 = mock.NewMockRepository(ctrl)
+		passwordResetTokenConsumer = mock.NewMockpasswordResetTokenConsumer(ctrl)
+		passwordPolicyChecker      = mock.NewMockpasswordPolicyChecker(ctrl)
+		passwordHashGenerator      = mock.NewMockpasswordHashGenerator(ctrl)
+		userTokensRevoker          = mock.NewMockuserTokensRevoker(ctrl)
+	)
+
+	var (
+		ctx          = context.Background()
+		userID       = int64(1)
+		token        = "dummyToken"
+		password     = "dummyPassword"
+		passwordHash = "dummyPasswordHash"
+		in           = &dto.PasswordResetIn{Token: token, Password: password, IP: "192.0.2.1", UserAgent: "dummyUserAgent"}
+		noError      = ""
+	)
+
+	userFactory := func() *domain.User {
+		return &domain.User{ID: userID, Name: "dummyName", Email: "dummyEmail@example.com", PasswordHash: "oldHash", Active: true}
+	}
+
+	expectBeginning := func() *mock.MockTxCommitter {
+		tx := mock.NewMockTxCommitter(ctrl)
+
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+
+		return tx
+	}
+
+	expectConsuming := func(tx *mock.MockTxCommitter, userID int64, err error) {
+		passwordResetTokenConsumer.EXPECT().
+			ConsumeToken(gomock.Eq(ctx), gomock.Eq(token), gomock.Eq(tx)).
+			Return(userID, err)
+	}
+
+	expectGetting := func(tx *mock.MockTxCommitter, user *domain.User, err error) {
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(user, err)
+				return r
+			})
+	}
+
+	expectPolicyCheck := func(err error) {
+		passwordPolicyChecker.EXPECT().
+			Check(gomock.Eq(password), gomock.Eq("dummyName"), gomock.Eq("dummyEmail@example.com")).
+			Return(err)
+	}
+
+	expectSaving := func(tx *mock.MockTxCommitter, err error) {
+		expUser := userFactory()
+		expUser.PasswordHash = passwordHash
+
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Eq(expUser)).
+					Return(err)
+				return r
+			})
+	}
+
+	expectAuditEventAdding := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      domain.AuditEventPasswordReset,
+						ActorID:   userID,
+						IP:        in.IP,
+						UserAgent: in.UserAgent,
+						Outcome:   domain.AuditOutcomeSuccess,
+					})).
+					Return(err)
+				return r
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				tx := expectBeginning()
+				expectConsuming(tx, userID, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectPolicyCheck(nil)
+
+				passwordHashGenerator.EXPECT().
+					Generate(gomock.Eq(password)).
+					Return(passwordHash, nil)
+
+				expectSaving(tx, nil)
+
+				userTokensRevoker.EXPECT().
+					RevokeUser(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(tx)).
+					Return(nil)
+
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "invalid token",
+			setup: func() {
+				tx := expectBeginning()
+				expectConsuming(tx, 0, dto.ErrInvalidPasswordResetToken)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInvalidPasswordResetToken.Error(),
+		},
+		{
+			name: "error on consuming token",
+			setup: func() {
+				tx := expectBeginning()
+				expectConsuming(tx, 0, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "password reset token consuming error: dummy error",
+		},
+		{
+			name: "error on getting user",
+			setup: func() {
+				tx := expectBeginning()
+				expectConsuming(tx, userID, nil)
+				expectGetting(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth getting error: dummy error",
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				tx := expectBeginning()
+				expectConsuming(tx, userID, nil)
+				expectGetting(tx, nil, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInvalidPasswordResetToken.Error(),
+		},
+		{
+			name: "password policy violated",
+			setup: func() {
+				tx := expectBeginning()
+				expectConsuming(tx, userID, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectPolicyCheck(&dto.PasswordPolicyError{Violations: []string{dto.PasswordRuleMinLength}})
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "password policy violated: min_length",
+		},
+		{
+			name: "error on generating password hash",
+			setup: func() {
+				tx := expectBeginning()
+				expectConsuming(tx, userID, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectPolicyCheck(nil)
+
+				passwordHashGenerator.EXPECT().
+					Generate(gomock.Eq(password)).
+					Return("", errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "password hash generation error: dummy error",
+		},
+		{
+			name: "error on saving user",
+			setup: func() {
+				tx := expectBeginning()
+				expectConsuming(tx, userID, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectPolicyCheck(nil)
+
+				passwordHashGenerator.EXPECT().
+					Generate(gomock.Eq(password)).
+					Return(passwordHash, nil)
+
+				expectSaving(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth saving error: dummy error",
+		},
+		{
+			name: "error on revoking user tokens",
+			setup: func() {
+				tx := expectBeginning()
+				expectConsuming(tx, userID, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectPolicyCheck(nil)
+
+				passwordHashGenerator.EXPECT().
+					Generate(gomock.Eq(password)).
+					Return(passwordHash, nil)
+
+				expectSaving(tx, nil)
+
+				userTokensRevoker.EXPECT().
+					RevokeUser(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(tx)).
+					Return(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "user tokens revoking error: dummy error",
+		},
+		{
+			name: "error on adding audit event",
+			setup: func() {
+				tx := expectBeginning()
+				expectConsuming(tx, userID, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectPolicyCheck(nil)
+
+				passwordHashGenerator.EXPECT().
+					Generate(gomock.Eq(password)).
+					Return(passwordHash, nil)
+
+				expectSaving(tx, nil)
+
+				userTokensRevoker.EXPECT().
+					RevokeUser(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(tx)).
+					Return(nil)
+
+				expectAuditEventAdding(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				tx := expectBeginning()
+				expectConsuming(tx, userID, nil)
+				expectGetting(tx, userFactory(), nil)
+				expectPolicyCheck(nil)
+
+				passwordHashGenerator.EXPECT().
+					Generate(gomock.Eq(password)).
+					Return(passwordHash, nil)
+
+				expectSaving(tx, nil)
+
+				userTokensRevoker.EXPECT().
+					RevokeUser(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(tx)).
+					Return(nil)
+
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewPasswordResetCase(
+				repository,
+				passwordResetTokenConsumer,
+				passwordPolicyChecker,
+				passwordHashGenerator,
+				userTokensRevoker,
+			)
+			err := u.Use(ctx, in)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+//go:generate mockgen -source=case_personal_access_token_create.go -destination=mock/case_personal_access_token_create.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type personalAccessTokenIssuer interface {
+	Issue(
+		ctx context.Context,
+		userID int64,
+		name string,
+		scopes []string,
+		expiresAt *time.Time,
+		repository PersonalAccessTokenRepository,
+	) (*PersonalAccessToken, string, error)
+}
+
+type PersonalAccessTokenCreateCase struct {
+	repository                Repository
+	personalAccessTokenIssuer personalAccessTokenIssuer
+}
+
+func NewPersonalAccessTokenCreateCase(
+	repository Repository,
+	personalAccessTokenService personalAccessTokenIssuer,
+) *PersonalAccessTokenCreateCase {
+	return &PersonalAccessTokenCreateCase{
+		repository:                repository,
+		personalAccessTokenIssuer: personalAccessTokenService,
+	}
+}
+
+// Use creates the token with the scopes, which must be granted to the request creating it.
+func (c *PersonalAccessTokenCreateCase) Use(ctx context.Context, in *dto.PersonalAccessTokenCreateIn) (*dto.PersonalAccessTokenCreateOut, error) {
+	for _, scope := range in.Scopes {
+		if !contains(in.Permissions, scope) {
+			return nil, dto.ErrScopeNotGranted
+		}
+	}
+
+	token, raw, err := c.personalAccessTokenIssuer.Issue(ctx, in.UserID, in.Name, in.Scopes, in.ExpiresAt,
+		c.repository.PersonalAccessToken())
+	if err != nil {
+		return nil, fmt.Errorf("personal access token issuing error: %w", err)
+	}
+
+	return &dto.PersonalAccessTokenCreateOut{
+		ID:    token.ID,
+		Token: raw,
+	}, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
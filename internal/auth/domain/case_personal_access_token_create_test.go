@@ -0,0 +1,114 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestPersonalAccessTokenCreateCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository                    = mock.NewMockRepository(ctrl)
+		personalAccessTokenRepository = mock.NewMockPersonalAccessTokenRepository(ctrl)
+		personalAccessTokenIssuer     = mock.NewMockpersonalAccessTokenIssuer(ctrl)
+	)
+
+	var (
+		ctx       = context.Background()
+		userID    = int64(1)
+		name      = "CI"
+		expiresAt = time.Now().Add(24 * time.Hour)
+		tokenID   = "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b"
+		token     = "bpat_dummyToken"
+		inFactory = func() *dto.PersonalAccessTokenCreateIn {
+			return &dto.PersonalAccessTokenCreateIn{
+				UserID:      userID,
+				Permissions: []string{domain.PermissionPostRead, domain.PermissionPostWrite},
+				Name:        name,
+				Scopes:      []string{domain.PermissionPostWrite},
+				ExpiresAt:   &expiresAt,
+			}
+		}
+		noError = ""
+	)
+
+	expectIssuing := func(issued *domain.PersonalAccessToken, token string, err error) {
+		repository.EXPECT().
+			PersonalAccessToken().
+			Return(personalAccessTokenRepository)
+
+		personalAccessTokenIssuer.EXPECT().
+			Issue(
+				gomock.Eq(ctx),
+				gomock.Eq(userID),
+				gomock.Eq(name),
+				gomock.Eq([]string{domain.PermissionPostWrite}),
+				gomock.Eq(&expiresAt),
+				gomock.Eq(personalAccessTokenRepository),
+			).
+			Return(issued, token, err)
+	}
+
+	tests := []struct {
+		name   string
+		in     *dto.PersonalAccessTokenCreateIn
+		setup  func()
+		expOut *dto.PersonalAccessTokenCreateOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			in:   inFactory(),
+			setup: func() {
+				expectIssuing(&domain.PersonalAccessToken{ID: tokenID}, token, nil)
+			},
+			expOut: &dto.PersonalAccessTokenCreateOut{ID: tokenID, Token: token},
+			expErr: noError,
+		},
+		{
+			name: "scope not granted",
+			in: func() *dto.PersonalAccessTokenCreateIn {
+				in := inFactory()
+				in.Scopes = []string{domain.PermissionPostWrite, domain.PermissionUserManage}
+				return in
+			}(),
+			expErr: dto.ErrScopeNotGranted.Error(),
+		},
+		{
+			name: "error on issuing token",
+			in:   inFactory(),
+			setup: func() {
+				expectIssuing(nil, "", errors.New("dummy error"))
+			},
+			expErr: "personal access token issuing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+
+			u := domain.NewPersonalAccessTokenCreateCase(repository, personalAccessTokenIssuer)
+			out, err := u.Use(ctx, tt.in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
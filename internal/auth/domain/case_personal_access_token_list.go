@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type PersonalAccessTokenListCase struct {
+	repository Repository
+}
+
+func NewPersonalAccessTokenListCase(repository Repository) *PersonalAccessTokenListCase {
+	return &PersonalAccessTokenListCase{
+		repository: repository,
+	}
+}
+
+// Use returns the active tokens of the user without the tokens themselves.
+func (c *PersonalAccessTokenListCase) Use(ctx context.Context, in *dto.PersonalAccessTokenListIn) (*dto.PersonalAccessTokenListOut, error) {
+	tokens, err := c.repository.PersonalAccessToken().GetActive(ctx, in.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("active personal access tokens getting error: %w", err)
+	}
+
+	out := &dto.PersonalAccessTokenListOut{Tokens: make([]dto.PersonalAccessToken, 0, len(tokens))}
+	for _, token := range tokens {
+		out.Tokens = append(out.Tokens, dto.PersonalAccessToken{
+			ID:        token.ID,
+			Name:      token.Name,
+			Scopes:    token.Scopes,
+			CreatedAt: token.CreatedAt,
+			ExpiresAt: token.ExpiresAt,
+		})
+	}
+
+	return out, nil
+}
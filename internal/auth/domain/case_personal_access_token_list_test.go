@@ -0,0 +1,110 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestPersonalAccessTokenListCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository                    = mock.NewMockRepository(ctrl)
+		personalAccessTokenRepository = mock.NewMockPersonalAccessTokenRepository(ctrl)
+	)
+
+	var (
+		ctx       = context.Background()
+		userID    = int64(1)
+		now       = time.Now()
+		expiresAt = now.Add(24 * time.Hour)
+		in        = &dto.PersonalAccessTokenListIn{UserID: userID}
+		noError   = ""
+	)
+
+	expectGetting := func(tokens []*domain.PersonalAccessToken, err error) {
+		repository.EXPECT().
+			PersonalAccessToken().
+			Return(personalAccessTokenRepository)
+
+		personalAccessTokenRepository.EXPECT().
+			GetActive(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(tokens, err)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.PersonalAccessTokenListOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				expectGetting([]*domain.PersonalAccessToken{
+					{
+						ID:        "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b",
+						UserID:    userID,
+						Name:      "CI",
+						TokenHash: "dummyTokenHash",
+						Scopes:    []string{domain.PermissionPostWrite},
+						CreatedAt: now,
+						ExpiresAt: &expiresAt,
+					},
+				}, nil)
+			},
+			expOut: &dto.PersonalAccessTokenListOut{
+				Tokens: []dto.PersonalAccessToken{
+					{
+						ID:        "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b",
+						Name:      "CI",
+						Scopes:    []string{domain.PermissionPostWrite},
+						CreatedAt: now,
+						ExpiresAt: &expiresAt,
+					},
+				},
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: no tokens",
+			setup: func() {
+				expectGetting(nil, nil)
+			},
+			expOut: &dto.PersonalAccessTokenListOut{Tokens: []dto.PersonalAccessToken{}},
+			expErr: noError,
+		},
+		{
+			name: "error on getting tokens",
+			setup: func() {
+				expectGetting(nil, errors.New("dummy error"))
+			},
+			expErr: "active personal access tokens getting error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewPersonalAccessTokenListCase(repository)
+			out, err := u.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type PersonalAccessTokenRevokeCase struct {
+	repository Repository
+}
+
+func NewPersonalAccessTokenRevokeCase(repository Repository) *PersonalAccessTokenRevokeCase {
+	return &PersonalAccessTokenRevokeCase{
+		repository: repository,
+	}
+}
+
+// Use revokes the token of the user, tokens of other users and already ended ones are not found.
+func (c *PersonalAccessTokenRevokeCase) Use(ctx context.Context, in *dto.PersonalAccessTokenRevokeIn) error {
+	token, err := c.repository.PersonalAccessToken().Get(ctx, in.ID)
+	if err != nil {
+		return fmt.Errorf("personal access token getting error: %w", err)
+	}
+	if token == nil || token.UserID != in.UserID || !token.IsActive(time.Now()) {
+		return dto.ErrPersonalAccessTokenNotFound
+	}
+
+	if err = c.repository.PersonalAccessToken().Revoke(ctx, in.ID); err != nil {
+		return fmt.Errorf("personal access token revoking error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestPersonalAccessTokenRevokeCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository                    = mock.NewMockRepository(ctrl)
+		personalAccessTokenRepository = mock.NewMockPersonalAccessTokenRepository(ctrl)
+	)
+
+	var (
+		ctx     = context.Background()
+		userID  = int64(1)
+		tokenID = "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b"
+		past    = time.Now().Add(-time.Minute)
+		in      = &dto.PersonalAccessTokenRevokeIn{UserID: userID, ID: tokenID}
+		noError = ""
+	)
+
+	repository.EXPECT().
+		PersonalAccessToken().
+		Return(personalAccessTokenRepository).
+		AnyTimes()
+
+	expectGetting := func(token *domain.PersonalAccessToken, err error) {
+		personalAccessTokenRepository.EXPECT().
+			Get(gomock.Eq(ctx), gomock.Eq(tokenID)).
+			Return(token, err)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				expectGetting(&domain.PersonalAccessToken{ID: tokenID, UserID: userID}, nil)
+
+				personalAccessTokenRepository.EXPECT().
+					Revoke(gomock.Eq(ctx), gomock.Eq(tokenID)).
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on getting token",
+			setup: func() {
+				expectGetting(nil, errors.New("dummy error"))
+			},
+			expErr: "personal access token getting error: dummy error",
+		},
+		{
+			name: "token not found",
+			setup: func() {
+				expectGetting(nil, nil)
+			},
+			expErr: dto.ErrPersonalAccessTokenNotFound.Error(),
+		},
+		{
+			name: "token of another user",
+			setup: func() {
+				expectGetting(&domain.PersonalAccessToken{ID: tokenID, UserID: 2}, nil)
+			},
+			expErr: dto.ErrPersonalAccessTokenNotFound.Error(),
+		},
+		{
+			name: "token already revoked",
+			setup: func() {
+				expectGetting(&domain.PersonalAccessToken{ID: tokenID, UserID: userID, Revoked: true}, nil)
+			},
+			expErr: dto.ErrPersonalAccessTokenNotFound.Error(),
+		},
+		{
+			name: "token expired",
+			setup: func() {
+				expectGetting(&domain.PersonalAccessToken{ID: tokenID, UserID: userID, ExpiresAt: &past}, nil)
+			},
+			expErr: dto.ErrPersonalAccessTokenNotFound.Error(),
+		},
+		{
+			name: "error on revoking token",
+			setup: func() {
+				expectGetting(&domain.PersonalAccessToken{ID: tokenID, UserID: userID}, nil)
+
+				personalAccessTokenRepository.EXPECT().
+					Revoke(gomock.Eq(ctx), gomock.Eq(tokenID)).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "personal access token revoking error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewPersonalAccessTokenRevokeCase(repository)
+			err := u.Use(ctx, in)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
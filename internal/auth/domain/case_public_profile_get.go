@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type PublicProfileGetCase struct {
+	repository Repository
+}
+
+func NewPublicProfileGetCase(repository Repository) *PublicProfileGetCase {
+	return &PublicProfileGetCase{
+		repository: repository,
+	}
+}
+
+// Use finds the profile by the handle case-insensitively, since handles are stored in lowercase.
+func (c *PublicProfileGetCase) Use(ctx context.Context, in *dto.PublicProfileGetIn) (*dto.PublicProfileGetOut, error) {
+	profile, err := c.repository.User().GetProfileByHandle(ctx, strings.ToLower(in.Handle))
+	if err != nil {
+		return nil, fmt.Errorf("profile getting by handle error: %w", err)
+	}
+	if profile == nil {
+		return nil, dto.ErrProfileNotFound
+	}
+
+	out := &dto.PublicProfileGetOut{
+		Profile: dto.PublicProfile{
+			Handle:      profile.Handle,
+			DisplayName: profile.DisplayName,
+			Bio:         profile.Bio,
+			Website:     profile.Website,
+			AvatarURL:   profile.AvatarURL,
+		},
+	}
+	return out, nil
+}
@@ -0,0 +1,98 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestPublicProfileGetCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository     = mock.NewMockRepository(ctrl)
+		userRepository = mock.NewMockUserRepository(ctrl)
+	)
+
+	var (
+		ctx     = context.Background()
+		in      = &dto.PublicProfileGetIn{Handle: "Ivanov"}
+		noError = ""
+	)
+
+	expectGetting := func(profile *domain.Profile, err error) {
+		repository.EXPECT().
+			User().
+			Return(userRepository)
+
+		userRepository.EXPECT().
+			GetProfileByHandle(gomock.Eq(ctx), gomock.Eq("ivanov")).
+			Return(profile, err)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.PublicProfileGetOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				expectGetting(&domain.Profile{
+					UserID:      1,
+					Handle:      "ivanov",
+					DisplayName: "Ivan",
+					Bio:         "Writer",
+					Website:     "https://ivanov.example.com",
+					AvatarURL:   "https://cdn.example.com/ivanov.png",
+				}, nil)
+			},
+			expOut: &dto.PublicProfileGetOut{Profile: dto.PublicProfile{
+				Handle:      "ivanov",
+				DisplayName: "Ivan",
+				Bio:         "Writer",
+				Website:     "https://ivanov.example.com",
+				AvatarURL:   "https://cdn.example.com/ivanov.png",
+			}},
+			expErr: noError,
+		},
+		{
+			name: "error on getting profile",
+			setup: func() {
+				expectGetting(nil, errors.New("dummy error"))
+			},
+			expErr: "profile getting by handle error: dummy error",
+		},
+		{
+			name: "profile not found",
+			setup: func() {
+				expectGetting(nil, nil)
+			},
+			expErr: dto.ErrProfileNotFound.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewPublicProfileGetCase(repository)
+			out, err := c.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
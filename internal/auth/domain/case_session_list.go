@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type SessionListCase struct {
+	repository Repository
+}
+
+func NewSessionListCase(repository Repository) *SessionListCase {
+	return &SessionListCase{
+		repository: repository,
+	}
+}
+
+// Use returns the active sessions of the user, the session of the request is marked as current.
+func (c *SessionListCase) Use(ctx context.Context, in *dto.SessionListIn) (*dto.SessionListOut, error) {
+	sessions, err := c.repository.Session().GetActive(ctx, in.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("active sessions getting error: %w", err)
+	}
+
+	out := &dto.SessionListOut{Sessions: make([]dto.Session, 0, len(sessions))}
+	for _, session := range sessions {
+		out.Sessions = append(out.Sessions, dto.Session{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			Current:    session.ID == in.SessionID,
+		})
+	}
+
+	return out, nil
+}
@@ -0,0 +1,97 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestSessionListCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository        = mock.NewMockRepository(ctrl)
+		sessionRepository = mock.NewMockSessionRepository(ctrl)
+	)
+
+	var (
+		ctx       = context.Background()
+		userID    = int64(1)
+		sessionID = "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b"
+		otherID   = "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+		now       = time.Now()
+		in        = &dto.SessionListIn{UserID: userID, SessionID: sessionID}
+		noError   = ""
+	)
+
+	expectGetting := func(sessions []*domain.Session, err error) {
+		repository.EXPECT().
+			Session().
+			Return(sessionRepository)
+
+		sessionRepository.EXPECT().
+			GetActive(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(sessions, err)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.SessionListOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				expectGetting([]*domain.Session{
+					{ID: otherID, UserID: userID, UserAgent: "curl/8.4.0", IP: "192.0.2.2", CreatedAt: now, LastUsedAt: now},
+					{ID: sessionID, UserID: userID, UserAgent: "Firefox", IP: "192.0.2.1", CreatedAt: now, LastUsedAt: now},
+				}, nil)
+			},
+			expOut: &dto.SessionListOut{Sessions: []dto.Session{
+				{ID: otherID, UserAgent: "curl/8.4.0", IP: "192.0.2.2", CreatedAt: now, LastUsedAt: now},
+				{ID: sessionID, UserAgent: "Firefox", IP: "192.0.2.1", CreatedAt: now, LastUsedAt: now, Current: true},
+			}},
+			expErr: noError,
+		},
+		{
+			name: "no sessions",
+			setup: func() {
+				expectGetting(nil, nil)
+			},
+			expOut: &dto.SessionListOut{Sessions: []dto.Session{}},
+			expErr: noError,
+		},
+		{
+			name: "error on getting sessions",
+			setup: func() {
+				expectGetting(nil, errors.New("dummy error"))
+			},
+			expErr: "active sessions getting error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewSessionListCase(repository)
+			out, err := c.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+//go:generate mockgen -source=case_session_revoke.go -destination=mock/case_session_revoke.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type sessionRevoker interface {
+	Revoke(ctx context.Context, id string, userID int64, tx TxCommitter) error
+}
+
+type SessionRevokeCase struct {
+	repository     Repository
+	sessionRevoker sessionRevoker
+}
+
+func NewSessionRevokeCase(repository Repository, sessionService sessionRevoker) *SessionRevokeCase {
+	return &SessionRevokeCase{
+		repository:     repository,
+		sessionRevoker: sessionService,
+	}
+}
+
+// Use signs the user out of the session, the access tokens of the session are rejected right away.
+func (c *SessionRevokeCase) Use(ctx context.Context, in *dto.SessionRevokeIn) error {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	if err = c.sessionRevoker.Revoke(ctx, in.ID, in.UserID, tx); err != nil {
+		tx.Rollback()
+		if err == dto.ErrSessionNotFound {
+			return err
+		}
+		return fmt.Errorf("session revoking error: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return nil
+}
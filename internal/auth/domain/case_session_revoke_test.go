@@ -0,0 +1,113 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestSessionRevokeCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository     = mock.NewMockRepository(ctrl)
+		sessionRevoker = mock.NewMocksessionRevoker(ctrl)
+	)
+
+	var (
+		ctx       = context.Background()
+		userID    = int64(1)
+		sessionID = "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b"
+		in        = &dto.SessionRevokeIn{UserID: userID, ID: sessionID}
+		noError   = ""
+	)
+
+	expectRevoking := func(err error) *mock.MockTxCommitter {
+		tx := mock.NewMockTxCommitter(ctrl)
+
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+
+		sessionRevoker.EXPECT().
+			Revoke(gomock.Eq(ctx), gomock.Eq(sessionID), gomock.Eq(userID), gomock.Eq(tx)).
+			Return(err)
+
+		return tx
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				tx := expectRevoking(nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "session not found",
+			setup: func() {
+				tx := expectRevoking(dto.ErrSessionNotFound)
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrSessionNotFound.Error(),
+		},
+		{
+			name: "error on revoking",
+			setup: func() {
+				tx := expectRevoking(errors.New("dummy error"))
+				tx.EXPECT().Rollback()
+			},
+			expErr: "session revoking error: dummy error",
+		},
+		{
+			name: "error on tx committing",
+			setup: func() {
+				tx := expectRevoking(nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewSessionRevokeCase(repository, sessionRevoker)
+			err := c.Use(ctx, in)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+//go:generate mockgen -source=case_two_factor_confirm.go -destination=mock/case_two_factor_confirm.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type twoFactorEnabler interface {
+	Enable(ctx context.Context, userID int64, code string, repository TwoFactorRepository) ([]string, error)
+}
+
+type TwoFactorConfirmCase struct {
+	repository       Repository
+	twoFactorEnabler twoFactorEnabler
+}
+
+func NewTwoFactorConfirmCase(repository Repository, twoFactorEnabler twoFactorEnabler) *TwoFactorConfirmCase {
+	return &TwoFactorConfirmCase{
+		repository:       repository,
+		twoFactorEnabler: twoFactorEnabler,
+	}
+}
+
+// Use enables two-factor authentication and returns the recovery codes.
+func (c *TwoFactorConfirmCase) Use(ctx context.Context, in *dto.TwoFactorConfirmIn) (*dto.TwoFactorConfirmOut, error) {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	out, err := c.useInTx(ctx, in, tx)
+	if err != nil && err != dto.ErrInvalidTwoFactorCode {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// the failed attempt has to be kept
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, fmt.Errorf("tx committing error: %w", commitErr)
+	}
+
+	return out, err
+}
+
+func (c *TwoFactorConfirmCase) useInTx(ctx context.Context, in *dto.TwoFactorConfirmIn, tx TxCommitter) (*dto.TwoFactorConfirmOut, error) {
+	codes, err := c.twoFactorEnabler.Enable(ctx, in.UserID, in.Code, tx.TwoFactor())
+	if err != nil {
+		switch err {
+		case dto.ErrTwoFactorNotEnabled, dto.ErrTwoFactorAlreadyEnabled, dto.ErrInvalidTwoFactorCode:
+			return nil, err
+		default:
+			return nil, fmt.Errorf("two-factor enabling error: %w", err)
+		}
+	}
+
+	return &dto.TwoFactorConfirmOut{RecoveryCodes: codes}, nil
+}
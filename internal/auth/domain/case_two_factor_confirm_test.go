@@ -0,0 +1,143 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestTwoFactorConfirmCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository          = mock.NewMockRepository(ctrl)
+		twoFactorRepository = mock.NewMockTwoFactorRepository(ctrl)
+		twoFactorEnabler    = mock.NewMocktwoFactorEnabler(ctrl)
+	)
+
+	var (
+		ctx           = context.Background()
+		userID        = int64(1)
+		code          = "123456"
+		recoveryCodes = []string{"abcde-fghij", "klmno-pqrst"}
+		in            = &dto.TwoFactorConfirmIn{UserID: userID, Code: code}
+		noError       = ""
+	)
+
+	expectEnabling := func(codes []string, err error) *mock.MockTxCommitter {
+		tx := mock.NewMockTxCommitter(ctrl)
+
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+
+		tx.EXPECT().
+			TwoFactor().
+			Return(twoFactorRepository)
+
+		twoFactorEnabler.EXPECT().
+			Enable(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(code), gomock.Eq(twoFactorRepository)).
+			Return(codes, err)
+
+		return tx
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.TwoFactorConfirmOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				tx := expectEnabling(recoveryCodes, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.TwoFactorConfirmOut{RecoveryCodes: recoveryCodes},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "two-factor not enrolled",
+			setup: func() {
+				tx := expectEnabling(nil, dto.ErrTwoFactorNotEnabled)
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrTwoFactorNotEnabled.Error(),
+		},
+		{
+			name: "two-factor already enabled",
+			setup: func() {
+				tx := expectEnabling(nil, dto.ErrTwoFactorAlreadyEnabled)
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrTwoFactorAlreadyEnabled.Error(),
+		},
+		{
+			name: "invalid code",
+			setup: func() {
+				// the failed attempt is kept
+				tx := expectEnabling(nil, dto.ErrInvalidTwoFactorCode)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: dto.ErrInvalidTwoFactorCode.Error(),
+		},
+		{
+			name: "error on enabling",
+			setup: func() {
+				tx := expectEnabling(nil, errors.New("dummy error"))
+				tx.EXPECT().Rollback()
+			},
+			expErr: "two-factor enabling error: dummy error",
+		},
+		{
+			name: "error on tx committing",
+			setup: func() {
+				tx := expectEnabling(recoveryCodes, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewTwoFactorConfirmCase(repository, twoFactorEnabler)
+			out, err := c.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
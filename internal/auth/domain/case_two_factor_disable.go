@@ -0,0 +1,58 @@
+//go:generate mockgen -source=case_two_factor_disable.go -destination=mock/case_two_factor_disable.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type twoFactorDisabler interface {
+	Disable(ctx context.Context, userID int64, code string, repository TwoFactorRepository) error
+}
+
+type TwoFactorDisableCase struct {
+	repository        Repository
+	twoFactorDisabler twoFactorDisabler
+}
+
+func NewTwoFactorDisableCase(repository Repository, twoFactorDisabler twoFactorDisabler) *TwoFactorDisableCase {
+	return &TwoFactorDisableCase{
+		repository:        repository,
+		twoFactorDisabler: twoFactorDisabler,
+	}
+}
+
+// Use disables two-factor authentication, the code or a recovery code is required.
+func (c *TwoFactorDisableCase) Use(ctx context.Context, in *dto.TwoFactorDisableIn) error {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	err = c.useInTx(ctx, in, tx)
+	if err != nil && err != dto.ErrInvalidTwoFactorCode {
+		tx.Rollback()
+		return err
+	}
+
+	// the failed attempt has to be kept
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("tx committing error: %w", commitErr)
+	}
+
+	return err
+}
+
+func (c *TwoFactorDisableCase) useInTx(ctx context.Context, in *dto.TwoFactorDisableIn, tx TxCommitter) error {
+	if err := c.twoFactorDisabler.Disable(ctx, in.UserID, in.Code, tx.TwoFactor()); err != nil {
+		switch err {
+		case dto.ErrTwoFactorNotEnabled, dto.ErrInvalidTwoFactorCode, dto.ErrTooManyTwoFactorAttempts:
+			return err
+		default:
+			return fmt.Errorf("two-factor disabling error: %w", err)
+		}
+	}
+	return nil
+}
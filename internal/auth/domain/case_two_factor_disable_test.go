@@ -0,0 +1,138 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestTwoFactorDisableCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository          = mock.NewMockRepository(ctrl)
+		twoFactorRepository = mock.NewMockTwoFactorRepository(ctrl)
+		twoFactorDisabler   = mock.NewMocktwoFactorDisabler(ctrl)
+	)
+
+	var (
+		ctx     = context.Background()
+		userID  = int64(1)
+		code    = "123456"
+		in      = &dto.TwoFactorDisableIn{UserID: userID, Code: code}
+		noError = ""
+	)
+
+	expectDisabling := func(err error) *mock.MockTxCommitter {
+		tx := mock.NewMockTxCommitter(ctrl)
+
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+
+		tx.EXPECT().
+			TwoFactor().
+			Return(twoFactorRepository)
+
+		twoFactorDisabler.EXPECT().
+			Disable(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(code), gomock.Eq(twoFactorRepository)).
+			Return(err)
+
+		return tx
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				tx := expectDisabling(nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "two-factor not enabled",
+			setup: func() {
+				tx := expectDisabling(dto.ErrTwoFactorNotEnabled)
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrTwoFactorNotEnabled.Error(),
+		},
+		{
+			name: "invalid code",
+			setup: func() {
+				// the failed attempt is kept
+				tx := expectDisabling(dto.ErrInvalidTwoFactorCode)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: dto.ErrInvalidTwoFactorCode.Error(),
+		},
+		{
+			name: "too many attempts",
+			setup: func() {
+				tx := expectDisabling(dto.ErrTooManyTwoFactorAttempts)
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrTooManyTwoFactorAttempts.Error(),
+		},
+		{
+			name: "error on disabling",
+			setup: func() {
+				tx := expectDisabling(errors.New("dummy error"))
+				tx.EXPECT().Rollback()
+			},
+			expErr: "two-factor disabling error: dummy error",
+		},
+		{
+			name: "error on tx committing",
+			setup: func() {
+				tx := expectDisabling(nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewTwoFactorDisableCase(repository, twoFactorDisabler)
+			err := c.Use(ctx, in)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+//go:generate mockgen -source=case_two_factor_enroll.go -destination=mock/case_two_factor_enroll.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type twoFactorEnroller interface {
+	Enroll(ctx context.Context, user *User, repository TwoFactorRepository) (*dto.TwoFactorEnrollOut, error)
+}
+
+type TwoFactorEnrollCase struct {
+	repository        Repository
+	twoFactorEnroller twoFactorEnroller
+}
+
+func NewTwoFactorEnrollCase(repository Repository, twoFactorEnroller twoFactorEnroller) *TwoFactorEnrollCase {
+	return &TwoFactorEnrollCase{
+		repository:        repository,
+		twoFactorEnroller: twoFactorEnroller,
+	}
+}
+
+// Use generates a new TOTP secret for the user, it has to be confirmed to enable two-factor authentication.
+func (c *TwoFactorEnrollCase) Use(ctx context.Context, in *dto.TwoFactorEnrollIn) (*dto.TwoFactorEnrollOut, error) {
+	user, err := c.repository.User().Get(ctx, in.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("auth getting error: %w", err)
+	}
+	if user == nil {
+		return nil, dto.ErrUserNotFound
+	}
+
+	out, err := c.twoFactorEnroller.Enroll(ctx, user, c.repository.TwoFactor())
+	if err != nil {
+		if err == dto.ErrTwoFactorAlreadyEnabled {
+			return nil, err
+		}
+		return nil, fmt.Errorf("two-factor enrollment error: %w", err)
+	}
+
+	return out, nil
+}
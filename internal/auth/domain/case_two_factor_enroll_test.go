@@ -0,0 +1,148 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestTwoFactorEnrollCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository          = mock.NewMockRepository(ctrl)
+		userRepository      = mock.NewMockUserRepository(ctrl)
+		twoFactorRepository = mock.NewMockTwoFactorRepository(ctrl)
+		twoFactorEnroller   = mock.NewMocktwoFactorEnroller(ctrl)
+	)
+
+	var (
+		ctx     = context.Background()
+		userID  = int64(1)
+		user    = &domain.User{ID: userID, Email: "dummyEmail@example.com", Active: true}
+		in      = &dto.TwoFactorEnrollIn{UserID: userID}
+		out     = &dto.TwoFactorEnrollOut{Secret: "dummySecret", URI: "otpauth://totp/dummy"}
+		noError = ""
+	)
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.TwoFactorEnrollOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				repository.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(user, nil)
+
+				repository.EXPECT().
+					TwoFactor().
+					Return(twoFactorRepository)
+
+				twoFactorEnroller.EXPECT().
+					Enroll(gomock.Eq(ctx), gomock.Eq(user), gomock.Eq(twoFactorRepository)).
+					Return(out, nil)
+			},
+			expOut: out,
+			expErr: noError,
+		},
+		{
+			name: "error on getting user",
+			setup: func() {
+				repository.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "auth getting error: dummy error",
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				repository.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(nil, nil)
+			},
+			expErr: dto.ErrUserNotFound.Error(),
+		},
+		{
+			name: "two-factor already enabled",
+			setup: func() {
+				repository.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(user, nil)
+
+				repository.EXPECT().
+					TwoFactor().
+					Return(twoFactorRepository)
+
+				twoFactorEnroller.EXPECT().
+					Enroll(gomock.Eq(ctx), gomock.Eq(user), gomock.Eq(twoFactorRepository)).
+					Return(nil, dto.ErrTwoFactorAlreadyEnabled)
+			},
+			expErr: dto.ErrTwoFactorAlreadyEnabled.Error(),
+		},
+		{
+			name: "error on enrollment",
+			setup: func() {
+				repository.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(user, nil)
+
+				repository.EXPECT().
+					TwoFactor().
+					Return(twoFactorRepository)
+
+				twoFactorEnroller.EXPECT().
+					Enroll(gomock.Eq(ctx), gomock.Eq(user), gomock.Eq(twoFactorRepository)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "two-factor enrollment error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewTwoFactorEnrollCase(repository, twoFactorEnroller)
+			out, err := c.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -9,7 +9,7 @@ import (
 )
 
 type userActivator interface {
-	Activate(ctx context.Context, activationCode string, tx TxCommitter) error
+	Activate(ctx context.Context, activationCode string, tx TxCommitter) (int64, error)
 }
 
 type UserActivateCase struct {
@@ -33,9 +33,9 @@ func (c *UserActivateCase) Use(ctx context.Context, in *dto.UserActivateIn) erro
 		return fmt.Errorf("tx beginning error: %w", err)
 	}
 
-	if err = c.activator.Activate(ctx, in.Code, tx); err != nil {
+	if err = c.useInTx(ctx, in, tx); err != nil {
 		tx.Rollback()
-		return fmt.Errorf("activation error: %w", err)
+		return err
 	}
 
 	if err = tx.Commit(); err != nil {
@@ -44,3 +44,23 @@ func (c *UserActivateCase) Use(ctx context.Context, in *dto.UserActivateIn) erro
 
 	return nil
 }
+
+func (c *UserActivateCase) useInTx(ctx context.Context, in *dto.UserActivateIn, tx TxCommitter) error {
+	userID, err := c.activator.Activate(ctx, in.Code, tx)
+	if err != nil {
+		switch err {
+		case dto.ErrUserActivationCodeNotFound, dto.ErrExpiredUserActivationCode, dto.ErrUserNotFound:
+			return err
+		default:
+			return fmt.Errorf("activation error: %w", err)
+		}
+	}
+
+	return addAuditEvent(ctx, tx.AuditLog(), &AuditEvent{
+		Type:      AuditEventUserActivated,
+		ActorID:   userID,
+		IP:        in.IP,
+		UserAgent: in.UserAgent,
+		Outcome:   AuditOutcomeSuccess,
+	})
+}
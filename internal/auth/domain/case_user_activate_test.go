@@ -24,10 +24,29 @@ func TestActivateUsecase(t *testing.T) {
 	var (
 		ctx     = context.Background()
 		code    = "dummyCode"
-		in      = &dto.UserActivateIn{Code: code}
+		userID  = int64(1)
+		in      = &dto.UserActivateIn{Code: code, IP: "192.0.2.1", UserAgent: "dummyUserAgent"}
 		noError = ""
 	)
 
+	expectAuditEventAdding := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      domain.AuditEventUserActivated,
+						ActorID:   userID,
+						IP:        in.IP,
+						UserAgent: in.UserAgent,
+						Outcome:   domain.AuditOutcomeSuccess,
+					})).
+					Return(err)
+				return r
+			})
+	}
+
 	tests := []struct {
 		name   string
 		setup  func()
@@ -44,7 +63,9 @@ func TestActivateUsecase(t *testing.T) {
 
 				userActivator.EXPECT().
 					Activate(gomock.Eq(ctx), gomock.Eq(code), gomock.Eq(tx)).
-					Return(nil)
+					Return(userID, nil)
+
+				expectAuditEventAdding(tx, nil)
 
 				tx.EXPECT().
 					Commit().
@@ -72,13 +93,87 @@ func TestActivateUsecase(t *testing.T) {
 
 				userActivator.EXPECT().
 					Activate(gomock.Eq(ctx), gomock.Eq(code), gomock.Eq(tx)).
-					Return(errors.New("dummy error"))
+					Return(int64(0), errors.New("dummy error"))
 
 				tx.EXPECT().
 					Rollback()
 			},
 			expErr: "activation error: dummy error",
 		},
+		{
+			name: "activation code not found",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				userActivator.EXPECT().
+					Activate(gomock.Eq(ctx), gomock.Eq(code), gomock.Eq(tx)).
+					Return(int64(0), dto.ErrUserActivationCodeNotFound)
+
+				tx.EXPECT().
+					Rollback()
+			},
+			expErr: dto.ErrUserActivationCodeNotFound.Error(),
+		},
+		{
+			name: "expired activation code",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				userActivator.EXPECT().
+					Activate(gomock.Eq(ctx), gomock.Eq(code), gomock.Eq(tx)).
+					Return(int64(0), dto.ErrExpiredUserActivationCode)
+
+				tx.EXPECT().
+					Rollback()
+			},
+			expErr: dto.ErrExpiredUserActivationCode.Error(),
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				userActivator.EXPECT().
+					Activate(gomock.Eq(ctx), gomock.Eq(code), gomock.Eq(tx)).
+					Return(int64(0), dto.ErrUserNotFound)
+
+				tx.EXPECT().
+					Rollback()
+			},
+			expErr: dto.ErrUserNotFound.Error(),
+		},
+		{
+			name: "error on audit event adding",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				userActivator.EXPECT().
+					Activate(gomock.Eq(ctx), gomock.Eq(code), gomock.Eq(tx)).
+					Return(userID, nil)
+
+				expectAuditEventAdding(tx, errors.New("dummy error"))
+
+				tx.EXPECT().
+					Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
 		{
 			name: "error on tx committing",
 			setup: func() {
@@ -90,7 +185,9 @@ func TestActivateUsecase(t *testing.T) {
 
 				userActivator.EXPECT().
 					Activate(gomock.Eq(ctx), gomock.Eq(code), gomock.Eq(tx)).
-					Return(nil)
+					Return(userID, nil)
+
+				expectAuditEventAdding(tx, nil)
 
 				tx.EXPECT().
 					Commit().
@@ -0,0 +1,66 @@
+//go:generate mockgen -source=case_user_activation_resend.go -destination=mock/case_user_activation_resend.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type activationCodeResender interface {
+	ResendCode(ctx context.Context, user *User, tx TxCommitter) error
+}
+
+type UserActivationResendCase struct {
+	repository             Repository
+	activationCodeResender activationCodeResender
+}
+
+func NewUserActivationResendCase(
+	repository Repository,
+	activationCodeResender activationCodeResender,
+) *UserActivationResendCase {
+	return &UserActivationResendCase{
+		repository:             repository,
+		activationCodeResender: activationCodeResender,
+	}
+}
+
+// Use sends a new activation code if the user is waiting for activation.
+// Unknown and activated users are ignored to not disclose them,
+// as well as the users who got a code recently, since the limit would disclose them too.
+func (c *UserActivationResendCase) Use(ctx context.Context, in *dto.UserActivationResendIn) error {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	if err = c.useInTx(ctx, in, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *UserActivationResendCase) useInTx(ctx context.Context, in *dto.UserActivationResendIn, tx TxCommitter) error {
+	user, err := tx.User().GetByEmail(ctx, NormalizeEmail(in.Email))
+	if err != nil {
+		return fmt.Errorf("auth getting by email error: %w", err)
+	}
+	if user == nil {
+		return nil
+	}
+
+	switch err = c.activationCodeResender.ResendCode(ctx, user, tx); err {
+	case nil, dto.ErrUserActivationCodeNotFound, dto.ErrActivationCodeResendLimit:
+		return nil
+	default:
+		return fmt.Errorf("activation code resending error: %w", err)
+	}
+}
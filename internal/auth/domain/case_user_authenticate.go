@@ -6,57 +6,328 @@ import (
 	"fmt"
 
 	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/repository"
 )
 
 type passwordValidator interface {
 	Validate(password, hash string) error
 }
 
+type passwordRehasher interface {
+	NeedsRehash(hash string) bool
+	Generate(password string) (string, error)
+}
+
 type accessTokenIssuer interface {
-	NewObject(userID int64) *AccessTokenObject
+	NewObject(userID int64, sessionID string, access *UserAccess) *AccessTokenObject
 	Sign(object *AccessTokenObject) (string, error)
 }
 
+type refreshTokenIssuer interface {
+	Issue(ctx context.Context, session *Session, repository RefreshTokenRepository) (string, error)
+}
+
+type sessionStarter interface {
+	Start(ctx context.Context, userID int64, userAgent, ip string, repository SessionRepository) (*Session, error)
+}
+
+type loginThrottler interface {
+	Check(ctx context.Context, email, ip string) error
+	AddFailure(ctx context.Context, email, ip string) error
+	Reset(ctx context.Context, email string) error
+}
+
+type twoFactorChallengeIssuer interface {
+	IsEnabled(ctx context.Context, userID int64, repository TwoFactorRepository) (bool, error)
+	IssueChallenge(ctx context.Context, userID int64, repository TwoFactorChallengeRepository) (string, error)
+}
+
 type UserAuthenticateCase struct {
-	userRepository    UserRepository
-	passwordValidator passwordValidator
-	accessTokenIssuer accessTokenIssuer
+	repository               Repository
+	passwordValidator        passwordValidator
+	passwordRehasher         passwordRehasher
+	accessTokenIssuer        accessTokenIssuer
+	refreshTokenIssuer       refreshTokenIssuer
+	loginThrottler           loginThrottler
+	twoFactorChallengeIssuer twoFactorChallengeIssuer
+	sessionStarter           sessionStarter
 }
 
 func NewUserAuthenticateCase(
-	userRepository UserRepository,
+	repository Repository,
 	passwordHashValidator passwordValidator,
+	passwordHashRehasher passwordRehasher,
 	accessTokenService accessTokenIssuer,
+	refreshTokenService refreshTokenIssuer,
+	loginThrottleService loginThrottler,
+	twoFactorService twoFactorChallengeIssuer,
+	sessionService sessionStarter,
 ) *UserAuthenticateCase {
 	return &UserAuthenticateCase{
-		userRepository:    userRepository,
-		passwordValidator: passwordHashValidator,
-		accessTokenIssuer: accessTokenService,
+		repository:               repository,
+		passwordValidator:        passwordHashValidator,
+		passwordRehasher:         passwordHashRehasher,
+		accessTokenIssuer:        accessTokenService,
+		refreshTokenIssuer:       refreshTokenService,
+		loginThrottler:           loginThrottleService,
+		twoFactorChallengeIssuer: twoFactorService,
+		sessionStarter:           sessionService,
 	}
 }
 
+// Use returns only the two-factor token if the user has two-factor authentication enabled,
+// the tokens are returned by UserAuthenticateTwoFactorCase then.
 func (c *UserAuthenticateCase) Use(ctx context.Context, in *dto.UserAuthenticateIn) (*dto.UserAuthenticateOut, error) {
-	user, err := c.userRepository.GetByEmail(ctx, in.Email)
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	out, err := c.useInTx(ctx, in, tx)
+	if err != nil && !isLoginFailure(err) {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// the failed login has to be kept in the audit log
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, fmt.Errorf("tx committing error: %w", commitErr)
+	}
+
+	return out, err
+}
+
+func (c *UserAuthenticateCase) useInTx(ctx context.Context, in *dto.UserAuthenticateIn, tx TxCommitter) (*dto.UserAuthenticateOut, error) {
+	out, userID, err := c.authenticate(ctx, in, tx)
+	// the login is recorded once the second factor is passed
+	if err == nil && out.TwoFactorToken != "" {
+		return out, nil
+	}
+
+	if err = recordLogin(ctx, tx, userID, in.IP, in.UserAgent, err); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// authenticate returns ID of the user as soon as the user is found, so the failed logins are attributed to the user.
+func (c *UserAuthenticateCase) authenticate(ctx context.Context, in *dto.UserAuthenticateIn, tx TxCommitter) (*dto.UserAuthenticateOut, int64, error) {
+	email := NormalizeEmail(in.Email)
+
+	if err := checkLogin(ctx, c.loginThrottler, email, in.IP); err != nil {
+		return nil, 0, err
+	}
+
+	user, err := tx.User().GetByEmail(ctx, email)
 	if err != nil {
-		return nil, fmt.Errorf("auth getting by email error: %w", err)
+		return nil, 0, fmt.Errorf("auth getting by email error: %w", err)
 	}
 	if user == nil {
-		return nil, dto.ErrUserNotFound
+		return nil, 0, failLogin(ctx, c.loginThrottler, email, in.IP, dto.ErrUserNotFound)
 	}
 
 	if err = c.passwordValidator.Validate(in.Password, user.PasswordHash); err != nil {
-		return nil, fmt.Errorf("password validate by hash error: %w", err)
+		if err == dto.ErrIncorrectPassword {
+			return nil, user.ID, failLogin(ctx, c.loginThrottler, email, in.IP, err)
+		}
+		return nil, user.ID, fmt.Errorf("password validate by hash error: %w", err)
+	}
+
+	// checked after the password to not disclose the activation state to others
+	if !user.Active {
+		return nil, user.ID, dto.ErrUserNotActivated
+	}
+
+	if err = checkUserSuspension(ctx, tx.Suspension(), user.ID); err != nil {
+		return nil, user.ID, err
 	}
 
-	accessToken, err := newAccessToken(c.accessTokenIssuer, user.ID)
+	if c.passwordRehasher.NeedsRehash(user.PasswordHash) {
+		if err = c.rehashPassword(ctx, user, in.Password, tx); err != nil {
+			return nil, user.ID, err
+		}
+	}
+
+	twoFactorEnabled, err := c.twoFactorChallengeIssuer.IsEnabled(ctx, user.ID, tx.TwoFactor())
 	if err != nil {
-		return nil, fmt.Errorf("access token creation error: %w", err)
+		return nil, user.ID, fmt.Errorf("two-factor checking error: %w", err)
+	}
+	if twoFactorEnabled {
+		// the login failures are kept until the second factor is passed
+		token, err := c.twoFactorChallengeIssuer.IssueChallenge(ctx, user.ID, tx.TwoFactorChallenge())
+		if err != nil {
+			return nil, user.ID, fmt.Errorf("two-factor challenge issuing error: %w", err)
+		}
+		return &dto.UserAuthenticateOut{TwoFactorToken: token}, user.ID, nil
 	}
 
-	return &dto.UserAuthenticateOut{AccessToken: accessToken}, nil
+	if err = c.loginThrottler.Reset(ctx, email); err != nil {
+		return nil, user.ID, fmt.Errorf("login failures resetting error: %w", err)
+	}
+
+	accessToken, refreshToken, err := startSession(ctx, c.sessionStarter, c.accessTokenIssuer, c.refreshTokenIssuer,
+		user.ID, in.UserAgent, in.IP, tx)
+	if err != nil {
+		return nil, user.ID, err
+	}
+
+	out := &dto.UserAuthenticateOut{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}
+	return out, user.ID, nil
 }
 
-func newAccessToken(issuer accessTokenIssuer, userID int64) (string, error) {
-	object := issuer.NewObject(userID)
+// rehashPassword upgrades the hash of the password to the current algorithm and parameters,
+// it's only possible while the password is known.
+func (c *UserAuthenticateCase) rehashPassword(ctx context.Context, user *User, password string, tx TxCommitter) error {
+	hash, err := c.passwordRehasher.Generate(password)
+	if err != nil {
+		return fmt.Errorf("password hash generation error: %w", err)
+	}
+
+	user.PasswordHash = hash
+	if err = tx.User().Save(ctx, user); err != nil {
+		return fmt.Errorf("auth saving error: %w", err)
+	}
+
+	return nil
+}
+
+func checkLogin(ctx context.Context, throttler loginThrottler, email, ip string) error {
+	if err := throttler.Check(ctx, email, ip); err != nil {
+		if _, ok := err.(*dto.LoginThrottledError); ok {
+			return err
+		}
+		return fmt.Errorf("login throttling check error: %w", err)
+	}
+	return nil
+}
+
+// failLogin counts the failed attempt and returns the reason of the failure.
+func failLogin(ctx context.Context, throttler loginThrottler, email, ip string, reason error) error {
+	if err := throttler.AddFailure(ctx, email, ip); err != nil {
+		return fmt.Errorf("login failure adding error: %w", err)
+	}
+	return reason
+}
+
+// isLoginFailure reports whether the login is failed because of the client, not the server.
+func isLoginFailure(err error) bool {
+	switch err {
+	case dto.ErrUserNotFound, dto.ErrIncorrectPassword, dto.ErrUserNotActivated, dto.ErrUserSuspended,
+		dto.ErrInvalidTwoFactorCode:
+		return true
+	}
+	_, ok := err.(*dto.LoginThrottledError)
+	return ok
+}
+
+// recordLogin adds the successful or failed login to the audit log and returns loginErr,
+// the errors caused by the server are returned without recording.
+func recordLogin(ctx context.Context, repository repositoryGetter, userID int64, ip, userAgent string, loginErr error) error {
+	if loginErr != nil && !isLoginFailure(loginErr) {
+		return loginErr
+	}
+
+	event := &AuditEvent{
+		Type:      AuditEventUserLogin,
+		ActorID:   userID,
+		IP:        ip,
+		UserAgent: userAgent,
+		Outcome:   AuditOutcomeSuccess,
+	}
+	if loginErr != nil {
+		event.Outcome = AuditOutcomeFailure
+		event.Details = loginErr.Error()
+	}
+
+	if err := addAuditEvent(ctx, repository.AuditLog(), event); err != nil {
+		return err
+	}
+	return loginErr
+}
+
+func addAuditEvent(ctx context.Context, repository AuditLogRepository, event *AuditEvent) error {
+	if err := repository.Add(ctx, event); err != nil {
+		return fmt.Errorf("audit event adding error: %w", err)
+	}
+	return nil
+}
+
+// startSession starts the session of the user on the client and issues the tokens of the session.
+func startSession(
+	ctx context.Context,
+	starter sessionStarter,
+	accessIssuer accessTokenIssuer,
+	refreshIssuer refreshTokenIssuer,
+	userID int64,
+	userAgent, ip string,
+	repository repositoryGetter,
+) (string, string, error) {
+	session, err := starter.Start(ctx, userID, userAgent, ip, repository.Session())
+	if err != nil {
+		return "", "", fmt.Errorf("session starting error: %w", err)
+	}
+
+	accessToken, err := newAccessToken(ctx, accessIssuer, userID, session.ID, repository.Role())
+	if err != nil {
+		return "", "", fmt.Errorf("access token creation error: %w", err)
+	}
+
+	refreshToken, err := refreshIssuer.Issue(ctx, session, repository.RefreshToken())
+	if err != nil {
+		return "", "", fmt.Errorf("refresh token creation error: %w", err)
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// newAccessToken issues the access token carrying the current roles of the user.
+func newAccessToken(
+	ctx context.Context,
+	issuer accessTokenIssuer,
+	userID int64,
+	sessionID string,
+	roleRepository RoleRepository,
+) (string, error) {
+	access, err := roleRepository.GetUserAccess(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("user access getting error: %w", err)
+	}
+
+	object := issuer.NewObject(userID, sessionID, access)
 	return issuer.Sign(object)
 }
+
+// addUser saves the new user with the default role, the registration is recorded to the audit log.
+// dto.ErrEmailIsBusy is returned if the email is taken concurrently after its check.
+func addUser(ctx context.Context, user *User, ip, userAgent string, tx TxCommitter) error {
+	if err := tx.User().Save(ctx, user); err != nil {
+		if err == repository.ErrUniqueViolation {
+			return dto.ErrEmailIsBusy
+		}
+		return fmt.Errorf("auth saving error: %w", err)
+	}
+
+	if err := tx.Role().AddUserRole(ctx, user.ID, DefaultRole); err != nil {
+		return fmt.Errorf("user role adding error: %w", err)
+	}
+
+	events := []*AuditEvent{
+		{Type: AuditEventUserRegistered, Details: user.Email},
+		{Type: AuditEventRoleGranted, Details: DefaultRole},
+	}
+	for _, event := range events {
+		event.ActorID = user.ID
+		event.IP = ip
+		event.UserAgent = userAgent
+		event.Outcome = AuditOutcomeSuccess
+		if err := addAuditEvent(ctx, tx.AuditLog(), event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
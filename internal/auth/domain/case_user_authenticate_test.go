@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -17,29 +18,87 @@ func TestAuthenticateUsecase(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	var (
-		userRepository    = mock.NewMockUserRepository(ctrl)
-		passwordValidator = mock.NewMockpasswordValidator(ctrl)
-		accessTokenIssuer = mock.NewMockaccessTokenIssuer(ctrl)
+		repository         = mock.NewMockRepository(ctrl)
+		tx                 = mock.NewMockTxCommitter(ctrl)
+		userRepository     = mock.NewMockUserRepository(ctrl)
+		passwordValidator  = mock.NewMockpasswordValidator(ctrl)
+		passwordRehasher   = mock.NewMockpasswordRehasher(ctrl)
+		accessTokenIssuer  = mock.NewMockaccessTokenIssuer(ctrl)
+		refreshTokenIssuer = mock.NewMockrefreshTokenIssuer(ctrl)
+		loginThrottler     = mock.NewMockloginThrottler(ctrl)
+		twoFactorIssuer    = mock.NewMocktwoFactorChallengeIssuer(ctrl)
+		sessionStarter     = mock.NewMocksessionStarter(ctrl)
 	)
 
 	var (
 		ctx      = context.Background()
 		email    = "dummyEmail@example.com"
 		password = "dummyPassword!%"
+		ip       = "192.0.2.1"
 		user     = &domain.User{
 			ID:           1,
 			Email:        email,
 			PasswordHash: "dummyPasswordHash",
+			Active:       true,
 		}
-		accessTokenObject = &domain.AccessTokenObject{}
-		accessToken       = "dummyAccessToken"
-		in                = &dto.UserAuthenticateIn{
-			Email:    email,
-			Password: password,
+		userAgent              = "Mozilla/5.0"
+		session                = &domain.Session{ID: "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b", UserID: user.ID}
+		sessionRepository      = mock.NewMockSessionRepository(ctrl)
+		roleRepository         = mock.NewMockRoleRepository(ctrl)
+		access                 = &domain.UserAccess{Roles: []string{domain.RoleReader}, Permissions: []string{domain.PermissionPostRead}}
+		accessTokenObject      = &domain.AccessTokenObject{}
+		accessToken            = "dummyAccessToken"
+		refreshToken           = "dummyRefreshToken"
+		refreshTokenRepository = mock.NewMockRefreshTokenRepository(ctrl)
+		twoFactorRepository    = mock.NewMockTwoFactorRepository(ctrl)
+		challengeRepository    = mock.NewMockTwoFactorChallengeRepository(ctrl)
+		twoFactorToken         = "dummyTwoFactorToken"
+		in                     = &dto.UserAuthenticateIn{
+			Email:     "dummyEmail@Example.com ",
+			Password:  password,
+			IP:        ip,
+			UserAgent: userAgent,
 		}
 		noError = ""
 	)
 
+	expectBeginning := func() {
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+	}
+
+	expectLoginRecording := func(userID int64, outcome, details string, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      domain.AuditEventUserLogin,
+						ActorID:   userID,
+						IP:        ip,
+						UserAgent: userAgent,
+						Outcome:   outcome,
+						Details:   details,
+					})).
+					Return(err)
+				return r
+			})
+	}
+
+	expectSuspension := func(suspension *domain.Suspension, err error) {
+		tx.EXPECT().
+			Suspension().
+			DoAndReturn(func() domain.SuspensionRepository {
+				r := mock.NewMockSuspensionRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(suspension, err)
+				return r
+			})
+	}
+
 	tests := []struct {
 		name   string
 		setup  func()
@@ -49,6 +108,16 @@ func TestAuthenticateUsecase(t *testing.T) {
 		{
 			name: "happy path",
 			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
 				userRepository.EXPECT().
 					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
 					Return(user, nil)
@@ -57,38 +126,393 @@ func TestAuthenticateUsecase(t *testing.T) {
 					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
 					Return(nil)
 
+				expectSuspension(nil, nil)
+
+				passwordRehasher.EXPECT().
+					NeedsRehash(gomock.Eq(user.PasswordHash)).
+					Return(false)
+
+				tx.EXPECT().
+					TwoFactor().
+					Return(twoFactorRepository)
+
+				twoFactorIssuer.EXPECT().
+					IsEnabled(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(twoFactorRepository)).
+					Return(false, nil)
+
+				loginThrottler.EXPECT().
+					Reset(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(nil)
+
+				tx.EXPECT().
+					Session().
+					Return(sessionRepository)
+
+				sessionStarter.EXPECT().
+					Start(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+					Return(session, nil)
+
+				tx.EXPECT().
+					Role().
+					Return(roleRepository)
+
+				roleRepository.EXPECT().
+					GetUserAccess(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(access, nil)
+
+				accessTokenIssuer.EXPECT().
+					NewObject(gomock.Eq(user.ID), gomock.Eq(session.ID), gomock.Eq(access)).
+					Return(accessTokenObject)
+
+				accessTokenIssuer.EXPECT().
+					Sign(gomock.Eq(accessTokenObject)).
+					Return(accessToken, nil)
+
+				tx.EXPECT().
+					RefreshToken().
+					Return(refreshTokenRepository)
+
+				refreshTokenIssuer.EXPECT().
+					Issue(gomock.Eq(ctx), gomock.Eq(session), gomock.Eq(refreshTokenRepository)).
+					Return(refreshToken, nil)
+
+				expectLoginRecording(user.ID, domain.AuditOutcomeSuccess, "", nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.UserAuthenticateOut{
+				AccessToken:  accessToken,
+				RefreshToken: refreshToken,
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path: password rehashed",
+			setup: func() {
+				expectBeginning()
+
+				legacyUser := *user
+				legacyUser.PasswordHash = "dummyLegacyPasswordHash"
+				rehashedUser := *user
+				rehashedUser.PasswordHash = "dummyRehashedPasswordHash"
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(&legacyUser, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(legacyUser.PasswordHash)).
+					Return(nil)
+
+				expectSuspension(nil, nil)
+
+				passwordRehasher.EXPECT().
+					NeedsRehash(gomock.Eq(legacyUser.PasswordHash)).
+					Return(true)
+
+				passwordRehasher.EXPECT().
+					Generate(gomock.Eq(password)).
+					Return(rehashedUser.PasswordHash, nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Eq(&rehashedUser)).
+					Return(nil)
+
+				tx.EXPECT().
+					TwoFactor().
+					Return(twoFactorRepository)
+
+				twoFactorIssuer.EXPECT().
+					IsEnabled(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(twoFactorRepository)).
+					Return(false, nil)
+
+				loginThrottler.EXPECT().
+					Reset(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(nil)
+
+				tx.EXPECT().
+					Session().
+					Return(sessionRepository)
+
+				sessionStarter.EXPECT().
+					Start(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+					Return(session, nil)
+
+				tx.EXPECT().
+					Role().
+					Return(roleRepository)
+
+				roleRepository.EXPECT().
+					GetUserAccess(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(access, nil)
+
 				accessTokenIssuer.EXPECT().
-					NewObject(gomock.Eq(user.ID)).
+					NewObject(gomock.Eq(user.ID), gomock.Eq(session.ID), gomock.Eq(access)).
 					Return(accessTokenObject)
 
 				accessTokenIssuer.EXPECT().
 					Sign(gomock.Eq(accessTokenObject)).
 					Return(accessToken, nil)
+
+				tx.EXPECT().
+					RefreshToken().
+					Return(refreshTokenRepository)
+
+				refreshTokenIssuer.EXPECT().
+					Issue(gomock.Eq(ctx), gomock.Eq(session), gomock.Eq(refreshTokenRepository)).
+					Return(refreshToken, nil)
+
+				expectLoginRecording(user.ID, domain.AuditOutcomeSuccess, "", nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.UserAuthenticateOut{
+				AccessToken:  accessToken,
+				RefreshToken: refreshToken,
 			},
-			expOut: &dto.UserAuthenticateOut{AccessToken: accessToken},
 			expErr: noError,
 		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "login throttled",
+			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(&dto.LoginThrottledError{RetryAfter: time.Minute})
+
+				expectLoginRecording(0, domain.AuditOutcomeFailure, (&dto.LoginThrottledError{RetryAfter: time.Minute}).Error(), nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: (&dto.LoginThrottledError{RetryAfter: time.Minute}).Error(),
+		},
+		{
+			name: "error on checking login throttling",
+			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "login throttling check error: dummy error",
+		},
 		{
 			name: "error on getting auth",
 			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
 				userRepository.EXPECT().
 					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
 					Return(nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
 			},
 			expErr: "auth getting by email error: dummy error",
 		},
 		{
 			name: "auth not found",
 			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
 				userRepository.EXPECT().
 					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
 					Return(nil, nil)
+
+				loginThrottler.EXPECT().
+					AddFailure(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				expectLoginRecording(0, domain.AuditOutcomeFailure, dto.ErrUserNotFound.Error(), nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
 			},
 			expErr: dto.ErrUserNotFound.Error(),
 		},
+		{
+			name: "incorrect password",
+			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(user, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
+					Return(dto.ErrIncorrectPassword)
+
+				loginThrottler.EXPECT().
+					AddFailure(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				expectLoginRecording(user.ID, domain.AuditOutcomeFailure, dto.ErrIncorrectPassword.Error(), nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: dto.ErrIncorrectPassword.Error(),
+		},
+		{
+			name: "user not activated",
+			setup: func() {
+				expectBeginning()
+
+				inactiveUser := *user
+				inactiveUser.Active = false
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(&inactiveUser, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
+					Return(nil)
+
+				expectLoginRecording(user.ID, domain.AuditOutcomeFailure, dto.ErrUserNotActivated.Error(), nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: dto.ErrUserNotActivated.Error(),
+		},
+		{
+			name: "user suspended",
+			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(user, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
+					Return(nil)
+
+				expectSuspension(&domain.Suspension{UserID: user.ID, Reason: "spam"}, nil)
+
+				expectLoginRecording(user.ID, domain.AuditOutcomeFailure, dto.ErrUserSuspended.Error(), nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: dto.ErrUserSuspended.Error(),
+		},
+		{
+			name: "error on getting suspension",
+			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(user, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
+					Return(nil)
+
+				expectSuspension(nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "user suspension getting error: dummy error",
+		},
 		{
 			name: "error on validating password",
 			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
 				userRepository.EXPECT().
 					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
 					Return(user, nil)
@@ -96,12 +520,369 @@ func TestAuthenticateUsecase(t *testing.T) {
 				passwordValidator.EXPECT().
 					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
 					Return(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
 			},
 			expErr: "password validate by hash error: dummy error",
 		},
+		{
+			name: "error on adding login failure",
+			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(user, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
+					Return(dto.ErrIncorrectPassword)
+
+				loginThrottler.EXPECT().
+					AddFailure(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "login failure adding error: dummy error",
+		},
+		{
+			name: "error on generating password hash",
+			setup: func() {
+				expectBeginning()
+
+				legacyUser := *user
+				legacyUser.PasswordHash = "dummyLegacyPasswordHash"
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(&legacyUser, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(legacyUser.PasswordHash)).
+					Return(nil)
+
+				expectSuspension(nil, nil)
+
+				passwordRehasher.EXPECT().
+					NeedsRehash(gomock.Eq(legacyUser.PasswordHash)).
+					Return(true)
+
+				passwordRehasher.EXPECT().
+					Generate(gomock.Eq(password)).
+					Return("", errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "password hash generation error: dummy error",
+		},
+		{
+			name: "error on saving rehashed password",
+			setup: func() {
+				expectBeginning()
+
+				legacyUser := *user
+				legacyUser.PasswordHash = "dummyLegacyPasswordHash"
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(&legacyUser, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(legacyUser.PasswordHash)).
+					Return(nil)
+
+				expectSuspension(nil, nil)
+
+				passwordRehasher.EXPECT().
+					NeedsRehash(gomock.Eq(legacyUser.PasswordHash)).
+					Return(true)
+
+				passwordRehasher.EXPECT().
+					Generate(gomock.Eq(password)).
+					Return("dummyRehashedPasswordHash", nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Any()).
+					Return(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth saving error: dummy error",
+		},
+		{
+			name: "two-factor enabled",
+			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(user, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
+					Return(nil)
+
+				expectSuspension(nil, nil)
+
+				passwordRehasher.EXPECT().
+					NeedsRehash(gomock.Eq(user.PasswordHash)).
+					Return(false)
+
+				tx.EXPECT().
+					TwoFactor().
+					Return(twoFactorRepository)
+
+				twoFactorIssuer.EXPECT().
+					IsEnabled(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(twoFactorRepository)).
+					Return(true, nil)
+
+				tx.EXPECT().
+					TwoFactorChallenge().
+					Return(challengeRepository)
+
+				twoFactorIssuer.EXPECT().
+					IssueChallenge(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(challengeRepository)).
+					Return(twoFactorToken, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.UserAuthenticateOut{
+				TwoFactorToken: twoFactorToken,
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on checking two-factor",
+			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(user, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
+					Return(nil)
+
+				expectSuspension(nil, nil)
+
+				passwordRehasher.EXPECT().
+					NeedsRehash(gomock.Eq(user.PasswordHash)).
+					Return(false)
+
+				tx.EXPECT().
+					TwoFactor().
+					Return(twoFactorRepository)
+
+				twoFactorIssuer.EXPECT().
+					IsEnabled(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(twoFactorRepository)).
+					Return(false, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "two-factor checking error: dummy error",
+		},
+		{
+			name: "error on issuing two-factor challenge",
+			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(user, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
+					Return(nil)
+
+				expectSuspension(nil, nil)
+
+				passwordRehasher.EXPECT().
+					NeedsRehash(gomock.Eq(user.PasswordHash)).
+					Return(false)
+
+				tx.EXPECT().
+					TwoFactor().
+					Return(twoFactorRepository)
+
+				twoFactorIssuer.EXPECT().
+					IsEnabled(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(twoFactorRepository)).
+					Return(true, nil)
+
+				tx.EXPECT().
+					TwoFactorChallenge().
+					Return(challengeRepository)
+
+				twoFactorIssuer.EXPECT().
+					IssueChallenge(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(challengeRepository)).
+					Return("", errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "two-factor challenge issuing error: dummy error",
+		},
+		{
+			name: "error on resetting login failures",
+			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(user, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
+					Return(nil)
+
+				expectSuspension(nil, nil)
+
+				passwordRehasher.EXPECT().
+					NeedsRehash(gomock.Eq(user.PasswordHash)).
+					Return(false)
+
+				tx.EXPECT().
+					TwoFactor().
+					Return(twoFactorRepository)
+
+				twoFactorIssuer.EXPECT().
+					IsEnabled(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(twoFactorRepository)).
+					Return(false, nil)
+
+				loginThrottler.EXPECT().
+					Reset(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "login failures resetting error: dummy error",
+		},
+		{
+			name: "error on starting session",
+			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(user, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
+					Return(nil)
+
+				expectSuspension(nil, nil)
+
+				passwordRehasher.EXPECT().
+					NeedsRehash(gomock.Eq(user.PasswordHash)).
+					Return(false)
+
+				tx.EXPECT().
+					TwoFactor().
+					Return(twoFactorRepository)
+
+				twoFactorIssuer.EXPECT().
+					IsEnabled(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(twoFactorRepository)).
+					Return(false, nil)
+
+				loginThrottler.EXPECT().
+					Reset(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(nil)
+
+				tx.EXPECT().
+					Session().
+					Return(sessionRepository)
+
+				sessionStarter.EXPECT().
+					Start(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+					Return(nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "session starting error: dummy error",
+		},
 		{
 			name: "error on creating access token",
 			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
 				userRepository.EXPECT().
 					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
 					Return(user, nil)
@@ -110,16 +891,283 @@ func TestAuthenticateUsecase(t *testing.T) {
 					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
 					Return(nil)
 
+				expectSuspension(nil, nil)
+
+				passwordRehasher.EXPECT().
+					NeedsRehash(gomock.Eq(user.PasswordHash)).
+					Return(false)
+
+				tx.EXPECT().
+					TwoFactor().
+					Return(twoFactorRepository)
+
+				twoFactorIssuer.EXPECT().
+					IsEnabled(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(twoFactorRepository)).
+					Return(false, nil)
+
+				loginThrottler.EXPECT().
+					Reset(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(nil)
+
+				tx.EXPECT().
+					Session().
+					Return(sessionRepository)
+
+				sessionStarter.EXPECT().
+					Start(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+					Return(session, nil)
+
+				tx.EXPECT().
+					Role().
+					Return(roleRepository)
+
+				roleRepository.EXPECT().
+					GetUserAccess(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(access, nil)
+
 				accessTokenIssuer.EXPECT().
-					NewObject(gomock.Eq(user.ID)).
+					NewObject(gomock.Eq(user.ID), gomock.Eq(session.ID), gomock.Eq(access)).
 					Return(accessTokenObject)
 
 				accessTokenIssuer.EXPECT().
 					Sign(gomock.Eq(accessTokenObject)).
 					Return("", errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
 			},
 			expErr: "access token creation error: dummy error",
 		},
+		{
+			name: "error on creating refresh token",
+			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(user, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
+					Return(nil)
+
+				expectSuspension(nil, nil)
+
+				passwordRehasher.EXPECT().
+					NeedsRehash(gomock.Eq(user.PasswordHash)).
+					Return(false)
+
+				tx.EXPECT().
+					TwoFactor().
+					Return(twoFactorRepository)
+
+				twoFactorIssuer.EXPECT().
+					IsEnabled(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(twoFactorRepository)).
+					Return(false, nil)
+
+				loginThrottler.EXPECT().
+					Reset(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(nil)
+
+				tx.EXPECT().
+					Session().
+					Return(sessionRepository)
+
+				sessionStarter.EXPECT().
+					Start(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+					Return(session, nil)
+
+				tx.EXPECT().
+					Role().
+					Return(roleRepository)
+
+				roleRepository.EXPECT().
+					GetUserAccess(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(access, nil)
+
+				accessTokenIssuer.EXPECT().
+					NewObject(gomock.Eq(user.ID), gomock.Eq(session.ID), gomock.Eq(access)).
+					Return(accessTokenObject)
+
+				accessTokenIssuer.EXPECT().
+					Sign(gomock.Eq(accessTokenObject)).
+					Return(accessToken, nil)
+
+				tx.EXPECT().
+					RefreshToken().
+					Return(refreshTokenRepository)
+
+				refreshTokenIssuer.EXPECT().
+					Issue(gomock.Eq(ctx), gomock.Eq(session), gomock.Eq(refreshTokenRepository)).
+					Return("", errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "refresh token creation error: dummy error",
+		},
+		{
+			name: "error on recording login",
+			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(user, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
+					Return(nil)
+
+				expectSuspension(nil, nil)
+
+				passwordRehasher.EXPECT().
+					NeedsRehash(gomock.Eq(user.PasswordHash)).
+					Return(false)
+
+				tx.EXPECT().
+					TwoFactor().
+					Return(twoFactorRepository)
+
+				twoFactorIssuer.EXPECT().
+					IsEnabled(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(twoFactorRepository)).
+					Return(false, nil)
+
+				loginThrottler.EXPECT().
+					Reset(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(nil)
+
+				tx.EXPECT().
+					Session().
+					Return(sessionRepository)
+
+				sessionStarter.EXPECT().
+					Start(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+					Return(session, nil)
+
+				tx.EXPECT().
+					Role().
+					Return(roleRepository)
+
+				roleRepository.EXPECT().
+					GetUserAccess(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(access, nil)
+
+				accessTokenIssuer.EXPECT().
+					NewObject(gomock.Eq(user.ID), gomock.Eq(session.ID), gomock.Eq(access)).
+					Return(accessTokenObject)
+
+				accessTokenIssuer.EXPECT().
+					Sign(gomock.Eq(accessTokenObject)).
+					Return(accessToken, nil)
+
+				tx.EXPECT().
+					RefreshToken().
+					Return(refreshTokenRepository)
+
+				refreshTokenIssuer.EXPECT().
+					Issue(gomock.Eq(ctx), gomock.Eq(session), gomock.Eq(refreshTokenRepository)).
+					Return(refreshToken, nil)
+
+				expectLoginRecording(user.ID, domain.AuditOutcomeSuccess, "", errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				expectBeginning()
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(email), gomock.Eq(ip)).
+					Return(nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					GetByEmail(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(user, nil)
+
+				passwordValidator.EXPECT().
+					Validate(gomock.Eq(password), gomock.Eq(user.PasswordHash)).
+					Return(nil)
+
+				expectSuspension(nil, nil)
+
+				passwordRehasher.EXPECT().
+					NeedsRehash(gomock.Eq(user.PasswordHash)).
+					Return(false)
+
+				tx.EXPECT().
+					TwoFactor().
+					Return(twoFactorRepository)
+
+				twoFactorIssuer.EXPECT().
+					IsEnabled(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(twoFactorRepository)).
+					Return(false, nil)
+
+				loginThrottler.EXPECT().
+					Reset(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(nil)
+
+				tx.EXPECT().
+					Session().
+					Return(sessionRepository)
+
+				sessionStarter.EXPECT().
+					Start(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+					Return(session, nil)
+
+				tx.EXPECT().
+					Role().
+					Return(roleRepository)
+
+				roleRepository.EXPECT().
+					GetUserAccess(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(access, nil)
+
+				accessTokenIssuer.EXPECT().
+					NewObject(gomock.Eq(user.ID), gomock.Eq(session.ID), gomock.Eq(access)).
+					Return(accessTokenObject)
+
+				accessTokenIssuer.EXPECT().
+					Sign(gomock.Eq(accessTokenObject)).
+					Return(accessToken, nil)
+
+				tx.EXPECT().
+					RefreshToken().
+					Return(refreshTokenRepository)
+
+				refreshTokenIssuer.EXPECT().
+					Issue(gomock.Eq(ctx), gomock.Eq(session), gomock.Eq(refreshTokenRepository)).
+					Return(refreshToken, nil)
+
+				expectLoginRecording(user.ID, domain.AuditOutcomeSuccess, "", nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -127,7 +1175,7 @@ func TestAuthenticateUsecase(t *testing.T) {
 				tt.setup()
 			}
 
-			u := domain.NewUserAuthenticateCase(userRepository, passwordValidator, accessTokenIssuer)
+			u := domain.NewUserAuthenticateCase(repository, passwordValidator, passwordRehasher, accessTokenIssuer, refreshTokenIssuer, loginThrottler, twoFactorIssuer, sessionStarter)
 			out, err := u.Use(ctx, in)
 
 			assert.Equal(t, tt.expOut, out)
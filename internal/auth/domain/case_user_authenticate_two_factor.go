@@ -0,0 +1,132 @@
+//go:generate mockgen -source=case_user_authenticate_two_factor.go -destination=mock/case_user_authenticate_two_factor.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type twoFactorChallengePasser interface {
+	GetChallenge(ctx context.Context, token string, repository TwoFactorChallengeRepository) (*TwoFactorChallenge, error)
+	PassChallenge(
+		ctx context.Context,
+		challenge *TwoFactorChallenge,
+		code string,
+		challengeRepository TwoFactorChallengeRepository,
+		repository TwoFactorRepository,
+	) error
+}
+
+type UserAuthenticateTwoFactorCase struct {
+	repository               Repository
+	twoFactorChallengePasser twoFactorChallengePasser
+	accessTokenIssuer        accessTokenIssuer
+	refreshTokenIssuer       refreshTokenIssuer
+	loginThrottler           loginThrottler
+	sessionStarter           sessionStarter
+}
+
+func NewUserAuthenticateTwoFactorCase(
+	repository Repository,
+	twoFactorService twoFactorChallengePasser,
+	accessTokenService accessTokenIssuer,
+	refreshTokenService refreshTokenIssuer,
+	loginThrottleService loginThrottler,
+	sessionService sessionStarter,
+) *UserAuthenticateTwoFactorCase {
+	return &UserAuthenticateTwoFactorCase{
+		repository:               repository,
+		twoFactorChallengePasser: twoFactorService,
+		accessTokenIssuer:        accessTokenService,
+		refreshTokenIssuer:       refreshTokenService,
+		loginThrottler:           loginThrottleService,
+		sessionStarter:           sessionService,
+	}
+}
+
+// Use completes the authentication started with the password.
+// Invalid codes are counted as failed login attempts of the user.
+func (c *UserAuthenticateTwoFactorCase) Use(ctx context.Context, in *dto.UserAuthenticateTwoFactorIn) (*dto.UserAuthenticateOut, error) {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	out, err := c.useInTx(ctx, in, tx)
+	if err != nil && !isLoginFailure(err) {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// the failed attempt of the challenge and the failed login have to be kept
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, fmt.Errorf("tx committing error: %w", commitErr)
+	}
+
+	return out, err
+}
+
+func (c *UserAuthenticateTwoFactorCase) useInTx(ctx context.Context, in *dto.UserAuthenticateTwoFactorIn, tx TxCommitter) (*dto.UserAuthenticateOut, error) {
+	out, userID, err := c.authenticate(ctx, in, tx)
+	if err = recordLogin(ctx, tx, userID, in.IP, in.UserAgent, err); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *UserAuthenticateTwoFactorCase) authenticate(ctx context.Context, in *dto.UserAuthenticateTwoFactorIn, tx TxCommitter) (*dto.UserAuthenticateOut, int64, error) {
+	challenge, err := c.twoFactorChallengePasser.GetChallenge(ctx, in.TwoFactorToken, tx.TwoFactorChallenge())
+	if err != nil {
+		if err == dto.ErrInvalidTwoFactorToken {
+			return nil, 0, err
+		}
+		return nil, 0, fmt.Errorf("two-factor challenge getting error: %w", err)
+	}
+
+	user, err := tx.User().Get(ctx, challenge.UserID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("auth getting error: %w", err)
+	}
+	if user == nil {
+		return nil, 0, dto.ErrInvalidTwoFactorToken
+	}
+
+	if err = checkLogin(ctx, c.loginThrottler, user.Email, in.IP); err != nil {
+		return nil, user.ID, err
+	}
+
+	err = c.twoFactorChallengePasser.PassChallenge(ctx, challenge, in.Code, tx.TwoFactorChallenge(), tx.TwoFactor())
+	switch err {
+	case nil:
+	case dto.ErrInvalidTwoFactorCode:
+		return nil, user.ID, failLogin(ctx, c.loginThrottler, user.Email, in.IP, err)
+	case dto.ErrInvalidTwoFactorToken:
+		return nil, user.ID, err
+	default:
+		return nil, user.ID, fmt.Errorf("two-factor challenge passing error: %w", err)
+	}
+
+	if err = c.loginThrottler.Reset(ctx, user.Email); err != nil {
+		return nil, user.ID, fmt.Errorf("login failures resetting error: %w", err)
+	}
+
+	// the user could be suspended since the challenge was issued
+	if err = checkUserSuspension(ctx, tx.Suspension(), user.ID); err != nil {
+		return nil, user.ID, err
+	}
+
+	accessToken, refreshToken, err := startSession(ctx, c.sessionStarter, c.accessTokenIssuer, c.refreshTokenIssuer,
+		user.ID, in.UserAgent, in.IP, tx)
+	if err != nil {
+		return nil, user.ID, err
+	}
+
+	out := &dto.UserAuthenticateOut{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}
+	return out, user.ID, nil
+}
@@ -0,0 +1,583 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestUserAuthenticateTwoFactorCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository             = mock.NewMockRepository(ctrl)
+		tx                     = mock.NewMockTxCommitter(ctrl)
+		userRepository         = mock.NewMockUserRepository(ctrl)
+		twoFactorRepository    = mock.NewMockTwoFactorRepository(ctrl)
+		challengeRepository    = mock.NewMockTwoFactorChallengeRepository(ctrl)
+		refreshTokenRepository = mock.NewMockRefreshTokenRepository(ctrl)
+		sessionRepository      = mock.NewMockSessionRepository(ctrl)
+		roleRepository         = mock.NewMockRoleRepository(ctrl)
+		access                 = &domain.UserAccess{Roles: []string{domain.RoleReader}, Permissions: []string{domain.PermissionPostRead}}
+		challengePasser        = mock.NewMocktwoFactorChallengePasser(ctrl)
+		accessTokenIssuer      = mock.NewMockaccessTokenIssuer(ctrl)
+		refreshTokenIssuer     = mock.NewMockrefreshTokenIssuer(ctrl)
+		loginThrottler         = mock.NewMockloginThrottler(ctrl)
+		sessionStarter         = mock.NewMocksessionStarter(ctrl)
+	)
+
+	var (
+		ctx               = context.Background()
+		token             = "dummyTwoFactorToken"
+		code              = "123456"
+		ip                = "192.0.2.1"
+		user              = &domain.User{ID: 1, Email: "dummyEmail@example.com", Active: true}
+		challenge         = &domain.TwoFactorChallenge{TokenHash: "dummyTokenHash", UserID: user.ID}
+		accessTokenObject = &domain.AccessTokenObject{}
+		userAgent         = "Mozilla/5.0"
+		session           = &domain.Session{ID: "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b", UserID: user.ID}
+		accessToken       = "dummyAccessToken"
+		refreshToken      = "dummyRefreshToken"
+		in                = &dto.UserAuthenticateTwoFactorIn{TwoFactorToken: token, Code: code, IP: ip, UserAgent: userAgent}
+		noError           = ""
+	)
+
+	expectBeginning := func() {
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+	}
+
+	expectChallenge := func() {
+		tx.EXPECT().
+			TwoFactorChallenge().
+			Return(challengeRepository)
+
+		challengePasser.EXPECT().
+			GetChallenge(gomock.Eq(ctx), gomock.Eq(token), gomock.Eq(challengeRepository)).
+			Return(challenge, nil)
+
+		tx.EXPECT().
+			User().
+			Return(userRepository)
+
+		userRepository.EXPECT().
+			Get(gomock.Eq(ctx), gomock.Eq(user.ID)).
+			Return(user, nil)
+
+		loginThrottler.EXPECT().
+			Check(gomock.Eq(ctx), gomock.Eq(user.Email), gomock.Eq(ip)).
+			Return(nil)
+	}
+
+	expectPassing := func(err error) {
+		expectChallenge()
+
+		tx.EXPECT().
+			TwoFactorChallenge().
+			Return(challengeRepository)
+
+		tx.EXPECT().
+			TwoFactor().
+			Return(twoFactorRepository)
+
+		challengePasser.EXPECT().
+			PassChallenge(gomock.Eq(ctx), gomock.Eq(challenge), gomock.Eq(code), gomock.Eq(challengeRepository), gomock.Eq(twoFactorRepository)).
+			Return(err)
+	}
+
+	expectSuspension := func(suspension *domain.Suspension, err error) {
+		expectPassing(nil)
+
+		loginThrottler.EXPECT().
+			Reset(gomock.Eq(ctx), gomock.Eq(user.Email)).
+			Return(nil)
+
+		tx.EXPECT().
+			Suspension().
+			DoAndReturn(func() domain.SuspensionRepository {
+				r := mock.NewMockSuspensionRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(suspension, err)
+				return r
+			})
+	}
+
+	expectPassed := func() {
+		expectSuspension(nil, nil)
+	}
+
+	expectLoginRecording := func(outcome, details string, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      domain.AuditEventUserLogin,
+						ActorID:   user.ID,
+						IP:        ip,
+						UserAgent: userAgent,
+						Outcome:   outcome,
+						Details:   details,
+					})).
+					Return(err)
+				return r
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.UserAuthenticateOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				expectBeginning()
+
+				expectPassed()
+
+				tx.EXPECT().
+					Session().
+					Return(sessionRepository)
+
+				sessionStarter.EXPECT().
+					Start(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+					Return(session, nil)
+
+				tx.EXPECT().
+					Role().
+					Return(roleRepository)
+
+				roleRepository.EXPECT().
+					GetUserAccess(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(access, nil)
+
+				accessTokenIssuer.EXPECT().
+					NewObject(gomock.Eq(user.ID), gomock.Eq(session.ID), gomock.Eq(access)).
+					Return(accessTokenObject)
+
+				accessTokenIssuer.EXPECT().
+					Sign(gomock.Eq(accessTokenObject)).
+					Return(accessToken, nil)
+
+				tx.EXPECT().
+					RefreshToken().
+					Return(refreshTokenRepository)
+
+				refreshTokenIssuer.EXPECT().
+					Issue(gomock.Eq(ctx), gomock.Eq(session), gomock.Eq(refreshTokenRepository)).
+					Return(refreshToken, nil)
+
+				expectLoginRecording(domain.AuditOutcomeSuccess, "", nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.UserAuthenticateOut{
+				AccessToken:  accessToken,
+				RefreshToken: refreshToken,
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "invalid token",
+			setup: func() {
+				expectBeginning()
+
+				tx.EXPECT().
+					TwoFactorChallenge().
+					Return(challengeRepository)
+
+				challengePasser.EXPECT().
+					GetChallenge(gomock.Eq(ctx), gomock.Eq(token), gomock.Eq(challengeRepository)).
+					Return(nil, dto.ErrInvalidTwoFactorToken)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInvalidTwoFactorToken.Error(),
+		},
+		{
+			name: "error on getting challenge",
+			setup: func() {
+				expectBeginning()
+
+				tx.EXPECT().
+					TwoFactorChallenge().
+					Return(challengeRepository)
+
+				challengePasser.EXPECT().
+					GetChallenge(gomock.Eq(ctx), gomock.Eq(token), gomock.Eq(challengeRepository)).
+					Return(nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "two-factor challenge getting error: dummy error",
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				expectBeginning()
+
+				tx.EXPECT().
+					TwoFactorChallenge().
+					Return(challengeRepository)
+
+				challengePasser.EXPECT().
+					GetChallenge(gomock.Eq(ctx), gomock.Eq(token), gomock.Eq(challengeRepository)).
+					Return(challenge, nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(nil, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInvalidTwoFactorToken.Error(),
+		},
+		{
+			name: "login throttled",
+			setup: func() {
+				expectBeginning()
+
+				tx.EXPECT().
+					TwoFactorChallenge().
+					Return(challengeRepository)
+
+				challengePasser.EXPECT().
+					GetChallenge(gomock.Eq(ctx), gomock.Eq(token), gomock.Eq(challengeRepository)).
+					Return(challenge, nil)
+
+				tx.EXPECT().
+					User().
+					Return(userRepository)
+
+				userRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(user, nil)
+
+				loginThrottler.EXPECT().
+					Check(gomock.Eq(ctx), gomock.Eq(user.Email), gomock.Eq(ip)).
+					Return(&dto.LoginThrottledError{RetryAfter: time.Minute})
+
+				expectLoginRecording(domain.AuditOutcomeFailure, (&dto.LoginThrottledError{RetryAfter: time.Minute}).Error(), nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: (&dto.LoginThrottledError{RetryAfter: time.Minute}).Error(),
+		},
+		{
+			name: "invalid code",
+			setup: func() {
+				expectBeginning()
+
+				expectPassing(dto.ErrInvalidTwoFactorCode)
+
+				loginThrottler.EXPECT().
+					AddFailure(gomock.Eq(ctx), gomock.Eq(user.Email), gomock.Eq(ip)).
+					Return(nil)
+
+				expectLoginRecording(domain.AuditOutcomeFailure, dto.ErrInvalidTwoFactorCode.Error(), nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: dto.ErrInvalidTwoFactorCode.Error(),
+		},
+		{
+			name: "challenge passed concurrently",
+			setup: func() {
+				expectBeginning()
+
+				expectPassing(dto.ErrInvalidTwoFactorToken)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInvalidTwoFactorToken.Error(),
+		},
+		{
+			name: "error on passing challenge",
+			setup: func() {
+				expectBeginning()
+
+				expectPassing(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "two-factor challenge passing error: dummy error",
+		},
+		{
+			name: "error on resetting login failures",
+			setup: func() {
+				expectBeginning()
+
+				expectPassing(nil)
+
+				loginThrottler.EXPECT().
+					Reset(gomock.Eq(ctx), gomock.Eq(user.Email)).
+					Return(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "login failures resetting error: dummy error",
+		},
+		{
+			name: "user suspended",
+			setup: func() {
+				expectBeginning()
+
+				expectSuspension(&domain.Suspension{UserID: user.ID}, nil)
+				expectLoginRecording(domain.AuditOutcomeFailure, dto.ErrUserSuspended.Error(), nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: dto.ErrUserSuspended.Error(),
+		},
+		{
+			name: "error on checking suspension",
+			setup: func() {
+				expectBeginning()
+
+				expectSuspension(nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "user suspension getting error: dummy error",
+		},
+		{
+			name: "error on starting session",
+			setup: func() {
+				expectBeginning()
+
+				expectPassed()
+
+				tx.EXPECT().
+					Session().
+					Return(sessionRepository)
+
+				sessionStarter.EXPECT().
+					Start(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+					Return(nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "session starting error: dummy error",
+		},
+		{
+			name: "error on creating access token",
+			setup: func() {
+				expectBeginning()
+
+				expectPassed()
+
+				tx.EXPECT().
+					Session().
+					Return(sessionRepository)
+
+				sessionStarter.EXPECT().
+					Start(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+					Return(session, nil)
+
+				tx.EXPECT().
+					Role().
+					Return(roleRepository)
+
+				roleRepository.EXPECT().
+					GetUserAccess(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(access, nil)
+
+				accessTokenIssuer.EXPECT().
+					NewObject(gomock.Eq(user.ID), gomock.Eq(session.ID), gomock.Eq(access)).
+					Return(accessTokenObject)
+
+				accessTokenIssuer.EXPECT().
+					Sign(gomock.Eq(accessTokenObject)).
+					Return("", errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "access token creation error: dummy error",
+		},
+		{
+			name: "error on creating refresh token",
+			setup: func() {
+				expectBeginning()
+
+				expectPassed()
+
+				tx.EXPECT().
+					Session().
+					Return(sessionRepository)
+
+				sessionStarter.EXPECT().
+					Start(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+					Return(session, nil)
+
+				tx.EXPECT().
+					Role().
+					Return(roleRepository)
+
+				roleRepository.EXPECT().
+					GetUserAccess(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(access, nil)
+
+				accessTokenIssuer.EXPECT().
+					NewObject(gomock.Eq(user.ID), gomock.Eq(session.ID), gomock.Eq(access)).
+					Return(accessTokenObject)
+
+				accessTokenIssuer.EXPECT().
+					Sign(gomock.Eq(accessTokenObject)).
+					Return(accessToken, nil)
+
+				tx.EXPECT().
+					RefreshToken().
+					Return(refreshTokenRepository)
+
+				refreshTokenIssuer.EXPECT().
+					Issue(gomock.Eq(ctx), gomock.Eq(session), gomock.Eq(refreshTokenRepository)).
+					Return("", errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "refresh token creation error: dummy error",
+		},
+		{
+			name: "error on recording login",
+			setup: func() {
+				expectBeginning()
+
+				expectPassed()
+
+				tx.EXPECT().
+					Session().
+					Return(sessionRepository)
+
+				sessionStarter.EXPECT().
+					Start(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+					Return(session, nil)
+
+				tx.EXPECT().
+					Role().
+					Return(roleRepository)
+
+				roleRepository.EXPECT().
+					GetUserAccess(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(access, nil)
+
+				accessTokenIssuer.EXPECT().
+					NewObject(gomock.Eq(user.ID), gomock.Eq(session.ID), gomock.Eq(access)).
+					Return(accessTokenObject)
+
+				accessTokenIssuer.EXPECT().
+					Sign(gomock.Eq(accessTokenObject)).
+					Return(accessToken, nil)
+
+				tx.EXPECT().
+					RefreshToken().
+					Return(refreshTokenRepository)
+
+				refreshTokenIssuer.EXPECT().
+					Issue(gomock.Eq(ctx), gomock.Eq(session), gomock.Eq(refreshTokenRepository)).
+					Return(refreshToken, nil)
+
+				expectLoginRecording(domain.AuditOutcomeSuccess, "", errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				expectBeginning()
+
+				expectPassed()
+
+				tx.EXPECT().
+					Session().
+					Return(sessionRepository)
+
+				sessionStarter.EXPECT().
+					Start(gomock.Eq(ctx), gomock.Eq(user.ID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+					Return(session, nil)
+
+				tx.EXPECT().
+					Role().
+					Return(roleRepository)
+
+				roleRepository.EXPECT().
+					GetUserAccess(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(access, nil)
+
+				accessTokenIssuer.EXPECT().
+					NewObject(gomock.Eq(user.ID), gomock.Eq(session.ID), gomock.Eq(access)).
+					Return(accessTokenObject)
+
+				accessTokenIssuer.EXPECT().
+					Sign(gomock.Eq(accessTokenObject)).
+					Return(accessToken, nil)
+
+				tx.EXPECT().
+					RefreshToken().
+					Return(refreshTokenRepository)
+
+				refreshTokenIssuer.EXPECT().
+					Issue(gomock.Eq(ctx), gomock.Eq(session), gomock.Eq(refreshTokenRepository)).
+					Return(refreshToken, nil)
+
+				expectLoginRecording(domain.AuditOutcomeSuccess, "", nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewUserAuthenticateTwoFactorCase(repository, challengePasser, accessTokenIssuer, refreshTokenIssuer, loginThrottler, sessionStarter)
+			out, err := c.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+//go:generate mockgen -source=case_user_logout.go -destination=mock/case_user_logout.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type accessTokenRevoker interface {
+	Revoke(ctx context.Context, object *AccessTokenObject, repository AccessTokenRevocationRepository) error
+}
+
+type refreshTokenRevoker interface {
+	Revoke(ctx context.Context, token string, userID int64, repository RefreshTokenRepository) error
+}
+
+type UserLogoutCase struct {
+	repository                   Repository
+	accessTokenParser            accessTokenParser
+	accessTokenRevocationChecker accessTokenRevocationChecker
+	accessTokenRevoker           accessTokenRevoker
+	refreshTokenRevoker          refreshTokenRevoker
+}
+
+func NewUserLogoutCase(
+	repository Repository,
+	accessTokenParser accessTokenParser,
+	accessTokenRevocationChecker accessTokenRevocationChecker,
+	accessTokenRevoker accessTokenRevoker,
+	refreshTokenRevoker refreshTokenRevoker,
+) *UserLogoutCase {
+	return &UserLogoutCase{
+		repository:                   repository,
+		accessTokenParser:            accessTokenParser,
+		accessTokenRevocationChecker: accessTokenRevocationChecker,
+		accessTokenRevoker:           accessTokenRevoker,
+		refreshTokenRevoker:          refreshTokenRevoker,
+	}
+}
+
+func (c *UserLogoutCase) Use(ctx context.Context, in *dto.UserLogoutIn) error {
+	tokenObject, err := validateAccessToken(ctx, in.AccessToken, c.accessTokenParser, c.accessTokenRevocationChecker, c.repository)
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	if err = c.useInTx(ctx, in, tokenObject, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *UserLogoutCase) useInTx(ctx context.Context, in *dto.UserLogoutIn, tokenObject *AccessTokenObject, tx TxCommitter) error {
+	if err := c.accessTokenRevoker.Revoke(ctx, tokenObject, tx.AccessTokenRevocation()); err != nil {
+		return fmt.Errorf("access token revoking error: %w", err)
+	}
+
+	if tokenObject.SessionID != "" {
+		if err := tx.Session().Revoke(ctx, tokenObject.SessionID); err != nil {
+			return fmt.Errorf("session revoking error: %w", err)
+		}
+		if err := tx.RefreshToken().RevokeFamily(ctx, tokenObject.SessionID); err != nil {
+			return fmt.Errorf("refresh token family revoking error: %w", err)
+		}
+	}
+
+	if in.RefreshToken == "" {
+		return nil
+	}
+
+	if err := c.refreshTokenRevoker.Revoke(ctx, in.RefreshToken, tokenObject.UserID, tx.RefreshToken()); err != nil {
+		return fmt.Errorf("refresh token revoking error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+//go:generate mockgen -source=case_user_logout_everywhere.go -destination=mock/case_user_logout_everywhere.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type userTokensRevoker interface {
+	RevokeUser(ctx context.Context, userID int64, tx TxCommitter) error
+}
+
+type UserLogoutEverywhereCase struct {
+	repository                   Repository
+	accessTokenParser            accessTokenParser
+	accessTokenRevocationChecker accessTokenRevocationChecker
+	userTokensRevoker            userTokensRevoker
+}
+
+func NewUserLogoutEverywhereCase(
+	repository Repository,
+	accessTokenParser accessTokenParser,
+	accessTokenRevocationChecker accessTokenRevocationChecker,
+	userTokensRevoker userTokensRevoker,
+) *UserLogoutEverywhereCase {
+	return &UserLogoutEverywhereCase{
+		repository:                   repository,
+		accessTokenParser:            accessTokenParser,
+		accessTokenRevocationChecker: accessTokenRevocationChecker,
+		userTokensRevoker:            userTokensRevoker,
+	}
+}
+
+func (c *UserLogoutEverywhereCase) Use(ctx context.Context, in *dto.UserLogoutEverywhereIn) error {
+	tokenObject, err := validateAccessToken(ctx, in.AccessToken, c.accessTokenParser, c.accessTokenRevocationChecker, c.repository)
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	if err = c.userTokensRevoker.RevokeUser(ctx, tokenObject.UserID, tx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("user tokens revoking error: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return nil
+}
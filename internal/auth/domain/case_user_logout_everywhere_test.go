@@ -0,0 +1,162 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestUserLogoutEverywhereUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository                   = mock.NewMockRepository(ctrl)
+		accessTokenParser            = mock.NewMockaccessTokenParser(ctrl)
+		accessTokenRevocationChecker = mock.NewMockaccessTokenRevocationChecker(ctrl)
+		userTokensRevoker            = mock.NewMockuserTokensRevoker(ctrl)
+	)
+
+	var (
+		ctx                             = context.Background()
+		userID                          = int64(1)
+		accessToken                     = "dummyAccessToken"
+		tokenObject                     = &domain.AccessTokenObject{UserID: userID, ID: "dummyTokenID"}
+		accessTokenRevocationRepository = mock.NewMockAccessTokenRevocationRepository(ctrl)
+		in                              = &dto.UserLogoutEverywhereIn{AccessToken: accessToken}
+		noError                         = ""
+	)
+
+	expectValidAccessToken := func() {
+		accessTokenParser.EXPECT().
+			ParseAndValidate(gomock.Eq(accessToken)).
+			Return(tokenObject, nil)
+
+		repository.EXPECT().
+			AccessTokenRevocation().
+			Return(accessTokenRevocationRepository)
+
+		accessTokenRevocationChecker.EXPECT().
+			IsRevoked(gomock.Eq(ctx), gomock.Eq(tokenObject), gomock.Eq(accessTokenRevocationRepository)).
+			Return(false, nil)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectValidAccessToken()
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				userTokensRevoker.EXPECT().
+					RevokeUser(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(tx)).
+					Return(nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "access token revoked",
+			setup: func() {
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(accessToken)).
+					Return(tokenObject, nil)
+
+				repository.EXPECT().
+					AccessTokenRevocation().
+					Return(accessTokenRevocationRepository)
+
+				accessTokenRevocationChecker.EXPECT().
+					IsRevoked(gomock.Eq(ctx), gomock.Eq(tokenObject), gomock.Eq(accessTokenRevocationRepository)).
+					Return(true, nil)
+			},
+			expErr: dto.ErrInvalidAccessToken.Error(),
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				expectValidAccessToken()
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "error on revoking user tokens",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectValidAccessToken()
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				userTokensRevoker.EXPECT().
+					RevokeUser(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(tx)).
+					Return(errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "user tokens revoking error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectValidAccessToken()
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				userTokensRevoker.EXPECT().
+					RevokeUser(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(tx)).
+					Return(nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+
+			u := domain.NewUserLogoutEverywhereCase(repository, accessTokenParser, accessTokenRevocationChecker, userTokensRevoker)
+			err := u.Use(ctx, in)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
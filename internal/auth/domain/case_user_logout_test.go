@@ -0,0 +1,318 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestUserLogoutUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository                   = mock.NewMockRepository(ctrl)
+		accessTokenParser            = mock.NewMockaccessTokenParser(ctrl)
+		accessTokenRevocationChecker = mock.NewMockaccessTokenRevocationChecker(ctrl)
+		accessTokenRevoker           = mock.NewMockaccessTokenRevoker(ctrl)
+		refreshTokenRevoker          = mock.NewMockrefreshTokenRevoker(ctrl)
+	)
+
+	var (
+		ctx                             = context.Background()
+		userID                          = int64(1)
+		accessToken                     = "dummyAccessToken"
+		refreshToken                    = "dummyRefreshToken"
+		sessionID                       = "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b"
+		tokenObject                     = &domain.AccessTokenObject{UserID: userID, ID: "dummyTokenID"}
+		sessionTokenObject              = &domain.AccessTokenObject{UserID: userID, SessionID: sessionID, ID: "dummyTokenID"}
+		accessTokenRevocationRepository = mock.NewMockAccessTokenRevocationRepository(ctrl)
+		refreshTokenRepository          = mock.NewMockRefreshTokenRepository(ctrl)
+		noError                         = ""
+	)
+
+	expectValidAccessToken := func(object *domain.AccessTokenObject) {
+		accessTokenParser.EXPECT().
+			ParseAndValidate(gomock.Eq(accessToken)).
+			Return(object, nil)
+
+		repository.EXPECT().
+			AccessTokenRevocation().
+			Return(accessTokenRevocationRepository)
+
+		accessTokenRevocationChecker.EXPECT().
+			IsRevoked(gomock.Eq(ctx), gomock.Eq(object), gomock.Eq(accessTokenRevocationRepository)).
+			Return(false, nil)
+
+		if object.SessionID == "" {
+			return
+		}
+
+		repository.EXPECT().
+			Session().
+			DoAndReturn(func() domain.SessionRepository {
+				r := mock.NewMockSessionRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(sessionID)).
+					Return(&domain.Session{ID: sessionID, UserID: userID, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+				return r
+			})
+	}
+
+	expectAccessTokenRevoking := func(tx *mock.MockTxCommitter, object *domain.AccessTokenObject, err error) {
+		tx.EXPECT().
+			AccessTokenRevocation().
+			Return(accessTokenRevocationRepository)
+
+		accessTokenRevoker.EXPECT().
+			Revoke(gomock.Eq(ctx), gomock.Eq(object), gomock.Eq(accessTokenRevocationRepository)).
+			Return(err)
+	}
+
+	expectRefreshTokenRevoking := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			RefreshToken().
+			Return(refreshTokenRepository)
+
+		refreshTokenRevoker.EXPECT().
+			Revoke(gomock.Eq(ctx), gomock.Eq(refreshToken), gomock.Eq(userID), gomock.Eq(refreshTokenRepository)).
+			Return(err)
+	}
+
+	expectSessionRevoking := func(tx *mock.MockTxCommitter, sessionErr, familyErr error) {
+		tx.EXPECT().
+			Session().
+			DoAndReturn(func() domain.SessionRepository {
+				r := mock.NewMockSessionRepository(ctrl)
+				r.EXPECT().
+					Revoke(gomock.Eq(ctx), gomock.Eq(sessionID)).
+					Return(sessionErr)
+				return r
+			})
+
+		if sessionErr != nil {
+			return
+		}
+
+		tx.EXPECT().
+			RefreshToken().
+			DoAndReturn(func() domain.RefreshTokenRepository {
+				r := mock.NewMockRefreshTokenRepository(ctrl)
+				r.EXPECT().
+					RevokeFamily(gomock.Eq(ctx), gomock.Eq(sessionID)).
+					Return(familyErr)
+				return r
+			})
+	}
+
+	tests := []struct {
+		name   string
+		in     *dto.UserLogoutIn
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			in:   &dto.UserLogoutIn{AccessToken: accessToken, RefreshToken: refreshToken},
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectValidAccessToken(tokenObject)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectAccessTokenRevoking(tx, tokenObject, nil)
+				expectRefreshTokenRevoking(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path without refresh token",
+			in:   &dto.UserLogoutIn{AccessToken: accessToken},
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectValidAccessToken(tokenObject)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectAccessTokenRevoking(tx, tokenObject, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path with session",
+			in:   &dto.UserLogoutIn{AccessToken: accessToken},
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectValidAccessToken(sessionTokenObject)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectAccessTokenRevoking(tx, sessionTokenObject, nil)
+				expectSessionRevoking(tx, nil, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on revoking session",
+			in:   &dto.UserLogoutIn{AccessToken: accessToken},
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectValidAccessToken(sessionTokenObject)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectAccessTokenRevoking(tx, sessionTokenObject, nil)
+				expectSessionRevoking(tx, errors.New("dummy error"), nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "session revoking error: dummy error",
+		},
+		{
+			name: "error on revoking refresh token family of session",
+			in:   &dto.UserLogoutIn{AccessToken: accessToken},
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectValidAccessToken(sessionTokenObject)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectAccessTokenRevoking(tx, sessionTokenObject, nil)
+				expectSessionRevoking(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "refresh token family revoking error: dummy error",
+		},
+		{
+			name: "invalid access token",
+			in:   &dto.UserLogoutIn{AccessToken: accessToken},
+			setup: func() {
+				accessTokenParser.EXPECT().
+					ParseAndValidate(gomock.Eq(accessToken)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: dto.ErrInvalidAccessToken.Error(),
+		},
+		{
+			name: "error on beginning tx",
+			in:   &dto.UserLogoutIn{AccessToken: accessToken},
+			setup: func() {
+				expectValidAccessToken(tokenObject)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "error on revoking access token",
+			in:   &dto.UserLogoutIn{AccessToken: accessToken, RefreshToken: refreshToken},
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectValidAccessToken(tokenObject)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectAccessTokenRevoking(tx, tokenObject, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "access token revoking error: dummy error",
+		},
+		{
+			name: "error on revoking refresh token",
+			in:   &dto.UserLogoutIn{AccessToken: accessToken, RefreshToken: refreshToken},
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectValidAccessToken(tokenObject)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectAccessTokenRevoking(tx, tokenObject, nil)
+				expectRefreshTokenRevoking(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "refresh token revoking error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			in:   &dto.UserLogoutIn{AccessToken: accessToken},
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectValidAccessToken(tokenObject)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				expectAccessTokenRevoking(tx, tokenObject, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+
+			u := domain.NewUserLogoutCase(repository, accessTokenParser, accessTokenRevocationChecker, accessTokenRevoker, refreshTokenRevoker)
+			err := u.Use(ctx, tt.in)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
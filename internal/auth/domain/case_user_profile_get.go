@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type UserProfileGetCase struct {
+	repository Repository
+}
+
+func NewUserProfileGetCase(repository Repository) *UserProfileGetCase {
+	return &UserProfileGetCase{
+		repository: repository,
+	}
+}
+
+// Use returns the profile of the user along with the private information.
+func (c *UserProfileGetCase) Use(ctx context.Context, in *dto.UserProfileGetIn) (*dto.UserProfileGetOut, error) {
+	user, profile, err := getUserProfile(ctx, c.repository.User(), in.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.UserProfileGetOut{Profile: newUserProfile(user, profile)}, nil
+}
+
+func getUserProfile(ctx context.Context, userRepository UserRepository, userID int64) (*User, *Profile, error) {
+	user, err := userRepository.Get(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("auth getting error: %w", err)
+	}
+	if user == nil {
+		return nil, nil, dto.ErrUserNotFound
+	}
+
+	profile, err := userRepository.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("profile getting error: %w", err)
+	}
+	if profile == nil {
+		return nil, nil, dto.ErrUserNotFound
+	}
+
+	return user, profile, nil
+}
+
+func newUserProfile(user *User, profile *Profile) dto.UserProfile {
+	return dto.UserProfile{
+		ID:          user.ID,
+		Name:        user.Name,
+		Email:       user.Email,
+		Handle:      profile.Handle,
+		DisplayName: profile.DisplayName,
+		Bio:         profile.Bio,
+		Website:     profile.Website,
+		AvatarURL:   profile.AvatarURL,
+	}
+}
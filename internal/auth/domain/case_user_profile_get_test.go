@@ -0,0 +1,118 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestUserProfileGetCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository     = mock.NewMockRepository(ctrl)
+		userRepository = mock.NewMockUserRepository(ctrl)
+	)
+
+	var (
+		ctx     = context.Background()
+		userID  = int64(1)
+		user    = &domain.User{ID: userID, Name: "dummyName", Email: "dummyEmail@example.com", Active: true}
+		profile = &domain.Profile{UserID: userID, Handle: "ivanov", DisplayName: "Ivan", Bio: "Writer"}
+		in      = &dto.UserProfileGetIn{UserID: userID}
+		noError = ""
+	)
+
+	expectUserGetting := func(user *domain.User, err error) {
+		repository.EXPECT().
+			User().
+			Return(userRepository)
+
+		userRepository.EXPECT().
+			Get(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(user, err)
+	}
+
+	expectProfileGetting := func(profile *domain.Profile, err error) {
+		userRepository.EXPECT().
+			GetProfile(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(profile, err)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.UserProfileGetOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				expectUserGetting(user, nil)
+				expectProfileGetting(profile, nil)
+			},
+			expOut: &dto.UserProfileGetOut{Profile: dto.UserProfile{
+				ID:          userID,
+				Name:        "dummyName",
+				Email:       "dummyEmail@example.com",
+				Handle:      "ivanov",
+				DisplayName: "Ivan",
+				Bio:         "Writer",
+			}},
+			expErr: noError,
+		},
+		{
+			name: "error on getting user",
+			setup: func() {
+				expectUserGetting(nil, errors.New("dummy error"))
+			},
+			expErr: "auth getting error: dummy error",
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				expectUserGetting(nil, nil)
+			},
+			expErr: dto.ErrUserNotFound.Error(),
+		},
+		{
+			name: "error on getting profile",
+			setup: func() {
+				expectUserGetting(user, nil)
+				expectProfileGetting(nil, errors.New("dummy error"))
+			},
+			expErr: "profile getting error: dummy error",
+		},
+		{
+			name: "profile not found",
+			setup: func() {
+				expectUserGetting(user, nil)
+				expectProfileGetting(nil, nil)
+			},
+			expErr: dto.ErrUserNotFound.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewUserProfileGetCase(repository)
+			out, err := c.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/repository"
+)
+
+type UserProfileUpdateCase struct {
+	repository Repository
+}
+
+func NewUserProfileUpdateCase(repository Repository) *UserProfileUpdateCase {
+	return &UserProfileUpdateCase{
+		repository: repository,
+	}
+}
+
+// Use changes the given fields of the profile, the handle cannot be taken by another user.
+func (c *UserProfileUpdateCase) Use(ctx context.Context, in *dto.UserProfileUpdateIn) (*dto.UserProfileUpdateOut, error) {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	out, err := c.useInTx(ctx, in, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return out, nil
+}
+
+func (c *UserProfileUpdateCase) useInTx(ctx context.Context, in *dto.UserProfileUpdateIn, tx TxCommitter) (*dto.UserProfileUpdateOut, error) {
+	userRepository := tx.User()
+
+	user, profile, err := getUserProfile(ctx, userRepository, in.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Handle != nil && *in.Handle != "" && *in.Handle != profile.Handle {
+		owner, err := userRepository.GetProfileByHandle(ctx, *in.Handle)
+		if err != nil {
+			return nil, fmt.Errorf("profile getting by handle error: %w", err)
+		}
+		if owner != nil {
+			return nil, dto.ErrHandleIsBusy
+		}
+	}
+
+	setIfNotNil(&profile.Handle, in.Handle)
+	setIfNotNil(&profile.DisplayName, in.DisplayName)
+	setIfNotNil(&profile.Bio, in.Bio)
+	setIfNotNil(&profile.Website, in.Website)
+	setIfNotNil(&profile.AvatarURL, in.AvatarURL)
+
+	// the handle can be taken concurrently after its check
+	if err = userRepository.SaveProfile(ctx, profile); err != nil {
+		if err == repository.ErrUniqueViolation {
+			return nil, dto.ErrHandleIsBusy
+		}
+		return nil, fmt.Errorf("profile saving error: %w", err)
+	}
+
+	return &dto.UserProfileUpdateOut{Profile: newUserProfile(user, profile)}, nil
+}
+
+func setIfNotNil(field *string, value *string) {
+	if value != nil {
+		*field = *value
+	}
+}
@@ -0,0 +1,280 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	common_repository "github.com/art-es/blog/internal/common/repository"
+)
+
+func TestUserProfileUpdateCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository     = mock.NewMockRepository(ctrl)
+		userRepository = mock.NewMockUserRepository(ctrl)
+	)
+
+	var (
+		ctx       = context.Background()
+		userID    = int64(1)
+		user      = &domain.User{ID: userID, Name: "dummyName", Email: "dummyEmail@example.com", Active: true}
+		handle    = "ivanov"
+		bio       = ""
+		newHandle = "ivan"
+		website   = "https://ivan.example.com"
+		noError   = ""
+	)
+
+	profileFactory := func() *domain.Profile {
+		return &domain.Profile{UserID: userID, Handle: handle, DisplayName: "Ivan", Bio: "Writer"}
+	}
+
+	updatedProfileFactory := func() *domain.Profile {
+		return &domain.Profile{UserID: userID, Handle: newHandle, DisplayName: "Ivan", Bio: bio, Website: website}
+	}
+
+	inFactory := func(handle *string) *dto.UserProfileUpdateIn {
+		return &dto.UserProfileUpdateIn{UserID: userID, Handle: handle, Bio: &bio, Website: &website}
+	}
+
+	expectBeginning := func() *mock.MockTxCommitter {
+		tx := mock.NewMockTxCommitter(ctrl)
+
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+
+		tx.EXPECT().
+			User().
+			Return(userRepository)
+
+		return tx
+	}
+
+	expectGetting := func(user *domain.User, profile *domain.Profile, err error) {
+		userRepository.EXPECT().
+			Get(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(user, nil)
+
+		if user != nil {
+			userRepository.EXPECT().
+				GetProfile(gomock.Eq(ctx), gomock.Eq(userID)).
+				Return(profile, err)
+		}
+	}
+
+	expectHandleOwner := func(owner *domain.Profile, err error) {
+		userRepository.EXPECT().
+			GetProfileByHandle(gomock.Eq(ctx), gomock.Eq(newHandle)).
+			Return(owner, err)
+	}
+
+	expectSaving := func(profile *domain.Profile, err error) {
+		userRepository.EXPECT().
+			SaveProfile(gomock.Eq(ctx), gomock.Eq(profile)).
+			Return(err)
+	}
+
+	tests := []struct {
+		name   string
+		in     *dto.UserProfileUpdateIn
+		setup  func()
+		expOut *dto.UserProfileUpdateOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			in:   inFactory(&newHandle),
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(user, profileFactory(), nil)
+				expectHandleOwner(nil, nil)
+				expectSaving(updatedProfileFactory(), nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.UserProfileUpdateOut{Profile: dto.UserProfile{
+				ID:          userID,
+				Name:        "dummyName",
+				Email:       "dummyEmail@example.com",
+				Handle:      newHandle,
+				DisplayName: "Ivan",
+				Website:     website,
+			}},
+			expErr: noError,
+		},
+		{
+			name: "same handle",
+			in:   inFactory(&handle),
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(user, profileFactory(), nil)
+
+				profile := updatedProfileFactory()
+				profile.Handle = handle
+				expectSaving(profile, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.UserProfileUpdateOut{Profile: dto.UserProfile{
+				ID:          userID,
+				Name:        "dummyName",
+				Email:       "dummyEmail@example.com",
+				Handle:      handle,
+				DisplayName: "Ivan",
+				Website:     website,
+			}},
+			expErr: noError,
+		},
+		{
+			name: "unchanged handle",
+			in:   inFactory(nil),
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(user, profileFactory(), nil)
+
+				profile := updatedProfileFactory()
+				profile.Handle = handle
+				expectSaving(profile, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.UserProfileUpdateOut{Profile: dto.UserProfile{
+				ID:          userID,
+				Name:        "dummyName",
+				Email:       "dummyEmail@example.com",
+				Handle:      handle,
+				DisplayName: "Ivan",
+				Website:     website,
+			}},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			in:   inFactory(&newHandle),
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "user not found",
+			in:   inFactory(&newHandle),
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(nil, nil, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrUserNotFound.Error(),
+		},
+		{
+			name: "error on getting profile",
+			in:   inFactory(&newHandle),
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(user, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "profile getting error: dummy error",
+		},
+		{
+			name: "busy handle",
+			in:   inFactory(&newHandle),
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(user, profileFactory(), nil)
+				expectHandleOwner(&domain.Profile{UserID: 2, Handle: newHandle}, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrHandleIsBusy.Error(),
+		},
+		{
+			name: "error on getting handle owner",
+			in:   inFactory(&newHandle),
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(user, profileFactory(), nil)
+				expectHandleOwner(nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "profile getting by handle error: dummy error",
+		},
+		{
+			name: "handle taken concurrently",
+			in:   inFactory(&newHandle),
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(user, profileFactory(), nil)
+				expectHandleOwner(nil, nil)
+				expectSaving(updatedProfileFactory(), common_repository.ErrUniqueViolation)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrHandleIsBusy.Error(),
+		},
+		{
+			name: "error on saving profile",
+			in:   inFactory(&newHandle),
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(user, profileFactory(), nil)
+				expectHandleOwner(nil, nil)
+				expectSaving(updatedProfileFactory(), errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "profile saving error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			in:   inFactory(&newHandle),
+			setup: func() {
+				tx := expectBeginning()
+				expectGetting(user, profileFactory(), nil)
+				expectHandleOwner(nil, nil)
+				expectSaving(updatedProfileFactory(), nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewUserProfileUpdateCase(repository)
+			out, err := c.Use(ctx, tt.in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -8,6 +8,11 @@ import (
 	"github.com/art-es/blog/internal/auth/dto"
 )
 
+type passwordPolicyChecker interface {
+	// Check returns *dto.PasswordPolicyError if the password violates the policy.
+	Check(password, name, email string) error
+}
+
 type passwordHashGenerator interface {
 	Generate(password string) (string, error)
 }
@@ -16,67 +21,118 @@ type activationCodeSender interface {
 	SendCode(ctx context.Context, user *User, tx TxCommitter) error
 }
 
+type registrationPolicyChecker interface {
+	// CheckEmail returns dto.ErrDisposableEmail or dto.ErrEmailDomainNotAllowed if the email cannot be registered.
+	CheckEmail(email string) error
+	InviteRequired() bool
+}
+
+type inviteRedeemer interface {
+	Redeem(ctx context.Context, code string, repository InviteRepository) (*Invite, error)
+}
+
 type UserRegisterCase struct {
-	repository            Repository
-	passwordHashGenerator passwordHashGenerator
-	activationCodeSender  activationCodeSender
+	repository                Repository
+	passwordPolicyChecker     passwordPolicyChecker
+	passwordHashGenerator     passwordHashGenerator
+	activationCodeSender      activationCodeSender
+	registrationPolicyChecker registrationPolicyChecker
+	inviteRedeemer            inviteRedeemer
 }
 
 func NewUserRegisterCase(
 	repository Repository,
+	passwordPolicyChecker passwordPolicyChecker,
 	passwordHashGenerator passwordHashGenerator,
 	activationCodeSender activationCodeSender,
+	registrationPolicyChecker registrationPolicyChecker,
+	inviteRedeemer inviteRedeemer,
 ) *UserRegisterCase {
 	return &UserRegisterCase{
-		repository:            repository,
-		passwordHashGenerator: passwordHashGenerator,
-		activationCodeSender:  activationCodeSender,
+		repository:                repository,
+		passwordPolicyChecker:     passwordPolicyChecker,
+		passwordHashGenerator:     passwordHashGenerator,
+		activationCodeSender:      activationCodeSender,
+		registrationPolicyChecker: registrationPolicyChecker,
+		inviteRedeemer:            inviteRedeemer,
 	}
 }
 
-func (u *UserRegisterCase) Use(ctx context.Context, in *dto.UserRegisterIn) error {
+// Use registers the user and sends the activation code, unless the invite activates the user.
+// The invite is required if the registration is invite-only, but it can be used in the other modes as well.
+func (u *UserRegisterCase) Use(ctx context.Context, in *dto.UserRegisterIn) (*dto.UserRegisterOut, error) {
+	email := NormalizeEmail(in.Email)
+
+	if err := u.registrationPolicyChecker.CheckEmail(email); err != nil {
+		return nil, err
+	}
+
+	if in.InviteCode == "" && u.registrationPolicyChecker.InviteRequired() {
+		return nil, dto.ErrInviteRequired
+	}
+
+	if err := u.passwordPolicyChecker.Check(in.Password, in.Name, email); err != nil {
+		return nil, err
+	}
+
 	tx, err := u.repository.BeginTx(ctx)
 	if err != nil {
-		return fmt.Errorf("tx beginning error: %w", err)
+		return nil, fmt.Errorf("tx beginning error: %w", err)
 	}
 
-	if err = u.useInTx(ctx, in, tx); err != nil {
+	out, err := u.useInTx(ctx, in, email, tx)
+	if err != nil {
 		tx.Rollback()
-		return err
+		return nil, err
 	}
 
 	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("tx committing error: %w", err)
+		return nil, fmt.Errorf("tx committing error: %w", err)
 	}
 
-	return nil
+	return out, nil
 }
 
-func (u *UserRegisterCase) useInTx(ctx context.Context, in *dto.UserRegisterIn, tx TxCommitter) error {
-	if err := validateEmail(ctx, in.Email, tx.User()); err != nil {
-		return err
+func (u *UserRegisterCase) useInTx(ctx context.Context, in *dto.UserRegisterIn, email string, tx TxCommitter) (*dto.UserRegisterOut, error) {
+	if err := validateEmail(ctx, email, tx.User()); err != nil {
+		return nil, err
+	}
+
+	var activated bool
+	if in.InviteCode != "" {
+		invite, err := u.inviteRedeemer.Redeem(ctx, in.InviteCode, tx.Invite())
+		if err != nil {
+			if err == dto.ErrInvalidInvite {
+				return nil, err
+			}
+			return nil, fmt.Errorf("invite redeeming error: %w", err)
+		}
+		activated = invite.SkipActivation
 	}
 
 	passwordHash, err := u.passwordHashGenerator.Generate(in.Password)
 	if err != nil {
-		return fmt.Errorf("password hash generation error: %w", err)
+		return nil, fmt.Errorf("password hash generation error: %w", err)
 	}
 
 	user := User{
 		Name:         in.Name,
-		Email:        in.Email,
+		Email:        email,
 		PasswordHash: passwordHash,
+		Active:       activated,
 	}
 
-	if err = tx.User().Save(ctx, &user); err != nil {
-		return fmt.Errorf("auth saving error: %w", err)
+	if err = addUser(ctx, &user, in.IP, in.UserAgent, tx); err != nil {
+		return nil, err
 	}
 
-	if err = u.activationCodeSender.SendCode(ctx, &user, tx); err != nil {
-		return fmt.Errorf("auth activation code sending error: %w", err)
+	if !user.Active {
+		if err = u.activationCodeSender.SendCode(ctx, &user, tx); err != nil {
+			return nil, fmt.Errorf("auth activation code sending error: %w", err)
+		}
 	}
 
-	return nil
+	return &dto.UserRegisterOut{Activated: user.Active}, nil
 }
 
 func validateEmail(ctx context.Context, email string, userRepository UserRepository) error {
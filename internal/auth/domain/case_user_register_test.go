@@ -11,6 +11,7 @@ import (
 	"github.com/art-es/blog/internal/auth/domain"
 	"github.com/art-es/blog/internal/auth/domain/mock"
 	"github.com/art-es/blog/internal/auth/dto"
+	common_repository "github.com/art-es/blog/internal/common/repository"
 )
 
 func TestRegisterUsecase(t *testing.T) {
@@ -18,8 +19,11 @@ func TestRegisterUsecase(t *testing.T) {
 	defer ctrl.Finish()
 	var (
 		repository            = mock.NewMockRepository(ctrl)
+		passwordPolicyChecker = mock.NewMockpasswordPolicyChecker(ctrl)
 		passwordHashGenerator = mock.NewMockpasswordHashGenerator(ctrl)
 		activationCodeSender  = mock.NewMockactivationCodeSender(ctrl)
+		registrationPolicy    = mock.NewMockregistrationPolicyChecker(ctrl)
+		inviteRedeemer        = mock.NewMockinviteRedeemer(ctrl)
 	)
 
 	var (
@@ -30,13 +34,44 @@ func TestRegisterUsecase(t *testing.T) {
 		passwordHash = "dummyHashedPassword%$1"
 		userID       = int64(1)
 		in           = &dto.UserRegisterIn{
-			Name:     name,
-			Email:    email,
-			Password: password,
+			Name:      name,
+			Email:     " dummyEmail@EXAMPLE.com",
+			Password:  password,
+			IP:        "192.0.2.1",
+			UserAgent: "dummyUserAgent",
 		}
 		noError = ""
 	)
 
+	expectAuditEventAdding := func(tx *mock.MockTxCommitter, eventType, details string, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      eventType,
+						ActorID:   userID,
+						IP:        in.IP,
+						UserAgent: in.UserAgent,
+						Outcome:   domain.AuditOutcomeSuccess,
+						Details:   details,
+					})).
+					Return(err)
+				return r
+			})
+	}
+
+	expectRegistrationAllowed := func() {
+		registrationPolicy.EXPECT().
+			CheckEmail(gomock.Eq(email)).
+			Return(nil)
+
+		registrationPolicy.EXPECT().
+			InviteRequired().
+			Return(false)
+	}
+
 	for _, tt := range []struct {
 		name   string
 		setup  func()
@@ -47,6 +82,12 @@ func TestRegisterUsecase(t *testing.T) {
 			setup: func() {
 				tx := mock.NewMockTxCommitter(ctrl)
 
+				expectRegistrationAllowed()
+
+				passwordPolicyChecker.EXPECT().
+					Check(gomock.Eq(password), gomock.Eq(name), gomock.Eq(email)).
+					Return(nil)
+
 				repository.EXPECT().
 					BeginTx(gomock.Eq(ctx)).
 					Return(tx, nil)
@@ -84,6 +125,19 @@ func TestRegisterUsecase(t *testing.T) {
 						return r
 					})
 
+				tx.EXPECT().
+					Role().
+					DoAndReturn(func() domain.RoleRepository {
+						r := mock.NewMockRoleRepository(ctrl)
+						r.EXPECT().
+							AddUserRole(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(domain.DefaultRole)).
+							Return(nil)
+						return r
+					})
+
+				expectAuditEventAdding(tx, domain.AuditEventUserRegistered, email, nil)
+				expectAuditEventAdding(tx, domain.AuditEventRoleGranted, domain.DefaultRole, nil)
+
 				activationCodeSender.EXPECT().
 					SendCode(
 						gomock.Eq(ctx),
@@ -103,9 +157,48 @@ func TestRegisterUsecase(t *testing.T) {
 			},
 			expErr: noError,
 		},
+		{
+			name: "password policy violated",
+			setup: func() {
+				expectRegistrationAllowed()
+
+				passwordPolicyChecker.EXPECT().
+					Check(gomock.Eq(password), gomock.Eq(name), gomock.Eq(email)).
+					Return(&dto.PasswordPolicyError{Violations: []string{dto.PasswordRuleNotName}})
+			},
+			expErr: "password policy violated: not_name",
+		},
+		{
+			name: "disposable email",
+			setup: func() {
+				registrationPolicy.EXPECT().
+					CheckEmail(gomock.Eq(email)).
+					Return(dto.ErrDisposableEmail)
+			},
+			expErr: dto.ErrDisposableEmail.Error(),
+		},
+		{
+			name: "invite required",
+			setup: func() {
+				registrationPolicy.EXPECT().
+					CheckEmail(gomock.Eq(email)).
+					Return(nil)
+
+				registrationPolicy.EXPECT().
+					InviteRequired().
+					Return(true)
+			},
+			expErr: dto.ErrInviteRequired.Error(),
+		},
 		{
 			name: "error on beginning tx",
 			setup: func() {
+				expectRegistrationAllowed()
+
+				passwordPolicyChecker.EXPECT().
+					Check(gomock.Eq(password), gomock.Eq(name), gomock.Eq(email)).
+					Return(nil)
+
 				repository.EXPECT().
 					BeginTx(gomock.Eq(ctx)).
 					Return(nil, errors.New("dummy error"))
@@ -117,6 +210,12 @@ func TestRegisterUsecase(t *testing.T) {
 			setup: func() {
 				tx := mock.NewMockTxCommitter(ctrl)
 
+				expectRegistrationAllowed()
+
+				passwordPolicyChecker.EXPECT().
+					Check(gomock.Eq(password), gomock.Eq(name), gomock.Eq(email)).
+					Return(nil)
+
 				repository.EXPECT().
 					BeginTx(gomock.Eq(ctx)).
 					Return(tx, nil)
@@ -140,6 +239,12 @@ func TestRegisterUsecase(t *testing.T) {
 			setup: func() {
 				tx := mock.NewMockTxCommitter(ctrl)
 
+				expectRegistrationAllowed()
+
+				passwordPolicyChecker.EXPECT().
+					Check(gomock.Eq(password), gomock.Eq(name), gomock.Eq(email)).
+					Return(nil)
+
 				repository.EXPECT().
 					BeginTx(gomock.Eq(ctx)).
 					Return(tx, nil)
@@ -163,6 +268,12 @@ func TestRegisterUsecase(t *testing.T) {
 			setup: func() {
 				tx := mock.NewMockTxCommitter(ctrl)
 
+				expectRegistrationAllowed()
+
+				passwordPolicyChecker.EXPECT().
+					Check(gomock.Eq(password), gomock.Eq(name), gomock.Eq(email)).
+					Return(nil)
+
 				repository.EXPECT().
 					BeginTx(gomock.Eq(ctx)).
 					Return(tx, nil)
@@ -190,6 +301,12 @@ func TestRegisterUsecase(t *testing.T) {
 			setup: func() {
 				tx := mock.NewMockTxCommitter(ctrl)
 
+				expectRegistrationAllowed()
+
+				passwordPolicyChecker.EXPECT().
+					Check(gomock.Eq(password), gomock.Eq(name), gomock.Eq(email)).
+					Return(nil)
+
 				repository.EXPECT().
 					BeginTx(gomock.Eq(ctx)).
 					Return(tx, nil)
@@ -228,11 +345,180 @@ func TestRegisterUsecase(t *testing.T) {
 			},
 			expErr: "auth saving error: dummy error",
 		},
+		{
+			name: "email taken concurrently",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectRegistrationAllowed()
+
+				passwordPolicyChecker.EXPECT().
+					Check(gomock.Eq(password), gomock.Eq(name), gomock.Eq(email)).
+					Return(nil)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				tx.EXPECT().
+					User().
+					DoAndReturn(func() domain.UserRepository {
+						r := mock.NewMockUserRepository(ctrl)
+						r.EXPECT().
+							EmailExists(gomock.Eq(ctx), gomock.Eq(email)).
+							Return(false, nil)
+						return r
+					})
+
+				passwordHashGenerator.EXPECT().
+					Generate(gomock.Eq(password)).
+					Return(passwordHash, nil)
+
+				tx.EXPECT().
+					User().
+					DoAndReturn(func() domain.UserRepository {
+						r := mock.NewMockUserRepository(ctrl)
+						user := &domain.User{
+							ID:           0,
+							Name:         name,
+							Email:        email,
+							PasswordHash: passwordHash,
+						}
+						r.EXPECT().
+							Save(gomock.Eq(ctx), gomock.Eq(user)).
+							Return(common_repository.ErrUniqueViolation)
+						return r
+					})
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrEmailIsBusy.Error(),
+		},
+		{
+			name: "error on adding user role",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectRegistrationAllowed()
+
+				passwordPolicyChecker.EXPECT().
+					Check(gomock.Eq(password), gomock.Eq(name), gomock.Eq(email)).
+					Return(nil)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				tx.EXPECT().
+					User().
+					DoAndReturn(func() domain.UserRepository {
+						r := mock.NewMockUserRepository(ctrl)
+						r.EXPECT().
+							EmailExists(gomock.Eq(ctx), gomock.Eq(email)).
+							Return(false, nil)
+						return r
+					})
+
+				passwordHashGenerator.EXPECT().
+					Generate(gomock.Eq(password)).
+					Return(passwordHash, nil)
+
+				tx.EXPECT().
+					User().
+					DoAndReturn(func() domain.UserRepository {
+						r := mock.NewMockUserRepository(ctrl)
+						r.EXPECT().
+							Save(gomock.Eq(ctx), gomock.Any()).
+							DoAndReturn(func(ctx context.Context, user *domain.User) error {
+								user.ID = userID
+								return nil
+							})
+						return r
+					})
+
+				tx.EXPECT().
+					Role().
+					DoAndReturn(func() domain.RoleRepository {
+						r := mock.NewMockRoleRepository(ctrl)
+						r.EXPECT().
+							AddUserRole(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(domain.DefaultRole)).
+							Return(errors.New("dummy error"))
+						return r
+					})
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "user role adding error: dummy error",
+		},
+		{
+			name: "error on adding audit event",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectRegistrationAllowed()
+
+				passwordPolicyChecker.EXPECT().
+					Check(gomock.Eq(password), gomock.Eq(name), gomock.Eq(email)).
+					Return(nil)
+
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(tx, nil)
+
+				tx.EXPECT().
+					User().
+					DoAndReturn(func() domain.UserRepository {
+						r := mock.NewMockUserRepository(ctrl)
+						r.EXPECT().
+							EmailExists(gomock.Eq(ctx), gomock.Eq(email)).
+							Return(false, nil)
+						return r
+					})
+
+				passwordHashGenerator.EXPECT().
+					Generate(gomock.Eq(password)).
+					Return(passwordHash, nil)
+
+				tx.EXPECT().
+					User().
+					DoAndReturn(func() domain.UserRepository {
+						r := mock.NewMockUserRepository(ctrl)
+						r.EXPECT().
+							Save(gomock.Eq(ctx), gomock.Any()).
+							DoAndReturn(func(ctx context.Context, user *domain.User) error {
+								user.ID = userID
+								return nil
+							})
+						return r
+					})
+
+				tx.EXPECT().
+					Role().
+					DoAndReturn(func() domain.RoleRepository {
+						r := mock.NewMockRoleRepository(ctrl)
+						r.EXPECT().
+							AddUserRole(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(domain.DefaultRole)).
+							Return(nil)
+						return r
+					})
+
+				expectAuditEventAdding(tx, domain.AuditEventUserRegistered, email, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
 		{
 			name: "error on sending activation code",
 			setup: func() {
 				tx := mock.NewMockTxCommitter(ctrl)
 
+				expectRegistrationAllowed()
+
+				passwordPolicyChecker.EXPECT().
+					Check(gomock.Eq(password), gomock.Eq(name), gomock.Eq(email)).
+					Return(nil)
+
 				repository.EXPECT().
 					BeginTx(gomock.Eq(ctx)).
 					Return(tx, nil)
@@ -270,6 +556,19 @@ func TestRegisterUsecase(t *testing.T) {
 						return r
 					})
 
+				tx.EXPECT().
+					Role().
+					DoAndReturn(func() domain.RoleRepository {
+						r := mock.NewMockRoleRepository(ctrl)
+						r.EXPECT().
+							AddUserRole(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(domain.DefaultRole)).
+							Return(nil)
+						return r
+					})
+
+				expectAuditEventAdding(tx, domain.AuditEventUserRegistered, email, nil)
+				expectAuditEventAdding(tx, domain.AuditEventRoleGranted, domain.DefaultRole, nil)
+
 				activationCodeSender.EXPECT().
 					SendCode(
 						gomock.Eq(ctx),
@@ -292,6 +591,12 @@ func TestRegisterUsecase(t *testing.T) {
 			setup: func() {
 				tx := mock.NewMockTxCommitter(ctrl)
 
+				expectRegistrationAllowed()
+
+				passwordPolicyChecker.EXPECT().
+					Check(gomock.Eq(password), gomock.Eq(name), gomock.Eq(email)).
+					Return(nil)
+
 				repository.EXPECT().
 					BeginTx(gomock.Eq(ctx)).
 					Return(tx, nil)
@@ -329,6 +634,19 @@ func TestRegisterUsecase(t *testing.T) {
 						return r
 					})
 
+				tx.EXPECT().
+					Role().
+					DoAndReturn(func() domain.RoleRepository {
+						r := mock.NewMockRoleRepository(ctrl)
+						r.EXPECT().
+							AddUserRole(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(domain.DefaultRole)).
+							Return(nil)
+						return r
+					})
+
+				expectAuditEventAdding(tx, domain.AuditEventUserRegistered, email, nil)
+				expectAuditEventAdding(tx, domain.AuditEventRoleGranted, domain.DefaultRole, nil)
+
 				activationCodeSender.EXPECT().
 					SendCode(
 						gomock.Eq(ctx),
@@ -354,15 +672,208 @@ func TestRegisterUsecase(t *testing.T) {
 				tt.setup()
 			}
 
-			u := domain.NewUserRegisterCase(repository, passwordHashGenerator, activationCodeSender)
-			err := u.Use(ctx, in)
+			u := domain.NewUserRegisterCase(repository, passwordPolicyChecker, passwordHashGenerator, activationCodeSender,
+				registrationPolicy, inviteRedeemer)
+			out, err := u.Use(ctx, in)
 
 			if tt.expErr == noError {
 				assert.NoError(t, err)
+				assert.Equal(t, &dto.UserRegisterOut{}, out)
 				return
 			}
 
+			assert.Nil(t, out)
 			assert.EqualError(t, err, tt.expErr)
 		})
 	}
 }
+
+func TestRegisterUsecase_invite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository            = mock.NewMockRepository(ctrl)
+		passwordPolicyChecker = mock.NewMockpasswordPolicyChecker(ctrl)
+		passwordHashGenerator = mock.NewMockpasswordHashGenerator(ctrl)
+		activationCodeSender  = mock.NewMockactivationCodeSender(ctrl)
+		registrationPolicy    = mock.NewMockregistrationPolicyChecker(ctrl)
+		inviteRedeemer        = mock.NewMockinviteRedeemer(ctrl)
+		inviteRepository      = mock.NewMockInviteRepository(ctrl)
+	)
+
+	var (
+		ctx          = context.Background()
+		name         = "dummyName"
+		email        = "dummyEmail@example.com"
+		password     = "dummyPassword1!"
+		passwordHash = "dummyHashedPassword%$1"
+		userID       = int64(1)
+		inviteCode   = "dummyInviteCode"
+		in           = &dto.UserRegisterIn{
+			Name:       name,
+			Email:      email,
+			Password:   password,
+			InviteCode: inviteCode,
+			IP:         "192.0.2.1",
+			UserAgent:  "dummyUserAgent",
+		}
+		noError = ""
+		noOut   = (*dto.UserRegisterOut)(nil)
+	)
+
+	// the invite is checked instead of the registration mode
+	expectInviteRedeeming := func(tx *mock.MockTxCommitter, invite *domain.Invite, err error) {
+		registrationPolicy.EXPECT().
+			CheckEmail(gomock.Eq(email)).
+			Return(nil)
+
+		passwordPolicyChecker.EXPECT().
+			Check(gomock.Eq(password), gomock.Eq(name), gomock.Eq(email)).
+			Return(nil)
+
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					EmailExists(gomock.Eq(ctx), gomock.Eq(email)).
+					Return(false, nil)
+				return r
+			})
+
+		tx.EXPECT().
+			Invite().
+			Return(inviteRepository)
+
+		inviteRedeemer.EXPECT().
+			Redeem(gomock.Eq(ctx), gomock.Eq(inviteCode), gomock.Eq(inviteRepository)).
+			Return(invite, err)
+	}
+
+	expectUserAdding := func(tx *mock.MockTxCommitter, user *domain.User) {
+		passwordHashGenerator.EXPECT().
+			Generate(gomock.Eq(password)).
+			Return(passwordHash, nil)
+
+		tx.EXPECT().
+			User().
+			DoAndReturn(func() domain.UserRepository {
+				r := mock.NewMockUserRepository(ctrl)
+				r.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Eq(user)).
+					DoAndReturn(func(ctx context.Context, user *domain.User) error {
+						user.ID = userID
+						return nil
+					})
+				return r
+			})
+
+		tx.EXPECT().
+			Role().
+			DoAndReturn(func() domain.RoleRepository {
+				r := mock.NewMockRoleRepository(ctrl)
+				r.EXPECT().
+					AddUserRole(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(domain.DefaultRole)).
+					Return(nil)
+				return r
+			})
+
+		auditLogRepository := mock.NewMockAuditLogRepository(ctrl)
+		tx.EXPECT().
+			AuditLog().
+			Return(auditLogRepository).
+			Times(2)
+
+		auditLogRepository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			Return(nil).
+			Times(2)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.UserRegisterOut
+		expErr string
+	}{
+		{
+			name: "happy path: activation skipped",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectInviteRedeeming(tx, &domain.Invite{ID: 1, SkipActivation: true}, nil)
+				expectUserAdding(tx, &domain.User{Name: name, Email: email, PasswordHash: passwordHash, Active: true})
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.UserRegisterOut{Activated: true},
+			expErr: noError,
+		},
+		{
+			name: "happy path: activation required",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectInviteRedeeming(tx, &domain.Invite{ID: 1}, nil)
+				expectUserAdding(tx, &domain.User{Name: name, Email: email, PasswordHash: passwordHash})
+
+				activationCodeSender.EXPECT().
+					SendCode(gomock.Eq(ctx), gomock.Any(), gomock.Eq(tx)).
+					Return(nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.UserRegisterOut{},
+			expErr: noError,
+		},
+		{
+			name: "invalid invite",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectInviteRedeeming(tx, nil, dto.ErrInvalidInvite)
+
+				tx.EXPECT().Rollback()
+			},
+			expOut: noOut,
+			expErr: dto.ErrInvalidInvite.Error(),
+		},
+		{
+			name: "error on redeeming invite",
+			setup: func() {
+				tx := mock.NewMockTxCommitter(ctrl)
+
+				expectInviteRedeeming(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expOut: noOut,
+			expErr: "invite redeeming error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			u := domain.NewUserRegisterCase(repository, passwordPolicyChecker, passwordHashGenerator, activationCodeSender,
+				registrationPolicy, inviteRedeemer)
+			out, err := u.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+//go:generate mockgen -source=case_webauthn_login_begin.go -destination=mock/case_webauthn_login_begin.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type webAuthnLoginStarter interface {
+	BeginLogin(ctx context.Context, repository WebAuthnChallengeRepository) (*dto.WebAuthnLoginBeginOut, error)
+}
+
+type WebAuthnLoginBeginCase struct {
+	repository           Repository
+	webAuthnLoginStarter webAuthnLoginStarter
+}
+
+func NewWebAuthnLoginBeginCase(repository Repository, webAuthnService webAuthnLoginStarter) *WebAuthnLoginBeginCase {
+	return &WebAuthnLoginBeginCase{
+		repository:           repository,
+		webAuthnLoginStarter: webAuthnService,
+	}
+}
+
+// Use returns the options to sign in with a passkey, the user is unknown until the passkey is chosen.
+func (c *WebAuthnLoginBeginCase) Use(ctx context.Context) (*dto.WebAuthnLoginBeginOut, error) {
+	out, err := c.webAuthnLoginStarter.BeginLogin(ctx, c.repository.WebAuthnChallenge())
+	if err != nil {
+		return nil, fmt.Errorf("webauthn login beginning error: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,79 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestWebAuthnLoginBeginCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository           = mock.NewMockRepository(ctrl)
+		challengeRepository  = mock.NewMockWebAuthnChallengeRepository(ctrl)
+		webAuthnLoginStarter = mock.NewMockwebAuthnLoginStarter(ctrl)
+	)
+
+	var (
+		ctx     = context.Background()
+		out     = &dto.WebAuthnLoginBeginOut{Challenge: "dummyChallenge", RPID: "example.com"}
+		noError = ""
+	)
+
+	expectBeginning := func(out *dto.WebAuthnLoginBeginOut, err error) {
+		repository.EXPECT().
+			WebAuthnChallenge().
+			Return(challengeRepository)
+
+		webAuthnLoginStarter.EXPECT().
+			BeginLogin(gomock.Eq(ctx), gomock.Eq(challengeRepository)).
+			Return(out, err)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.WebAuthnLoginBeginOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				expectBeginning(out, nil)
+			},
+			expOut: out,
+			expErr: noError,
+		},
+		{
+			name: "error on beginning login",
+			setup: func() {
+				expectBeginning(nil, errors.New("dummy error"))
+			},
+			expErr: "webauthn login beginning error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewWebAuthnLoginBeginCase(repository, webAuthnLoginStarter)
+			out, err := c.Use(ctx)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+//go:generate mockgen -source=case_webauthn_login_finish.go -destination=mock/case_webauthn_login_finish.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type webAuthnLoginFinisher interface {
+	FinishLogin(ctx context.Context, in *dto.WebAuthnLoginFinishIn, tx TxCommitter) (*WebAuthnCredential, bool, error)
+}
+
+type WebAuthnLoginFinishCase struct {
+	repository               Repository
+	webAuthnLoginFinisher    webAuthnLoginFinisher
+	twoFactorChallengeIssuer twoFactorChallengeIssuer
+	accessTokenIssuer        accessTokenIssuer
+	refreshTokenIssuer       refreshTokenIssuer
+	sessionStarter           sessionStarter
+}
+
+func NewWebAuthnLoginFinishCase(
+	repository Repository,
+	webAuthnService webAuthnLoginFinisher,
+	twoFactorService twoFactorChallengeIssuer,
+	accessTokenService accessTokenIssuer,
+	refreshTokenService refreshTokenIssuer,
+	sessionService sessionStarter,
+) *WebAuthnLoginFinishCase {
+	return &WebAuthnLoginFinishCase{
+		repository:               repository,
+		webAuthnLoginFinisher:    webAuthnService,
+		twoFactorChallengeIssuer: twoFactorService,
+		accessTokenIssuer:        accessTokenService,
+		refreshTokenIssuer:       refreshTokenService,
+		sessionStarter:           sessionService,
+	}
+}
+
+// Use signs in the owner of the passkey. The passkey verifying the user, e.g. with the biometrics, is two factors itself,
+// otherwise only the two-factor token is returned like by UserAuthenticateCase if the user has two-factor authentication enabled.
+func (c *WebAuthnLoginFinishCase) Use(ctx context.Context, in *dto.WebAuthnLoginFinishIn) (*dto.UserAuthenticateOut, error) {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	out, err := c.useInTx(ctx, in, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return out, nil
+}
+
+func (c *WebAuthnLoginFinishCase) useInTx(ctx context.Context, in *dto.WebAuthnLoginFinishIn, tx TxCommitter) (*dto.UserAuthenticateOut, error) {
+	credential, userVerified, err := c.webAuthnLoginFinisher.FinishLogin(ctx, in, tx)
+	if err != nil {
+		if err == dto.ErrInvalidWebAuthnResponse {
+			return nil, err
+		}
+		return nil, fmt.Errorf("webauthn login finishing error: %w", err)
+	}
+
+	user, err := tx.User().Get(ctx, credential.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("auth getting error: %w", err)
+	}
+	if user == nil {
+		return nil, dto.ErrInvalidWebAuthnResponse
+	}
+
+	if err = checkUserSuspension(ctx, tx.Suspension(), user.ID); err != nil {
+		return nil, err
+	}
+
+	if !userVerified {
+		twoFactorEnabled, err := c.twoFactorChallengeIssuer.IsEnabled(ctx, user.ID, tx.TwoFactor())
+		if err != nil {
+			return nil, fmt.Errorf("two-factor checking error: %w", err)
+		}
+		if twoFactorEnabled {
+			token, err := c.twoFactorChallengeIssuer.IssueChallenge(ctx, user.ID, tx.TwoFactorChallenge())
+			if err != nil {
+				return nil, fmt.Errorf("two-factor challenge issuing error: %w", err)
+			}
+			return &dto.UserAuthenticateOut{TwoFactorToken: token}, nil
+		}
+	}
+
+	accessToken, refreshToken, err := startSession(ctx, c.sessionStarter, c.accessTokenIssuer, c.refreshTokenIssuer,
+		user.ID, in.UserAgent, in.IP, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = recordLogin(ctx, tx, user.ID, in.IP, in.UserAgent, nil); err != nil {
+		return nil, err
+	}
+
+	return &dto.UserAuthenticateOut{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
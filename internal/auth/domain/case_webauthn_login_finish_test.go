@@ -0,0 +1,377 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestWebAuthnLoginFinishCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository                   = mock.NewMockRepository(ctrl)
+		userRepository               = mock.NewMockUserRepository(ctrl)
+		twoFactorRepository          = mock.NewMockTwoFactorRepository(ctrl)
+		twoFactorChallengeRepository = mock.NewMockTwoFactorChallengeRepository(ctrl)
+		refreshTokenRepository       = mock.NewMockRefreshTokenRepository(ctrl)
+		sessionRepository            = mock.NewMockSessionRepository(ctrl)
+		roleRepository               = mock.NewMockRoleRepository(ctrl)
+		access                       = &domain.UserAccess{Roles: []string{domain.RoleReader}, Permissions: []string{domain.PermissionPostRead}}
+		webAuthnLoginFinisher        = mock.NewMockwebAuthnLoginFinisher(ctrl)
+		twoFactorChallengeIssuer     = mock.NewMocktwoFactorChallengeIssuer(ctrl)
+		accessTokenIssuer            = mock.NewMockaccessTokenIssuer(ctrl)
+		refreshTokenIssuer           = mock.NewMockrefreshTokenIssuer(ctrl)
+		sessionStarter               = mock.NewMocksessionStarter(ctrl)
+	)
+
+	var (
+		ctx               = context.Background()
+		userID            = int64(1)
+		accessToken       = "dummyAccessToken"
+		refreshToken      = "dummyRefreshToken"
+		twoFactorToken    = "dummyTwoFactorToken"
+		ip                = "192.0.2.1"
+		userAgent         = "Mozilla/5.0"
+		session           = &domain.Session{ID: "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b", UserID: userID}
+		accessTokenObject = &domain.AccessTokenObject{UserID: userID, SessionID: session.ID}
+		credential        = &domain.WebAuthnCredential{ID: "dummyCredentialID", UserID: userID, SignCount: 2}
+		user              = &domain.User{ID: userID, Name: "Ivan", Email: "i.ivanov@example.com", Active: true}
+		in                = &dto.WebAuthnLoginFinishIn{
+			CredentialID:      credential.ID,
+			ClientDataJSON:    "dummyClientDataJSON",
+			AuthenticatorData: "dummyAuthenticatorData",
+			Signature:         "dummySignature",
+			IP:                ip,
+			UserAgent:         userAgent,
+		}
+		noError = ""
+	)
+
+	expectBeginning := func() *mock.MockTxCommitter {
+		tx := mock.NewMockTxCommitter(ctrl)
+
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+
+		tx.EXPECT().
+			User().
+			Return(userRepository).
+			AnyTimes()
+
+		return tx
+	}
+
+	expectFinishing := func(tx *mock.MockTxCommitter, userVerified bool, err error) {
+		var finished *domain.WebAuthnCredential
+		if err == nil {
+			finished = credential
+		}
+
+		webAuthnLoginFinisher.EXPECT().
+			FinishLogin(gomock.Eq(ctx), gomock.Eq(in), gomock.Eq(tx)).
+			Return(finished, userVerified, err)
+	}
+
+	expectUser := func(tx *mock.MockTxCommitter, userVerified bool, user *domain.User, err error) {
+		expectFinishing(tx, userVerified, nil)
+
+		userRepository.EXPECT().
+			Get(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(user, err)
+	}
+
+	expectSuspensionChecking := func(tx *mock.MockTxCommitter, userVerified bool, suspension *domain.Suspension, err error) {
+		expectUser(tx, userVerified, user, nil)
+
+		tx.EXPECT().
+			Suspension().
+			DoAndReturn(func() domain.SuspensionRepository {
+				r := mock.NewMockSuspensionRepository(ctrl)
+				r.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(suspension, err)
+				return r
+			})
+	}
+
+	expectTwoFactorChecking := func(tx *mock.MockTxCommitter, enabled bool, err error) {
+		expectSuspensionChecking(tx, false, nil, nil)
+
+		tx.EXPECT().
+			TwoFactor().
+			Return(twoFactorRepository)
+
+		twoFactorChallengeIssuer.EXPECT().
+			IsEnabled(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(twoFactorRepository)).
+			Return(enabled, err)
+	}
+
+	expectSession := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			Session().
+			Return(sessionRepository)
+
+		var started *domain.Session
+		if err == nil {
+			started = session
+		}
+
+		sessionStarter.EXPECT().
+			Start(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(userAgent), gomock.Eq(ip), gomock.Eq(sessionRepository)).
+			Return(started, err)
+	}
+
+	expectTokensIssuing := func(tx *mock.MockTxCommitter) {
+		expectSession(tx, nil)
+
+		tx.EXPECT().
+			Role().
+			Return(roleRepository)
+
+		roleRepository.EXPECT().
+			GetUserAccess(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(access, nil)
+
+		accessTokenIssuer.EXPECT().
+			NewObject(gomock.Eq(userID), gomock.Eq(session.ID), gomock.Eq(access)).
+			Return(accessTokenObject)
+
+		accessTokenIssuer.EXPECT().
+			Sign(gomock.Eq(accessTokenObject)).
+			Return(accessToken, nil)
+
+		tx.EXPECT().
+			RefreshToken().
+			Return(refreshTokenRepository)
+
+		refreshTokenIssuer.EXPECT().
+			Issue(gomock.Eq(ctx), gomock.Eq(session), gomock.Eq(refreshTokenRepository)).
+			Return(refreshToken, nil)
+	}
+
+	expectLoginRecording := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      domain.AuditEventUserLogin,
+						ActorID:   userID,
+						IP:        ip,
+						UserAgent: userAgent,
+						Outcome:   domain.AuditOutcomeSuccess,
+					})).
+					Return(err)
+				return r
+			})
+	}
+
+	expectTokens := func(tx *mock.MockTxCommitter) {
+		expectTokensIssuing(tx)
+		expectLoginRecording(tx, nil)
+
+		tx.EXPECT().
+			Commit().
+			Return(nil)
+	}
+
+	tokensOut := &dto.UserAuthenticateOut{AccessToken: accessToken, RefreshToken: refreshToken}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.UserAuthenticateOut
+		expErr string
+	}{
+		{
+			name: "happy path: user verified by authenticator",
+			setup: func() {
+				tx := expectBeginning()
+				expectSuspensionChecking(tx, true, nil, nil)
+				expectTokens(tx)
+			},
+			expOut: tokensOut,
+			expErr: noError,
+		},
+		{
+			name: "happy path: user not verified, two-factor disabled",
+			setup: func() {
+				tx := expectBeginning()
+				expectTwoFactorChecking(tx, false, nil)
+				expectTokens(tx)
+			},
+			expOut: tokensOut,
+			expErr: noError,
+		},
+		{
+			name: "happy path: user not verified, two-factor enabled",
+			setup: func() {
+				tx := expectBeginning()
+				expectTwoFactorChecking(tx, true, nil)
+
+				tx.EXPECT().
+					TwoFactorChallenge().
+					Return(twoFactorChallengeRepository)
+
+				twoFactorChallengeIssuer.EXPECT().
+					IssueChallenge(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(twoFactorChallengeRepository)).
+					Return(twoFactorToken, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.UserAuthenticateOut{TwoFactorToken: twoFactorToken},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "invalid response",
+			setup: func() {
+				tx := expectBeginning()
+				expectFinishing(tx, false, dto.ErrInvalidWebAuthnResponse)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name: "error on finishing login",
+			setup: func() {
+				tx := expectBeginning()
+				expectFinishing(tx, false, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "webauthn login finishing error: dummy error",
+		},
+		{
+			name: "error on getting user",
+			setup: func() {
+				tx := expectBeginning()
+				expectUser(tx, true, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "auth getting error: dummy error",
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				tx := expectBeginning()
+				expectUser(tx, true, nil, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name: "user suspended",
+			setup: func() {
+				tx := expectBeginning()
+				expectSuspensionChecking(tx, true, &domain.Suspension{UserID: userID}, nil)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrUserSuspended.Error(),
+		},
+		{
+			name: "error on checking suspension",
+			setup: func() {
+				tx := expectBeginning()
+				expectSuspensionChecking(tx, true, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "user suspension getting error: dummy error",
+		},
+		{
+			name: "error on checking two-factor",
+			setup: func() {
+				tx := expectBeginning()
+				expectTwoFactorChecking(tx, false, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "two-factor checking error: dummy error",
+		},
+		{
+			name: "error on starting session",
+			setup: func() {
+				tx := expectBeginning()
+				expectSuspensionChecking(tx, true, nil, nil)
+				expectSession(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "session starting error: dummy error",
+		},
+		{
+			name: "error on recording login",
+			setup: func() {
+				tx := expectBeginning()
+				expectSuspensionChecking(tx, true, nil, nil)
+				expectTokensIssuing(tx)
+				expectLoginRecording(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				tx := expectBeginning()
+				expectSuspensionChecking(tx, true, nil, nil)
+				expectTokensIssuing(tx)
+				expectLoginRecording(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewWebAuthnLoginFinishCase(
+				repository,
+				webAuthnLoginFinisher,
+				twoFactorChallengeIssuer,
+				accessTokenIssuer,
+				refreshTokenIssuer,
+				sessionStarter,
+			)
+			out, err := c.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
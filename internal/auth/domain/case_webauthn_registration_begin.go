@@ -0,0 +1,53 @@
+//go:generate mockgen -source=case_webauthn_registration_begin.go -destination=mock/case_webauthn_registration_begin.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+type webAuthnRegistrationStarter interface {
+	BeginRegistration(
+		ctx context.Context,
+		user *User,
+		credentials []*WebAuthnCredential,
+		repository WebAuthnChallengeRepository,
+	) (*dto.WebAuthnRegistrationBeginOut, error)
+}
+
+type WebAuthnRegistrationBeginCase struct {
+	repository                  Repository
+	webAuthnRegistrationStarter webAuthnRegistrationStarter
+}
+
+func NewWebAuthnRegistrationBeginCase(repository Repository, webAuthnService webAuthnRegistrationStarter) *WebAuthnRegistrationBeginCase {
+	return &WebAuthnRegistrationBeginCase{
+		repository:                  repository,
+		webAuthnRegistrationStarter: webAuthnService,
+	}
+}
+
+// Use returns the options to create a passkey of the user, it's added by WebAuthnRegistrationFinishCase.
+func (c *WebAuthnRegistrationBeginCase) Use(ctx context.Context, in *dto.WebAuthnRegistrationBeginIn) (*dto.WebAuthnRegistrationBeginOut, error) {
+	user, err := c.repository.User().Get(ctx, in.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("auth getting error: %w", err)
+	}
+	if user == nil {
+		return nil, dto.ErrUserNotFound
+	}
+
+	credentials, err := c.repository.WebAuthnCredential().GetByUser(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("webauthn credentials getting error: %w", err)
+	}
+
+	out, err := c.webAuthnRegistrationStarter.BeginRegistration(ctx, user, credentials, c.repository.WebAuthnChallenge())
+	if err != nil {
+		return nil, fmt.Errorf("webauthn registration beginning error: %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,130 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestWebAuthnRegistrationBeginCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository                  = mock.NewMockRepository(ctrl)
+		userRepository              = mock.NewMockUserRepository(ctrl)
+		credentialRepository        = mock.NewMockWebAuthnCredentialRepository(ctrl)
+		challengeRepository         = mock.NewMockWebAuthnChallengeRepository(ctrl)
+		webAuthnRegistrationStarter = mock.NewMockwebAuthnRegistrationStarter(ctrl)
+	)
+
+	var (
+		ctx         = context.Background()
+		userID      = int64(1)
+		user        = &domain.User{ID: userID, Name: "Ivan", Email: "i.ivanov@example.com", Active: true}
+		credentials = []*domain.WebAuthnCredential{{ID: "dummyCredentialID", UserID: userID}}
+		in          = &dto.WebAuthnRegistrationBeginIn{UserID: userID}
+		out         = &dto.WebAuthnRegistrationBeginOut{Challenge: "dummyChallenge", RPID: "example.com"}
+		noError     = ""
+	)
+
+	expectUser := func(user *domain.User, err error) {
+		repository.EXPECT().
+			User().
+			Return(userRepository)
+
+		userRepository.EXPECT().
+			Get(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(user, err)
+	}
+
+	expectCredentials := func(err error) {
+		expectUser(user, nil)
+
+		repository.EXPECT().
+			WebAuthnCredential().
+			Return(credentialRepository)
+
+		credentialRepository.EXPECT().
+			GetByUser(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(credentials, err)
+	}
+
+	expectBeginning := func(out *dto.WebAuthnRegistrationBeginOut, err error) {
+		expectCredentials(nil)
+
+		repository.EXPECT().
+			WebAuthnChallenge().
+			Return(challengeRepository)
+
+		webAuthnRegistrationStarter.EXPECT().
+			BeginRegistration(gomock.Eq(ctx), gomock.Eq(user), gomock.Eq(credentials), gomock.Eq(challengeRepository)).
+			Return(out, err)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.WebAuthnRegistrationBeginOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				expectBeginning(out, nil)
+			},
+			expOut: out,
+			expErr: noError,
+		},
+		{
+			name: "error on getting user",
+			setup: func() {
+				expectUser(nil, errors.New("dummy error"))
+			},
+			expErr: "auth getting error: dummy error",
+		},
+		{
+			name: "user not found",
+			setup: func() {
+				expectUser(nil, nil)
+			},
+			expErr: dto.ErrUserNotFound.Error(),
+		},
+		{
+			name: "error on getting credentials",
+			setup: func() {
+				expectCredentials(errors.New("dummy error"))
+			},
+			expErr: "webauthn credentials getting error: dummy error",
+		},
+		{
+			name: "error on beginning registration",
+			setup: func() {
+				expectBeginning(nil, errors.New("dummy error"))
+			},
+			expErr: "webauthn registration beginning error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewWebAuthnRegistrationBeginCase(repository, webAuthnRegistrationStarter)
+			out, err := c.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
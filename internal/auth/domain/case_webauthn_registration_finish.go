@@ -0,0 +1,88 @@
+//go:generate mockgen -source=case_webauthn_registration_finish.go -destination=mock/case_webauthn_registration_finish.go -package=mock
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/repository"
+)
+
+type webAuthnRegistrationFinisher interface {
+	FinishRegistration(
+		ctx context.Context,
+		userID int64,
+		clientDataJSON, attestationObject string,
+		repository WebAuthnChallengeRepository,
+	) (*WebAuthnCredential, error)
+}
+
+type WebAuthnRegistrationFinishCase struct {
+	repository                   Repository
+	webAuthnRegistrationFinisher webAuthnRegistrationFinisher
+}
+
+func NewWebAuthnRegistrationFinishCase(repository Repository, webAuthnService webAuthnRegistrationFinisher) *WebAuthnRegistrationFinishCase {
+	return &WebAuthnRegistrationFinishCase{
+		repository:                   repository,
+		webAuthnRegistrationFinisher: webAuthnService,
+	}
+}
+
+// Use verifies the response of the authenticator and adds the passkey to the user.
+func (c *WebAuthnRegistrationFinishCase) Use(ctx context.Context, in *dto.WebAuthnRegistrationFinishIn) (*dto.WebAuthnRegistrationFinishOut, error) {
+	tx, err := c.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tx beginning error: %w", err)
+	}
+
+	out, err := c.useInTx(ctx, in, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("tx committing error: %w", err)
+	}
+
+	return out, nil
+}
+
+func (c *WebAuthnRegistrationFinishCase) useInTx(
+	ctx context.Context,
+	in *dto.WebAuthnRegistrationFinishIn,
+	tx TxCommitter,
+) (*dto.WebAuthnRegistrationFinishOut, error) {
+	credential, err := c.webAuthnRegistrationFinisher.FinishRegistration(ctx, in.UserID,
+		in.ClientDataJSON, in.AttestationObject, tx.WebAuthnChallenge())
+	if err != nil {
+		if err == dto.ErrInvalidWebAuthnResponse {
+			return nil, err
+		}
+		return nil, fmt.Errorf("webauthn registration finishing error: %w", err)
+	}
+
+	credential.Name = in.Name
+	if err = tx.WebAuthnCredential().Add(ctx, credential); err != nil {
+		if err == repository.ErrUniqueViolation {
+			return nil, dto.ErrWebAuthnCredentialExists
+		}
+		return nil, fmt.Errorf("webauthn credential adding error: %w", err)
+	}
+
+	err = addAuditEvent(ctx, tx.AuditLog(), &AuditEvent{
+		Type:      AuditEventPasskeyAdded,
+		ActorID:   in.UserID,
+		IP:        in.IP,
+		UserAgent: in.UserAgent,
+		Outcome:   AuditOutcomeSuccess,
+		Details:   credential.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.WebAuthnRegistrationFinishOut{CredentialID: credential.ID}, nil
+}
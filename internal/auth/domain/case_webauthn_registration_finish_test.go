@@ -0,0 +1,215 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	common_repository "github.com/art-es/blog/internal/common/repository"
+)
+
+func TestWebAuthnRegistrationFinishCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository                   = mock.NewMockRepository(ctrl)
+		credentialRepository         = mock.NewMockWebAuthnCredentialRepository(ctrl)
+		challengeRepository          = mock.NewMockWebAuthnChallengeRepository(ctrl)
+		webAuthnRegistrationFinisher = mock.NewMockwebAuthnRegistrationFinisher(ctrl)
+	)
+
+	var (
+		ctx               = context.Background()
+		userID            = int64(1)
+		credentialID      = "dummyCredentialID"
+		name              = "MacBook"
+		clientDataJSON    = "dummyClientDataJSON"
+		attestationObject = "dummyAttestationObject"
+		ip                = "192.0.2.1"
+		userAgent         = "Mozilla/5.0"
+		in                = &dto.WebAuthnRegistrationFinishIn{
+			UserID:            userID,
+			Name:              name,
+			ClientDataJSON:    clientDataJSON,
+			AttestationObject: attestationObject,
+			IP:                ip,
+			UserAgent:         userAgent,
+		}
+		noError = ""
+	)
+
+	credentialFactory := func() *domain.WebAuthnCredential {
+		return &domain.WebAuthnCredential{ID: credentialID, UserID: userID, PublicKey: []byte("dummyPublicKey")}
+	}
+
+	expectBeginning := func() *mock.MockTxCommitter {
+		tx := mock.NewMockTxCommitter(ctrl)
+
+		repository.EXPECT().
+			BeginTx(gomock.Eq(ctx)).
+			Return(tx, nil)
+
+		tx.EXPECT().
+			WebAuthnChallenge().
+			Return(challengeRepository)
+
+		return tx
+	}
+
+	expectFinishing := func(tx *mock.MockTxCommitter, credential *domain.WebAuthnCredential, err error) {
+		webAuthnRegistrationFinisher.EXPECT().
+			FinishRegistration(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(clientDataJSON), gomock.Eq(attestationObject),
+				gomock.Eq(challengeRepository)).
+			Return(credential, err)
+	}
+
+	expectAdding := func(tx *mock.MockTxCommitter, err error) {
+		expectFinishing(tx, credentialFactory(), nil)
+
+		tx.EXPECT().
+			WebAuthnCredential().
+			Return(credentialRepository)
+
+		named := credentialFactory()
+		named.Name = name
+		credentialRepository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Eq(named)).
+			Return(err)
+	}
+
+	expectAuditEventAdding := func(tx *mock.MockTxCommitter, err error) {
+		tx.EXPECT().
+			AuditLog().
+			DoAndReturn(func() domain.AuditLogRepository {
+				r := mock.NewMockAuditLogRepository(ctrl)
+				r.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Eq(&domain.AuditEvent{
+						Type:      domain.AuditEventPasskeyAdded,
+						ActorID:   userID,
+						IP:        ip,
+						UserAgent: userAgent,
+						Outcome:   domain.AuditOutcomeSuccess,
+						Details:   name,
+					})).
+					Return(err)
+				return r
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expOut *dto.WebAuthnRegistrationFinishOut
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				tx := expectBeginning()
+				expectAdding(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(nil)
+			},
+			expOut: &dto.WebAuthnRegistrationFinishOut{CredentialID: credentialID},
+			expErr: noError,
+		},
+		{
+			name: "error on beginning tx",
+			setup: func() {
+				repository.EXPECT().
+					BeginTx(gomock.Eq(ctx)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "tx beginning error: dummy error",
+		},
+		{
+			name: "invalid response",
+			setup: func() {
+				tx := expectBeginning()
+				expectFinishing(tx, nil, dto.ErrInvalidWebAuthnResponse)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name: "error on finishing registration",
+			setup: func() {
+				tx := expectBeginning()
+				expectFinishing(tx, nil, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "webauthn registration finishing error: dummy error",
+		},
+		{
+			name: "credential exists",
+			setup: func() {
+				tx := expectBeginning()
+				expectAdding(tx, common_repository.ErrUniqueViolation)
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: dto.ErrWebAuthnCredentialExists.Error(),
+		},
+		{
+			name: "error on adding credential",
+			setup: func() {
+				tx := expectBeginning()
+				expectAdding(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "webauthn credential adding error: dummy error",
+		},
+		{
+			name: "error on adding audit event",
+			setup: func() {
+				tx := expectBeginning()
+				expectAdding(tx, nil)
+				expectAuditEventAdding(tx, errors.New("dummy error"))
+
+				tx.EXPECT().Rollback()
+			},
+			expErr: "audit event adding error: dummy error",
+		},
+		{
+			name: "error on committing tx",
+			setup: func() {
+				tx := expectBeginning()
+				expectAdding(tx, nil)
+				expectAuditEventAdding(tx, nil)
+
+				tx.EXPECT().
+					Commit().
+					Return(errors.New("dummy error"))
+			},
+			expErr: "tx committing error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			c := domain.NewWebAuthnRegistrationFinishCase(repository, webAuthnRegistrationFinisher)
+			out, err := c.Use(ctx, in)
+
+			assert.Equal(t, tt.expOut, out)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
@@ -10,6 +10,176 @@ type User struct {
 	Active       bool
 }
 
+// UserFilter selects the users matching all the non-zero fields, recent users first.
+type UserFilter struct {
+	// Query matches a part of the email or the name case-insensitively.
+	Query string
+	// BeforeID continues the listing after the user with this ID.
+	BeforeID int64
+	Limit    int
+}
+
+// Suspension blocks the user from signing in and from using the tokens issued before.
+// A zero Until means the user is banned until unbanned.
+type Suspension struct {
+	UserID int64
+	Reason string
+	Until  time.Time
+	// SuspendedBy is ID of the admin who suspended the user.
+	SuspendedBy int64
+	// CreatedAt is set by the repository.
+	CreatedAt time.Time
+}
+
+// IsActive reports whether the user is blocked at the moment.
+func (s *Suspension) IsActive(now time.Time) bool {
+	return s.Until.IsZero() || now.Before(s.Until)
+}
+
+// Profile is the public information of the user, an empty Handle means the user hasn't chosen it yet.
+type Profile struct {
+	UserID      int64
+	Handle      string
+	DisplayName string
+	Bio         string
+	Website     string
+	AvatarURL   string
+}
+
+const (
+	RoleAdmin  = "admin"
+	RoleAuthor = "author"
+	RoleReader = "reader"
+
+	// DefaultRole is given to the new users.
+	DefaultRole = RoleReader
+)
+
+const (
+	PermissionPostRead     = "post:read"
+	PermissionPostWrite    = "post:write"
+	PermissionPostModerate = "post:moderate"
+	PermissionCommentWrite = "comment:write"
+	PermissionUserManage   = "user:manage"
+	PermissionAuditRead    = "audit:read"
+)
+
+// UserAccess is the roles of the user with the permissions granted by them.
+type UserAccess struct {
+	Roles       []string
+	Permissions []string
+}
+
+type ActivationCode struct {
+	Code      string
+	UserID    int64
+	CreatedAt time.Time
+}
+
+type PasswordResetToken struct {
+	TokenHash string
+	UserID    int64
+	ExpiresAt time.Time
+}
+
+// MagicLinkToken signs the user in without a password. It's bound to the email the link is sent to,
+// so the link stops working once the user has another email.
+type MagicLinkToken struct {
+	TokenHash string
+	UserID    int64
+	Email     string
+	ExpiresAt time.Time
+}
+
+// Invite allows registering when the registration is invite-only. It can be used up to MaxUses times until ExpiresAt.
+type Invite struct {
+	ID       int64
+	CodeHash string
+	MaxUses  int
+	Uses     int
+	// SkipActivation activates the users registered with the invite without the activation code.
+	SkipActivation bool
+	ExpiresAt      time.Time
+	// CreatedBy is ID of the admin who created the invite.
+	CreatedBy int64
+}
+
+// EmailChange is pending until it's confirmed with the code sent to the new email,
+// then it can be undone from the old email until UndoExpiresAt.
+type EmailChange struct {
+	ID        int64
+	UserID    int64
+	OldEmail  string
+	NewEmail  string
+	CodeHash  string
+	ExpiresAt time.Time
+	// UndoTokenHash and UndoExpiresAt are set on the confirmation.
+	UndoTokenHash string
+	UndoExpiresAt time.Time
+}
+
+// TwoFactor is the TOTP secret of the user, it is enabled after confirming with a valid code.
+type TwoFactor struct {
+	UserID       int64
+	Secret       string
+	Enabled      bool
+	LastUsedStep int64
+	// Attempts is the number of failed codes while confirming or disabling two-factor authentication.
+	Attempts int
+}
+
+// TwoFactorChallenge is the pending authentication waiting for the second factor.
+type TwoFactorChallenge struct {
+	TokenHash string
+	UserID    int64
+	ExpiresAt time.Time
+	Attempts  int
+}
+
+// ExternalIdentity links the user to the subject of an OpenID Connect provider.
+type ExternalIdentity struct {
+	Provider string
+	Subject  string
+	UserID   int64
+}
+
+// OIDCState is the pending authorization request to an OpenID Connect provider.
+type OIDCState struct {
+	StateHash    string
+	Provider     string
+	CodeVerifier string
+	Nonce        string
+	ExpiresAt    time.Time
+}
+
+// WebAuthnCredential is the passkey of the user. ID is the base64url encoded credential ID
+// and PublicKey is COSE encoded.
+type WebAuthnCredential struct {
+	ID        string
+	UserID    int64
+	Name      string
+	PublicKey []byte
+	// SignCount is the last signature counter reported by the authenticator, it stays 0 if the authenticator doesn't count.
+	SignCount uint32
+	// CreatedAt is set by the repository.
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+const (
+	WebAuthnCeremonyRegistration = "registration"
+	WebAuthnCeremonyLogin        = "login"
+)
+
+// WebAuthnChallenge is the pending registration or login ceremony.
+// UserID is 0 for the login, since the user is found by the credential.
+type WebAuthnChallenge struct {
+	ChallengeHash string
+	Ceremony      string
+	UserID        int64
+	ExpiresAt     time.Time
+}
+
 type AccessTokenObject struct {
 	ExpirationTime time.Time
 	NotBefore      time.Time
@@ -17,4 +187,116 @@ type AccessTokenObject struct {
 	Audience       []string
 	Issuer         string
 	UserID         int64
+	SessionID      string
+	Roles          []string
+	Permissions    []string
+	ID             string
+}
+
+// Session is the sign-in of the user on a device. The refresh token family of the session has the same ID.
+type Session struct {
+	ID         string
+	UserID     int64
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+	Revoked    bool
+}
+
+// IsActive reports whether the tokens of the session are accepted at the moment.
+func (s *Session) IsActive(now time.Time) bool {
+	return !s.Revoked && now.Before(s.ExpiresAt)
+}
+
+// PersonalAccessToken is the long-lived credential of the user for scripts, it grants only its scopes.
+type PersonalAccessToken struct {
+	ID        string
+	UserID    int64
+	Name      string
+	TokenHash string
+	Scopes    []string
+	CreatedAt time.Time
+	// ExpiresAt is nil for the tokens which never expire.
+	ExpiresAt *time.Time
+	Revoked   bool
+}
+
+// IsActive reports whether the token is accepted at the moment.
+func (t *PersonalAccessToken) IsActive(now time.Time) bool {
+	return !t.Revoked && (t.ExpiresAt == nil || now.Before(*t.ExpiresAt))
+}
+
+type RefreshToken struct {
+	ID              int64
+	TokenHash       string
+	FamilyID        string
+	UserID          int64
+	ExpiresAt       time.Time
+	FamilyExpiresAt time.Time
+	Used            bool
+	Revoked         bool
+}
+
+// LoginAttempts counts consecutive failed authentications made with the key,
+// i.e. against the same account or from the same client address.
+type LoginAttempts struct {
+	Key          string
+	Failures     int
+	LastFailedAt time.Time
+}
+
+const (
+	AuditEventUserRegistered       = "user.registered"
+	AuditEventUserActivated        = "user.activated"
+	AuditEventUserLogin            = "user.login"
+	AuditEventTokenRefreshed       = "token.refreshed"
+	AuditEventPasswordChanged      = "password.changed"
+	AuditEventPasswordReset        = "password.reset"
+	AuditEventEmailChangeRequested = "email.change_requested"
+	AuditEventEmailChanged         = "email.changed"
+	AuditEventEmailChangeUndone    = "email.change_undone"
+	AuditEventRoleGranted          = "role.granted"
+	AuditEventUserSuspended        = "user.suspended"
+	AuditEventUserBanned           = "user.banned"
+	AuditEventUserUnbanned         = "user.unbanned"
+	AuditEventUserSessionsReset    = "user.sessions_reset"
+	AuditEventInviteCreated        = "invite.created"
+	AuditEventPasskeyAdded         = "passkey.added"
+)
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+// AuditEvent is the entry of the append-only log of security-relevant actions.
+type AuditEvent struct {
+	ID   int64
+	Type string
+	// ActorID is 0 if the action is made by an unknown user, e.g. a login with an unregistered email.
+	ActorID int64
+	// TargetID is the user the action is made on by the actor, e.g. by an admin, 0 if it's the actor.
+	TargetID  int64
+	IP        string
+	UserAgent string
+	Outcome   string
+	// Details is the reason of a failure or the subject of the action, e.g. the granted role.
+	Details string
+	// CreatedAt is set by the repository.
+	CreatedAt time.Time
+}
+
+// AuditEventFilter selects the events matching all the non-zero fields, recent events first.
+type AuditEventFilter struct {
+	ActorID  int64
+	TargetID int64
+	Type     string
+	Outcome  string
+	From     *time.Time
+	To       *time.Time
+	// BeforeID continues the listing after the event with this ID.
+	BeforeID int64
+	Limit    int
 }
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// NormalizeEmail brings the email to the form it's stored and looked up in.
+// The email is trimmed, its domain is lower-cased and converted to punycode if it's internationalized.
+// The local part is kept as is, since it's up to the mail server whether it's case-sensitive,
+// the emails differing only in its case are considered the same by the repository though.
+func NormalizeEmail(email string) string {
+	email = strings.TrimSpace(email)
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+
+	local, domain := email[:at], strings.ToLower(email[at+1:])
+	// the domain is kept lower-cased, if it's not a valid one
+	if ascii, err := idna.Lookup.ToASCII(domain); err == nil {
+		domain = ascii
+	}
+
+	return local + "@" + domain
+}
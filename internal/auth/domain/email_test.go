@@ -0,0 +1,58 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		expEmail string
+	}{
+		{
+			name:     "normalized",
+			email:    "i.ivanov@example.com",
+			expEmail: "i.ivanov@example.com",
+		},
+		{
+			name:     "surrounding spaces",
+			email:    " \ti.ivanov@example.com\n",
+			expEmail: "i.ivanov@example.com",
+		},
+		{
+			name:     "upper-case domain",
+			email:    "I.Ivanov@Example.COM",
+			expEmail: "I.Ivanov@example.com",
+		},
+		{
+			name:     "internationalized domain",
+			email:    "ivan@Пример.РФ",
+			expEmail: "ivan@xn--e1afmkfd.xn--p1ai",
+		},
+		{
+			name:     "at sign in local part",
+			email:    `"i@ivanov"@Example.com`,
+			expEmail: `"i@ivanov"@example.com`,
+		},
+		{
+			name:     "invalid domain",
+			email:    "ivan@EXAMPLE..com",
+			expEmail: "ivan@example..com",
+		},
+		{
+			name:     "no at sign",
+			email:    " Ivanov ",
+			expEmail: "Ivanov",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expEmail, domain.NormalizeEmail(tt.email))
+		})
+	}
+}
@@ -36,11 +36,12 @@ func (m *MockuserActivator) EXPECT() *MockuserActivatorMockRecorder {
 }
 
 // Activate mocks base method.
-func (m *MockuserActivator) Activate(ctx context.Context, activationCode string, tx domain.TxCommitter) error {
+func (m *MockuserActivator) Activate(ctx context.Context, activationCode string, tx domain.TxCommitter) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Activate", ctx, activationCode, tx)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Activate indicates an expected call of Activate.
@@ -5,6 +5,7 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	domain "github.com/art-es/blog/internal/auth/domain"
@@ -48,6 +49,58 @@ func (mr *MockpasswordValidatorMockRecorder) Validate(password, hash interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockpasswordValidator)(nil).Validate), password, hash)
 }
 
+// MockpasswordRehasher is a mock of passwordRehasher interface.
+type MockpasswordRehasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordRehasherMockRecorder
+}
+
+// MockpasswordRehasherMockRecorder is the mock recorder for MockpasswordRehasher.
+type MockpasswordRehasherMockRecorder struct {
+	mock *MockpasswordRehasher
+}
+
+// NewMockpasswordRehasher creates a new mock instance.
+func NewMockpasswordRehasher(ctrl *gomock.Controller) *MockpasswordRehasher {
+	mock := &MockpasswordRehasher{ctrl: ctrl}
+	mock.recorder = &MockpasswordRehasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordRehasher) EXPECT() *MockpasswordRehasherMockRecorder {
+	return m.recorder
+}
+
+// Generate mocks base method.
+func (m *MockpasswordRehasher) Generate(password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockpasswordRehasherMockRecorder) Generate(password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockpasswordRehasher)(nil).Generate), password)
+}
+
+// NeedsRehash mocks base method.
+func (m *MockpasswordRehasher) NeedsRehash(hash string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsRehash", hash)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsRehash indicates an expected call of NeedsRehash.
+func (mr *MockpasswordRehasherMockRecorder) NeedsRehash(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRehash", reflect.TypeOf((*MockpasswordRehasher)(nil).NeedsRehash), hash)
+}
+
 // MockaccessTokenIssuer is a mock of accessTokenIssuer interface.
 type MockaccessTokenIssuer struct {
 	ctrl     *gomock.Controller
@@ -72,17 +125,17 @@ func (m *MockaccessTokenIssuer) EXPECT() *MockaccessTokenIssuerMockRecorder {
 }
 
 // NewObject mocks base method.
-func (m *MockaccessTokenIssuer) NewObject(userID int64) *domain.AccessTokenObject {
+func (m *MockaccessTokenIssuer) NewObject(userID int64, sessionID string, access *domain.UserAccess) *domain.AccessTokenObject {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NewObject", userID)
+	ret := m.ctrl.Call(m, "NewObject", userID, sessionID, access)
 	ret0, _ := ret[0].(*domain.AccessTokenObject)
 	return ret0
 }
 
 // NewObject indicates an expected call of NewObject.
-func (mr *MockaccessTokenIssuerMockRecorder) NewObject(userID interface{}) *gomock.Call {
+func (mr *MockaccessTokenIssuerMockRecorder) NewObject(userID, sessionID, access interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewObject", reflect.TypeOf((*MockaccessTokenIssuer)(nil).NewObject), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewObject", reflect.TypeOf((*MockaccessTokenIssuer)(nil).NewObject), userID, sessionID, access)
 }
 
 // Sign mocks base method.
@@ -99,3 +152,197 @@ func (mr *MockaccessTokenIssuerMockRecorder) Sign(object interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockaccessTokenIssuer)(nil).Sign), object)
 }
+
+// MockrefreshTokenIssuer is a mock of refreshTokenIssuer interface.
+type MockrefreshTokenIssuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockrefreshTokenIssuerMockRecorder
+}
+
+// MockrefreshTokenIssuerMockRecorder is the mock recorder for MockrefreshTokenIssuer.
+type MockrefreshTokenIssuerMockRecorder struct {
+	mock *MockrefreshTokenIssuer
+}
+
+// NewMockrefreshTokenIssuer creates a new mock instance.
+func NewMockrefreshTokenIssuer(ctrl *gomock.Controller) *MockrefreshTokenIssuer {
+	mock := &MockrefreshTokenIssuer{ctrl: ctrl}
+	mock.recorder = &MockrefreshTokenIssuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrefreshTokenIssuer) EXPECT() *MockrefreshTokenIssuerMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method.
+func (m *MockrefreshTokenIssuer) Issue(ctx context.Context, session *domain.Session, repository domain.RefreshTokenRepository) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", ctx, session, repository)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockrefreshTokenIssuerMockRecorder) Issue(ctx, session, repository interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockrefreshTokenIssuer)(nil).Issue), ctx, session, repository)
+}
+
+// MocksessionStarter is a mock of sessionStarter interface.
+type MocksessionStarter struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionStarterMockRecorder
+}
+
+// MocksessionStarterMockRecorder is the mock recorder for MocksessionStarter.
+type MocksessionStarterMockRecorder struct {
+	mock *MocksessionStarter
+}
+
+// NewMocksessionStarter creates a new mock instance.
+func NewMocksessionStarter(ctrl *gomock.Controller) *MocksessionStarter {
+	mock := &MocksessionStarter{ctrl: ctrl}
+	mock.recorder = &MocksessionStarterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionStarter) EXPECT() *MocksessionStarterMockRecorder {
+	return m.recorder
+}
+
+// Start mocks base method.
+func (m *MocksessionStarter) Start(ctx context.Context, userID int64, userAgent, ip string, repository domain.SessionRepository) (*domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, userID, userAgent, ip, repository)
+	ret0, _ := ret[0].(*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MocksessionStarterMockRecorder) Start(ctx, userID, userAgent, ip, repository interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MocksessionStarter)(nil).Start), ctx, userID, userAgent, ip, repository)
+}
+
+// MockloginThrottler is a mock of loginThrottler interface.
+type MockloginThrottler struct {
+	ctrl     *gomock.Controller
+	recorder *MockloginThrottlerMockRecorder
+}
+
+// MockloginThrottlerMockRecorder is the mock recorder for MockloginThrottler.
+type MockloginThrottlerMockRecorder struct {
+	mock *MockloginThrottler
+}
+
+// NewMockloginThrottler creates a new mock instance.
+func NewMockloginThrottler(ctrl *gomock.Controller) *MockloginThrottler {
+	mock := &MockloginThrottler{ctrl: ctrl}
+	mock.recorder = &MockloginThrottlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockloginThrottler) EXPECT() *MockloginThrottlerMockRecorder {
+	return m.recorder
+}
+
+// AddFailure mocks base method.
+func (m *MockloginThrottler) AddFailure(ctx context.Context, email, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFailure", ctx, email, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFailure indicates an expected call of AddFailure.
+func (mr *MockloginThrottlerMockRecorder) AddFailure(ctx, email, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFailure", reflect.TypeOf((*MockloginThrottler)(nil).AddFailure), ctx, email, ip)
+}
+
+// Check mocks base method.
+func (m *MockloginThrottler) Check(ctx context.Context, email, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, email, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockloginThrottlerMockRecorder) Check(ctx, email, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockloginThrottler)(nil).Check), ctx, email, ip)
+}
+
+// Reset mocks base method.
+func (m *MockloginThrottler) Reset(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockloginThrottlerMockRecorder) Reset(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockloginThrottler)(nil).Reset), ctx, email)
+}
+
+// MocktwoFactorChallengeIssuer is a mock of twoFactorChallengeIssuer interface.
+type MocktwoFactorChallengeIssuer struct {
+	ctrl     *gomock.Controller
+	recorder *MocktwoFactorChallengeIssuerMockRecorder
+}
+
+// MocktwoFactorChallengeIssuerMockRecorder is the mock recorder for MocktwoFactorChallengeIssuer.
+type MocktwoFactorChallengeIssuerMockRecorder struct {
+	mock *MocktwoFactorChallengeIssuer
+}
+
+// NewMocktwoFactorChallengeIssuer creates a new mock instance.
+func NewMocktwoFactorChallengeIssuer(ctrl *gomock.Controller) *MocktwoFactorChallengeIssuer {
+	mock := &MocktwoFactorChallengeIssuer{ctrl: ctrl}
+	mock.recorder = &MocktwoFactorChallengeIssuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktwoFactorChallengeIssuer) EXPECT() *MocktwoFactorChallengeIssuerMockRecorder {
+	return m.recorder
+}
+
+// IsEnabled mocks base method.
+func (m *MocktwoFactorChallengeIssuer) IsEnabled(ctx context.Context, userID int64, repository domain.TwoFactorRepository) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEnabled", ctx, userID, repository)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEnabled indicates an expected call of IsEnabled.
+func (mr *MocktwoFactorChallengeIssuerMockRecorder) IsEnabled(ctx, userID, repository interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEnabled", reflect.TypeOf((*MocktwoFactorChallengeIssuer)(nil).IsEnabled), ctx, userID, repository)
+}
+
+// IssueChallenge mocks base method.
+func (m *MocktwoFactorChallengeIssuer) IssueChallenge(ctx context.Context, userID int64, repository domain.TwoFactorChallengeRepository) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueChallenge", ctx, userID, repository)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueChallenge indicates an expected call of IssueChallenge.
+func (mr *MocktwoFactorChallengeIssuerMockRecorder) IssueChallenge(ctx, userID, repository interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueChallenge", reflect.TypeOf((*MocktwoFactorChallengeIssuer)(nil).IssueChallenge), ctx, userID, repository)
+}
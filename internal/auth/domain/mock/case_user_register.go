@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: case_registration.go
+// Source: case_user_register.go
 
 // Package mock is a generated GoMock package.
 package mock
@@ -12,6 +12,43 @@ import (
 	gomock "github.com/golang/mock/gomock"
 )
 
+// MockpasswordPolicyChecker is a mock of passwordPolicyChecker interface.
+type MockpasswordPolicyChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordPolicyCheckerMockRecorder
+}
+
+// MockpasswordPolicyCheckerMockRecorder is the mock recorder for MockpasswordPolicyChecker.
+type MockpasswordPolicyCheckerMockRecorder struct {
+	mock *MockpasswordPolicyChecker
+}
+
+// NewMockpasswordPolicyChecker creates a new mock instance.
+func NewMockpasswordPolicyChecker(ctrl *gomock.Controller) *MockpasswordPolicyChecker {
+	mock := &MockpasswordPolicyChecker{ctrl: ctrl}
+	mock.recorder = &MockpasswordPolicyCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordPolicyChecker) EXPECT() *MockpasswordPolicyCheckerMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockpasswordPolicyChecker) Check(password, name, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", password, name, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockpasswordPolicyCheckerMockRecorder) Check(password, name, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockpasswordPolicyChecker)(nil).Check), password, name, email)
+}
+
 // MockpasswordHashGenerator is a mock of passwordHashGenerator interface.
 type MockpasswordHashGenerator struct {
 	ctrl     *gomock.Controller
@@ -86,3 +123,92 @@ func (mr *MockactivationCodeSenderMockRecorder) SendCode(ctx, user, tx interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCode", reflect.TypeOf((*MockactivationCodeSender)(nil).SendCode), ctx, user, tx)
 }
+
+// MockregistrationPolicyChecker is a mock of registrationPolicyChecker interface.
+type MockregistrationPolicyChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockregistrationPolicyCheckerMockRecorder
+}
+
+// MockregistrationPolicyCheckerMockRecorder is the mock recorder for MockregistrationPolicyChecker.
+type MockregistrationPolicyCheckerMockRecorder struct {
+	mock *MockregistrationPolicyChecker
+}
+
+// NewMockregistrationPolicyChecker creates a new mock instance.
+func NewMockregistrationPolicyChecker(ctrl *gomock.Controller) *MockregistrationPolicyChecker {
+	mock := &MockregistrationPolicyChecker{ctrl: ctrl}
+	mock.recorder = &MockregistrationPolicyCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockregistrationPolicyChecker) EXPECT() *MockregistrationPolicyCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckEmail mocks base method.
+func (m *MockregistrationPolicyChecker) CheckEmail(email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckEmail", email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckEmail indicates an expected call of CheckEmail.
+func (mr *MockregistrationPolicyCheckerMockRecorder) CheckEmail(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckEmail", reflect.TypeOf((*MockregistrationPolicyChecker)(nil).CheckEmail), email)
+}
+
+// InviteRequired mocks base method.
+func (m *MockregistrationPolicyChecker) InviteRequired() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteRequired")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// InviteRequired indicates an expected call of InviteRequired.
+func (mr *MockregistrationPolicyCheckerMockRecorder) InviteRequired() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteRequired", reflect.TypeOf((*MockregistrationPolicyChecker)(nil).InviteRequired))
+}
+
+// MockinviteRedeemer is a mock of inviteRedeemer interface.
+type MockinviteRedeemer struct {
+	ctrl     *gomock.Controller
+	recorder *MockinviteRedeemerMockRecorder
+}
+
+// MockinviteRedeemerMockRecorder is the mock recorder for MockinviteRedeemer.
+type MockinviteRedeemerMockRecorder struct {
+	mock *MockinviteRedeemer
+}
+
+// NewMockinviteRedeemer creates a new mock instance.
+func NewMockinviteRedeemer(ctrl *gomock.Controller) *MockinviteRedeemer {
+	mock := &MockinviteRedeemer{ctrl: ctrl}
+	mock.recorder = &MockinviteRedeemerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockinviteRedeemer) EXPECT() *MockinviteRedeemerMockRecorder {
+	return m.recorder
+}
+
+// Redeem mocks base method.
+func (m *MockinviteRedeemer) Redeem(ctx context.Context, code string, repository domain.InviteRepository) (*domain.Invite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", ctx, code, repository)
+	ret0, _ := ret[0].(*domain.Invite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockinviteRedeemerMockRecorder) Redeem(ctx, code, repository interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockinviteRedeemer)(nil).Redeem), ctx, code, repository)
+}
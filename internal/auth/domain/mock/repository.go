@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/art-es/blog/internal/auth/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -80,6 +81,21 @@ func (mr *MockUserRepositoryMockRecorder) Exists(ctx, id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockUserRepository)(nil).Exists), ctx, id)
 }
 
+// Find mocks base method.
+func (m *MockUserRepository) Find(ctx context.Context, filter *domain.UserFilter) ([]*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, filter)
+	ret0, _ := ret[0].([]*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockUserRepositoryMockRecorder) Find(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockUserRepository)(nil).Find), ctx, filter)
+}
+
 // Get mocks base method.
 func (m *MockUserRepository) Get(ctx context.Context, id int64) (*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +126,36 @@ func (mr *MockUserRepositoryMockRecorder) GetByEmail(ctx, email interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserRepository)(nil).GetByEmail), ctx, email)
 }
 
+// GetProfile mocks base method.
+func (m *MockUserRepository) GetProfile(ctx context.Context, userID int64) (*domain.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", ctx, userID)
+	ret0, _ := ret[0].(*domain.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockUserRepositoryMockRecorder) GetProfile(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockUserRepository)(nil).GetProfile), ctx, userID)
+}
+
+// GetProfileByHandle mocks base method.
+func (m *MockUserRepository) GetProfileByHandle(ctx context.Context, handle string) (*domain.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileByHandle", ctx, handle)
+	ret0, _ := ret[0].(*domain.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileByHandle indicates an expected call of GetProfileByHandle.
+func (mr *MockUserRepositoryMockRecorder) GetProfileByHandle(ctx, handle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileByHandle", reflect.TypeOf((*MockUserRepository)(nil).GetProfileByHandle), ctx, handle)
+}
+
 // Save mocks base method.
 func (m *MockUserRepository) Save(ctx context.Context, user *domain.User) error {
 	m.ctrl.T.Helper()
@@ -124,6 +170,138 @@ func (mr *MockUserRepositoryMockRecorder) Save(ctx, user interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserRepository)(nil).Save), ctx, user)
 }
 
+// SaveProfile mocks base method.
+func (m *MockUserRepository) SaveProfile(ctx context.Context, profile *domain.Profile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProfile", ctx, profile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveProfile indicates an expected call of SaveProfile.
+func (mr *MockUserRepositoryMockRecorder) SaveProfile(ctx, profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProfile", reflect.TypeOf((*MockUserRepository)(nil).SaveProfile), ctx, profile)
+}
+
+// MockSuspensionRepository is a mock of SuspensionRepository interface.
+type MockSuspensionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSuspensionRepositoryMockRecorder
+}
+
+// MockSuspensionRepositoryMockRecorder is the mock recorder for MockSuspensionRepository.
+type MockSuspensionRepositoryMockRecorder struct {
+	mock *MockSuspensionRepository
+}
+
+// NewMockSuspensionRepository creates a new mock instance.
+func NewMockSuspensionRepository(ctrl *gomock.Controller) *MockSuspensionRepository {
+	mock := &MockSuspensionRepository{ctrl: ctrl}
+	mock.recorder = &MockSuspensionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSuspensionRepository) EXPECT() *MockSuspensionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockSuspensionRepository) Get(ctx context.Context, userID int64) (*domain.Suspension, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID)
+	ret0, _ := ret[0].(*domain.Suspension)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSuspensionRepositoryMockRecorder) Get(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSuspensionRepository)(nil).Get), ctx, userID)
+}
+
+// Remove mocks base method.
+func (m *MockSuspensionRepository) Remove(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockSuspensionRepositoryMockRecorder) Remove(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockSuspensionRepository)(nil).Remove), ctx, userID)
+}
+
+// Save mocks base method.
+func (m *MockSuspensionRepository) Save(ctx context.Context, suspension *domain.Suspension) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, suspension)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSuspensionRepositoryMockRecorder) Save(ctx, suspension interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSuspensionRepository)(nil).Save), ctx, suspension)
+}
+
+// MockRoleRepository is a mock of RoleRepository interface.
+type MockRoleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleRepositoryMockRecorder
+}
+
+// MockRoleRepositoryMockRecorder is the mock recorder for MockRoleRepository.
+type MockRoleRepositoryMockRecorder struct {
+	mock *MockRoleRepository
+}
+
+// NewMockRoleRepository creates a new mock instance.
+func NewMockRoleRepository(ctrl *gomock.Controller) *MockRoleRepository {
+	mock := &MockRoleRepository{ctrl: ctrl}
+	mock.recorder = &MockRoleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleRepository) EXPECT() *MockRoleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddUserRole mocks base method.
+func (m *MockRoleRepository) AddUserRole(ctx context.Context, userID int64, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserRole", ctx, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUserRole indicates an expected call of AddUserRole.
+func (mr *MockRoleRepositoryMockRecorder) AddUserRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserRole", reflect.TypeOf((*MockRoleRepository)(nil).AddUserRole), ctx, userID, role)
+}
+
+// GetUserAccess mocks base method.
+func (m *MockRoleRepository) GetUserAccess(ctx context.Context, userID int64) (*domain.UserAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAccess", ctx, userID)
+	ret0, _ := ret[0].(*domain.UserAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAccess indicates an expected call of GetUserAccess.
+func (mr *MockRoleRepositoryMockRecorder) GetUserAccess(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAccess", reflect.TypeOf((*MockRoleRepository)(nil).GetUserAccess), ctx, userID)
+}
+
 // MockActivationCodeRepository is a mock of ActivationCodeRepository interface.
 type MockActivationCodeRepository struct {
 	ctrl     *gomock.Controller
@@ -161,19 +339,34 @@ func (mr *MockActivationCodeRepositoryMockRecorder) Add(ctx, code, userID interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockActivationCodeRepository)(nil).Add), ctx, code, userID)
 }
 
-// GetUserID mocks base method.
-func (m *MockActivationCodeRepository) GetUserID(ctx context.Context, code string) (int64, error) {
+// Get mocks base method.
+func (m *MockActivationCodeRepository) Get(ctx context.Context, code string) (*domain.ActivationCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, code)
+	ret0, _ := ret[0].(*domain.ActivationCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockActivationCodeRepositoryMockRecorder) Get(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockActivationCodeRepository)(nil).Get), ctx, code)
+}
+
+// GetLatest mocks base method.
+func (m *MockActivationCodeRepository) GetLatest(ctx context.Context, userID int64) (*domain.ActivationCode, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserID", ctx, code)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "GetLatest", ctx, userID)
+	ret0, _ := ret[0].(*domain.ActivationCode)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserID indicates an expected call of GetUserID.
-func (mr *MockActivationCodeRepositoryMockRecorder) GetUserID(ctx, code interface{}) *gomock.Call {
+// GetLatest indicates an expected call of GetLatest.
+func (mr *MockActivationCodeRepositoryMockRecorder) GetLatest(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserID", reflect.TypeOf((*MockActivationCodeRepository)(nil).GetUserID), ctx, code)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatest", reflect.TypeOf((*MockActivationCodeRepository)(nil).GetLatest), ctx, userID)
 }
 
 // RemoveCodes mocks base method.
@@ -190,196 +383,2189 @@ func (mr *MockActivationCodeRepositoryMockRecorder) RemoveCodes(ctx, userID inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCodes", reflect.TypeOf((*MockActivationCodeRepository)(nil).RemoveCodes), ctx, userID)
 }
 
-// MockrepositoryGetter is a mock of repositoryGetter interface.
-type MockrepositoryGetter struct {
+// MockPasswordResetTokenRepository is a mock of PasswordResetTokenRepository interface.
+type MockPasswordResetTokenRepository struct {
 	ctrl     *gomock.Controller
-	recorder *MockrepositoryGetterMockRecorder
+	recorder *MockPasswordResetTokenRepositoryMockRecorder
 }
 
-// MockrepositoryGetterMockRecorder is the mock recorder for MockrepositoryGetter.
-type MockrepositoryGetterMockRecorder struct {
-	mock *MockrepositoryGetter
+// MockPasswordResetTokenRepositoryMockRecorder is the mock recorder for MockPasswordResetTokenRepository.
+type MockPasswordResetTokenRepositoryMockRecorder struct {
+	mock *MockPasswordResetTokenRepository
 }
 
-// NewMockrepositoryGetter creates a new mock instance.
-func NewMockrepositoryGetter(ctrl *gomock.Controller) *MockrepositoryGetter {
-	mock := &MockrepositoryGetter{ctrl: ctrl}
-	mock.recorder = &MockrepositoryGetterMockRecorder{mock}
+// NewMockPasswordResetTokenRepository creates a new mock instance.
+func NewMockPasswordResetTokenRepository(ctrl *gomock.Controller) *MockPasswordResetTokenRepository {
+	mock := &MockPasswordResetTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetTokenRepositoryMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockrepositoryGetter) EXPECT() *MockrepositoryGetterMockRecorder {
+func (m *MockPasswordResetTokenRepository) EXPECT() *MockPasswordResetTokenRepositoryMockRecorder {
 	return m.recorder
 }
 
-// ActivationCode mocks base method.
-func (m *MockrepositoryGetter) ActivationCode() domain.ActivationCodeRepository {
+// Add mocks base method.
+func (m *MockPasswordResetTokenRepository) Add(ctx context.Context, token *domain.PasswordResetToken) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ActivationCode")
-	ret0, _ := ret[0].(domain.ActivationCodeRepository)
+	ret := m.ctrl.Call(m, "Add", ctx, token)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ActivationCode indicates an expected call of ActivationCode.
-func (mr *MockrepositoryGetterMockRecorder) ActivationCode() *gomock.Call {
+// Add indicates an expected call of Add.
+func (mr *MockPasswordResetTokenRepositoryMockRecorder) Add(ctx, token interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivationCode", reflect.TypeOf((*MockrepositoryGetter)(nil).ActivationCode))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockPasswordResetTokenRepository)(nil).Add), ctx, token)
 }
 
-// User mocks base method.
-func (m *MockrepositoryGetter) User() domain.UserRepository {
+// RemoveUserTokens mocks base method.
+func (m *MockPasswordResetTokenRepository) RemoveUserTokens(ctx context.Context, userID int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "User")
-	ret0, _ := ret[0].(domain.UserRepository)
+	ret := m.ctrl.Call(m, "RemoveUserTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// User indicates an expected call of User.
-func (mr *MockrepositoryGetterMockRecorder) User() *gomock.Call {
+// RemoveUserTokens indicates an expected call of RemoveUserTokens.
+func (mr *MockPasswordResetTokenRepositoryMockRecorder) RemoveUserTokens(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "User", reflect.TypeOf((*MockrepositoryGetter)(nil).User))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserTokens", reflect.TypeOf((*MockPasswordResetTokenRepository)(nil).RemoveUserTokens), ctx, userID)
 }
 
-// MockRepository is a mock of Repository interface.
-type MockRepository struct {
+// Take mocks base method.
+func (m *MockPasswordResetTokenRepository) Take(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx, tokenHash)
+	ret0, _ := ret[0].(*domain.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockPasswordResetTokenRepositoryMockRecorder) Take(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockPasswordResetTokenRepository)(nil).Take), ctx, tokenHash)
+}
+
+// MockInviteRepository is a mock of InviteRepository interface.
+type MockInviteRepository struct {
 	ctrl     *gomock.Controller
-	recorder *MockRepositoryMockRecorder
+	recorder *MockInviteRepositoryMockRecorder
 }
 
-// MockRepositoryMockRecorder is the mock recorder for MockRepository.
-type MockRepositoryMockRecorder struct {
-	mock *MockRepository
+// MockInviteRepositoryMockRecorder is the mock recorder for MockInviteRepository.
+type MockInviteRepositoryMockRecorder struct {
+	mock *MockInviteRepository
 }
 
-// NewMockRepository creates a new mock instance.
-func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
-	mock := &MockRepository{ctrl: ctrl}
-	mock.recorder = &MockRepositoryMockRecorder{mock}
+// NewMockInviteRepository creates a new mock instance.
+func NewMockInviteRepository(ctrl *gomock.Controller) *MockInviteRepository {
+	mock := &MockInviteRepository{ctrl: ctrl}
+	mock.recorder = &MockInviteRepositoryMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+func (m *MockInviteRepository) EXPECT() *MockInviteRepositoryMockRecorder {
 	return m.recorder
 }
 
-// ActivationCode mocks base method.
-func (m *MockRepository) ActivationCode() domain.ActivationCodeRepository {
+// Add mocks base method.
+func (m *MockInviteRepository) Add(ctx context.Context, invite *domain.Invite) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ActivationCode")
-	ret0, _ := ret[0].(domain.ActivationCodeRepository)
+	ret := m.ctrl.Call(m, "Add", ctx, invite)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ActivationCode indicates an expected call of ActivationCode.
-func (mr *MockRepositoryMockRecorder) ActivationCode() *gomock.Call {
+// Add indicates an expected call of Add.
+func (mr *MockInviteRepositoryMockRecorder) Add(ctx, invite interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivationCode", reflect.TypeOf((*MockRepository)(nil).ActivationCode))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockInviteRepository)(nil).Add), ctx, invite)
 }
 
-// BeginTx mocks base method.
-func (m *MockRepository) BeginTx(arg0 context.Context) (domain.TxCommitter, error) {
+// Use mocks base method.
+func (m *MockInviteRepository) Use(ctx context.Context, codeHash string) (*domain.Invite, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BeginTx", arg0)
-	ret0, _ := ret[0].(domain.TxCommitter)
+	ret := m.ctrl.Call(m, "Use", ctx, codeHash)
+	ret0, _ := ret[0].(*domain.Invite)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// BeginTx indicates an expected call of BeginTx.
-func (mr *MockRepositoryMockRecorder) BeginTx(arg0 interface{}) *gomock.Call {
+// Use indicates an expected call of Use.
+func (mr *MockInviteRepositoryMockRecorder) Use(ctx, codeHash interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*MockRepository)(nil).BeginTx), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Use", reflect.TypeOf((*MockInviteRepository)(nil).Use), ctx, codeHash)
 }
 
-// User mocks base method.
-func (m *MockRepository) User() domain.UserRepository {
+// MockMagicLinkTokenRepository is a mock of MagicLinkTokenRepository interface.
+type MockMagicLinkTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMagicLinkTokenRepositoryMockRecorder
+}
+
+// MockMagicLinkTokenRepositoryMockRecorder is the mock recorder for MockMagicLinkTokenRepository.
+type MockMagicLinkTokenRepositoryMockRecorder struct {
+	mock *MockMagicLinkTokenRepository
+}
+
+// NewMockMagicLinkTokenRepository creates a new mock instance.
+func NewMockMagicLinkTokenRepository(ctrl *gomock.Controller) *MockMagicLinkTokenRepository {
+	mock := &MockMagicLinkTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockMagicLinkTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMagicLinkTokenRepository) EXPECT() *MockMagicLinkTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockMagicLinkTokenRepository) Add(ctx context.Context, token *domain.MagicLinkToken) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "User")
-	ret0, _ := ret[0].(domain.UserRepository)
+	ret := m.ctrl.Call(m, "Add", ctx, token)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// User indicates an expected call of User.
-func (mr *MockRepositoryMockRecorder) User() *gomock.Call {
+// Add indicates an expected call of Add.
+func (mr *MockMagicLinkTokenRepositoryMockRecorder) Add(ctx, token interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "User", reflect.TypeOf((*MockRepository)(nil).User))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockMagicLinkTokenRepository)(nil).Add), ctx, token)
 }
 
-// MockTxCommitter is a mock of TxCommitter interface.
-type MockTxCommitter struct {
+// RemoveUserTokens mocks base method.
+func (m *MockMagicLinkTokenRepository) RemoveUserTokens(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveUserTokens indicates an expected call of RemoveUserTokens.
+func (mr *MockMagicLinkTokenRepositoryMockRecorder) RemoveUserTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserTokens", reflect.TypeOf((*MockMagicLinkTokenRepository)(nil).RemoveUserTokens), ctx, userID)
+}
+
+// Take mocks base method.
+func (m *MockMagicLinkTokenRepository) Take(ctx context.Context, tokenHash string) (*domain.MagicLinkToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx, tokenHash)
+	ret0, _ := ret[0].(*domain.MagicLinkToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockMagicLinkTokenRepositoryMockRecorder) Take(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockMagicLinkTokenRepository)(nil).Take), ctx, tokenHash)
+}
+
+// MockEmailChangeRepository is a mock of EmailChangeRepository interface.
+type MockEmailChangeRepository struct {
 	ctrl     *gomock.Controller
-	recorder *MockTxCommitterMockRecorder
+	recorder *MockEmailChangeRepositoryMockRecorder
 }
 
-// MockTxCommitterMockRecorder is the mock recorder for MockTxCommitter.
-type MockTxCommitterMockRecorder struct {
-	mock *MockTxCommitter
+// MockEmailChangeRepositoryMockRecorder is the mock recorder for MockEmailChangeRepository.
+type MockEmailChangeRepositoryMockRecorder struct {
+	mock *MockEmailChangeRepository
 }
 
-// NewMockTxCommitter creates a new mock instance.
-func NewMockTxCommitter(ctrl *gomock.Controller) *MockTxCommitter {
-	mock := &MockTxCommitter{ctrl: ctrl}
-	mock.recorder = &MockTxCommitterMockRecorder{mock}
+// NewMockEmailChangeRepository creates a new mock instance.
+func NewMockEmailChangeRepository(ctrl *gomock.Controller) *MockEmailChangeRepository {
+	mock := &MockEmailChangeRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailChangeRepositoryMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTxCommitter) EXPECT() *MockTxCommitterMockRecorder {
+func (m *MockEmailChangeRepository) EXPECT() *MockEmailChangeRepositoryMockRecorder {
 	return m.recorder
 }
 
-// ActivationCode mocks base method.
-func (m *MockTxCommitter) ActivationCode() domain.ActivationCodeRepository {
+// Add mocks base method.
+func (m *MockEmailChangeRepository) Add(ctx context.Context, change *domain.EmailChange) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ActivationCode")
-	ret0, _ := ret[0].(domain.ActivationCodeRepository)
+	ret := m.ctrl.Call(m, "Add", ctx, change)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ActivationCode indicates an expected call of ActivationCode.
-func (mr *MockTxCommitterMockRecorder) ActivationCode() *gomock.Call {
+// Add indicates an expected call of Add.
+func (mr *MockEmailChangeRepositoryMockRecorder) Add(ctx, change interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivationCode", reflect.TypeOf((*MockTxCommitter)(nil).ActivationCode))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockEmailChangeRepository)(nil).Add), ctx, change)
 }
 
-// Commit mocks base method.
-func (m *MockTxCommitter) Commit() error {
+// Confirm mocks base method.
+func (m *MockEmailChangeRepository) Confirm(ctx context.Context, id int64, undoTokenHash string, undoExpiresAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Commit")
+	ret := m.ctrl.Call(m, "Confirm", ctx, id, undoTokenHash, undoExpiresAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Commit indicates an expected call of Commit.
-func (mr *MockTxCommitterMockRecorder) Commit() *gomock.Call {
+// Confirm indicates an expected call of Confirm.
+func (mr *MockEmailChangeRepositoryMockRecorder) Confirm(ctx, id, undoTokenHash, undoExpiresAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockTxCommitter)(nil).Commit))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockEmailChangeRepository)(nil).Confirm), ctx, id, undoTokenHash, undoExpiresAt)
 }
 
-// Rollback mocks base method.
-func (m *MockTxCommitter) Rollback() {
+// GetPending mocks base method.
+func (m *MockEmailChangeRepository) GetPending(ctx context.Context, userID int64, codeHash string) (*domain.EmailChange, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Rollback")
+	ret := m.ctrl.Call(m, "GetPending", ctx, userID, codeHash)
+	ret0, _ := ret[0].(*domain.EmailChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Rollback indicates an expected call of Rollback.
-func (mr *MockTxCommitterMockRecorder) Rollback() *gomock.Call {
+// GetPending indicates an expected call of GetPending.
+func (mr *MockEmailChangeRepositoryMockRecorder) GetPending(ctx, userID, codeHash interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockTxCommitter)(nil).Rollback))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPending", reflect.TypeOf((*MockEmailChangeRepository)(nil).GetPending), ctx, userID, codeHash)
 }
 
-// User mocks base method.
-func (m *MockTxCommitter) User() domain.UserRepository {
+// RemovePending mocks base method.
+func (m *MockEmailChangeRepository) RemovePending(ctx context.Context, userID int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "User")
-	ret0, _ := ret[0].(domain.UserRepository)
+	ret := m.ctrl.Call(m, "RemovePending", ctx, userID)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// User indicates an expected call of User.
-func (mr *MockTxCommitterMockRecorder) User() *gomock.Call {
+// RemovePending indicates an expected call of RemovePending.
+func (mr *MockEmailChangeRepositoryMockRecorder) RemovePending(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "User", reflect.TypeOf((*MockTxCommitter)(nil).User))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePending", reflect.TypeOf((*MockEmailChangeRepository)(nil).RemovePending), ctx, userID)
+}
+
+// RemoveUserChanges mocks base method.
+func (m *MockEmailChangeRepository) RemoveUserChanges(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserChanges", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveUserChanges indicates an expected call of RemoveUserChanges.
+func (mr *MockEmailChangeRepositoryMockRecorder) RemoveUserChanges(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserChanges", reflect.TypeOf((*MockEmailChangeRepository)(nil).RemoveUserChanges), ctx, userID)
+}
+
+// TakeConfirmed mocks base method.
+func (m *MockEmailChangeRepository) TakeConfirmed(ctx context.Context, undoTokenHash string) (*domain.EmailChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeConfirmed", ctx, undoTokenHash)
+	ret0, _ := ret[0].(*domain.EmailChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeConfirmed indicates an expected call of TakeConfirmed.
+func (mr *MockEmailChangeRepositoryMockRecorder) TakeConfirmed(ctx, undoTokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeConfirmed", reflect.TypeOf((*MockEmailChangeRepository)(nil).TakeConfirmed), ctx, undoTokenHash)
+}
+
+// MockTwoFactorRepository is a mock of TwoFactorRepository interface.
+type MockTwoFactorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorRepositoryMockRecorder
+}
+
+// MockTwoFactorRepositoryMockRecorder is the mock recorder for MockTwoFactorRepository.
+type MockTwoFactorRepositoryMockRecorder struct {
+	mock *MockTwoFactorRepository
+}
+
+// NewMockTwoFactorRepository creates a new mock instance.
+func NewMockTwoFactorRepository(ctrl *gomock.Controller) *MockTwoFactorRepository {
+	mock := &MockTwoFactorRepository{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorRepository) EXPECT() *MockTwoFactorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddAttempt mocks base method.
+func (m *MockTwoFactorRepository) AddAttempt(ctx context.Context, userID int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttempt", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAttempt indicates an expected call of AddAttempt.
+func (mr *MockTwoFactorRepositoryMockRecorder) AddAttempt(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttempt", reflect.TypeOf((*MockTwoFactorRepository)(nil).AddAttempt), ctx, userID)
+}
+
+// AddRecoveryCodes mocks base method.
+func (m *MockTwoFactorRepository) AddRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecoveryCodes", ctx, userID, codeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRecoveryCodes indicates an expected call of AddRecoveryCodes.
+func (mr *MockTwoFactorRepositoryMockRecorder) AddRecoveryCodes(ctx, userID, codeHashes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecoveryCodes", reflect.TypeOf((*MockTwoFactorRepository)(nil).AddRecoveryCodes), ctx, userID, codeHashes)
+}
+
+// Enable mocks base method.
+func (m *MockTwoFactorRepository) Enable(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enable", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enable indicates an expected call of Enable.
+func (mr *MockTwoFactorRepositoryMockRecorder) Enable(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enable", reflect.TypeOf((*MockTwoFactorRepository)(nil).Enable), ctx, userID)
+}
+
+// Get mocks base method.
+func (m *MockTwoFactorRepository) Get(ctx context.Context, userID int64) (*domain.TwoFactor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID)
+	ret0, _ := ret[0].(*domain.TwoFactor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTwoFactorRepositoryMockRecorder) Get(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTwoFactorRepository)(nil).Get), ctx, userID)
+}
+
+// Remove mocks base method.
+func (m *MockTwoFactorRepository) Remove(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockTwoFactorRepositoryMockRecorder) Remove(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockTwoFactorRepository)(nil).Remove), ctx, userID)
+}
+
+// RemoveRecoveryCodes mocks base method.
+func (m *MockTwoFactorRepository) RemoveRecoveryCodes(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRecoveryCodes", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveRecoveryCodes indicates an expected call of RemoveRecoveryCodes.
+func (mr *MockTwoFactorRepositoryMockRecorder) RemoveRecoveryCodes(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRecoveryCodes", reflect.TypeOf((*MockTwoFactorRepository)(nil).RemoveRecoveryCodes), ctx, userID)
+}
+
+// ResetAttempts mocks base method.
+func (m *MockTwoFactorRepository) ResetAttempts(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetAttempts", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetAttempts indicates an expected call of ResetAttempts.
+func (mr *MockTwoFactorRepositoryMockRecorder) ResetAttempts(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAttempts", reflect.TypeOf((*MockTwoFactorRepository)(nil).ResetAttempts), ctx, userID)
+}
+
+// Save mocks base method.
+func (m *MockTwoFactorRepository) Save(ctx context.Context, twoFactor *domain.TwoFactor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, twoFactor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockTwoFactorRepositoryMockRecorder) Save(ctx, twoFactor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTwoFactorRepository)(nil).Save), ctx, twoFactor)
+}
+
+// TakeRecoveryCode mocks base method.
+func (m *MockTwoFactorRepository) TakeRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeRecoveryCode", ctx, userID, codeHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeRecoveryCode indicates an expected call of TakeRecoveryCode.
+func (mr *MockTwoFactorRepositoryMockRecorder) TakeRecoveryCode(ctx, userID, codeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeRecoveryCode", reflect.TypeOf((*MockTwoFactorRepository)(nil).TakeRecoveryCode), ctx, userID, codeHash)
+}
+
+// UseStep mocks base method.
+func (m *MockTwoFactorRepository) UseStep(ctx context.Context, userID, step int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseStep", ctx, userID, step)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseStep indicates an expected call of UseStep.
+func (mr *MockTwoFactorRepositoryMockRecorder) UseStep(ctx, userID, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseStep", reflect.TypeOf((*MockTwoFactorRepository)(nil).UseStep), ctx, userID, step)
+}
+
+// MockTwoFactorChallengeRepository is a mock of TwoFactorChallengeRepository interface.
+type MockTwoFactorChallengeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorChallengeRepositoryMockRecorder
+}
+
+// MockTwoFactorChallengeRepositoryMockRecorder is the mock recorder for MockTwoFactorChallengeRepository.
+type MockTwoFactorChallengeRepositoryMockRecorder struct {
+	mock *MockTwoFactorChallengeRepository
+}
+
+// NewMockTwoFactorChallengeRepository creates a new mock instance.
+func NewMockTwoFactorChallengeRepository(ctrl *gomock.Controller) *MockTwoFactorChallengeRepository {
+	mock := &MockTwoFactorChallengeRepository{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorChallengeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorChallengeRepository) EXPECT() *MockTwoFactorChallengeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockTwoFactorChallengeRepository) Add(ctx context.Context, challenge *domain.TwoFactorChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, challenge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockTwoFactorChallengeRepositoryMockRecorder) Add(ctx, challenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockTwoFactorChallengeRepository)(nil).Add), ctx, challenge)
+}
+
+// AddAttempt mocks base method.
+func (m *MockTwoFactorChallengeRepository) AddAttempt(ctx context.Context, tokenHash string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttempt", ctx, tokenHash)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAttempt indicates an expected call of AddAttempt.
+func (mr *MockTwoFactorChallengeRepositoryMockRecorder) AddAttempt(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttempt", reflect.TypeOf((*MockTwoFactorChallengeRepository)(nil).AddAttempt), ctx, tokenHash)
+}
+
+// Get mocks base method.
+func (m *MockTwoFactorChallengeRepository) Get(ctx context.Context, tokenHash string) (*domain.TwoFactorChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tokenHash)
+	ret0, _ := ret[0].(*domain.TwoFactorChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTwoFactorChallengeRepositoryMockRecorder) Get(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTwoFactorChallengeRepository)(nil).Get), ctx, tokenHash)
+}
+
+// Remove mocks base method.
+func (m *MockTwoFactorChallengeRepository) Remove(ctx context.Context, tokenHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, tokenHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockTwoFactorChallengeRepositoryMockRecorder) Remove(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockTwoFactorChallengeRepository)(nil).Remove), ctx, tokenHash)
+}
+
+// MockExternalIdentityRepository is a mock of ExternalIdentityRepository interface.
+type MockExternalIdentityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExternalIdentityRepositoryMockRecorder
+}
+
+// MockExternalIdentityRepositoryMockRecorder is the mock recorder for MockExternalIdentityRepository.
+type MockExternalIdentityRepositoryMockRecorder struct {
+	mock *MockExternalIdentityRepository
+}
+
+// NewMockExternalIdentityRepository creates a new mock instance.
+func NewMockExternalIdentityRepository(ctrl *gomock.Controller) *MockExternalIdentityRepository {
+	mock := &MockExternalIdentityRepository{ctrl: ctrl}
+	mock.recorder = &MockExternalIdentityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExternalIdentityRepository) EXPECT() *MockExternalIdentityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockExternalIdentityRepository) Add(ctx context.Context, identity *domain.ExternalIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockExternalIdentityRepositoryMockRecorder) Add(ctx, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockExternalIdentityRepository)(nil).Add), ctx, identity)
+}
+
+// Get mocks base method.
+func (m *MockExternalIdentityRepository) Get(ctx context.Context, provider, subject string) (*domain.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, provider, subject)
+	ret0, _ := ret[0].(*domain.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockExternalIdentityRepositoryMockRecorder) Get(ctx, provider, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockExternalIdentityRepository)(nil).Get), ctx, provider, subject)
+}
+
+// MockOIDCStateRepository is a mock of OIDCStateRepository interface.
+type MockOIDCStateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCStateRepositoryMockRecorder
+}
+
+// MockOIDCStateRepositoryMockRecorder is the mock recorder for MockOIDCStateRepository.
+type MockOIDCStateRepositoryMockRecorder struct {
+	mock *MockOIDCStateRepository
+}
+
+// NewMockOIDCStateRepository creates a new mock instance.
+func NewMockOIDCStateRepository(ctrl *gomock.Controller) *MockOIDCStateRepository {
+	mock := &MockOIDCStateRepository{ctrl: ctrl}
+	mock.recorder = &MockOIDCStateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCStateRepository) EXPECT() *MockOIDCStateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockOIDCStateRepository) Add(ctx context.Context, state *domain.OIDCState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockOIDCStateRepositoryMockRecorder) Add(ctx, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockOIDCStateRepository)(nil).Add), ctx, state)
+}
+
+// Take mocks base method.
+func (m *MockOIDCStateRepository) Take(ctx context.Context, stateHash string) (*domain.OIDCState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx, stateHash)
+	ret0, _ := ret[0].(*domain.OIDCState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockOIDCStateRepositoryMockRecorder) Take(ctx, stateHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockOIDCStateRepository)(nil).Take), ctx, stateHash)
+}
+
+// MockWebAuthnCredentialRepository is a mock of WebAuthnCredentialRepository interface.
+type MockWebAuthnCredentialRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebAuthnCredentialRepositoryMockRecorder
+}
+
+// MockWebAuthnCredentialRepositoryMockRecorder is the mock recorder for MockWebAuthnCredentialRepository.
+type MockWebAuthnCredentialRepositoryMockRecorder struct {
+	mock *MockWebAuthnCredentialRepository
+}
+
+// NewMockWebAuthnCredentialRepository creates a new mock instance.
+func NewMockWebAuthnCredentialRepository(ctrl *gomock.Controller) *MockWebAuthnCredentialRepository {
+	mock := &MockWebAuthnCredentialRepository{ctrl: ctrl}
+	mock.recorder = &MockWebAuthnCredentialRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebAuthnCredentialRepository) EXPECT() *MockWebAuthnCredentialRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockWebAuthnCredentialRepository) Add(ctx context.Context, credential *domain.WebAuthnCredential) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, credential)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockWebAuthnCredentialRepositoryMockRecorder) Add(ctx, credential interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockWebAuthnCredentialRepository)(nil).Add), ctx, credential)
+}
+
+// Get mocks base method.
+func (m *MockWebAuthnCredentialRepository) Get(ctx context.Context, id string) (*domain.WebAuthnCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*domain.WebAuthnCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWebAuthnCredentialRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWebAuthnCredentialRepository)(nil).Get), ctx, id)
+}
+
+// GetByUser mocks base method.
+func (m *MockWebAuthnCredentialRepository) GetByUser(ctx context.Context, userID int64) ([]*domain.WebAuthnCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userID)
+	ret0, _ := ret[0].([]*domain.WebAuthnCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockWebAuthnCredentialRepositoryMockRecorder) GetByUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockWebAuthnCredentialRepository)(nil).GetByUser), ctx, userID)
+}
+
+// Touch mocks base method.
+func (m *MockWebAuthnCredentialRepository) Touch(ctx context.Context, id string, signCount uint32, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, id, signCount, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockWebAuthnCredentialRepositoryMockRecorder) Touch(ctx, id, signCount, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockWebAuthnCredentialRepository)(nil).Touch), ctx, id, signCount, usedAt)
+}
+
+// MockWebAuthnChallengeRepository is a mock of WebAuthnChallengeRepository interface.
+type MockWebAuthnChallengeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebAuthnChallengeRepositoryMockRecorder
+}
+
+// MockWebAuthnChallengeRepositoryMockRecorder is the mock recorder for MockWebAuthnChallengeRepository.
+type MockWebAuthnChallengeRepositoryMockRecorder struct {
+	mock *MockWebAuthnChallengeRepository
+}
+
+// NewMockWebAuthnChallengeRepository creates a new mock instance.
+func NewMockWebAuthnChallengeRepository(ctrl *gomock.Controller) *MockWebAuthnChallengeRepository {
+	mock := &MockWebAuthnChallengeRepository{ctrl: ctrl}
+	mock.recorder = &MockWebAuthnChallengeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebAuthnChallengeRepository) EXPECT() *MockWebAuthnChallengeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockWebAuthnChallengeRepository) Add(ctx context.Context, challenge *domain.WebAuthnChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, challenge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockWebAuthnChallengeRepositoryMockRecorder) Add(ctx, challenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockWebAuthnChallengeRepository)(nil).Add), ctx, challenge)
+}
+
+// Take mocks base method.
+func (m *MockWebAuthnChallengeRepository) Take(ctx context.Context, challengeHash string) (*domain.WebAuthnChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx, challengeHash)
+	ret0, _ := ret[0].(*domain.WebAuthnChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockWebAuthnChallengeRepositoryMockRecorder) Take(ctx, challengeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockWebAuthnChallengeRepository)(nil).Take), ctx, challengeHash)
+}
+
+// MockSessionRepository is a mock of SessionRepository interface.
+type MockSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionRepositoryMockRecorder
+}
+
+// MockSessionRepositoryMockRecorder is the mock recorder for MockSessionRepository.
+type MockSessionRepositoryMockRecorder struct {
+	mock *MockSessionRepository
+}
+
+// NewMockSessionRepository creates a new mock instance.
+func NewMockSessionRepository(ctrl *gomock.Controller) *MockSessionRepository {
+	mock := &MockSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionRepository) EXPECT() *MockSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockSessionRepository) Add(ctx context.Context, session *domain.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockSessionRepositoryMockRecorder) Add(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockSessionRepository)(nil).Add), ctx, session)
+}
+
+// Get mocks base method.
+func (m *MockSessionRepository) Get(ctx context.Context, id string) (*domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSessionRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSessionRepository)(nil).Get), ctx, id)
+}
+
+// GetActive mocks base method.
+func (m *MockSessionRepository) GetActive(ctx context.Context, userID int64) ([]*domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActive", ctx, userID)
+	ret0, _ := ret[0].([]*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActive indicates an expected call of GetActive.
+func (mr *MockSessionRepositoryMockRecorder) GetActive(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActive", reflect.TypeOf((*MockSessionRepository)(nil).GetActive), ctx, userID)
+}
+
+// Revoke mocks base method.
+func (m *MockSessionRepository) Revoke(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockSessionRepositoryMockRecorder) Revoke(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockSessionRepository)(nil).Revoke), ctx, id)
+}
+
+// RevokeUser mocks base method.
+func (m *MockSessionRepository) RevokeUser(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUser indicates an expected call of RevokeUser.
+func (mr *MockSessionRepositoryMockRecorder) RevokeUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUser", reflect.TypeOf((*MockSessionRepository)(nil).RevokeUser), ctx, userID)
+}
+
+// Touch mocks base method.
+func (m *MockSessionRepository) Touch(ctx context.Context, id string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockSessionRepositoryMockRecorder) Touch(ctx, id, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockSessionRepository)(nil).Touch), ctx, id, usedAt)
+}
+
+// MockPersonalAccessTokenRepository is a mock of PersonalAccessTokenRepository interface.
+type MockPersonalAccessTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonalAccessTokenRepositoryMockRecorder
+}
+
+// MockPersonalAccessTokenRepositoryMockRecorder is the mock recorder for MockPersonalAccessTokenRepository.
+type MockPersonalAccessTokenRepositoryMockRecorder struct {
+	mock *MockPersonalAccessTokenRepository
+}
+
+// NewMockPersonalAccessTokenRepository creates a new mock instance.
+func NewMockPersonalAccessTokenRepository(ctrl *gomock.Controller) *MockPersonalAccessTokenRepository {
+	mock := &MockPersonalAccessTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockPersonalAccessTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonalAccessTokenRepository) EXPECT() *MockPersonalAccessTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockPersonalAccessTokenRepository) Add(ctx context.Context, token *domain.PersonalAccessToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) Add(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).Add), ctx, token)
+}
+
+// Get mocks base method.
+func (m *MockPersonalAccessTokenRepository) Get(ctx context.Context, id string) (*domain.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*domain.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).Get), ctx, id)
+}
+
+// GetActive mocks base method.
+func (m *MockPersonalAccessTokenRepository) GetActive(ctx context.Context, userID int64) ([]*domain.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActive", ctx, userID)
+	ret0, _ := ret[0].([]*domain.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActive indicates an expected call of GetActive.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) GetActive(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActive", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).GetActive), ctx, userID)
+}
+
+// GetByHash mocks base method.
+func (m *MockPersonalAccessTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*domain.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) GetByHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).GetByHash), ctx, tokenHash)
+}
+
+// Revoke mocks base method.
+func (m *MockPersonalAccessTokenRepository) Revoke(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) Revoke(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).Revoke), ctx, id)
+}
+
+// MockRefreshTokenRepository is a mock of RefreshTokenRepository interface.
+type MockRefreshTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenRepositoryMockRecorder
+}
+
+// MockRefreshTokenRepositoryMockRecorder is the mock recorder for MockRefreshTokenRepository.
+type MockRefreshTokenRepositoryMockRecorder struct {
+	mock *MockRefreshTokenRepository
+}
+
+// NewMockRefreshTokenRepository creates a new mock instance.
+func NewMockRefreshTokenRepository(ctrl *gomock.Controller) *MockRefreshTokenRepository {
+	mock := &MockRefreshTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenRepository) EXPECT() *MockRefreshTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockRefreshTokenRepository) Add(ctx context.Context, token *domain.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockRefreshTokenRepositoryMockRecorder) Add(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockRefreshTokenRepository)(nil).Add), ctx, token)
+}
+
+// GetByHash mocks base method.
+func (m *MockRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*domain.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockRefreshTokenRepositoryMockRecorder) GetByHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockRefreshTokenRepository)(nil).GetByHash), ctx, tokenHash)
+}
+
+// MarkUsed mocks base method.
+func (m *MockRefreshTokenRepository) MarkUsed(ctx context.Context, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockRefreshTokenRepositoryMockRecorder) MarkUsed(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockRefreshTokenRepository)(nil).MarkUsed), ctx, id)
+}
+
+// RevokeFamily mocks base method.
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MockRefreshTokenRepositoryMockRecorder) RevokeFamily(ctx, familyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MockRefreshTokenRepository)(nil).RevokeFamily), ctx, familyID)
+}
+
+// RevokeUser mocks base method.
+func (m *MockRefreshTokenRepository) RevokeUser(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUser indicates an expected call of RevokeUser.
+func (mr *MockRefreshTokenRepositoryMockRecorder) RevokeUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUser", reflect.TypeOf((*MockRefreshTokenRepository)(nil).RevokeUser), ctx, userID)
+}
+
+// MockAccessTokenRevocationRepository is a mock of AccessTokenRevocationRepository interface.
+type MockAccessTokenRevocationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessTokenRevocationRepositoryMockRecorder
+}
+
+// MockAccessTokenRevocationRepositoryMockRecorder is the mock recorder for MockAccessTokenRevocationRepository.
+type MockAccessTokenRevocationRepositoryMockRecorder struct {
+	mock *MockAccessTokenRevocationRepository
+}
+
+// NewMockAccessTokenRevocationRepository creates a new mock instance.
+func NewMockAccessTokenRevocationRepository(ctrl *gomock.Controller) *MockAccessTokenRevocationRepository {
+	mock := &MockAccessTokenRevocationRepository{ctrl: ctrl}
+	mock.recorder = &MockAccessTokenRevocationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccessTokenRevocationRepository) EXPECT() *MockAccessTokenRevocationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddToken mocks base method.
+func (m *MockAccessTokenRevocationRepository) AddToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToken", ctx, tokenID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToken indicates an expected call of AddToken.
+func (mr *MockAccessTokenRevocationRepositoryMockRecorder) AddToken(ctx, tokenID, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToken", reflect.TypeOf((*MockAccessTokenRevocationRepository)(nil).AddToken), ctx, tokenID, expiresAt)
+}
+
+// AddUser mocks base method.
+func (m *MockAccessTokenRevocationRepository) AddUser(ctx context.Context, userID int64, revokedBefore, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUser", ctx, userID, revokedBefore, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUser indicates an expected call of AddUser.
+func (mr *MockAccessTokenRevocationRepositoryMockRecorder) AddUser(ctx, userID, revokedBefore, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockAccessTokenRevocationRepository)(nil).AddUser), ctx, userID, revokedBefore, expiresAt)
+}
+
+// IsRevoked mocks base method.
+func (m *MockAccessTokenRevocationRepository) IsRevoked(ctx context.Context, tokenID string, userID int64, issuedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", ctx, tokenID, userID, issuedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockAccessTokenRevocationRepositoryMockRecorder) IsRevoked(ctx, tokenID, userID, issuedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockAccessTokenRevocationRepository)(nil).IsRevoked), ctx, tokenID, userID, issuedAt)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockAccessTokenRevocationRepository) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", ctx, tokenID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockAccessTokenRevocationRepositoryMockRecorder) IsTokenRevoked(ctx, tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockAccessTokenRevocationRepository)(nil).IsTokenRevoked), ctx, tokenID)
+}
+
+// MockLoginAttemptRepository is a mock of LoginAttemptRepository interface.
+type MockLoginAttemptRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptRepositoryMockRecorder
+}
+
+// MockLoginAttemptRepositoryMockRecorder is the mock recorder for MockLoginAttemptRepository.
+type MockLoginAttemptRepositoryMockRecorder struct {
+	mock *MockLoginAttemptRepository
+}
+
+// NewMockLoginAttemptRepository creates a new mock instance.
+func NewMockLoginAttemptRepository(ctrl *gomock.Controller) *MockLoginAttemptRepository {
+	mock := &MockLoginAttemptRepository{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttemptRepository) EXPECT() *MockLoginAttemptRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddFailure mocks base method.
+func (m *MockLoginAttemptRepository) AddFailure(ctx context.Context, key string, failedAt, resetBefore time.Time) (*domain.LoginAttempts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFailure", ctx, key, failedAt, resetBefore)
+	ret0, _ := ret[0].(*domain.LoginAttempts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFailure indicates an expected call of AddFailure.
+func (mr *MockLoginAttemptRepositoryMockRecorder) AddFailure(ctx, key, failedAt, resetBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFailure", reflect.TypeOf((*MockLoginAttemptRepository)(nil).AddFailure), ctx, key, failedAt, resetBefore)
+}
+
+// Get mocks base method.
+func (m *MockLoginAttemptRepository) Get(ctx context.Context, key string) (*domain.LoginAttempts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(*domain.LoginAttempts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockLoginAttemptRepositoryMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLoginAttemptRepository)(nil).Get), ctx, key)
+}
+
+// Remove mocks base method.
+func (m *MockLoginAttemptRepository) Remove(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockLoginAttemptRepositoryMockRecorder) Remove(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockLoginAttemptRepository)(nil).Remove), ctx, key)
+}
+
+// MockAuditLogRepository is a mock of AuditLogRepository interface.
+type MockAuditLogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogRepositoryMockRecorder
+}
+
+// MockAuditLogRepositoryMockRecorder is the mock recorder for MockAuditLogRepository.
+type MockAuditLogRepositoryMockRecorder struct {
+	mock *MockAuditLogRepository
+}
+
+// NewMockAuditLogRepository creates a new mock instance.
+func NewMockAuditLogRepository(ctrl *gomock.Controller) *MockAuditLogRepository {
+	mock := &MockAuditLogRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditLogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLogRepository) EXPECT() *MockAuditLogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockAuditLogRepository) Add(ctx context.Context, event *domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockAuditLogRepositoryMockRecorder) Add(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockAuditLogRepository)(nil).Add), ctx, event)
+}
+
+// Find mocks base method.
+func (m *MockAuditLogRepository) Find(ctx context.Context, filter *domain.AuditEventFilter) ([]*domain.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, filter)
+	ret0, _ := ret[0].([]*domain.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockAuditLogRepositoryMockRecorder) Find(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockAuditLogRepository)(nil).Find), ctx, filter)
+}
+
+// MockrepositoryGetter is a mock of repositoryGetter interface.
+type MockrepositoryGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockrepositoryGetterMockRecorder
+}
+
+// MockrepositoryGetterMockRecorder is the mock recorder for MockrepositoryGetter.
+type MockrepositoryGetterMockRecorder struct {
+	mock *MockrepositoryGetter
+}
+
+// NewMockrepositoryGetter creates a new mock instance.
+func NewMockrepositoryGetter(ctrl *gomock.Controller) *MockrepositoryGetter {
+	mock := &MockrepositoryGetter{ctrl: ctrl}
+	mock.recorder = &MockrepositoryGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrepositoryGetter) EXPECT() *MockrepositoryGetterMockRecorder {
+	return m.recorder
+}
+
+// AccessTokenRevocation mocks base method.
+func (m *MockrepositoryGetter) AccessTokenRevocation() domain.AccessTokenRevocationRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccessTokenRevocation")
+	ret0, _ := ret[0].(domain.AccessTokenRevocationRepository)
+	return ret0
+}
+
+// AccessTokenRevocation indicates an expected call of AccessTokenRevocation.
+func (mr *MockrepositoryGetterMockRecorder) AccessTokenRevocation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccessTokenRevocation", reflect.TypeOf((*MockrepositoryGetter)(nil).AccessTokenRevocation))
+}
+
+// ActivationCode mocks base method.
+func (m *MockrepositoryGetter) ActivationCode() domain.ActivationCodeRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivationCode")
+	ret0, _ := ret[0].(domain.ActivationCodeRepository)
+	return ret0
+}
+
+// ActivationCode indicates an expected call of ActivationCode.
+func (mr *MockrepositoryGetterMockRecorder) ActivationCode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivationCode", reflect.TypeOf((*MockrepositoryGetter)(nil).ActivationCode))
+}
+
+// AuditLog mocks base method.
+func (m *MockrepositoryGetter) AuditLog() domain.AuditLogRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditLog")
+	ret0, _ := ret[0].(domain.AuditLogRepository)
+	return ret0
+}
+
+// AuditLog indicates an expected call of AuditLog.
+func (mr *MockrepositoryGetterMockRecorder) AuditLog() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditLog", reflect.TypeOf((*MockrepositoryGetter)(nil).AuditLog))
+}
+
+// EmailChange mocks base method.
+func (m *MockrepositoryGetter) EmailChange() domain.EmailChangeRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmailChange")
+	ret0, _ := ret[0].(domain.EmailChangeRepository)
+	return ret0
+}
+
+// EmailChange indicates an expected call of EmailChange.
+func (mr *MockrepositoryGetterMockRecorder) EmailChange() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmailChange", reflect.TypeOf((*MockrepositoryGetter)(nil).EmailChange))
+}
+
+// ExternalIdentity mocks base method.
+func (m *MockrepositoryGetter) ExternalIdentity() domain.ExternalIdentityRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExternalIdentity")
+	ret0, _ := ret[0].(domain.ExternalIdentityRepository)
+	return ret0
+}
+
+// ExternalIdentity indicates an expected call of ExternalIdentity.
+func (mr *MockrepositoryGetterMockRecorder) ExternalIdentity() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExternalIdentity", reflect.TypeOf((*MockrepositoryGetter)(nil).ExternalIdentity))
+}
+
+// Invite mocks base method.
+func (m *MockrepositoryGetter) Invite() domain.InviteRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite")
+	ret0, _ := ret[0].(domain.InviteRepository)
+	return ret0
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockrepositoryGetterMockRecorder) Invite() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockrepositoryGetter)(nil).Invite))
+}
+
+// MagicLinkToken mocks base method.
+func (m *MockrepositoryGetter) MagicLinkToken() domain.MagicLinkTokenRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MagicLinkToken")
+	ret0, _ := ret[0].(domain.MagicLinkTokenRepository)
+	return ret0
+}
+
+// MagicLinkToken indicates an expected call of MagicLinkToken.
+func (mr *MockrepositoryGetterMockRecorder) MagicLinkToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MagicLinkToken", reflect.TypeOf((*MockrepositoryGetter)(nil).MagicLinkToken))
+}
+
+// OIDCState mocks base method.
+func (m *MockrepositoryGetter) OIDCState() domain.OIDCStateRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OIDCState")
+	ret0, _ := ret[0].(domain.OIDCStateRepository)
+	return ret0
+}
+
+// OIDCState indicates an expected call of OIDCState.
+func (mr *MockrepositoryGetterMockRecorder) OIDCState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OIDCState", reflect.TypeOf((*MockrepositoryGetter)(nil).OIDCState))
+}
+
+// PasswordResetToken mocks base method.
+func (m *MockrepositoryGetter) PasswordResetToken() domain.PasswordResetTokenRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordResetToken")
+	ret0, _ := ret[0].(domain.PasswordResetTokenRepository)
+	return ret0
+}
+
+// PasswordResetToken indicates an expected call of PasswordResetToken.
+func (mr *MockrepositoryGetterMockRecorder) PasswordResetToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordResetToken", reflect.TypeOf((*MockrepositoryGetter)(nil).PasswordResetToken))
+}
+
+// PersonalAccessToken mocks base method.
+func (m *MockrepositoryGetter) PersonalAccessToken() domain.PersonalAccessTokenRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PersonalAccessToken")
+	ret0, _ := ret[0].(domain.PersonalAccessTokenRepository)
+	return ret0
+}
+
+// PersonalAccessToken indicates an expected call of PersonalAccessToken.
+func (mr *MockrepositoryGetterMockRecorder) PersonalAccessToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersonalAccessToken", reflect.TypeOf((*MockrepositoryGetter)(nil).PersonalAccessToken))
+}
+
+// RefreshToken mocks base method.
+func (m *MockrepositoryGetter) RefreshToken() domain.RefreshTokenRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken")
+	ret0, _ := ret[0].(domain.RefreshTokenRepository)
+	return ret0
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockrepositoryGetterMockRecorder) RefreshToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockrepositoryGetter)(nil).RefreshToken))
+}
+
+// Role mocks base method.
+func (m *MockrepositoryGetter) Role() domain.RoleRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Role")
+	ret0, _ := ret[0].(domain.RoleRepository)
+	return ret0
+}
+
+// Role indicates an expected call of Role.
+func (mr *MockrepositoryGetterMockRecorder) Role() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Role", reflect.TypeOf((*MockrepositoryGetter)(nil).Role))
+}
+
+// Session mocks base method.
+func (m *MockrepositoryGetter) Session() domain.SessionRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Session")
+	ret0, _ := ret[0].(domain.SessionRepository)
+	return ret0
+}
+
+// Session indicates an expected call of Session.
+func (mr *MockrepositoryGetterMockRecorder) Session() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Session", reflect.TypeOf((*MockrepositoryGetter)(nil).Session))
+}
+
+// Suspension mocks base method.
+func (m *MockrepositoryGetter) Suspension() domain.SuspensionRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suspension")
+	ret0, _ := ret[0].(domain.SuspensionRepository)
+	return ret0
+}
+
+// Suspension indicates an expected call of Suspension.
+func (mr *MockrepositoryGetterMockRecorder) Suspension() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suspension", reflect.TypeOf((*MockrepositoryGetter)(nil).Suspension))
+}
+
+// TwoFactor mocks base method.
+func (m *MockrepositoryGetter) TwoFactor() domain.TwoFactorRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TwoFactor")
+	ret0, _ := ret[0].(domain.TwoFactorRepository)
+	return ret0
+}
+
+// TwoFactor indicates an expected call of TwoFactor.
+func (mr *MockrepositoryGetterMockRecorder) TwoFactor() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TwoFactor", reflect.TypeOf((*MockrepositoryGetter)(nil).TwoFactor))
+}
+
+// TwoFactorChallenge mocks base method.
+func (m *MockrepositoryGetter) TwoFactorChallenge() domain.TwoFactorChallengeRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TwoFactorChallenge")
+	ret0, _ := ret[0].(domain.TwoFactorChallengeRepository)
+	return ret0
+}
+
+// TwoFactorChallenge indicates an expected call of TwoFactorChallenge.
+func (mr *MockrepositoryGetterMockRecorder) TwoFactorChallenge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TwoFactorChallenge", reflect.TypeOf((*MockrepositoryGetter)(nil).TwoFactorChallenge))
+}
+
+// User mocks base method.
+func (m *MockrepositoryGetter) User() domain.UserRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "User")
+	ret0, _ := ret[0].(domain.UserRepository)
+	return ret0
+}
+
+// User indicates an expected call of User.
+func (mr *MockrepositoryGetterMockRecorder) User() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "User", reflect.TypeOf((*MockrepositoryGetter)(nil).User))
+}
+
+// WebAuthnChallenge mocks base method.
+func (m *MockrepositoryGetter) WebAuthnChallenge() domain.WebAuthnChallengeRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebAuthnChallenge")
+	ret0, _ := ret[0].(domain.WebAuthnChallengeRepository)
+	return ret0
+}
+
+// WebAuthnChallenge indicates an expected call of WebAuthnChallenge.
+func (mr *MockrepositoryGetterMockRecorder) WebAuthnChallenge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebAuthnChallenge", reflect.TypeOf((*MockrepositoryGetter)(nil).WebAuthnChallenge))
+}
+
+// WebAuthnCredential mocks base method.
+func (m *MockrepositoryGetter) WebAuthnCredential() domain.WebAuthnCredentialRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebAuthnCredential")
+	ret0, _ := ret[0].(domain.WebAuthnCredentialRepository)
+	return ret0
+}
+
+// WebAuthnCredential indicates an expected call of WebAuthnCredential.
+func (mr *MockrepositoryGetterMockRecorder) WebAuthnCredential() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebAuthnCredential", reflect.TypeOf((*MockrepositoryGetter)(nil).WebAuthnCredential))
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AccessTokenRevocation mocks base method.
+func (m *MockRepository) AccessTokenRevocation() domain.AccessTokenRevocationRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccessTokenRevocation")
+	ret0, _ := ret[0].(domain.AccessTokenRevocationRepository)
+	return ret0
+}
+
+// AccessTokenRevocation indicates an expected call of AccessTokenRevocation.
+func (mr *MockRepositoryMockRecorder) AccessTokenRevocation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccessTokenRevocation", reflect.TypeOf((*MockRepository)(nil).AccessTokenRevocation))
+}
+
+// ActivationCode mocks base method.
+func (m *MockRepository) ActivationCode() domain.ActivationCodeRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivationCode")
+	ret0, _ := ret[0].(domain.ActivationCodeRepository)
+	return ret0
+}
+
+// ActivationCode indicates an expected call of ActivationCode.
+func (mr *MockRepositoryMockRecorder) ActivationCode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivationCode", reflect.TypeOf((*MockRepository)(nil).ActivationCode))
+}
+
+// AuditLog mocks base method.
+func (m *MockRepository) AuditLog() domain.AuditLogRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditLog")
+	ret0, _ := ret[0].(domain.AuditLogRepository)
+	return ret0
+}
+
+// AuditLog indicates an expected call of AuditLog.
+func (mr *MockRepositoryMockRecorder) AuditLog() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditLog", reflect.TypeOf((*MockRepository)(nil).AuditLog))
+}
+
+// BeginTx mocks base method.
+func (m *MockRepository) BeginTx(arg0 context.Context) (domain.TxCommitter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", arg0)
+	ret0, _ := ret[0].(domain.TxCommitter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockRepositoryMockRecorder) BeginTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*MockRepository)(nil).BeginTx), arg0)
+}
+
+// EmailChange mocks base method.
+func (m *MockRepository) EmailChange() domain.EmailChangeRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmailChange")
+	ret0, _ := ret[0].(domain.EmailChangeRepository)
+	return ret0
+}
+
+// EmailChange indicates an expected call of EmailChange.
+func (mr *MockRepositoryMockRecorder) EmailChange() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmailChange", reflect.TypeOf((*MockRepository)(nil).EmailChange))
+}
+
+// ExternalIdentity mocks base method.
+func (m *MockRepository) ExternalIdentity() domain.ExternalIdentityRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExternalIdentity")
+	ret0, _ := ret[0].(domain.ExternalIdentityRepository)
+	return ret0
+}
+
+// ExternalIdentity indicates an expected call of ExternalIdentity.
+func (mr *MockRepositoryMockRecorder) ExternalIdentity() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExternalIdentity", reflect.TypeOf((*MockRepository)(nil).ExternalIdentity))
+}
+
+// Invite mocks base method.
+func (m *MockRepository) Invite() domain.InviteRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite")
+	ret0, _ := ret[0].(domain.InviteRepository)
+	return ret0
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockRepositoryMockRecorder) Invite() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockRepository)(nil).Invite))
+}
+
+// MagicLinkToken mocks base method.
+func (m *MockRepository) MagicLinkToken() domain.MagicLinkTokenRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MagicLinkToken")
+	ret0, _ := ret[0].(domain.MagicLinkTokenRepository)
+	return ret0
+}
+
+// MagicLinkToken indicates an expected call of MagicLinkToken.
+func (mr *MockRepositoryMockRecorder) MagicLinkToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MagicLinkToken", reflect.TypeOf((*MockRepository)(nil).MagicLinkToken))
+}
+
+// OIDCState mocks base method.
+func (m *MockRepository) OIDCState() domain.OIDCStateRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OIDCState")
+	ret0, _ := ret[0].(domain.OIDCStateRepository)
+	return ret0
+}
+
+// OIDCState indicates an expected call of OIDCState.
+func (mr *MockRepositoryMockRecorder) OIDCState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OIDCState", reflect.TypeOf((*MockRepository)(nil).OIDCState))
+}
+
+// PasswordResetToken mocks base method.
+func (m *MockRepository) PasswordResetToken() domain.PasswordResetTokenRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordResetToken")
+	ret0, _ := ret[0].(domain.PasswordResetTokenRepository)
+	return ret0
+}
+
+// PasswordResetToken indicates an expected call of PasswordResetToken.
+func (mr *MockRepositoryMockRecorder) PasswordResetToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordResetToken", reflect.TypeOf((*MockRepository)(nil).PasswordResetToken))
+}
+
+// PersonalAccessToken mocks base method.
+func (m *MockRepository) PersonalAccessToken() domain.PersonalAccessTokenRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PersonalAccessToken")
+	ret0, _ := ret[0].(domain.PersonalAccessTokenRepository)
+	return ret0
+}
+
+// PersonalAccessToken indicates an expected call of PersonalAccessToken.
+func (mr *MockRepositoryMockRecorder) PersonalAccessToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersonalAccessToken", reflect.TypeOf((*MockRepository)(nil).PersonalAccessToken))
+}
+
+// RefreshToken mocks base method.
+func (m *MockRepository) RefreshToken() domain.RefreshTokenRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken")
+	ret0, _ := ret[0].(domain.RefreshTokenRepository)
+	return ret0
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockRepositoryMockRecorder) RefreshToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockRepository)(nil).RefreshToken))
+}
+
+// Role mocks base method.
+func (m *MockRepository) Role() domain.RoleRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Role")
+	ret0, _ := ret[0].(domain.RoleRepository)
+	return ret0
+}
+
+// Role indicates an expected call of Role.
+func (mr *MockRepositoryMockRecorder) Role() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Role", reflect.TypeOf((*MockRepository)(nil).Role))
+}
+
+// Session mocks base method.
+func (m *MockRepository) Session() domain.SessionRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Session")
+	ret0, _ := ret[0].(domain.SessionRepository)
+	return ret0
+}
+
+// Session indicates an expected call of Session.
+func (mr *MockRepositoryMockRecorder) Session() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Session", reflect.TypeOf((*MockRepository)(nil).Session))
+}
+
+// Suspension mocks base method.
+func (m *MockRepository) Suspension() domain.SuspensionRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suspension")
+	ret0, _ := ret[0].(domain.SuspensionRepository)
+	return ret0
+}
+
+// Suspension indicates an expected call of Suspension.
+func (mr *MockRepositoryMockRecorder) Suspension() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suspension", reflect.TypeOf((*MockRepository)(nil).Suspension))
+}
+
+// TwoFactor mocks base method.
+func (m *MockRepository) TwoFactor() domain.TwoFactorRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TwoFactor")
+	ret0, _ := ret[0].(domain.TwoFactorRepository)
+	return ret0
+}
+
+// TwoFactor indicates an expected call of TwoFactor.
+func (mr *MockRepositoryMockRecorder) TwoFactor() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TwoFactor", reflect.TypeOf((*MockRepository)(nil).TwoFactor))
+}
+
+// TwoFactorChallenge mocks base method.
+func (m *MockRepository) TwoFactorChallenge() domain.TwoFactorChallengeRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TwoFactorChallenge")
+	ret0, _ := ret[0].(domain.TwoFactorChallengeRepository)
+	return ret0
+}
+
+// TwoFactorChallenge indicates an expected call of TwoFactorChallenge.
+func (mr *MockRepositoryMockRecorder) TwoFactorChallenge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TwoFactorChallenge", reflect.TypeOf((*MockRepository)(nil).TwoFactorChallenge))
+}
+
+// User mocks base method.
+func (m *MockRepository) User() domain.UserRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "User")
+	ret0, _ := ret[0].(domain.UserRepository)
+	return ret0
+}
+
+// User indicates an expected call of User.
+func (mr *MockRepositoryMockRecorder) User() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "User", reflect.TypeOf((*MockRepository)(nil).User))
+}
+
+// WebAuthnChallenge mocks base method.
+func (m *MockRepository) WebAuthnChallenge() domain.WebAuthnChallengeRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebAuthnChallenge")
+	ret0, _ := ret[0].(domain.WebAuthnChallengeRepository)
+	return ret0
+}
+
+// WebAuthnChallenge indicates an expected call of WebAuthnChallenge.
+func (mr *MockRepositoryMockRecorder) WebAuthnChallenge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebAuthnChallenge", reflect.TypeOf((*MockRepository)(nil).WebAuthnChallenge))
+}
+
+// WebAuthnCredential mocks base method.
+func (m *MockRepository) WebAuthnCredential() domain.WebAuthnCredentialRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebAuthnCredential")
+	ret0, _ := ret[0].(domain.WebAuthnCredentialRepository)
+	return ret0
+}
+
+// WebAuthnCredential indicates an expected call of WebAuthnCredential.
+func (mr *MockRepositoryMockRecorder) WebAuthnCredential() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebAuthnCredential", reflect.TypeOf((*MockRepository)(nil).WebAuthnCredential))
+}
+
+// MockTxCommitter is a mock of TxCommitter interface.
+type MockTxCommitter struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxCommitterMockRecorder
+}
+
+// MockTxCommitterMockRecorder is the mock recorder for MockTxCommitter.
+type MockTxCommitterMockRecorder struct {
+	mock *MockTxCommitter
+}
+
+// NewMockTxCommitter creates a new mock instance.
+func NewMockTxCommitter(ctrl *gomock.Controller) *MockTxCommitter {
+	mock := &MockTxCommitter{ctrl: ctrl}
+	mock.recorder = &MockTxCommitterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTxCommitter) EXPECT() *MockTxCommitterMockRecorder {
+	return m.recorder
+}
+
+// AccessTokenRevocation mocks base method.
+func (m *MockTxCommitter) AccessTokenRevocation() domain.AccessTokenRevocationRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccessTokenRevocation")
+	ret0, _ := ret[0].(domain.AccessTokenRevocationRepository)
+	return ret0
+}
+
+// AccessTokenRevocation indicates an expected call of AccessTokenRevocation.
+func (mr *MockTxCommitterMockRecorder) AccessTokenRevocation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccessTokenRevocation", reflect.TypeOf((*MockTxCommitter)(nil).AccessTokenRevocation))
+}
+
+// ActivationCode mocks base method.
+func (m *MockTxCommitter) ActivationCode() domain.ActivationCodeRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivationCode")
+	ret0, _ := ret[0].(domain.ActivationCodeRepository)
+	return ret0
+}
+
+// ActivationCode indicates an expected call of ActivationCode.
+func (mr *MockTxCommitterMockRecorder) ActivationCode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivationCode", reflect.TypeOf((*MockTxCommitter)(nil).ActivationCode))
+}
+
+// AuditLog mocks base method.
+func (m *MockTxCommitter) AuditLog() domain.AuditLogRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditLog")
+	ret0, _ := ret[0].(domain.AuditLogRepository)
+	return ret0
+}
+
+// AuditLog indicates an expected call of AuditLog.
+func (mr *MockTxCommitterMockRecorder) AuditLog() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditLog", reflect.TypeOf((*MockTxCommitter)(nil).AuditLog))
+}
+
+// Commit mocks base method.
+func (m *MockTxCommitter) Commit() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockTxCommitterMockRecorder) Commit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockTxCommitter)(nil).Commit))
+}
+
+// EmailChange mocks base method.
+func (m *MockTxCommitter) EmailChange() domain.EmailChangeRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmailChange")
+	ret0, _ := ret[0].(domain.EmailChangeRepository)
+	return ret0
+}
+
+// EmailChange indicates an expected call of EmailChange.
+func (mr *MockTxCommitterMockRecorder) EmailChange() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmailChange", reflect.TypeOf((*MockTxCommitter)(nil).EmailChange))
+}
+
+// ExternalIdentity mocks base method.
+func (m *MockTxCommitter) ExternalIdentity() domain.ExternalIdentityRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExternalIdentity")
+	ret0, _ := ret[0].(domain.ExternalIdentityRepository)
+	return ret0
+}
+
+// ExternalIdentity indicates an expected call of ExternalIdentity.
+func (mr *MockTxCommitterMockRecorder) ExternalIdentity() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExternalIdentity", reflect.TypeOf((*MockTxCommitter)(nil).ExternalIdentity))
+}
+
+// Invite mocks base method.
+func (m *MockTxCommitter) Invite() domain.InviteRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite")
+	ret0, _ := ret[0].(domain.InviteRepository)
+	return ret0
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockTxCommitterMockRecorder) Invite() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockTxCommitter)(nil).Invite))
+}
+
+// MagicLinkToken mocks base method.
+func (m *MockTxCommitter) MagicLinkToken() domain.MagicLinkTokenRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MagicLinkToken")
+	ret0, _ := ret[0].(domain.MagicLinkTokenRepository)
+	return ret0
+}
+
+// MagicLinkToken indicates an expected call of MagicLinkToken.
+func (mr *MockTxCommitterMockRecorder) MagicLinkToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MagicLinkToken", reflect.TypeOf((*MockTxCommitter)(nil).MagicLinkToken))
+}
+
+// OIDCState mocks base method.
+func (m *MockTxCommitter) OIDCState() domain.OIDCStateRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OIDCState")
+	ret0, _ := ret[0].(domain.OIDCStateRepository)
+	return ret0
+}
+
+// OIDCState indicates an expected call of OIDCState.
+func (mr *MockTxCommitterMockRecorder) OIDCState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OIDCState", reflect.TypeOf((*MockTxCommitter)(nil).OIDCState))
+}
+
+// PasswordResetToken mocks base method.
+func (m *MockTxCommitter) PasswordResetToken() domain.PasswordResetTokenRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordResetToken")
+	ret0, _ := ret[0].(domain.PasswordResetTokenRepository)
+	return ret0
+}
+
+// PasswordResetToken indicates an expected call of PasswordResetToken.
+func (mr *MockTxCommitterMockRecorder) PasswordResetToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordResetToken", reflect.TypeOf((*MockTxCommitter)(nil).PasswordResetToken))
+}
+
+// PersonalAccessToken mocks base method.
+func (m *MockTxCommitter) PersonalAccessToken() domain.PersonalAccessTokenRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PersonalAccessToken")
+	ret0, _ := ret[0].(domain.PersonalAccessTokenRepository)
+	return ret0
+}
+
+// PersonalAccessToken indicates an expected call of PersonalAccessToken.
+func (mr *MockTxCommitterMockRecorder) PersonalAccessToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersonalAccessToken", reflect.TypeOf((*MockTxCommitter)(nil).PersonalAccessToken))
+}
+
+// RefreshToken mocks base method.
+func (m *MockTxCommitter) RefreshToken() domain.RefreshTokenRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken")
+	ret0, _ := ret[0].(domain.RefreshTokenRepository)
+	return ret0
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockTxCommitterMockRecorder) RefreshToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockTxCommitter)(nil).RefreshToken))
+}
+
+// Role mocks base method.
+func (m *MockTxCommitter) Role() domain.RoleRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Role")
+	ret0, _ := ret[0].(domain.RoleRepository)
+	return ret0
+}
+
+// Role indicates an expected call of Role.
+func (mr *MockTxCommitterMockRecorder) Role() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Role", reflect.TypeOf((*MockTxCommitter)(nil).Role))
+}
+
+// Rollback mocks base method.
+func (m *MockTxCommitter) Rollback() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Rollback")
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockTxCommitterMockRecorder) Rollback() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockTxCommitter)(nil).Rollback))
+}
+
+// Session mocks base method.
+func (m *MockTxCommitter) Session() domain.SessionRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Session")
+	ret0, _ := ret[0].(domain.SessionRepository)
+	return ret0
+}
+
+// Session indicates an expected call of Session.
+func (mr *MockTxCommitterMockRecorder) Session() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Session", reflect.TypeOf((*MockTxCommitter)(nil).Session))
+}
+
+// Suspension mocks base method.
+func (m *MockTxCommitter) Suspension() domain.SuspensionRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suspension")
+	ret0, _ := ret[0].(domain.SuspensionRepository)
+	return ret0
+}
+
+// Suspension indicates an expected call of Suspension.
+func (mr *MockTxCommitterMockRecorder) Suspension() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suspension", reflect.TypeOf((*MockTxCommitter)(nil).Suspension))
+}
+
+// TwoFactor mocks base method.
+func (m *MockTxCommitter) TwoFactor() domain.TwoFactorRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TwoFactor")
+	ret0, _ := ret[0].(domain.TwoFactorRepository)
+	return ret0
+}
+
+// TwoFactor indicates an expected call of TwoFactor.
+func (mr *MockTxCommitterMockRecorder) TwoFactor() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TwoFactor", reflect.TypeOf((*MockTxCommitter)(nil).TwoFactor))
+}
+
+// TwoFactorChallenge mocks base method.
+func (m *MockTxCommitter) TwoFactorChallenge() domain.TwoFactorChallengeRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TwoFactorChallenge")
+	ret0, _ := ret[0].(domain.TwoFactorChallengeRepository)
+	return ret0
+}
+
+// TwoFactorChallenge indicates an expected call of TwoFactorChallenge.
+func (mr *MockTxCommitterMockRecorder) TwoFactorChallenge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TwoFactorChallenge", reflect.TypeOf((*MockTxCommitter)(nil).TwoFactorChallenge))
+}
+
+// User mocks base method.
+func (m *MockTxCommitter) User() domain.UserRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "User")
+	ret0, _ := ret[0].(domain.UserRepository)
+	return ret0
+}
+
+// User indicates an expected call of User.
+func (mr *MockTxCommitterMockRecorder) User() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "User", reflect.TypeOf((*MockTxCommitter)(nil).User))
+}
+
+// WebAuthnChallenge mocks base method.
+func (m *MockTxCommitter) WebAuthnChallenge() domain.WebAuthnChallengeRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebAuthnChallenge")
+	ret0, _ := ret[0].(domain.WebAuthnChallengeRepository)
+	return ret0
+}
+
+// WebAuthnChallenge indicates an expected call of WebAuthnChallenge.
+func (mr *MockTxCommitterMockRecorder) WebAuthnChallenge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebAuthnChallenge", reflect.TypeOf((*MockTxCommitter)(nil).WebAuthnChallenge))
+}
+
+// WebAuthnCredential mocks base method.
+func (m *MockTxCommitter) WebAuthnCredential() domain.WebAuthnCredentialRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebAuthnCredential")
+	ret0, _ := ret[0].(domain.WebAuthnCredentialRepository)
+	return ret0
+}
+
+// WebAuthnCredential indicates an expected call of WebAuthnCredential.
+func (mr *MockTxCommitterMockRecorder) WebAuthnCredential() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebAuthnCredential", reflect.TypeOf((*MockTxCommitter)(nil).WebAuthnCredential))
 }
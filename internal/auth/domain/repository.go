@@ -3,28 +3,206 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/art-es/blog/internal/common/repository"
 )
 
 type UserRepository interface {
 	Activate(ctx context.Context, id int64) (bool, error)
-	Get(ctx context.Context, id int64) (*User, error) // FIXME: dead code
+	Get(ctx context.Context, id int64) (*User, error)
+	// GetByEmail and EmailExists match the email case-insensitively.
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	EmailExists(ctx context.Context, email string) (bool, error)
 	Exists(ctx context.Context, id int64) (bool, error)
+	// Save returns repository.ErrUniqueViolation if the email is taken by another user.
 	Save(ctx context.Context, user *User) error
+	GetProfile(ctx context.Context, userID int64) (*Profile, error)
+	GetProfileByHandle(ctx context.Context, handle string) (*Profile, error)
+	// SaveProfile returns repository.ErrUniqueViolation if the handle is taken by another user.
+	SaveProfile(ctx context.Context, profile *Profile) error
+	Find(ctx context.Context, filter *UserFilter) ([]*User, error)
+}
+
+type SuspensionRepository interface {
+	// Get returns nil if the user has never been suspended or is unbanned.
+	Get(ctx context.Context, userID int64) (*Suspension, error)
+	// Save replaces the previous suspension of the user.
+	Save(ctx context.Context, suspension *Suspension) error
+	Remove(ctx context.Context, userID int64) error
+}
+
+type RoleRepository interface {
+	// GetUserAccess returns the roles of the user with the permissions granted by them.
+	GetUserAccess(ctx context.Context, userID int64) (*UserAccess, error)
+	AddUserRole(ctx context.Context, userID int64, role string) error
 }
 
 type ActivationCodeRepository interface {
 	Add(ctx context.Context, code string, userID int64) error
-	GetUserID(ctx context.Context, code string) (int64, error)
+	Get(ctx context.Context, code string) (*ActivationCode, error)
+	GetLatest(ctx context.Context, userID int64) (*ActivationCode, error)
 	RemoveCodes(ctx context.Context, userID int64) error
 }
 
+type PasswordResetTokenRepository interface {
+	Add(ctx context.Context, token *PasswordResetToken) error
+	// Take removes the token and returns it, nil is returned if the token is not found.
+	Take(ctx context.Context, tokenHash string) (*PasswordResetToken, error)
+	RemoveUserTokens(ctx context.Context, userID int64) error
+}
+
+type InviteRepository interface {
+	// Add sets ID of the invite.
+	Add(ctx context.Context, invite *Invite) error
+	// Use counts the use of the invite and returns it, nil is returned if the invite is unknown or used up.
+	Use(ctx context.Context, codeHash string) (*Invite, error)
+}
+
+type MagicLinkTokenRepository interface {
+	Add(ctx context.Context, token *MagicLinkToken) error
+	// Take removes the token and returns it, nil is returned if the token is not found.
+	Take(ctx context.Context, tokenHash string) (*MagicLinkToken, error)
+	RemoveUserTokens(ctx context.Context, userID int64) error
+}
+
+type EmailChangeRepository interface {
+	// Add sets ID of the change.
+	Add(ctx context.Context, change *EmailChange) error
+	// GetPending returns the unconfirmed change of the user with the code, nil is returned if it's not found.
+	GetPending(ctx context.Context, userID int64, codeHash string) (*EmailChange, error)
+	// RemovePending removes the unconfirmed changes of the user, the confirmed ones stay undoable.
+	RemovePending(ctx context.Context, userID int64) error
+	Confirm(ctx context.Context, id int64, undoTokenHash string, undoExpiresAt time.Time) error
+	// TakeConfirmed removes the confirmed change and returns it, nil is returned if the change is not found.
+	TakeConfirmed(ctx context.Context, undoTokenHash string) (*EmailChange, error)
+	RemoveUserChanges(ctx context.Context, userID int64) error
+}
+
+type TwoFactorRepository interface {
+	// Save replaces the secret of the user, the replaced one is disabled.
+	Save(ctx context.Context, twoFactor *TwoFactor) error
+	Get(ctx context.Context, userID int64) (*TwoFactor, error)
+	Enable(ctx context.Context, userID int64) error
+	// UseStep remembers the time step of the accepted code,
+	// false is returned if the step isn't later than the previously used one.
+	UseStep(ctx context.Context, userID int64, step int64) (bool, error)
+	// AddAttempt increments and returns the failed attempts of the secret.
+	AddAttempt(ctx context.Context, userID int64) (int, error)
+	ResetAttempts(ctx context.Context, userID int64) error
+	// Remove removes the secret with the recovery codes.
+	Remove(ctx context.Context, userID int64) error
+	AddRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error
+	// TakeRecoveryCode removes the code, false is returned if the code is not found.
+	TakeRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error)
+	RemoveRecoveryCodes(ctx context.Context, userID int64) error
+}
+
+type TwoFactorChallengeRepository interface {
+	Add(ctx context.Context, challenge *TwoFactorChallenge) error
+	Get(ctx context.Context, tokenHash string) (*TwoFactorChallenge, error)
+	// AddAttempt increments and returns the failed attempts of the challenge.
+	AddAttempt(ctx context.Context, tokenHash string) (int, error)
+	// Remove returns false if the challenge is not found.
+	Remove(ctx context.Context, tokenHash string) (bool, error)
+}
+
+type ExternalIdentityRepository interface {
+	Add(ctx context.Context, identity *ExternalIdentity) error
+	Get(ctx context.Context, provider, subject string) (*ExternalIdentity, error)
+}
+
+type OIDCStateRepository interface {
+	Add(ctx context.Context, state *OIDCState) error
+	// Take removes the state and returns it, nil is returned if the state is not found.
+	Take(ctx context.Context, stateHash string) (*OIDCState, error)
+}
+
+type WebAuthnCredentialRepository interface {
+	// Add returns repository.ErrUniqueViolation if the credential is registered already.
+	Add(ctx context.Context, credential *WebAuthnCredential) error
+	// Get returns nil if the credential is not found.
+	Get(ctx context.Context, id string) (*WebAuthnCredential, error)
+	GetByUser(ctx context.Context, userID int64) ([]*WebAuthnCredential, error)
+	Touch(ctx context.Context, id string, signCount uint32, usedAt time.Time) error
+}
+
+type WebAuthnChallengeRepository interface {
+	Add(ctx context.Context, challenge *WebAuthnChallenge) error
+	// Take removes the challenge and returns it, nil is returned if the challenge is not found.
+	Take(ctx context.Context, challengeHash string) (*WebAuthnChallenge, error)
+}
+
+type SessionRepository interface {
+	Add(ctx context.Context, session *Session) error
+	Get(ctx context.Context, id string) (*Session, error)
+	// GetActive returns the sessions of the user which are neither revoked nor expired, recently used first.
+	GetActive(ctx context.Context, userID int64) ([]*Session, error)
+	Touch(ctx context.Context, id string, usedAt time.Time) error
+	Revoke(ctx context.Context, id string) error
+	RevokeUser(ctx context.Context, userID int64) error
+}
+
+type PersonalAccessTokenRepository interface {
+	Add(ctx context.Context, token *PersonalAccessToken) error
+	Get(ctx context.Context, id string) (*PersonalAccessToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (*PersonalAccessToken, error)
+	// GetActive returns the tokens of the user which are neither revoked nor expired, recently created first.
+	GetActive(ctx context.Context, userID int64) ([]*PersonalAccessToken, error)
+	Revoke(ctx context.Context, id string) error
+}
+
+type RefreshTokenRepository interface {
+	Add(ctx context.Context, token *RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	MarkUsed(ctx context.Context, id int64) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeUser(ctx context.Context, userID int64) error
+}
+
+type AccessTokenRevocationRepository interface {
+	AddToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	AddUser(ctx context.Context, userID int64, revokedBefore, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, tokenID string, userID int64, issuedAt time.Time) (bool, error)
+	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
+}
+
+// LoginAttemptRepository is not bound to transactions, so it can be backed by other storages.
+type LoginAttemptRepository interface {
+	// AddFailure increments the failures of the key and returns the updated attempts.
+	// The failures made before resetBefore are forgotten.
+	AddFailure(ctx context.Context, key string, failedAt, resetBefore time.Time) (*LoginAttempts, error)
+	// Get returns nil if the key has no failures.
+	Get(ctx context.Context, key string) (*LoginAttempts, error)
+	Remove(ctx context.Context, key string) error
+}
+
+type AuditLogRepository interface {
+	// Add sets ID and CreatedAt of the event.
+	Add(ctx context.Context, event *AuditEvent) error
+	Find(ctx context.Context, filter *AuditEventFilter) ([]*AuditEvent, error)
+}
+
 type repositoryGetter interface {
 	User() UserRepository
+	Role() RoleRepository
+	Suspension() SuspensionRepository
 	ActivationCode() ActivationCodeRepository
+	PasswordResetToken() PasswordResetTokenRepository
+	MagicLinkToken() MagicLinkTokenRepository
+	Invite() InviteRepository
+	EmailChange() EmailChangeRepository
+	TwoFactor() TwoFactorRepository
+	TwoFactorChallenge() TwoFactorChallengeRepository
+	ExternalIdentity() ExternalIdentityRepository
+	OIDCState() OIDCStateRepository
+	WebAuthnCredential() WebAuthnCredentialRepository
+	WebAuthnChallenge() WebAuthnChallengeRepository
+	Session() SessionRepository
+	PersonalAccessToken() PersonalAccessTokenRepository
+	RefreshToken() RefreshTokenRepository
+	AccessTokenRevocation() AccessTokenRevocationRepository
+	AuditLog() AuditLogRepository
 }
 
 type Repository interface {
@@ -15,6 +15,12 @@ type claims struct {
 	Audience       *jwt.ClaimStrings `json:"aud"`
 	Issuer         string            `json:"iss"`
 	Subject        string            `json:"sub"`
+	ID             string            `json:"jti"`
+	// SessionID is the session ID claim of OpenID Connect Front-Channel Logout
+	// https://openid.net/specs/openid-connect-frontchannel-1_0.html#ClaimsContents
+	SessionID   string   `json:"sid,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
 }
 
 func (c *claims) GetExpirationTime() (*jwt.NumericDate, error) {
@@ -0,0 +1,113 @@
+package access_token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+// Key is an asymmetric key identified by the "kid" header of tokens.
+// Keys without the private part are used for verification only.
+type Key struct {
+	id      string
+	method  jwt.SigningMethod
+	private crypto.PrivateKey
+	public  crypto.PublicKey
+}
+
+// NewKey parses PEM encoded PKCS #8 private key or PKIX public key.
+// RSA keys are used with RS256, Ed25519 keys are used with EdDSA.
+func NewKey(id string, pemBytes []byte) (*Key, error) {
+	if id == "" {
+		return nil, errors.New("key id is empty")
+	}
+
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse private key error: %w", err)
+		}
+		return newKey(id, private)
+	case "PUBLIC KEY":
+		public, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse public key error: %w", err)
+		}
+		return newKey(id, public)
+	default:
+		return nil, fmt.Errorf("unexpected PEM block type: %s", block.Type)
+	}
+}
+
+// GenerateKey creates a random Ed25519 key.
+func GenerateKey(id string) (*Key, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate key error: %w", err)
+	}
+	return newKey(id, private)
+}
+
+func newKey(id string, key any) (*Key, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return &Key{id: id, method: jwt.SigningMethodRS256, private: k, public: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		return &Key{id: id, method: jwt.SigningMethodRS256, public: k}, nil
+	case ed25519.PrivateKey:
+		return &Key{id: id, method: jwt.SigningMethodEdDSA, private: k, public: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &Key{id: id, method: jwt.SigningMethodEdDSA, public: k}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %T", key)
+	}
+}
+
+func (k *Key) ID() string {
+	return k.id
+}
+
+// CanSign reports whether the key has the private part.
+func (k *Key) CanSign() bool {
+	return k.private != nil
+}
+
+// JSONWebKey returns the public part of the key
+// https://datatracker.ietf.org/doc/html/rfc7517#section-4
+func (k *Key) JSONWebKey() dto.JSONWebKey {
+	jwk := dto.JSONWebKey{
+		KeyID:     k.id,
+		Use:       "sig",
+		Algorithm: k.method.Alg(),
+	}
+
+	switch public := k.public.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.Modulus = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.Exponent = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+
+	return jwk
+}
@@ -0,0 +1,94 @@
+package access_token_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain/service/access_token"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestNewKey(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	assert.NoError(t, err)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	assert.NoError(t, err)
+
+	var (
+		privatePEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})
+		publicPEM  = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+		expJWK     = dto.JSONWebKey{
+			KeyType:   "OKP",
+			KeyID:     "dummy",
+			Use:       "sig",
+			Algorithm: "EdDSA",
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(public),
+		}
+	)
+
+	tests := []struct {
+		name       string
+		id         string
+		pem        []byte
+		expCanSign bool
+		expErr     string
+	}{
+		{
+			name:       "private key",
+			id:         "dummy",
+			pem:        privatePEM,
+			expCanSign: true,
+		},
+		{
+			name:       "public key",
+			id:         "dummy",
+			pem:        publicPEM,
+			expCanSign: false,
+		},
+		{
+			name:   "empty id",
+			id:     "",
+			pem:    privatePEM,
+			expErr: "key id is empty",
+		},
+		{
+			name:   "no PEM data",
+			id:     "dummy",
+			pem:    []byte("dummy"),
+			expErr: "no PEM data found",
+		},
+		{
+			name:   "unexpected PEM block",
+			id:     "dummy",
+			pem:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: publicDER}),
+			expErr: "unexpected PEM block type: CERTIFICATE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := access_token.NewKey(tt.id, tt.pem)
+
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				assert.Nil(t, key)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.id, key.ID())
+			assert.Equal(t, tt.expCanSign, key.CanSign())
+			assert.Equal(t, expJWK, key.JSONWebKey())
+		})
+	}
+}
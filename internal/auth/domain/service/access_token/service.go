@@ -1,32 +1,55 @@
 package access_token
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 
 	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const (
+	// Lifetime is the period an access token is valid after issuing.
+	Lifetime = 12 * time.Hour
+	// Leeway is the period an expired access token is still accepted by ParseAndValidate.
+	Leeway = 2 * time.Hour
 )
 
 type Service struct {
-	secret              []byte
+	signingKey          *Key
+	keys                []*Key
+	keysByID            map[string]*Key
 	expirationTimeShift time.Duration
 	audience            []string
 	issuer              string
 	parserOpts          []jwt.ParserOption
 }
 
-func New(secret []byte) *Service {
+// New creates the service signing tokens with signingKey.
+// Tokens signed with any of verificationKeys are still accepted,
+// it allows rotating keys without invalidating issued tokens.
+func New(signingKey *Key, verificationKeys ...*Key) *Service {
 	const (
 		aud = "auth"
 		iss = "art-es"
 	)
 
+	keys := append([]*Key{signingKey}, verificationKeys...)
+	keysByID := make(map[string]*Key, len(keys))
+	for _, key := range keys {
+		keysByID[key.id] = key
+	}
+
 	return &Service{
-		secret:              secret,
-		expirationTimeShift: 12 * time.Hour,
+		signingKey:          signingKey,
+		keys:                keys,
+		keysByID:            keysByID,
+		expirationTimeShift: Lifetime,
 		audience:            []string{aud},
 		issuer:              iss,
 		parserOpts: []jwt.ParserOption{
@@ -34,46 +57,49 @@ func New(secret []byte) *Service {
 			jwt.WithIssuedAt(),
 			jwt.WithAudience(aud),
 			jwt.WithIssuer(iss),
+			jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
 		},
 	}
 }
 
-func (s *Service) NewObject(userID int64) *domain.AccessTokenObject {
+func (s *Service) NewObject(userID int64, sessionID string, access *domain.UserAccess) *domain.AccessTokenObject {
 	now := time.Now()
 
-	return &domain.AccessTokenObject{
+	object := &domain.AccessTokenObject{
 		ExpirationTime: now.Add(s.expirationTimeShift),
 		NotBefore:      now,
 		IssuedAt:       now,
 		Audience:       s.audience,
 		Issuer:         s.issuer,
 		UserID:         userID,
+		SessionID:      sessionID,
+		ID:             uuid.NewString(),
 	}
-}
-
-func (s *Service) Refresh(object *domain.AccessTokenObject) {
-	now := time.Now()
-
-	object.ExpirationTime = now.Add(s.expirationTimeShift)
-	object.NotBefore = now
-	object.IssuedAt = now
+	if access != nil {
+		object.Roles = access.Roles
+		object.Permissions = access.Permissions
+	}
+	return object
 }
 
 func (s *Service) Sign(object *domain.AccessTokenObject) (string, error) {
-	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, toClaims(object)).
-		SignedString(s.secret)
+	token := jwt.NewWithClaims(s.signingKey.method, toClaims(object))
+	token.Header["kid"] = s.signingKey.id
+
+	signed, err := token.SignedString(s.signingKey.private)
 	if err != nil {
 		return "", fmt.Errorf("signing error: %w", err)
 	}
-	return token, nil
+	return signed, nil
 }
 
-func (s *Service) Parse(token string) (*domain.AccessTokenObject, error) {
+func (s *Service) ParseAndValidate(token string) (*domain.AccessTokenObject, error) {
 	clm := &claims{}
+	parserOpts := append(s.parserOpts, jwt.WithExpirationRequired(), jwt.WithIssuedAt(), jwt.WithLeeway(Leeway))
 
-	_, err := jwt.ParseWithClaims(token, clm, s.parserFunc, s.parserOpts...)
+	_, err := jwt.ParseWithClaims(token, clm, s.parserFunc, parserOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("parse string with secret error: %w", err)
+		return nil, fmt.Errorf("parse string with key error: %w", err)
 	}
 
 	obj, err := toAccessTokenObject(clm)
@@ -83,27 +109,25 @@ func (s *Service) Parse(token string) (*domain.AccessTokenObject, error) {
 	return obj, nil
 }
 
-func (s *Service) ParseAndValidate(token string) (*domain.AccessTokenObject, error) {
-	clm := &claims{}
-	parserOpts := append(s.parserOpts, jwt.WithExpirationRequired(), jwt.WithIssuedAt(), jwt.WithLeeway(2*time.Hour))
-
-	_, err := jwt.ParseWithClaims(token, clm, s.parserFunc, parserOpts...)
-	if err != nil {
-		return nil, fmt.Errorf("parse string with secret error: %w", err)
+// JSONWebKeySet returns public keys accepted by the service.
+func (s *Service) JSONWebKeySet() []dto.JSONWebKey {
+	set := make([]dto.JSONWebKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		set = append(set, key.JSONWebKey())
 	}
-
-	obj, err := toAccessTokenObject(clm)
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert claims to jwt object")
-	}
-	return obj, nil
+	return set
 }
 
 func (s *Service) parserFunc(token *jwt.Token) (interface{}, error) {
-	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.keysByID[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id: %v", token.Header["kid"])
+	}
+	if token.Method.Alg() != key.method.Alg() {
 		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 	}
-	return s.secret, nil
+	return key.public, nil
 }
 
 func toClaims(o *domain.AccessTokenObject) *claims {
@@ -116,6 +140,10 @@ func toClaims(o *domain.AccessTokenObject) *claims {
 		Audience:       &aud,
 		Issuer:         o.Issuer,
 		Subject:        strconv.FormatInt(o.UserID, 10),
+		ID:             o.ID,
+		SessionID:      o.SessionID,
+		Roles:          o.Roles,
+		Permissions:    o.Permissions,
 	}
 }
 
@@ -130,6 +158,10 @@ func toAccessTokenObject(c *claims) (*domain.AccessTokenObject, error) {
 		return nil, fmt.Errorf("parse sub as int: %w", err)
 	}
 
+	if c.ID == "" {
+		return nil, errors.New("jti is empty")
+	}
+
 	return &domain.AccessTokenObject{
 		ExpirationTime: c.ExpirationTime.Time,
 		NotBefore:      c.NotBefore.Time,
@@ -137,5 +169,9 @@ func toAccessTokenObject(c *claims) (*domain.AccessTokenObject, error) {
 		Audience:       aud,
 		Issuer:         c.Issuer,
 		UserID:         userID,
+		SessionID:      c.SessionID,
+		Roles:          c.Roles,
+		Permissions:    c.Permissions,
+		ID:             c.ID,
 	}, nil
 }
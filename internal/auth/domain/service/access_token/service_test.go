@@ -1,6 +1,10 @@
 package access_token_test
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"testing"
 	"time"
 
@@ -8,24 +12,32 @@ import (
 
 	"github.com/art-es/blog/internal/auth/domain"
 	"github.com/art-es/blog/internal/auth/domain/service/access_token"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/testutil"
 )
 
 func TestService_happyPath(t *testing.T) {
-	const userID = int64(1)
-
-	service := access_token.New([]byte("secret"))
-
-	object := service.NewObject(userID)
-	assertObject(t, object, userID)
-
-	service.Refresh(object)
-	assertObject(t, object, userID)
+	const (
+		userID    = int64(1)
+		sessionID = "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b"
+	)
+
+	var (
+		service = access_token.New(generateKey(t, "current"))
+		access  = &domain.UserAccess{
+			Roles:       []string{domain.RoleAuthor},
+			Permissions: []string{domain.PermissionPostRead, domain.PermissionPostWrite},
+		}
+	)
+
+	object := service.NewObject(userID, sessionID, access)
+	assertObject(t, object, userID, sessionID, access)
 
 	token, err := service.Sign(object)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
 
-	parserResult, err := service.Parse(token)
+	parserResult, err := service.ParseAndValidate(token)
 	assert.NoError(t, err)
 	assertEqualObjects(t, object, parserResult)
 }
@@ -33,26 +45,30 @@ func TestService_happyPath(t *testing.T) {
 func TestService_ParseAndValidate_expired(t *testing.T) {
 	const userID = int64(1)
 
-	service := access_token.New([]byte("secret"))
+	service := access_token.New(generateKey(t, "current"))
 
-	object := service.NewObject(userID)
+	object := service.NewObject(userID, "", nil)
 	object.ExpirationTime = time.Now().Add(-3 * time.Hour)
 
 	token, err := service.Sign(object)
 	assert.NoError(t, err)
 
 	parsedObject, err := service.ParseAndValidate(token)
-	assert.EqualError(t, err, "parse string with secret error: token has invalid claims: token is expired")
+	assert.EqualError(t, err, "parse string with key error: token has invalid claims: token is expired")
 	assert.Nil(t, parsedObject)
 }
 
-func assertObject(t *testing.T, object *domain.AccessTokenObject, expUserID int64) {
+func assertObject(t *testing.T, object *domain.AccessTokenObject, expUserID int64, expSessionID string, expAccess *domain.UserAccess) {
 	assert.NotEqual(t, time.Time{}, object.ExpirationTime)
 	assert.Equal(t, object.NotBefore, object.IssuedAt)
 	assert.Equal(t, 12*time.Hour, object.ExpirationTime.Sub(object.IssuedAt))
 	assert.Equal(t, []string{"auth"}, object.Audience)
 	assert.Equal(t, "art-es", object.Issuer)
 	assert.Equal(t, expUserID, object.UserID)
+	assert.Equal(t, expSessionID, object.SessionID)
+	assert.Equal(t, expAccess.Roles, object.Roles)
+	assert.Equal(t, expAccess.Permissions, object.Permissions)
+	assert.True(t, testutil.IsUUID().Matches(object.ID))
 }
 
 func assertEqualObjects(t *testing.T, expected, actual *domain.AccessTokenObject) {
@@ -67,4 +83,133 @@ func assertEqualObjects(t *testing.T, expected, actual *domain.AccessTokenObject
 	assert.Equal(t, expected.Audience, actual.Audience)
 	assert.Equal(t, expected.Issuer, actual.Issuer)
 	assert.Equal(t, expected.UserID, actual.UserID)
+	assert.Equal(t, expected.SessionID, actual.SessionID)
+	assert.Equal(t, expected.Roles, actual.Roles)
+	assert.Equal(t, expected.Permissions, actual.Permissions)
+	assert.Equal(t, expected.ID, actual.ID)
+}
+
+func TestService_ParseAndValidate_withoutID(t *testing.T) {
+	const userID = int64(1)
+
+	service := access_token.New(generateKey(t, "current"))
+
+	object := service.NewObject(userID, "", nil)
+	object.ID = ""
+
+	token, err := service.Sign(object)
+	assert.NoError(t, err)
+
+	parsedObject, err := service.ParseAndValidate(token)
+	assert.EqualError(t, err, "failed to convert claims to jwt object")
+	assert.Nil(t, parsedObject)
+}
+
+func TestService_keyRotation(t *testing.T) {
+	const userID = int64(1)
+
+	var (
+		previousKey = generateKey(t, "previous")
+		currentKey  = generateKey(t, "current")
+		unknownKey  = generateKey(t, "unknown")
+	)
+
+	previousService := access_token.New(previousKey)
+	previousToken, err := previousService.Sign(previousService.NewObject(userID, "", nil))
+	assert.NoError(t, err)
+
+	unknownService := access_token.New(unknownKey)
+	unknownToken, err := unknownService.Sign(unknownService.NewObject(userID, "", nil))
+	assert.NoError(t, err)
+
+	service := access_token.New(currentKey, previousKey)
+
+	currentToken, err := service.Sign(service.NewObject(userID, "", nil))
+	assert.NoError(t, err)
+
+	parsedObject, err := service.ParseAndValidate(currentToken)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, parsedObject.UserID)
+
+	parsedObject, err = service.ParseAndValidate(previousToken)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, parsedObject.UserID)
+
+	parsedObject, err = service.ParseAndValidate(unknownToken)
+	assert.EqualError(t, err, "parse string with key error: token is unverifiable: error while executing keyfunc: unknown key id: unknown")
+	assert.Nil(t, parsedObject)
+
+	_, err = previousService.ParseAndValidate(currentToken)
+	assert.EqualError(t, err, "parse string with key error: token is unverifiable: error while executing keyfunc: unknown key id: current")
+}
+
+func TestService_ParseAndValidate_forgedKeyID(t *testing.T) {
+	const userID = int64(1)
+
+	// the token claims to be signed with the known key id, but with another key
+	forgedService := access_token.New(generateKey(t, "current"))
+	forgedToken, err := forgedService.Sign(forgedService.NewObject(userID, "", nil))
+	assert.NoError(t, err)
+
+	service := access_token.New(generateKey(t, "current"))
+
+	parsedObject, err := service.ParseAndValidate(forgedToken)
+	assert.EqualError(t, err, "parse string with key error: token signature is invalid: ed25519: verification error")
+	assert.Nil(t, parsedObject)
+}
+
+func TestService_RS256(t *testing.T) {
+	const userID = int64(1)
+
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	assert.NoError(t, err)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	assert.NoError(t, err)
+
+	signingKey, err := access_token.NewKey("rsa", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}))
+	assert.NoError(t, err)
+	assert.True(t, signingKey.CanSign())
+
+	verificationKey, err := access_token.NewKey("rsa", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	assert.NoError(t, err)
+	assert.False(t, verificationKey.CanSign())
+
+	signer := access_token.New(signingKey)
+	token, err := signer.Sign(signer.NewObject(userID, "", nil))
+	assert.NoError(t, err)
+
+	// the downstream service has the public key only
+	parsedObject, err := access_token.New(verificationKey).ParseAndValidate(token)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, parsedObject.UserID)
+
+	assert.Equal(t, signer.JSONWebKeySet(), access_token.New(verificationKey).JSONWebKeySet())
+}
+
+func TestService_JSONWebKeySet(t *testing.T) {
+	var (
+		currentKey  = generateKey(t, "current")
+		previousKey = generateKey(t, "previous")
+	)
+
+	set := access_token.New(currentKey, previousKey).JSONWebKeySet()
+
+	assert.Equal(t, []dto.JSONWebKey{currentKey.JSONWebKey(), previousKey.JSONWebKey()}, set)
+	assert.Equal(t, "current", set[0].KeyID)
+	assert.Equal(t, "OKP", set[0].KeyType)
+	assert.Equal(t, "sig", set[0].Use)
+	assert.Equal(t, "EdDSA", set[0].Algorithm)
+	assert.Equal(t, "Ed25519", set[0].Curve)
+	assert.Len(t, set[0].X, 43)
+	assert.Equal(t, "previous", set[1].KeyID)
+}
+
+func generateKey(t *testing.T, id string) *access_token.Key {
+	key, err := access_token.GenerateKey(id)
+	assert.NoError(t, err)
+	return key
 }
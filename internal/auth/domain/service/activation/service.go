@@ -4,6 +4,7 @@ package activation
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -17,38 +18,70 @@ type databus interface {
 }
 
 type Service struct {
-	logger  log.Logger
-	databus databus
+	logger         log.Logger
+	databus        databus
+	codeTTL        time.Duration
+	resendInterval time.Duration
 }
 
-func New(logger log.Logger, databus databus) *Service {
+// New creates the service. codeTTL limits the period an activation code is valid,
+// resendInterval is the minimal period between sending codes to the same user.
+func New(logger log.Logger, databus databus, codeTTL, resendInterval time.Duration) *Service {
 	return &Service{
-		logger:  logger,
-		databus: databus,
+		logger:         logger,
+		databus:        databus,
+		codeTTL:        codeTTL,
+		resendInterval: resendInterval,
 	}
 }
 
-func (s *Service) Activate(ctx context.Context, code string, tx domain.TxCommitter) error {
-	uid, err := tx.ActivationCode().GetUserID(ctx, code)
+// Activate returns the ID of the activated user.
+func (s *Service) Activate(ctx context.Context, code string, tx domain.TxCommitter) (int64, error) {
+	activationCode, err := tx.ActivationCode().Get(ctx, code)
 	if err != nil {
-		return fmt.Errorf("auth ID getting from repository error: %w", err)
+		return 0, fmt.Errorf("activation code getting from repository error: %w", err)
 	}
-	if uid == 0 {
-		return dto.ErrUserActivationCodeNotFound
+	if activationCode == nil {
+		return 0, dto.ErrUserActivationCodeNotFound
+	}
+	if !time.Now().Before(activationCode.CreatedAt.Add(s.codeTTL)) {
+		return 0, dto.ErrExpiredUserActivationCode
 	}
 
+	uid := activationCode.UserID
 	ok, err := tx.User().Activate(ctx, uid)
 	if err != nil {
-		return fmt.Errorf("auth getting from repository error: %w", err)
+		return 0, fmt.Errorf("auth getting from repository error: %w", err)
 	}
 	if !ok {
-		return dto.ErrUserNotFound
+		return 0, dto.ErrUserNotFound
 	}
 
 	if err = tx.ActivationCode().RemoveCodes(ctx, uid); err != nil {
+		return 0, fmt.Errorf("codes removing from repository error: %w", err)
+	}
+	return uid, nil
+}
+
+// ResendCode replaces pending activation codes of the user with a new one.
+// It returns dto.ErrUserActivationCodeNotFound if the user has no pending codes, i.e. is activated already.
+func (s *Service) ResendCode(ctx context.Context, user *domain.User, tx domain.TxCommitter) error {
+	latest, err := tx.ActivationCode().GetLatest(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("activation code getting from repository error: %w", err)
+	}
+	if latest == nil {
+		return dto.ErrUserActivationCodeNotFound
+	}
+	if time.Now().Before(latest.CreatedAt.Add(s.resendInterval)) {
+		return dto.ErrActivationCodeResendLimit
+	}
+
+	if err = tx.ActivationCode().RemoveCodes(ctx, user.ID); err != nil {
 		return fmt.Errorf("codes removing from repository error: %w", err)
 	}
-	return nil
+
+	return s.SendCode(ctx, user, tx)
 }
 
 func (s *Service) SendCode(ctx context.Context, user *domain.User, tx domain.TxCommitter) error {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -17,7 +18,11 @@ import (
 	"github.com/art-es/blog/internal/common/testutil"
 )
 
-const noError = ""
+const (
+	noError        = ""
+	codeTTL        = 24 * time.Hour
+	resendInterval = time.Minute
+)
 
 func TestService_Activate(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -28,9 +33,10 @@ func TestService_Activate(t *testing.T) {
 	)
 
 	var (
-		ctx    = context.Background()
-		userID = int64(1)
-		code   = "dummyCode"
+		ctx            = context.Background()
+		userID         = int64(1)
+		code           = "dummyCode"
+		activationCode = &domain.ActivationCode{Code: code, UserID: userID, CreatedAt: time.Now().Add(-time.Hour)}
 	)
 
 	tests := []struct {
@@ -46,8 +52,8 @@ func TestService_Activate(t *testing.T) {
 					DoAndReturn(func() domain.ActivationCodeRepository {
 						r := mockdomain.NewMockActivationCodeRepository(ctrl)
 						r.EXPECT().
-							GetUserID(gomock.Eq(ctx), gomock.Eq(code)).
-							Return(userID, nil)
+							Get(gomock.Eq(ctx), gomock.Eq(code)).
+							Return(activationCode, nil)
 						return r
 					})
 
@@ -74,19 +80,19 @@ func TestService_Activate(t *testing.T) {
 			expErr: noError,
 		},
 		{
-			name: "error on getting auth ID",
+			name: "error on getting activation code",
 			setup: func() {
 				tx.EXPECT().
 					ActivationCode().
 					DoAndReturn(func() domain.ActivationCodeRepository {
 						r := mockdomain.NewMockActivationCodeRepository(ctrl)
 						r.EXPECT().
-							GetUserID(gomock.Eq(ctx), gomock.Eq(code)).
-							Return(int64(0), errors.New("dummy error"))
+							Get(gomock.Eq(ctx), gomock.Eq(code)).
+							Return(nil, errors.New("dummy error"))
 						return r
 					})
 			},
-			expErr: "auth ID getting from repository error: dummy error",
+			expErr: "activation code getting from repository error: dummy error",
 		},
 		{
 			name: "code not found",
@@ -96,13 +102,28 @@ func TestService_Activate(t *testing.T) {
 					DoAndReturn(func() domain.ActivationCodeRepository {
 						r := mockdomain.NewMockActivationCodeRepository(ctrl)
 						r.EXPECT().
-							GetUserID(gomock.Eq(ctx), gomock.Eq(code)).
-							Return(int64(0), nil)
+							Get(gomock.Eq(ctx), gomock.Eq(code)).
+							Return(nil, nil)
 						return r
 					})
 			},
 			expErr: dto.ErrUserActivationCodeNotFound.Error(),
 		},
+		{
+			name: "code expired",
+			setup: func() {
+				tx.EXPECT().
+					ActivationCode().
+					DoAndReturn(func() domain.ActivationCodeRepository {
+						r := mockdomain.NewMockActivationCodeRepository(ctrl)
+						r.EXPECT().
+							Get(gomock.Eq(ctx), gomock.Eq(code)).
+							Return(&domain.ActivationCode{Code: code, UserID: userID, CreatedAt: time.Now().Add(-codeTTL)}, nil)
+						return r
+					})
+			},
+			expErr: dto.ErrExpiredUserActivationCode.Error(),
+		},
 		{
 			name: "error on activation",
 			setup: func() {
@@ -111,8 +132,8 @@ func TestService_Activate(t *testing.T) {
 					DoAndReturn(func() domain.ActivationCodeRepository {
 						r := mockdomain.NewMockActivationCodeRepository(ctrl)
 						r.EXPECT().
-							GetUserID(gomock.Eq(ctx), gomock.Eq(code)).
-							Return(userID, nil)
+							Get(gomock.Eq(ctx), gomock.Eq(code)).
+							Return(activationCode, nil)
 						return r
 					})
 
@@ -136,8 +157,8 @@ func TestService_Activate(t *testing.T) {
 					DoAndReturn(func() domain.ActivationCodeRepository {
 						r := mockdomain.NewMockActivationCodeRepository(ctrl)
 						r.EXPECT().
-							GetUserID(gomock.Eq(ctx), gomock.Eq(code)).
-							Return(userID, nil)
+							Get(gomock.Eq(ctx), gomock.Eq(code)).
+							Return(activationCode, nil)
 						return r
 					})
 
@@ -161,8 +182,8 @@ func TestService_Activate(t *testing.T) {
 					DoAndReturn(func() domain.ActivationCodeRepository {
 						r := mockdomain.NewMockActivationCodeRepository(ctrl)
 						r.EXPECT().
-							GetUserID(gomock.Eq(ctx), gomock.Eq(code)).
-							Return(userID, nil)
+							Get(gomock.Eq(ctx), gomock.Eq(code)).
+							Return(activationCode, nil)
 						return r
 					})
 
@@ -195,15 +216,17 @@ func TestService_Activate(t *testing.T) {
 				tt.setup()
 			}
 
-			s := activation.New(logger, nil)
-			err := s.Activate(ctx, code, tx)
+			s := activation.New(logger, nil, codeTTL, resendInterval)
+			id, err := s.Activate(ctx, code, tx)
 
 			if tt.expErr == noError {
 				assert.NoError(t, err)
+				assert.Equal(t, userID, id)
 				return
 			}
 
 			assert.EqualError(t, err, tt.expErr)
+			assert.Zero(t, id)
 		})
 	}
 }
@@ -284,6 +307,9 @@ func TestService_SendCode(t *testing.T) {
 							ProduceActivationEmail(gomock.Eq(ctx), gomock.Eq(msg)).
 							Return(errors.New("dummy error"))
 
+						logger.EXPECT().
+							Error(gomock.Eq("produce message to databus error"), gomock.Any(), gomock.Any())
+
 						return nil
 					})
 
@@ -298,7 +324,7 @@ func TestService_SendCode(t *testing.T) {
 				tt.setup()
 			}
 
-			s := activation.New(logger, databus)
+			s := activation.New(logger, databus, codeTTL, resendInterval)
 			err := s.SendCode(ctx, tt.user, tx)
 
 			if tt.expErr == noError {
@@ -310,3 +336,130 @@ func TestService_SendCode(t *testing.T) {
 		})
 	}
 }
+
+func TestService_ResendCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		logger  = log_mock.NewMockLogger(ctrl)
+		databus = mock.NewMockdatabus(ctrl)
+		tx      = mockdomain.NewMockTxCommitter(ctrl)
+	)
+
+	var (
+		ctx    = context.Background()
+		userID = int64(1)
+		email  = "dummyEmail@example.com"
+		user   = &domain.User{ID: userID, Email: email}
+	)
+
+	expectLatest := func(latest *domain.ActivationCode, err error) {
+		repo := mockdomain.NewMockActivationCodeRepository(ctrl)
+		repo.EXPECT().
+			GetLatest(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(latest, err)
+
+		tx.EXPECT().ActivationCode().Return(repo)
+	}
+
+	expectRemoving := func(err error) {
+		repo := mockdomain.NewMockActivationCodeRepository(ctrl)
+		repo.EXPECT().
+			RemoveCodes(gomock.Eq(ctx), gomock.Eq(userID)).
+			Return(err)
+
+		tx.EXPECT().ActivationCode().Return(repo)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				expectLatest(&domain.ActivationCode{UserID: userID, CreatedAt: time.Now().Add(-resendInterval)}, nil)
+				expectRemoving(nil)
+
+				repo := mockdomain.NewMockActivationCodeRepository(ctrl)
+				repo.EXPECT().
+					Add(gomock.Eq(ctx), testutil.IsUUID(), gomock.Eq(userID)).
+					DoAndReturn(func(_ context.Context, code string, _ int64) error {
+						msg := &dto.UserActivationEmailMessage{
+							Email: email,
+							Code:  code,
+						}
+
+						databus.EXPECT().
+							ProduceActivationEmail(gomock.Eq(ctx), gomock.Eq(msg)).
+							Return(nil)
+
+						return nil
+					})
+
+				tx.EXPECT().ActivationCode().Return(repo)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on getting latest code",
+			setup: func() {
+				expectLatest(nil, errors.New("dummy error"))
+			},
+			expErr: "activation code getting from repository error: dummy error",
+		},
+		{
+			name: "no pending codes",
+			setup: func() {
+				expectLatest(nil, nil)
+			},
+			expErr: dto.ErrUserActivationCodeNotFound.Error(),
+		},
+		{
+			name: "resend limit",
+			setup: func() {
+				expectLatest(&domain.ActivationCode{UserID: userID, CreatedAt: time.Now().Add(-resendInterval / 2)}, nil)
+			},
+			expErr: dto.ErrActivationCodeResendLimit.Error(),
+		},
+		{
+			name: "error on removing codes",
+			setup: func() {
+				expectLatest(&domain.ActivationCode{UserID: userID, CreatedAt: time.Now().Add(-resendInterval)}, nil)
+				expectRemoving(errors.New("dummy error"))
+			},
+			expErr: "codes removing from repository error: dummy error",
+		},
+		{
+			name: "error on adding new code",
+			setup: func() {
+				expectLatest(&domain.ActivationCode{UserID: userID, CreatedAt: time.Now().Add(-resendInterval)}, nil)
+				expectRemoving(nil)
+
+				repo := mockdomain.NewMockActivationCodeRepository(ctrl)
+				repo.EXPECT().
+					Add(gomock.Eq(ctx), testutil.IsUUID(), gomock.Eq(userID)).
+					Return(errors.New("dummy error"))
+
+				tx.EXPECT().ActivationCode().Return(repo)
+			},
+			expErr: "activation code adding to repository error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			s := activation.New(logger, databus, codeTTL, resendInterval)
+			err := s.ResendCode(ctx, user, tx)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+//go:generate mockgen -source=service.go -destination=mock/service.go -package=mock
+package email_change
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/log"
+)
+
+const tokenSize = 32
+
+type databus interface {
+	ProduceEmailChangeCodeEmail(ctx context.Context, msg *dto.EmailChangeCodeEmailMessage) error
+	ProduceEmailChangeNoticeEmail(ctx context.Context, msg *dto.EmailChangeNoticeEmailMessage) error
+}
+
+type Service struct {
+	logger     log.Logger
+	databus    databus
+	codeTTL    time.Duration
+	undoWindow time.Duration
+}
+
+func New(logger log.Logger, databus databus, codeTTL, undoWindow time.Duration) *Service {
+	return &Service{
+		logger:     logger,
+		databus:    databus,
+		codeTTL:    codeTTL,
+		undoWindow: undoWindow,
+	}
+}
+
+// SendCode replaces pending changes of the user with a new one.
+// The confirmation code is sent to the new email, the old email gets a notice.
+func (s *Service) SendCode(ctx context.Context, user *domain.User, newEmail string, tx domain.TxCommitter) error {
+	if err := tx.EmailChange().RemovePending(ctx, user.ID); err != nil {
+		return fmt.Errorf("pending email changes removing from repository error: %w", err)
+	}
+
+	code, err := generate()
+	if err != nil {
+		return fmt.Errorf("email change code generation error: %w", err)
+	}
+
+	change := &domain.EmailChange{
+		UserID:    user.ID,
+		OldEmail:  user.Email,
+		NewEmail:  newEmail,
+		CodeHash:  Hash(code),
+		ExpiresAt: time.Now().Add(s.codeTTL),
+	}
+
+	if err = tx.EmailChange().Add(ctx, change); err != nil {
+		return fmt.Errorf("email change adding to repository error: %w", err)
+	}
+
+	codeMsg := &dto.EmailChangeCodeEmailMessage{
+		Email: newEmail,
+		Code:  code,
+	}
+
+	if err = s.databus.ProduceEmailChangeCodeEmail(ctx, codeMsg); err != nil {
+		s.logError(err)
+	}
+
+	noticeMsg := &dto.EmailChangeNoticeEmailMessage{
+		Email:    user.Email,
+		NewEmail: newEmail,
+	}
+
+	if err = s.databus.ProduceEmailChangeNoticeEmail(ctx, noticeMsg); err != nil {
+		s.logError(err)
+	}
+
+	return nil
+}
+
+// CheckCode returns the pending change of the user with the code.
+// It returns dto.ErrInvalidEmailChangeCode if the change is not found or expired.
+func (s *Service) CheckCode(ctx context.Context, userID int64, code string, tx domain.TxCommitter) (*domain.EmailChange, error) {
+	change, err := tx.EmailChange().GetPending(ctx, userID, Hash(code))
+	if err != nil {
+		return nil, fmt.Errorf("pending email change getting from repository error: %w", err)
+	}
+	if change == nil || !time.Now().Before(change.ExpiresAt) {
+		return nil, dto.ErrInvalidEmailChangeCode
+	}
+
+	return change, nil
+}
+
+// IssueUndo confirms the change and sends the token undoing it to the old email.
+func (s *Service) IssueUndo(ctx context.Context, change *domain.EmailChange, tx domain.TxCommitter) error {
+	token, err := generate()
+	if err != nil {
+		return fmt.Errorf("email change undo token generation error: %w", err)
+	}
+
+	change.UndoTokenHash = Hash(token)
+	change.UndoExpiresAt = time.Now().Add(s.undoWindow)
+
+	if err = tx.EmailChange().Confirm(ctx, change.ID, change.UndoTokenHash, change.UndoExpiresAt); err != nil {
+		return fmt.Errorf("email change confirming in repository error: %w", err)
+	}
+
+	msg := &dto.EmailChangeNoticeEmailMessage{
+		Email:     change.OldEmail,
+		NewEmail:  change.NewEmail,
+		UndoToken: token,
+	}
+
+	if err = s.databus.ProduceEmailChangeNoticeEmail(ctx, msg); err != nil {
+		s.logError(err)
+	}
+
+	return nil
+}
+
+// TakeUndo invalidates the undo token and returns the change it undoes.
+// It returns dto.ErrInvalidEmailChangeUndoToken if the change is not found or the undo window is over.
+func (s *Service) TakeUndo(ctx context.Context, token string, tx domain.TxCommitter) (*domain.EmailChange, error) {
+	change, err := tx.EmailChange().TakeConfirmed(ctx, Hash(token))
+	if err != nil {
+		return nil, fmt.Errorf("confirmed email change taking from repository error: %w", err)
+	}
+	if change == nil || !time.Now().Before(change.UndoExpiresAt) {
+		return nil, dto.ErrInvalidEmailChangeUndoToken
+	}
+
+	return change, nil
+}
+
+func (s *Service) logError(err error) {
+	s.logger.Error("produce message to databus error",
+		log.Error(err),
+		log.String("location", "auth/service/email_change"))
+}
+
+// Hash returns the representation of the code or the undo token kept in the repository.
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generate() (string, error) {
+	b := make([]byte, tokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
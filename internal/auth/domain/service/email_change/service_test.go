@@ -0,0 +1,420 @@
+package email_change_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	mockdomain "github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/domain/service/email_change"
+	"github.com/art-es/blog/internal/auth/domain/service/email_change/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	log_mock "github.com/art-es/blog/internal/common/log/mock"
+)
+
+const (
+	noError    = ""
+	codeTTL    = 24 * time.Hour
+	undoWindow = 72 * time.Hour
+)
+
+func TestService_SendCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		logger     = log_mock.NewMockLogger(ctrl)
+		databus    = mock.NewMockdatabus(ctrl)
+		repository = mockdomain.NewMockEmailChangeRepository(ctrl)
+		tx         = mockdomain.NewMockTxCommitter(ctrl)
+	)
+
+	var (
+		ctx      = context.Background()
+		userID   = int64(1)
+		oldEmail = "old@example.com"
+		newEmail = "new@example.com"
+		user     = &domain.User{ID: userID, Email: oldEmail}
+	)
+
+	tx.EXPECT().
+		EmailChange().
+		Return(repository).
+		AnyTimes()
+
+	expectAdding := func(produceErr error) {
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			DoAndReturn(func(_ context.Context, change *domain.EmailChange) error {
+				assert.Equal(t, userID, change.UserID)
+				assert.Equal(t, oldEmail, change.OldEmail)
+				assert.Equal(t, newEmail, change.NewEmail)
+				assert.WithinDuration(t, time.Now().Add(codeTTL), change.ExpiresAt, time.Second)
+
+				databus.EXPECT().
+					ProduceEmailChangeCodeEmail(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg *dto.EmailChangeCodeEmailMessage) error {
+						assert.Equal(t, newEmail, msg.Email)
+						assert.Equal(t, change.CodeHash, email_change.Hash(msg.Code))
+						return produceErr
+					})
+
+				databus.EXPECT().
+					ProduceEmailChangeNoticeEmail(gomock.Eq(ctx), gomock.Eq(&dto.EmailChangeNoticeEmailMessage{
+						Email:    oldEmail,
+						NewEmail: newEmail,
+					})).
+					Return(produceErr)
+
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				repository.EXPECT().
+					RemovePending(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(nil)
+
+				expectAdding(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on removing pending changes",
+			setup: func() {
+				repository.EXPECT().
+					RemovePending(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "pending email changes removing from repository error: dummy error",
+		},
+		{
+			name: "error on adding change",
+			setup: func() {
+				repository.EXPECT().
+					RemovePending(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(nil)
+
+				repository.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Any()).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "email change adding to repository error: dummy error",
+		},
+		{
+			name: "error on producing email messages",
+			setup: func() {
+				repository.EXPECT().
+					RemovePending(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(nil)
+
+				expectAdding(errors.New("dummy error"))
+
+				logger.EXPECT().
+					Error(gomock.Eq("produce message to databus error"), gomock.Any(), gomock.Any()).
+					Times(2)
+			},
+			expErr: noError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			s := email_change.New(logger, databus, codeTTL, undoWindow)
+			err := s.SendCode(ctx, user, newEmail, tx)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
+
+func TestService_CheckCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository = mockdomain.NewMockEmailChangeRepository(ctrl)
+		tx         = mockdomain.NewMockTxCommitter(ctrl)
+	)
+
+	var (
+		ctx         = context.Background()
+		userID      = int64(1)
+		code        = "dummyCode"
+		codeHash    = email_change.Hash(code)
+		validChange = &domain.EmailChange{
+			ID:        2,
+			UserID:    userID,
+			OldEmail:  "old@example.com",
+			NewEmail:  "new@example.com",
+			CodeHash:  codeHash,
+			ExpiresAt: time.Now().Add(time.Minute),
+		}
+	)
+
+	tx.EXPECT().
+		EmailChange().
+		Return(repository).
+		AnyTimes()
+
+	tests := []struct {
+		name      string
+		setup     func()
+		expChange *domain.EmailChange
+		expErr    string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				repository.EXPECT().
+					GetPending(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(codeHash)).
+					Return(validChange, nil)
+			},
+			expChange: validChange,
+			expErr:    noError,
+		},
+		{
+			name: "error on getting change",
+			setup: func() {
+				repository.EXPECT().
+					GetPending(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(codeHash)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "pending email change getting from repository error: dummy error",
+		},
+		{
+			name: "change not found",
+			setup: func() {
+				repository.EXPECT().
+					GetPending(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(codeHash)).
+					Return(nil, nil)
+			},
+			expErr: dto.ErrInvalidEmailChangeCode.Error(),
+		},
+		{
+			name: "change expired",
+			setup: func() {
+				repository.EXPECT().
+					GetPending(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(codeHash)).
+					Return(&domain.EmailChange{ID: 2, UserID: userID, ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+			},
+			expErr: dto.ErrInvalidEmailChangeCode.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			s := email_change.New(nil, nil, codeTTL, undoWindow)
+			change, err := s.CheckCode(ctx, userID, code, tx)
+
+			assert.Equal(t, tt.expChange, change)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
+
+func TestService_IssueUndo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		logger     = log_mock.NewMockLogger(ctrl)
+		databus    = mock.NewMockdatabus(ctrl)
+		repository = mockdomain.NewMockEmailChangeRepository(ctrl)
+		tx         = mockdomain.NewMockTxCommitter(ctrl)
+	)
+
+	var (
+		ctx      = context.Background()
+		changeID = int64(2)
+		oldEmail = "old@example.com"
+		newEmail = "new@example.com"
+	)
+
+	tx.EXPECT().
+		EmailChange().
+		Return(repository).
+		AnyTimes()
+
+	expectConfirming := func(confirmErr error) {
+		repository.EXPECT().
+			Confirm(gomock.Eq(ctx), gomock.Eq(changeID), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int64, _ string, undoExpiresAt time.Time) error {
+				assert.WithinDuration(t, time.Now().Add(undoWindow), undoExpiresAt, time.Second)
+				return confirmErr
+			})
+	}
+
+	expectProducing := func(change *domain.EmailChange, produceErr error) {
+		databus.EXPECT().
+			ProduceEmailChangeNoticeEmail(gomock.Eq(ctx), gomock.Any()).
+			DoAndReturn(func(_ context.Context, msg *dto.EmailChangeNoticeEmailMessage) error {
+				assert.Equal(t, oldEmail, msg.Email)
+				assert.Equal(t, newEmail, msg.NewEmail)
+				assert.Equal(t, change.UndoTokenHash, email_change.Hash(msg.UndoToken))
+				return produceErr
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func(change *domain.EmailChange)
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func(change *domain.EmailChange) {
+				expectConfirming(nil)
+				expectProducing(change, nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on confirming change",
+			setup: func(_ *domain.EmailChange) {
+				expectConfirming(errors.New("dummy error"))
+			},
+			expErr: "email change confirming in repository error: dummy error",
+		},
+		{
+			name: "error on producing email message",
+			setup: func(change *domain.EmailChange) {
+				expectConfirming(nil)
+				expectProducing(change, errors.New("dummy error"))
+
+				logger.EXPECT().
+					Error(gomock.Eq("produce message to databus error"), gomock.Any(), gomock.Any())
+			},
+			expErr: noError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := &domain.EmailChange{ID: changeID, UserID: 1, OldEmail: oldEmail, NewEmail: newEmail}
+			tt.setup(change)
+
+			s := email_change.New(logger, databus, codeTTL, undoWindow)
+			err := s.IssueUndo(ctx, change, tx)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, change.UndoTokenHash)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
+
+func TestService_TakeUndo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository = mockdomain.NewMockEmailChangeRepository(ctrl)
+		tx         = mockdomain.NewMockTxCommitter(ctrl)
+	)
+
+	var (
+		ctx         = context.Background()
+		token       = "dummyToken"
+		tokenHash   = email_change.Hash(token)
+		validChange = &domain.EmailChange{
+			ID:            2,
+			UserID:        1,
+			OldEmail:      "old@example.com",
+			NewEmail:      "new@example.com",
+			UndoTokenHash: tokenHash,
+			UndoExpiresAt: time.Now().Add(time.Minute),
+		}
+	)
+
+	tx.EXPECT().
+		EmailChange().
+		Return(repository).
+		AnyTimes()
+
+	tests := []struct {
+		name      string
+		setup     func()
+		expChange *domain.EmailChange
+		expErr    string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				repository.EXPECT().
+					TakeConfirmed(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(validChange, nil)
+			},
+			expChange: validChange,
+			expErr:    noError,
+		},
+		{
+			name: "error on taking change",
+			setup: func() {
+				repository.EXPECT().
+					TakeConfirmed(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "confirmed email change taking from repository error: dummy error",
+		},
+		{
+			name: "change not found",
+			setup: func() {
+				repository.EXPECT().
+					TakeConfirmed(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(nil, nil)
+			},
+			expErr: dto.ErrInvalidEmailChangeUndoToken.Error(),
+		},
+		{
+			name: "undo window is over",
+			setup: func() {
+				repository.EXPECT().
+					TakeConfirmed(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(&domain.EmailChange{ID: 2, UserID: 1, UndoExpiresAt: time.Now().Add(-time.Minute)}, nil)
+			},
+			expErr: dto.ErrInvalidEmailChangeUndoToken.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			s := email_change.New(nil, nil, codeTTL, undoWindow)
+			change, err := s.TakeUndo(ctx, token, tx)
+
+			assert.Equal(t, tt.expChange, change)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
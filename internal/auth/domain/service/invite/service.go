@@ -0,0 +1,63 @@
+package invite
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const codeSize = 16
+
+type Service struct{}
+
+func New() *Service {
+	return &Service{}
+}
+
+// Issue adds the invite with a new code, the raw code is returned only here, the repository keeps its hash.
+func (s *Service) Issue(ctx context.Context, invite *domain.Invite, repository domain.InviteRepository) (string, error) {
+	code, err := generate()
+	if err != nil {
+		return "", fmt.Errorf("invite code generation error: %w", err)
+	}
+
+	invite.CodeHash = hash(code)
+	if err = repository.Add(ctx, invite); err != nil {
+		return "", fmt.Errorf("invite adding to repository error: %w", err)
+	}
+
+	return code, nil
+}
+
+// Redeem counts the use of the invite, dto.ErrInvalidInvite is returned for unknown, used up or expired ones.
+// The use of an expired invite is counted as well, so the transaction must be rolled back on the error.
+func (s *Service) Redeem(ctx context.Context, code string, repository domain.InviteRepository) (*domain.Invite, error) {
+	invite, err := repository.Use(ctx, hash(code))
+	if err != nil {
+		return nil, fmt.Errorf("invite using in repository error: %w", err)
+	}
+	if invite == nil || !time.Now().Before(invite.ExpiresAt) {
+		return nil, dto.ErrInvalidInvite
+	}
+	return invite, nil
+}
+
+func hash(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func generate() (string, error) {
+	b := make([]byte, codeSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
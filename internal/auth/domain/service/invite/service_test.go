@@ -0,0 +1,124 @@
+package invite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	mockdomain "github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/domain/service/invite"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const noError = ""
+
+func TestService_Issue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockInviteRepository(ctrl)
+
+	var (
+		ctx     = context.Background()
+		service = invite.New()
+	)
+
+	t.Run("happy path", func(t *testing.T) {
+		object := &domain.Invite{MaxUses: 1, ExpiresAt: time.Now().Add(time.Hour), CreatedBy: 1}
+
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Eq(object)).
+			Return(nil)
+
+		code, err := service.Issue(ctx, object, repository)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, code)
+		assert.NotEmpty(t, object.CodeHash)
+		assert.NotEqual(t, code, object.CodeHash)
+
+		repository.EXPECT().
+			Use(gomock.Eq(ctx), gomock.Eq(object.CodeHash)).
+			Return(object, nil)
+
+		used, err := service.Redeem(ctx, code, repository)
+		assert.NoError(t, err)
+		assert.Equal(t, object, used)
+	})
+
+	t.Run("error on adding invite", func(t *testing.T) {
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			Return(errors.New("dummy error"))
+
+		code, err := service.Issue(ctx, &domain.Invite{}, repository)
+		assert.Empty(t, code)
+		assert.EqualError(t, err, "invite adding to repository error: dummy error")
+	})
+}
+
+func TestService_Redeem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockInviteRepository(ctrl)
+
+	var (
+		ctx     = context.Background()
+		code    = "dummyCode"
+		service = invite.New()
+	)
+
+	tests := []struct {
+		name   string
+		stored *domain.Invite
+		err    error
+		expOut bool
+		expErr string
+	}{
+		{
+			name:   "happy path",
+			stored: &domain.Invite{ID: 1, ExpiresAt: time.Now().Add(time.Hour)},
+			expOut: true,
+			expErr: noError,
+		},
+		{
+			name:   "invite not found or used up",
+			expErr: dto.ErrInvalidInvite.Error(),
+		},
+		{
+			name:   "invite expired",
+			stored: &domain.Invite{ID: 1, ExpiresAt: time.Now().Add(-time.Minute)},
+			expErr: dto.ErrInvalidInvite.Error(),
+		},
+		{
+			name:   "error on using invite",
+			err:    errors.New("dummy error"),
+			expErr: "invite using in repository error: dummy error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository.EXPECT().
+				Use(gomock.Eq(ctx), gomock.Any()).
+				Return(tt.stored, tt.err)
+
+			out, err := service.Redeem(ctx, code, repository)
+
+			if tt.expOut {
+				assert.Equal(t, tt.stored, out)
+			} else {
+				assert.Nil(t, out)
+			}
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
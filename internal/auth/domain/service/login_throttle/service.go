@@ -0,0 +1,131 @@
+package login_throttle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+// Policy limits consecutive failed attempts made with the same key.
+type Policy struct {
+	// BackoffAfter is the number of failures after which each next attempt
+	// is delayed twice as long as the previous one, starting from the base delay.
+	BackoffAfter int
+	// LockoutAfter is the number of failures after which attempts are rejected
+	// for the whole lockout duration.
+	LockoutAfter int
+}
+
+type Service struct {
+	repository      domain.LoginAttemptRepository
+	accountPolicy   Policy
+	ipPolicy        Policy
+	baseDelay       time.Duration
+	lockoutDuration time.Duration
+}
+
+// New creates the service. Failures are tracked per account and per client IP,
+// a counter is forgotten when no failures are made with its key for the lockout duration.
+func New(
+	repository domain.LoginAttemptRepository,
+	accountPolicy, ipPolicy Policy,
+	baseDelay, lockoutDuration time.Duration,
+) *Service {
+	return &Service{
+		repository:      repository,
+		accountPolicy:   accountPolicy,
+		ipPolicy:        ipPolicy,
+		baseDelay:       baseDelay,
+		lockoutDuration: lockoutDuration,
+	}
+}
+
+// Check returns *dto.LoginThrottledError if the attempt has to be rejected.
+func (s *Service) Check(ctx context.Context, email, ip string) error {
+	now := time.Now()
+
+	var retryAfter time.Duration
+	for _, k := range s.keys(email, ip) {
+		attempts, err := s.repository.Get(ctx, k.key)
+		if err != nil {
+			return fmt.Errorf("login attempts getting from repository error: %w", err)
+		}
+		if attempts == nil {
+			continue
+		}
+
+		if d := s.blockedUntil(attempts, k.policy).Sub(now); d > retryAfter {
+			retryAfter = d
+		}
+	}
+
+	if retryAfter > 0 {
+		return &dto.LoginThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// AddFailure counts the failed attempt for the account and the client IP.
+func (s *Service) AddFailure(ctx context.Context, email, ip string) error {
+	now := time.Now()
+	resetBefore := now.Add(-s.lockoutDuration)
+
+	for _, k := range s.keys(email, ip) {
+		if _, err := s.repository.AddFailure(ctx, k.key, now, resetBefore); err != nil {
+			return fmt.Errorf("login failure adding to repository error: %w", err)
+		}
+	}
+	return nil
+}
+
+// Reset forgets the failures of the account. The client IP failures are kept,
+// otherwise signing in to an own account would reset the limit for guessing others.
+func (s *Service) Reset(ctx context.Context, email string) error {
+	if err := s.repository.Remove(ctx, accountKey(email)); err != nil {
+		return fmt.Errorf("login attempts removing from repository error: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) blockedUntil(attempts *domain.LoginAttempts, policy Policy) time.Time {
+	switch {
+	case attempts.Failures >= policy.LockoutAfter:
+		return attempts.LastFailedAt.Add(s.lockoutDuration)
+	case attempts.Failures >= policy.BackoffAfter:
+		return attempts.LastFailedAt.Add(s.backoffDelay(attempts.Failures - policy.BackoffAfter))
+	default:
+		return time.Time{}
+	}
+}
+
+func (s *Service) backoffDelay(exponent int) time.Duration {
+	delay := s.baseDelay
+	for i := 0; i < exponent && delay < s.lockoutDuration; i++ {
+		delay *= 2
+	}
+	if delay > s.lockoutDuration {
+		delay = s.lockoutDuration
+	}
+	return delay
+}
+
+type policyKey struct {
+	key    string
+	policy Policy
+}
+
+func (s *Service) keys(email, ip string) []policyKey {
+	keys := []policyKey{{key: accountKey(email), policy: s.accountPolicy}}
+	if ip != "" {
+		keys = append(keys, policyKey{key: "ip:" + ip, policy: s.ipPolicy})
+	}
+	return keys
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(email)
+}
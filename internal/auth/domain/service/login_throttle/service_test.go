@@ -0,0 +1,252 @@
+package login_throttle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	mockdomain "github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/domain/service/login_throttle"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const (
+	noError         = ""
+	baseDelay       = time.Second
+	lockoutDuration = 15 * time.Minute
+)
+
+var (
+	accountPolicy = login_throttle.Policy{BackoffAfter: 3, LockoutAfter: 6}
+	ipPolicy      = login_throttle.Policy{BackoffAfter: 10, LockoutAfter: 20}
+)
+
+func TestService_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockLoginAttemptRepository(ctrl)
+
+	var (
+		ctx        = context.Background()
+		email      = "I.Ivanov@example.com"
+		ip         = "192.0.2.1"
+		accountKey = "account:i.ivanov@example.com"
+		ipKey      = "ip:192.0.2.1"
+		now        = time.Now()
+	)
+
+	tests := []struct {
+		name          string
+		setup         func()
+		ip            string
+		expRetryAfter time.Duration
+		expErr        string
+	}{
+		{
+			name: "no failures",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(accountKey)).Return(nil, nil)
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(ipKey)).Return(nil, nil)
+			},
+			ip:     ip,
+			expErr: noError,
+		},
+		{
+			name: "failures below back-off",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(accountKey)).
+					Return(&domain.LoginAttempts{Key: accountKey, Failures: 2, LastFailedAt: now}, nil)
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(ipKey)).Return(nil, nil)
+			},
+			ip:     ip,
+			expErr: noError,
+		},
+		{
+			name: "account back-off",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(accountKey)).
+					Return(&domain.LoginAttempts{Key: accountKey, Failures: 5, LastFailedAt: now}, nil)
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(ipKey)).Return(nil, nil)
+			},
+			ip:            ip,
+			expRetryAfter: 4 * baseDelay,
+		},
+		{
+			name: "account back-off is over",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(accountKey)).
+					Return(&domain.LoginAttempts{Key: accountKey, Failures: 5, LastFailedAt: now.Add(-5 * baseDelay)}, nil)
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(ipKey)).Return(nil, nil)
+			},
+			ip:     ip,
+			expErr: noError,
+		},
+		{
+			name: "account lockout",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(accountKey)).
+					Return(&domain.LoginAttempts{Key: accountKey, Failures: 6, LastFailedAt: now.Add(-time.Minute)}, nil)
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(ipKey)).Return(nil, nil)
+			},
+			ip:            ip,
+			expRetryAfter: lockoutDuration - time.Minute,
+		},
+		{
+			name: "account lockout is over",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(accountKey)).
+					Return(&domain.LoginAttempts{Key: accountKey, Failures: 6, LastFailedAt: now.Add(-lockoutDuration)}, nil)
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(ipKey)).Return(nil, nil)
+			},
+			ip:     ip,
+			expErr: noError,
+		},
+		{
+			name: "back-off is capped by lockout",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(accountKey)).
+					Return(nil, nil)
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(ipKey)).
+					Return(&domain.LoginAttempts{Key: ipKey, Failures: 19, LastFailedAt: now}, nil)
+			},
+			ip:            ip,
+			expRetryAfter: 512 * baseDelay,
+		},
+		{
+			name: "the longest delay wins",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(accountKey)).
+					Return(&domain.LoginAttempts{Key: accountKey, Failures: 3, LastFailedAt: now}, nil)
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(ipKey)).
+					Return(&domain.LoginAttempts{Key: ipKey, Failures: 20, LastFailedAt: now}, nil)
+			},
+			ip:            ip,
+			expRetryAfter: lockoutDuration,
+		},
+		{
+			name: "unknown client IP",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(accountKey)).Return(nil, nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on getting attempts",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(accountKey)).
+					Return(nil, errors.New("dummy error"))
+			},
+			ip:     ip,
+			expErr: "login attempts getting from repository error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			s := login_throttle.New(repository, accountPolicy, ipPolicy, baseDelay, lockoutDuration)
+			err := s.Check(ctx, email, tt.ip)
+
+			if tt.expRetryAfter > 0 {
+				var throttledErr *dto.LoginThrottledError
+				if assert.ErrorAs(t, err, &throttledErr) {
+					assert.InDelta(t, tt.expRetryAfter, throttledErr.RetryAfter, float64(time.Second))
+				}
+				return
+			}
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
+
+func TestService_AddFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockLoginAttemptRepository(ctrl)
+
+	var (
+		ctx   = context.Background()
+		email = "I.Ivanov@example.com"
+		ip    = "192.0.2.1"
+	)
+
+	expectAddFailure := func(key string, err error) {
+		repository.EXPECT().
+			AddFailure(gomock.Eq(ctx), gomock.Eq(key), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, key string, failedAt, resetBefore time.Time) (*domain.LoginAttempts, error) {
+				assert.WithinDuration(t, time.Now(), failedAt, time.Second)
+				assert.Equal(t, lockoutDuration, failedAt.Sub(resetBefore))
+				if err != nil {
+					return nil, err
+				}
+				return &domain.LoginAttempts{Key: key, Failures: 1, LastFailedAt: failedAt}, nil
+			})
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		expectAddFailure("account:i.ivanov@example.com", nil)
+		expectAddFailure("ip:192.0.2.1", nil)
+
+		s := login_throttle.New(repository, accountPolicy, ipPolicy, baseDelay, lockoutDuration)
+		assert.NoError(t, s.AddFailure(ctx, email, ip))
+	})
+
+	t.Run("error on adding failure", func(t *testing.T) {
+		expectAddFailure("account:i.ivanov@example.com", errors.New("dummy error"))
+
+		s := login_throttle.New(repository, accountPolicy, ipPolicy, baseDelay, lockoutDuration)
+		err := s.AddFailure(ctx, email, ip)
+		assert.EqualError(t, err, "login failure adding to repository error: dummy error")
+	})
+}
+
+func TestService_Reset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockLoginAttemptRepository(ctrl)
+
+	var (
+		ctx   = context.Background()
+		email = "I.Ivanov@example.com"
+	)
+
+	t.Run("happy path", func(t *testing.T) {
+		repository.EXPECT().
+			Remove(gomock.Eq(ctx), gomock.Eq("account:i.ivanov@example.com")).
+			Return(nil)
+
+		s := login_throttle.New(repository, accountPolicy, ipPolicy, baseDelay, lockoutDuration)
+		assert.NoError(t, s.Reset(ctx, email))
+	})
+
+	t.Run("error on removing attempts", func(t *testing.T) {
+		repository.EXPECT().
+			Remove(gomock.Eq(ctx), gomock.Eq("account:i.ivanov@example.com")).
+			Return(errors.New("dummy error"))
+
+		s := login_throttle.New(repository, accountPolicy, ipPolicy, baseDelay, lockoutDuration)
+		err := s.Reset(ctx, email)
+		assert.EqualError(t, err, "login attempts removing from repository error: dummy error")
+	})
+}
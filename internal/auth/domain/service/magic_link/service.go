@@ -0,0 +1,104 @@
+//go:generate mockgen -source=service.go -destination=mock/service.go -package=mock
+package magic_link
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/log"
+)
+
+const tokenSize = 32
+
+type databus interface {
+	ProduceMagicLinkEmail(ctx context.Context, msg *dto.MagicLinkEmailMessage) error
+}
+
+type Service struct {
+	logger   log.Logger
+	databus  databus
+	tokenTTL time.Duration
+}
+
+func New(logger log.Logger, databus databus, tokenTTL time.Duration) *Service {
+	return &Service{
+		logger:   logger,
+		databus:  databus,
+		tokenTTL: tokenTTL,
+	}
+}
+
+// SendToken replaces login tokens of the user with a new one and sends it to the user's email.
+func (s *Service) SendToken(ctx context.Context, user *domain.User, tx domain.TxCommitter) error {
+	if err := tx.MagicLinkToken().RemoveUserTokens(ctx, user.ID); err != nil {
+		return fmt.Errorf("magic link tokens removing from repository error: %w", err)
+	}
+
+	token, err := generate()
+	if err != nil {
+		return fmt.Errorf("magic link token generation error: %w", err)
+	}
+
+	object := &domain.MagicLinkToken{
+		TokenHash: Hash(token),
+		UserID:    user.ID,
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(s.tokenTTL),
+	}
+
+	if err = tx.MagicLinkToken().Add(ctx, object); err != nil {
+		return fmt.Errorf("magic link token adding to repository error: %w", err)
+	}
+
+	msg := &dto.MagicLinkEmailMessage{
+		Email: user.Email,
+		Token: token,
+	}
+
+	if err = s.databus.ProduceMagicLinkEmail(ctx, msg); err != nil {
+		s.logger.Error("produce message to databus error",
+			log.Error(err),
+			log.String("location", "auth/service/magic_link"))
+	}
+
+	return nil
+}
+
+// ConsumeToken invalidates the token and the other login tokens of its user.
+// It returns the token, so the email it's bound to can be checked.
+func (s *Service) ConsumeToken(ctx context.Context, token string, tx domain.TxCommitter) (*domain.MagicLinkToken, error) {
+	object, err := tx.MagicLinkToken().Take(ctx, Hash(token))
+	if err != nil {
+		return nil, fmt.Errorf("magic link token taking from repository error: %w", err)
+	}
+	if object == nil || !time.Now().Before(object.ExpiresAt) {
+		return nil, dto.ErrInvalidMagicLinkToken
+	}
+
+	if err = tx.MagicLinkToken().RemoveUserTokens(ctx, object.UserID); err != nil {
+		return nil, fmt.Errorf("magic link tokens removing from repository error: %w", err)
+	}
+
+	return object, nil
+}
+
+// Hash returns the representation of the token kept in the repository.
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generate() (string, error) {
+	b := make([]byte, tokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
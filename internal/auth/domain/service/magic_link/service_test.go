@@ -0,0 +1,241 @@
+package magic_link_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	mockdomain "github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/domain/service/magic_link"
+	"github.com/art-es/blog/internal/auth/domain/service/magic_link/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	log_mock "github.com/art-es/blog/internal/common/log/mock"
+)
+
+const (
+	noError  = ""
+	tokenTTL = 15 * time.Minute
+)
+
+func TestService_SendToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		logger     = log_mock.NewMockLogger(ctrl)
+		databus    = mock.NewMockdatabus(ctrl)
+		repository = mockdomain.NewMockMagicLinkTokenRepository(ctrl)
+		tx         = mockdomain.NewMockTxCommitter(ctrl)
+	)
+
+	var (
+		ctx    = context.Background()
+		userID = int64(1)
+		email  = "dummyEmail@example.com"
+		user   = &domain.User{ID: userID, Email: email}
+	)
+
+	tx.EXPECT().
+		MagicLinkToken().
+		Return(repository).
+		AnyTimes()
+
+	expectAdding := func(produceErr error) {
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			DoAndReturn(func(_ context.Context, object *domain.MagicLinkToken) error {
+				assert.Equal(t, userID, object.UserID)
+				assert.Equal(t, email, object.Email)
+				assert.WithinDuration(t, time.Now().Add(tokenTTL), object.ExpiresAt, time.Second)
+
+				databus.EXPECT().
+					ProduceMagicLinkEmail(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg *dto.MagicLinkEmailMessage) error {
+						assert.Equal(t, email, msg.Email)
+						assert.Equal(t, object.TokenHash, magic_link.Hash(msg.Token))
+						return produceErr
+					})
+
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				repository.EXPECT().
+					RemoveUserTokens(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(nil)
+
+				expectAdding(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on removing previous tokens",
+			setup: func() {
+				repository.EXPECT().
+					RemoveUserTokens(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "magic link tokens removing from repository error: dummy error",
+		},
+		{
+			name: "error on adding token",
+			setup: func() {
+				repository.EXPECT().
+					RemoveUserTokens(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(nil)
+
+				repository.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Any()).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "magic link token adding to repository error: dummy error",
+		},
+		{
+			name: "error on producing email message",
+			setup: func() {
+				repository.EXPECT().
+					RemoveUserTokens(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(nil)
+
+				expectAdding(errors.New("dummy error"))
+
+				logger.EXPECT().
+					Error(gomock.Eq("produce message to databus error"), gomock.Any(), gomock.Any())
+			},
+			expErr: noError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			s := magic_link.New(logger, databus, tokenTTL)
+			err := s.SendToken(ctx, user, tx)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
+
+func TestService_ConsumeToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository = mockdomain.NewMockMagicLinkTokenRepository(ctrl)
+		tx         = mockdomain.NewMockTxCommitter(ctrl)
+	)
+
+	var (
+		ctx       = context.Background()
+		userID    = int64(1)
+		token     = "dummyToken"
+		tokenHash = magic_link.Hash(token)
+		validLink = &domain.MagicLinkToken{
+			TokenHash: tokenHash,
+			UserID:    userID,
+			Email:     "dummyEmail@example.com",
+			ExpiresAt: time.Now().Add(time.Minute),
+		}
+	)
+
+	tx.EXPECT().
+		MagicLinkToken().
+		Return(repository).
+		AnyTimes()
+
+	tests := []struct {
+		name     string
+		setup    func()
+		expToken *domain.MagicLinkToken
+		expErr   string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				repository.EXPECT().
+					Take(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(validLink, nil)
+
+				repository.EXPECT().
+					RemoveUserTokens(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(nil)
+			},
+			expToken: validLink,
+			expErr:   noError,
+		},
+		{
+			name: "error on taking token",
+			setup: func() {
+				repository.EXPECT().
+					Take(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "magic link token taking from repository error: dummy error",
+		},
+		{
+			name: "token not found",
+			setup: func() {
+				repository.EXPECT().
+					Take(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(nil, nil)
+			},
+			expErr: dto.ErrInvalidMagicLinkToken.Error(),
+		},
+		{
+			name: "token expired",
+			setup: func() {
+				repository.EXPECT().
+					Take(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(&domain.MagicLinkToken{TokenHash: tokenHash, UserID: userID, ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+			},
+			expErr: dto.ErrInvalidMagicLinkToken.Error(),
+		},
+		{
+			name: "error on removing user tokens",
+			setup: func() {
+				repository.EXPECT().
+					Take(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(validLink, nil)
+
+				repository.EXPECT().
+					RemoveUserTokens(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "magic link tokens removing from repository error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			s := magic_link.New(nil, nil, tokenTTL)
+			object, err := s.ConsumeToken(ctx, token, tx)
+
+			assert.Equal(t, tt.expToken, object)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
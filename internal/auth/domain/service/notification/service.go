@@ -0,0 +1,40 @@
+//go:generate mockgen -source=service.go -destination=mock/service.go -package=mock
+package notification
+
+import (
+	"context"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/log"
+)
+
+type databus interface {
+	ProducePasswordChangedEmail(ctx context.Context, msg *dto.PasswordChangedEmailMessage) error
+}
+
+// Service informs users about changes of their accounts.
+// Failed notifications are logged only, they don't affect the changes.
+type Service struct {
+	logger  log.Logger
+	databus databus
+}
+
+func New(logger log.Logger, databus databus) *Service {
+	return &Service{
+		logger:  logger,
+		databus: databus,
+	}
+}
+
+func (s *Service) NotifyPasswordChanged(ctx context.Context, user *domain.User) {
+	msg := &dto.PasswordChangedEmailMessage{
+		Email: user.Email,
+	}
+
+	if err := s.databus.ProducePasswordChangedEmail(ctx, msg); err != nil {
+		s.logger.Error("produce message to databus error",
+			log.Error(err),
+			log.String("location", "auth/service/notification"))
+	}
+}
@@ -0,0 +1,63 @@
+package notification_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/domain/service/notification"
+	"github.com/art-es/blog/internal/auth/domain/service/notification/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	log_mock "github.com/art-es/blog/internal/common/log/mock"
+)
+
+func TestService_NotifyPasswordChanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		logger  = log_mock.NewMockLogger(ctrl)
+		databus = mock.NewMockdatabus(ctrl)
+	)
+
+	var (
+		ctx   = context.Background()
+		email = "dummyEmail@example.com"
+		user  = &domain.User{ID: 1, Email: email}
+		msg   = &dto.PasswordChangedEmailMessage{Email: email}
+	)
+
+	tests := []struct {
+		name  string
+		setup func()
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				databus.EXPECT().
+					ProducePasswordChangedEmail(gomock.Eq(ctx), gomock.Eq(msg)).
+					Return(nil)
+			},
+		},
+		{
+			name: "error on producing email message",
+			setup: func() {
+				databus.EXPECT().
+					ProducePasswordChangedEmail(gomock.Eq(ctx), gomock.Eq(msg)).
+					Return(errors.New("dummy error"))
+
+				logger.EXPECT().
+					Error(gomock.Eq("produce message to databus error"), gomock.Any(), gomock.Any())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			notification.New(logger, databus).NotifyPasswordChanged(ctx, user)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package oidc_state
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const tokenSize = 32
+
+type Service struct {
+	ttl time.Duration
+}
+
+// New creates the service, ttl limits the time to sign in at the provider.
+func New(ttl time.Duration) *Service {
+	return &Service{
+		ttl: ttl,
+	}
+}
+
+// Issue remembers a new authorization request to the provider.
+// The code verifier is kept until the response, only its challenge is sent with the request.
+func (s *Service) Issue(ctx context.Context, provider string, repository domain.OIDCStateRepository) (*dto.OIDCRequest, error) {
+	state, err := generate()
+	if err != nil {
+		return nil, fmt.Errorf("state generation error: %w", err)
+	}
+
+	nonce, err := generate()
+	if err != nil {
+		return nil, fmt.Errorf("nonce generation error: %w", err)
+	}
+
+	codeVerifier, err := generate()
+	if err != nil {
+		return nil, fmt.Errorf("code verifier generation error: %w", err)
+	}
+
+	object := &domain.OIDCState{
+		StateHash:    Hash(state),
+		Provider:     provider,
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(s.ttl),
+	}
+
+	if err = repository.Add(ctx, object); err != nil {
+		return nil, fmt.Errorf("oidc state adding to repository error: %w", err)
+	}
+
+	return &dto.OIDCRequest{
+		State:         state,
+		Nonce:         nonce,
+		CodeChallenge: CodeChallenge(codeVerifier),
+		ExpiresAt:     object.ExpiresAt,
+	}, nil
+}
+
+// Take consumes the state returned by the provider, so the response cannot be replayed.
+func (s *Service) Take(ctx context.Context, provider, state string, repository domain.OIDCStateRepository) (*domain.OIDCState, error) {
+	object, err := repository.Take(ctx, Hash(state))
+	if err != nil {
+		return nil, fmt.Errorf("oidc state taking from repository error: %w", err)
+	}
+	if object == nil || object.Provider != provider || !time.Now().Before(object.ExpiresAt) {
+		return nil, dto.ErrInvalidOIDCState
+	}
+
+	return object, nil
+}
+
+// Hash returns the representation of the state kept in the repository.
+func Hash(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
+
+// CodeChallenge derives the PKCE code challenge from the verifier with S256 method
+// https://datatracker.ietf.org/doc/html/rfc7636#section-4.2
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// generate returns 43 URL-safe characters, which also suits the PKCE code verifier.
+func generate() (string, error) {
+	b := make([]byte, tokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
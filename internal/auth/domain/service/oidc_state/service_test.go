@@ -0,0 +1,171 @@
+package oidc_state_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	mockdomain "github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/domain/service/oidc_state"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const (
+	noError  = ""
+	provider = "example"
+	ttl      = 10 * time.Minute
+)
+
+func TestService_Issue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockOIDCStateRepository(ctrl)
+
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		var added *domain.OIDCState
+
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			DoAndReturn(func(_ context.Context, state *domain.OIDCState) error {
+				added = state
+				return nil
+			})
+
+		request, err := oidc_state.New(ttl).Issue(ctx, provider, repository)
+		assert.NoError(t, err)
+
+		assert.Len(t, request.State, 43)
+		assert.Len(t, request.Nonce, 43)
+		assert.NotEqual(t, request.State, request.Nonce)
+
+		assert.Equal(t, oidc_state.Hash(request.State), added.StateHash)
+		assert.Equal(t, provider, added.Provider)
+		assert.Equal(t, request.Nonce, added.Nonce)
+		assert.Len(t, added.CodeVerifier, 43)
+		assert.Equal(t, oidc_state.CodeChallenge(added.CodeVerifier), request.CodeChallenge)
+		assert.WithinDuration(t, time.Now().Add(ttl), added.ExpiresAt, time.Second)
+		assert.Equal(t, added.ExpiresAt, request.ExpiresAt)
+	})
+
+	t.Run("error on adding state", func(t *testing.T) {
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			Return(errors.New("dummy error"))
+
+		request, err := oidc_state.New(ttl).Issue(ctx, provider, repository)
+		assert.Nil(t, request)
+		assert.EqualError(t, err, "oidc state adding to repository error: dummy error")
+	})
+}
+
+func TestService_Take(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockOIDCStateRepository(ctrl)
+
+	var (
+		ctx   = context.Background()
+		state = "dummyState"
+	)
+
+	stateFactory := func() *domain.OIDCState {
+		return &domain.OIDCState{
+			StateHash:    oidc_state.Hash(state),
+			Provider:     provider,
+			CodeVerifier: "dummyCodeVerifier",
+			Nonce:        "dummyNonce",
+			ExpiresAt:    time.Now().Add(time.Minute),
+		}
+	}
+
+	expectTaking := func(object *domain.OIDCState, err error) {
+		repository.EXPECT().
+			Take(gomock.Eq(ctx), gomock.Eq(oidc_state.Hash(state))).
+			Return(object, err)
+	}
+
+	tests := []struct {
+		name     string
+		setup    func()
+		provider string
+		expOut   bool
+		expErr   string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				expectTaking(stateFactory(), nil)
+			},
+			provider: provider,
+			expOut:   true,
+			expErr:   noError,
+		},
+		{
+			name: "error on taking state",
+			setup: func() {
+				expectTaking(nil, errors.New("dummy error"))
+			},
+			provider: provider,
+			expErr:   "oidc state taking from repository error: dummy error",
+		},
+		{
+			name: "state not found",
+			setup: func() {
+				expectTaking(nil, nil)
+			},
+			provider: provider,
+			expErr:   dto.ErrInvalidOIDCState.Error(),
+		},
+		{
+			name: "state issued for another provider",
+			setup: func() {
+				expectTaking(stateFactory(), nil)
+			},
+			provider: "another",
+			expErr:   dto.ErrInvalidOIDCState.Error(),
+		},
+		{
+			name: "state expired",
+			setup: func() {
+				object := stateFactory()
+				object.ExpiresAt = time.Now().Add(-time.Second)
+				expectTaking(object, nil)
+			},
+			provider: provider,
+			expErr:   dto.ErrInvalidOIDCState.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			object, err := oidc_state.New(ttl).Take(ctx, tt.provider, state, repository)
+
+			if tt.expOut {
+				assert.Equal(t, "dummyCodeVerifier", object.CodeVerifier)
+				assert.Equal(t, "dummyNonce", object.Nonce)
+			} else {
+				assert.Nil(t, object)
+			}
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// https://datatracker.ietf.org/doc/html/rfc7636#appendix-B
+	challenge := oidc_state.CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", challenge)
+}
@@ -1,30 +1,142 @@
 package password_hash
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/art-es/blog/internal/auth/dto"
 )
 
+const (
+	argon2idPrefix = "$argon2id$"
+	saltLength     = 16
+	keyLength      = 32
+)
+
+var errMalformedHash = errors.New("malformed password hash")
+
+// Params are the cost parameters of Argon2id.
+type Params struct {
+	// Memory is in KiB.
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// DefaultParams follow the second recommended option of RFC 9106.
+var DefaultParams = Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+}
+
 type Service struct {
-	cost int
+	params Params
 }
 
-func New() *Service {
+func New(params Params) *Service {
 	return &Service{
-		cost: 10,
+		params: params,
 	}
 }
 
+// Generate hashes the password with Argon2id, the hash is encoded in the PHC string format
+// "$argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>".
 func (s *Service) Generate(password string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), s.cost)
-	return string(hash), err
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, s.params.Iterations, s.params.Memory, s.params.Parallelism, keyLength)
+	return encode(s.params, salt, key), nil
 }
 
+// Validate rejects any password if the hash is empty,
+// users signed up with an external identity have no password.
+// Bcrypt hashes made before Argon2id are still accepted.
 func (s *Service) Validate(password, hash string) error {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	if err == bcrypt.ErrMismatchedHashAndPassword {
+	if hash == "" {
 		return dto.ErrIncorrectPassword
 	}
-	return err
+
+	if !strings.HasPrefix(hash, argon2idPrefix) {
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return dto.ErrIncorrectPassword
+		}
+		return err
+	}
+
+	params, salt, key, err := decode(hash)
+	if err != nil {
+		return err
+	}
+
+	actual := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(actual, key) != 1 {
+		return dto.ErrIncorrectPassword
+	}
+	return nil
+}
+
+// NeedsRehash reports whether the hash is made with an outdated algorithm or weaker parameters than configured.
+func (s *Service) NeedsRehash(hash string) bool {
+	if hash == "" {
+		return false
+	}
+
+	params, _, key, err := decode(hash)
+	if err != nil {
+		return true
+	}
+
+	return params.Memory < s.params.Memory ||
+		params.Iterations < s.params.Iterations ||
+		params.Parallelism < s.params.Parallelism ||
+		len(key) < keyLength
+}
+
+func encode(params Params, salt, key []byte) string {
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+func decode(hash string) (Params, []byte, []byte, error) {
+	var params Params
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || "$"+parts[1]+"$" != argon2idPrefix {
+		return params, nil, nil, errMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, errMalformedHash
+	}
+
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil {
+		return params, nil, nil, errMalformedHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, errMalformedHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, errMalformedHash
+	}
+
+	return params, salt, key, nil
 }
@@ -1,33 +1,133 @@
 package password_hash
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/art-es/blog/internal/auth/dto"
 )
 
+// testParams keep the tests fast.
+var testParams = Params{Memory: 1024, Iterations: 1, Parallelism: 1}
+
 func TestService_happyPath(t *testing.T) {
 	const password = "qwer1234!"
-	s := New()
+	s := New(testParams)
 
 	hash, err := s.Generate(password)
 	assert.NoError(t, err)
-	assert.NotEmpty(t, hash)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
 
-	err = s.Validate(hash, password)
+	err = s.Validate(password, hash)
 	assert.NoError(t, err)
+	assert.False(t, s.NeedsRehash(hash))
 }
 
 func TestService_wrongPassword(t *testing.T) {
 	const password = "qwer1234!"
-	s := New()
+	s := New(testParams)
 
 	hash, err := s.Generate(password)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, hash)
 
-	err = s.Validate(hash, "fake-password")
+	err = s.Validate("fake-password", hash)
+	assert.ErrorIs(t, err, dto.ErrIncorrectPassword)
+}
+
+func TestService_longPassword(t *testing.T) {
+	password := strings.Repeat("a", 72)
+	s := New(testParams)
+
+	hash, err := s.Generate(password + "1")
+	assert.NoError(t, err)
+
+	// bcrypt would accept it, since it ignores the bytes after the 72nd
+	err = s.Validate(password+"2", hash)
 	assert.ErrorIs(t, err, dto.ErrIncorrectPassword)
 }
+
+func TestService_noPassword(t *testing.T) {
+	s := New(testParams)
+
+	err := s.Validate("", "")
+	assert.ErrorIs(t, err, dto.ErrIncorrectPassword)
+	assert.False(t, s.NeedsRehash(""))
+}
+
+func TestService_bcrypt(t *testing.T) {
+	const password = "qwer1234!"
+	s := New(testParams)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Validate(password, string(hash)))
+	assert.ErrorIs(t, s.Validate("fake-password", string(hash)), dto.ErrIncorrectPassword)
+	assert.True(t, s.NeedsRehash(string(hash)))
+}
+
+func TestService_NeedsRehash(t *testing.T) {
+	const password = "qwer1234!"
+
+	hash, err := New(testParams).Generate(password)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		params    Params
+		expRehash bool
+	}{
+		{
+			name:      "same parameters",
+			params:    testParams,
+			expRehash: false,
+		},
+		{
+			name:      "weaker configured parameters",
+			params:    Params{Memory: 512, Iterations: 1, Parallelism: 1},
+			expRehash: false,
+		},
+		{
+			name:      "more memory",
+			params:    Params{Memory: 2048, Iterations: 1, Parallelism: 1},
+			expRehash: true,
+		},
+		{
+			name:      "more iterations",
+			params:    Params{Memory: 1024, Iterations: 2, Parallelism: 1},
+			expRehash: true,
+		},
+		{
+			name:      "more parallelism",
+			params:    Params{Memory: 1024, Iterations: 1, Parallelism: 2},
+			expRehash: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(tt.params)
+			assert.Equal(t, tt.expRehash, s.NeedsRehash(hash))
+			// the hash keeps verifying with any configured parameters
+			assert.NoError(t, s.Validate(password, hash))
+		})
+	}
+}
+
+func TestService_malformedHash(t *testing.T) {
+	s := New(testParams)
+
+	for _, hash := range []string{
+		"$argon2id$v=19$m=1024,t=1,p=1$c2FsdA",
+		"$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=x,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$!!!",
+	} {
+		assert.EqualError(t, s.Validate("qwer1234!", hash), "malformed password hash", hash)
+		assert.True(t, s.NeedsRehash(hash), hash)
+	}
+}
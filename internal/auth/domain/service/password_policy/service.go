@@ -0,0 +1,165 @@
+package password_policy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const (
+	// minBreachedPrefixLength keeps short prefixes from banning too many passwords.
+	minBreachedPrefixLength = 10
+	// minIdentityPartLength keeps short name parts, e.g. initials, from banning too many passwords.
+	minIdentityPartLength = 3
+)
+
+//go:embed breached.txt
+var bundledBreachedList []byte
+
+// Policy is the rules a password has to follow.
+type Policy struct {
+	// MinLength is in characters.
+	MinLength int
+	// MinCharClasses is the number of classes the password has to use
+	// out of lowercase letters, uppercase letters, digits and symbols.
+	MinCharClasses int
+}
+
+var DefaultPolicy = Policy{
+	MinLength:      10,
+	MinCharClasses: 2,
+}
+
+type Service struct {
+	policy Policy
+	// breached maps the prefix lengths to the SHA-1 prefixes of breached passwords.
+	breached map[int]map[string]struct{}
+}
+
+// New creates the service with the breached password list, the bundled list is used when it's nil.
+// The list has an uppercase or lowercase hex SHA-1 hash of a password, or a prefix of the hash, per line.
+// A line may end with ":<count>" as in the Have I Been Pwned dumps, empty lines and lines starting with # are skipped.
+func New(policy Policy, breachedList []byte) (*Service, error) {
+	if breachedList == nil {
+		breachedList = bundledBreachedList
+	}
+
+	breached, err := parseBreachedList(breachedList)
+	if err != nil {
+		return nil, fmt.Errorf("breached list parsing error: %w", err)
+	}
+
+	return &Service{
+		policy:   policy,
+		breached: breached,
+	}, nil
+}
+
+// Check returns *dto.PasswordPolicyError if the password violates the policy.
+// The password cannot contain the name or the email of the user.
+func (s *Service) Check(password, name, email string) error {
+	var violations []string
+
+	if utf8.RuneCountInString(password) < s.policy.MinLength {
+		violations = append(violations, dto.PasswordRuleMinLength)
+	}
+	if countCharClasses(password) < s.policy.MinCharClasses {
+		violations = append(violations, dto.PasswordRuleCharClasses)
+	}
+	if containsAny(password, strings.Fields(name)...) {
+		violations = append(violations, dto.PasswordRuleNotName)
+	}
+	if local, _, _ := strings.Cut(email, "@"); containsAny(password, local) {
+		violations = append(violations, dto.PasswordRuleNotEmail)
+	}
+	if s.isBreached(password) {
+		violations = append(violations, dto.PasswordRuleNotBreached)
+	}
+
+	if len(violations) > 0 {
+		return &dto.PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+func (s *Service) isBreached(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	for length, prefixes := range s.breached {
+		if _, ok := prefixes[hash[:length]]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func parseBreachedList(data []byte) (map[int]map[string]struct{}, error) {
+	breached := make(map[int]map[string]struct{})
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		prefix, _, _ := strings.Cut(line, ":")
+		prefix = strings.ToUpper(prefix)
+		if len(prefix) < minBreachedPrefixLength || len(prefix) > sha1.Size*2 {
+			return nil, fmt.Errorf("line %d has prefix of invalid length %d", n, len(prefix))
+		}
+		if strings.Trim(prefix, "0123456789ABCDEF") != "" {
+			return nil, fmt.Errorf("line %d has non-hex prefix", n)
+		}
+
+		if breached[len(prefix)] == nil {
+			breached[len(prefix)] = make(map[string]struct{})
+		}
+		breached[len(prefix)][prefix] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return breached, nil
+}
+
+func countCharClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+// containsAny checks the parts case-insensitively.
+func containsAny(password string, parts ...string) bool {
+	password = strings.ToLower(password)
+	for _, part := range parts {
+		if utf8.RuneCountInString(part) < minIdentityPartLength {
+			continue
+		}
+		if strings.Contains(password, strings.ToLower(part)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package password_policy_test
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain/service/password_policy"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestService_Check(t *testing.T) {
+	const (
+		name  = "Ivan Ivanov"
+		email = "quokka.fan@example.com"
+	)
+
+	s, err := password_policy.New(password_policy.DefaultPolicy, nil)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		password      string
+		expViolations []string
+	}{
+		{
+			name:     "valid password",
+			password: "tame-Quokka-81",
+		},
+		{
+			name:          "too short",
+			password:      "Qwe-93",
+			expViolations: []string{dto.PasswordRuleMinLength},
+		},
+		{
+			name:     "length in characters, not bytes",
+			password: "Пароль-123",
+		},
+		{
+			name:          "single character class",
+			password:      "tamequokkaeight",
+			expViolations: []string{dto.PasswordRuleCharClasses},
+		},
+		{
+			name:          "contains name",
+			password:      "iVANOV-forever",
+			expViolations: []string{dto.PasswordRuleNotName},
+		},
+		{
+			name:          "contains email",
+			password:      "Quokka.Fan-2000",
+			expViolations: []string{dto.PasswordRuleNotEmail},
+		},
+		{
+			name:          "breached",
+			password:      "Password123",
+			expViolations: []string{dto.PasswordRuleNotBreached},
+		},
+		{
+			name:     "all violations",
+			password: "ivan",
+			expViolations: []string{
+				dto.PasswordRuleMinLength,
+				dto.PasswordRuleCharClasses,
+				dto.PasswordRuleNotName,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Check(tt.password, name, email)
+
+			if tt.expViolations == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, &dto.PasswordPolicyError{Violations: tt.expViolations}, err)
+			}
+		})
+	}
+}
+
+func TestService_Check_shortIdentityParts(t *testing.T) {
+	s, err := password_policy.New(password_policy.DefaultPolicy, nil)
+	assert.NoError(t, err)
+
+	// initials are too common to be banned
+	assert.NoError(t, s.Check("tame-Quokka-81", "A. Li", "al@example.com"))
+}
+
+func TestService_Check_configuredBreachedList(t *testing.T) {
+	const password = "tame-Quokka-81"
+	sum := sha1.Sum([]byte(password))
+	hash := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name string
+		list string
+	}{
+		{name: "full hash", list: hash},
+		{name: "prefix", list: "# comment\n\n" + hash[:16] + "\n"},
+		{name: "hash with count", list: hash + ":42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := password_policy.New(password_policy.DefaultPolicy, []byte(tt.list))
+			assert.NoError(t, err)
+
+			err = s.Check(password, "", "")
+			assert.Equal(t, &dto.PasswordPolicyError{Violations: []string{dto.PasswordRuleNotBreached}}, err)
+
+			// the bundled list is not used
+			assert.NoError(t, s.Check("Password123", "", ""))
+		})
+	}
+}
+
+func TestNew_invalidBreachedList(t *testing.T) {
+	tests := []struct {
+		name   string
+		list   string
+		expErr string
+	}{
+		{
+			name:   "short prefix",
+			list:   "5BAA6",
+			expErr: "breached list parsing error: line 1 has prefix of invalid length 5",
+		},
+		{
+			name:   "long prefix",
+			list:   "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD80",
+			expErr: "breached list parsing error: line 1 has prefix of invalid length 41",
+		},
+		{
+			name:   "non-hex prefix",
+			list:   "\n5BAA61E4C9B93F3F068Z",
+			expErr: "breached list parsing error: line 2 has non-hex prefix",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := password_policy.New(password_policy.DefaultPolicy, []byte(tt.list))
+
+			assert.Nil(t, s)
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+//go:generate mockgen -source=service.go -destination=mock/service.go -package=mock
+package password_reset
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/log"
+)
+
+const tokenSize = 32
+
+type databus interface {
+	ProducePasswordResetEmail(ctx context.Context, msg *dto.PasswordResetEmailMessage) error
+}
+
+type Service struct {
+	logger   log.Logger
+	databus  databus
+	tokenTTL time.Duration
+}
+
+func New(logger log.Logger, databus databus, tokenTTL time.Duration) *Service {
+	return &Service{
+		logger:   logger,
+		databus:  databus,
+		tokenTTL: tokenTTL,
+	}
+}
+
+// SendToken replaces reset tokens of the user with a new one and sends it to the user's email.
+func (s *Service) SendToken(ctx context.Context, user *domain.User, tx domain.TxCommitter) error {
+	if err := tx.PasswordResetToken().RemoveUserTokens(ctx, user.ID); err != nil {
+		return fmt.Errorf("password reset tokens removing from repository error: %w", err)
+	}
+
+	token, err := generate()
+	if err != nil {
+		return fmt.Errorf("password reset token generation error: %w", err)
+	}
+
+	object := &domain.PasswordResetToken{
+		TokenHash: Hash(token),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(s.tokenTTL),
+	}
+
+	if err = tx.PasswordResetToken().Add(ctx, object); err != nil {
+		return fmt.Errorf("password reset token adding to repository error: %w", err)
+	}
+
+	msg := &dto.PasswordResetEmailMessage{
+		Email: user.Email,
+		Token: token,
+	}
+
+	if err = s.databus.ProducePasswordResetEmail(ctx, msg); err != nil {
+		s.logger.Error("produce message to databus error",
+			log.Error(err),
+			log.String("location", "auth/service/password_reset"))
+	}
+
+	return nil
+}
+
+// ConsumeToken invalidates the token and the other reset tokens of its user.
+// It returns ID of the user the token is issued for.
+func (s *Service) ConsumeToken(ctx context.Context, token string, tx domain.TxCommitter) (int64, error) {
+	object, err := tx.PasswordResetToken().Take(ctx, Hash(token))
+	if err != nil {
+		return 0, fmt.Errorf("password reset token taking from repository error: %w", err)
+	}
+	if object == nil || !time.Now().Before(object.ExpiresAt) {
+		return 0, dto.ErrInvalidPasswordResetToken
+	}
+
+	if err = tx.PasswordResetToken().RemoveUserTokens(ctx, object.UserID); err != nil {
+		return 0, fmt.Errorf("password reset tokens removing from repository error: %w", err)
+	}
+
+	return object.UserID, nil
+}
+
+// Hash returns the representation of the token kept in the repository.
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generate() (string, error) {
+	b := make([]byte, tokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
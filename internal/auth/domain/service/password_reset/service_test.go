@@ -0,0 +1,234 @@
+package password_reset_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	mockdomain "github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/domain/service/password_reset"
+	"github.com/art-es/blog/internal/auth/domain/service/password_reset/mock"
+	"github.com/art-es/blog/internal/auth/dto"
+	log_mock "github.com/art-es/blog/internal/common/log/mock"
+)
+
+const (
+	noError  = ""
+	tokenTTL = time.Hour
+)
+
+func TestService_SendToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		logger     = log_mock.NewMockLogger(ctrl)
+		databus    = mock.NewMockdatabus(ctrl)
+		repository = mockdomain.NewMockPasswordResetTokenRepository(ctrl)
+		tx         = mockdomain.NewMockTxCommitter(ctrl)
+	)
+
+	var (
+		ctx    = context.Background()
+		userID = int64(1)
+		email  = "dummyEmail@example.com"
+		user   = &domain.User{ID: userID, Email: email}
+	)
+
+	tx.EXPECT().
+		PasswordResetToken().
+		Return(repository).
+		AnyTimes()
+
+	expectAdding := func(produceErr error) {
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			DoAndReturn(func(_ context.Context, object *domain.PasswordResetToken) error {
+				assert.Equal(t, userID, object.UserID)
+				assert.WithinDuration(t, time.Now().Add(tokenTTL), object.ExpiresAt, time.Second)
+
+				databus.EXPECT().
+					ProducePasswordResetEmail(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg *dto.PasswordResetEmailMessage) error {
+						assert.Equal(t, email, msg.Email)
+						assert.Equal(t, object.TokenHash, password_reset.Hash(msg.Token))
+						return produceErr
+					})
+
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				repository.EXPECT().
+					RemoveUserTokens(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(nil)
+
+				expectAdding(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on removing previous tokens",
+			setup: func() {
+				repository.EXPECT().
+					RemoveUserTokens(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "password reset tokens removing from repository error: dummy error",
+		},
+		{
+			name: "error on adding token",
+			setup: func() {
+				repository.EXPECT().
+					RemoveUserTokens(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(nil)
+
+				repository.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Any()).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "password reset token adding to repository error: dummy error",
+		},
+		{
+			name: "error on producing email message",
+			setup: func() {
+				repository.EXPECT().
+					RemoveUserTokens(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(nil)
+
+				expectAdding(errors.New("dummy error"))
+
+				logger.EXPECT().
+					Error(gomock.Eq("produce message to databus error"), gomock.Any(), gomock.Any())
+			},
+			expErr: noError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			s := password_reset.New(logger, databus, tokenTTL)
+			err := s.SendToken(ctx, user, tx)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
+
+func TestService_ConsumeToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		repository = mockdomain.NewMockPasswordResetTokenRepository(ctrl)
+		tx         = mockdomain.NewMockTxCommitter(ctrl)
+	)
+
+	var (
+		ctx       = context.Background()
+		userID    = int64(1)
+		token     = "dummyToken"
+		tokenHash = password_reset.Hash(token)
+	)
+
+	tx.EXPECT().
+		PasswordResetToken().
+		Return(repository).
+		AnyTimes()
+
+	tests := []struct {
+		name      string
+		setup     func()
+		expUserID int64
+		expErr    string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				repository.EXPECT().
+					Take(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(&domain.PasswordResetToken{TokenHash: tokenHash, UserID: userID, ExpiresAt: time.Now().Add(time.Minute)}, nil)
+
+				repository.EXPECT().
+					RemoveUserTokens(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(nil)
+			},
+			expUserID: userID,
+			expErr:    noError,
+		},
+		{
+			name: "error on taking token",
+			setup: func() {
+				repository.EXPECT().
+					Take(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "password reset token taking from repository error: dummy error",
+		},
+		{
+			name: "token not found",
+			setup: func() {
+				repository.EXPECT().
+					Take(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(nil, nil)
+			},
+			expErr: dto.ErrInvalidPasswordResetToken.Error(),
+		},
+		{
+			name: "token expired",
+			setup: func() {
+				repository.EXPECT().
+					Take(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(&domain.PasswordResetToken{TokenHash: tokenHash, UserID: userID, ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+			},
+			expErr: dto.ErrInvalidPasswordResetToken.Error(),
+		},
+		{
+			name: "error on removing user tokens",
+			setup: func() {
+				repository.EXPECT().
+					Take(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(&domain.PasswordResetToken{TokenHash: tokenHash, UserID: userID, ExpiresAt: time.Now().Add(time.Minute)}, nil)
+
+				repository.EXPECT().
+					RemoveUserTokens(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "password reset tokens removing from repository error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			s := password_reset.New(nil, nil, tokenTTL)
+			userID, err := s.ConsumeToken(ctx, token, tx)
+
+			assert.Equal(t, tt.expUserID, userID)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package personal_access_token
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const (
+	// Prefix tells the personal access tokens from the JWT access tokens.
+	Prefix    = "bpat_"
+	tokenSize = 32
+)
+
+type Service struct{}
+
+func New() *Service {
+	return &Service{}
+}
+
+// Issue creates the token of the user, the raw token is returned only here, the repository keeps its hash.
+// The token never expires if expiresAt is nil.
+func (s *Service) Issue(
+	ctx context.Context,
+	userID int64,
+	name string,
+	scopes []string,
+	expiresAt *time.Time,
+	repository domain.PersonalAccessTokenRepository,
+) (*domain.PersonalAccessToken, string, error) {
+	token, err := generate()
+	if err != nil {
+		return nil, "", fmt.Errorf("personal access token generation error: %w", err)
+	}
+
+	object := &domain.PersonalAccessToken{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Name:      name,
+		TokenHash: hash(token),
+		Scopes:    scopes,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+
+	if err = repository.Add(ctx, object); err != nil {
+		return nil, "", fmt.Errorf("personal access token adding to repository error: %w", err)
+	}
+
+	return object, token, nil
+}
+
+// IsPersonalAccessToken reports whether the token looks like a personal access token rather than a JWT.
+func (s *Service) IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, Prefix)
+}
+
+// Parse returns the active token, dto.ErrInvalidAccessToken is returned for unknown, revoked or expired ones.
+func (s *Service) Parse(ctx context.Context, token string, repository domain.PersonalAccessTokenRepository) (*domain.PersonalAccessToken, error) {
+	object, err := repository.GetByHash(ctx, hash(token))
+	if err != nil {
+		return nil, fmt.Errorf("personal access token getting from repository error: %w", err)
+	}
+	if object == nil || !object.IsActive(time.Now()) {
+		return nil, dto.ErrInvalidAccessToken
+	}
+	return object, nil
+}
+
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generate() (string, error) {
+	b := make([]byte, tokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return Prefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,165 @@
+package personal_access_token_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	mockdomain "github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/domain/service/personal_access_token"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/testutil"
+)
+
+const noError = ""
+
+func TestService_Issue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockPersonalAccessTokenRepository(ctrl)
+
+	var (
+		ctx       = context.Background()
+		userID    = int64(1)
+		name      = "CI"
+		scopes    = []string{domain.PermissionPostWrite}
+		expiresAt = time.Now().Add(24 * time.Hour)
+		service   = personal_access_token.New()
+	)
+
+	t.Run("happy path", func(t *testing.T) {
+		var added *domain.PersonalAccessToken
+
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			DoAndReturn(func(_ context.Context, token *domain.PersonalAccessToken) error {
+				added = token
+				return nil
+			})
+
+		out, token, err := service.Issue(ctx, userID, name, scopes, &expiresAt, repository)
+		assert.NoError(t, err)
+		assert.Equal(t, added, out)
+
+		assert.True(t, strings.HasPrefix(token, personal_access_token.Prefix))
+		assert.True(t, service.IsPersonalAccessToken(token))
+		assert.True(t, testutil.IsUUID().Matches(out.ID))
+		assert.Equal(t, userID, out.UserID)
+		assert.Equal(t, name, out.Name)
+		assert.Equal(t, scopes, out.Scopes)
+		assert.NotEmpty(t, out.TokenHash)
+		assert.NotEqual(t, token, out.TokenHash)
+		assert.WithinDuration(t, time.Now(), out.CreatedAt, time.Second)
+		assert.Equal(t, &expiresAt, out.ExpiresAt)
+		assert.False(t, out.Revoked)
+
+		repository.EXPECT().
+			GetByHash(gomock.Eq(ctx), gomock.Eq(out.TokenHash)).
+			Return(out, nil)
+
+		parsed, err := service.Parse(ctx, token, repository)
+		assert.NoError(t, err)
+		assert.Equal(t, out, parsed)
+	})
+
+	t.Run("error on adding token", func(t *testing.T) {
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			Return(errors.New("dummy error"))
+
+		out, token, err := service.Issue(ctx, userID, name, scopes, nil, repository)
+		assert.Nil(t, out)
+		assert.Empty(t, token)
+		assert.EqualError(t, err, "personal access token adding to repository error: dummy error")
+	})
+}
+
+func TestService_IsPersonalAccessToken(t *testing.T) {
+	service := personal_access_token.New()
+
+	assert.True(t, service.IsPersonalAccessToken("bpat_dummyToken"))
+	assert.False(t, service.IsPersonalAccessToken("eyJhbGciOiJSUzI1NiJ9.e30.c2ln"))
+	assert.False(t, service.IsPersonalAccessToken(""))
+}
+
+func TestService_Parse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockPersonalAccessTokenRepository(ctrl)
+
+	var (
+		ctx     = context.Background()
+		token   = "bpat_dummyToken"
+		past    = time.Now().Add(-time.Minute)
+		future  = time.Now().Add(time.Hour)
+		service = personal_access_token.New()
+	)
+
+	tests := []struct {
+		name   string
+		stored *domain.PersonalAccessToken
+		err    error
+		expOut bool
+		expErr string
+	}{
+		{
+			name:   "happy path",
+			stored: &domain.PersonalAccessToken{UserID: 1},
+			expOut: true,
+			expErr: noError,
+		},
+		{
+			name:   "happy path: not expired yet",
+			stored: &domain.PersonalAccessToken{UserID: 1, ExpiresAt: &future},
+			expOut: true,
+			expErr: noError,
+		},
+		{
+			name:   "token not found",
+			expErr: dto.ErrInvalidAccessToken.Error(),
+		},
+		{
+			name:   "token revoked",
+			stored: &domain.PersonalAccessToken{UserID: 1, Revoked: true},
+			expErr: dto.ErrInvalidAccessToken.Error(),
+		},
+		{
+			name:   "token expired",
+			stored: &domain.PersonalAccessToken{UserID: 1, ExpiresAt: &past},
+			expErr: dto.ErrInvalidAccessToken.Error(),
+		},
+		{
+			name:   "error on getting token",
+			err:    errors.New("dummy error"),
+			expErr: "personal access token getting from repository error: dummy error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository.EXPECT().
+				GetByHash(gomock.Eq(ctx), gomock.Any()).
+				Return(tt.stored, tt.err)
+
+			out, err := service.Parse(ctx, token, repository)
+
+			if tt.expOut {
+				assert.Equal(t, tt.stored, out)
+			} else {
+				assert.Nil(t, out)
+			}
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expErr)
+			}
+		})
+	}
+}
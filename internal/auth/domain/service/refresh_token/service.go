@@ -0,0 +1,138 @@
+package refresh_token
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const tokenSize = 32
+
+type Service struct {
+	ttl time.Duration
+}
+
+// New creates the service, ttl limits a single refresh token.
+func New(ttl time.Duration) *Service {
+	return &Service{
+		ttl: ttl,
+	}
+}
+
+// Issue starts the token family of the session and returns its first token.
+// The family lives as long as the session.
+func (s *Service) Issue(ctx context.Context, session *domain.Session, repository domain.RefreshTokenRepository) (string, error) {
+	object := &domain.RefreshToken{
+		FamilyID:        session.ID,
+		UserID:          session.UserID,
+		FamilyExpiresAt: session.ExpiresAt,
+	}
+
+	return s.add(ctx, object, time.Now(), repository)
+}
+
+// Rotate exchanges the token for the next one of the same family.
+// Presenting an already used token revokes the whole family.
+func (s *Service) Rotate(ctx context.Context, token string, repository domain.RefreshTokenRepository) (*domain.RefreshToken, string, error) {
+	stored, err := repository.GetByHash(ctx, Hash(token))
+	if err != nil {
+		return nil, "", fmt.Errorf("refresh token getting from repository error: %w", err)
+	}
+	if stored == nil || stored.Revoked {
+		return nil, "", dto.ErrInvalidRefreshToken
+	}
+	if stored.Used {
+		return nil, "", revokeFamily(ctx, stored.FamilyID, repository)
+	}
+
+	now := time.Now()
+	if !now.Before(stored.ExpiresAt) {
+		return nil, "", dto.ErrInvalidRefreshToken
+	}
+
+	marked, err := repository.MarkUsed(ctx, stored.ID)
+	if err != nil {
+		return nil, "", fmt.Errorf("refresh token marking as used in repository error: %w", err)
+	}
+	if !marked {
+		// the token has been used concurrently
+		return nil, "", revokeFamily(ctx, stored.FamilyID, repository)
+	}
+
+	object := &domain.RefreshToken{
+		FamilyID:        stored.FamilyID,
+		UserID:          stored.UserID,
+		FamilyExpiresAt: stored.FamilyExpiresAt,
+	}
+
+	newToken, err := s.add(ctx, object, now, repository)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return object, newToken, nil
+}
+
+// Revoke invalidates the token family if the token belongs to the user.
+// Unknown tokens are ignored.
+func (s *Service) Revoke(ctx context.Context, token string, userID int64, repository domain.RefreshTokenRepository) error {
+	stored, err := repository.GetByHash(ctx, Hash(token))
+	if err != nil {
+		return fmt.Errorf("refresh token getting from repository error: %w", err)
+	}
+	if stored == nil || stored.UserID != userID {
+		return nil
+	}
+
+	if err = repository.RevokeFamily(ctx, stored.FamilyID); err != nil {
+		return fmt.Errorf("refresh token family revoking in repository error: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) add(ctx context.Context, object *domain.RefreshToken, now time.Time, repository domain.RefreshTokenRepository) (string, error) {
+	token, err := generate()
+	if err != nil {
+		return "", fmt.Errorf("refresh token generation error: %w", err)
+	}
+
+	object.TokenHash = Hash(token)
+	object.ExpiresAt = now.Add(s.ttl)
+	if object.ExpiresAt.After(object.FamilyExpiresAt) {
+		object.ExpiresAt = object.FamilyExpiresAt
+	}
+
+	if err = repository.Add(ctx, object); err != nil {
+		return "", fmt.Errorf("refresh token adding to repository error: %w", err)
+	}
+
+	return token, nil
+}
+
+func revokeFamily(ctx context.Context, familyID string, repository domain.RefreshTokenRepository) error {
+	if err := repository.RevokeFamily(ctx, familyID); err != nil {
+		return fmt.Errorf("refresh token family revoking in repository error: %w", err)
+	}
+	return dto.ErrReusedRefreshToken
+}
+
+// Hash returns the representation of the token kept in the repository.
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generate() (string, error) {
+	b := make([]byte, tokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,389 @@
+package refresh_token_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	mockdomain "github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/domain/service/refresh_token"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const (
+	noError     = ""
+	ttl         = time.Hour
+	maxLifetime = 24 * time.Hour
+)
+
+func TestService_Issue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockRefreshTokenRepository(ctrl)
+
+	var (
+		ctx     = context.Background()
+		session = &domain.Session{
+			ID:        "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b",
+			UserID:    1,
+			ExpiresAt: time.Now().Add(maxLifetime),
+		}
+	)
+
+	t.Run("happy path", func(t *testing.T) {
+		var added *domain.RefreshToken
+
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			DoAndReturn(func(_ context.Context, token *domain.RefreshToken) error {
+				added = token
+				return nil
+			})
+
+		token, err := refresh_token.New(ttl).Issue(ctx, session, repository)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token)
+
+		assert.Equal(t, refresh_token.Hash(token), added.TokenHash)
+		assert.Equal(t, session.ID, added.FamilyID)
+		assert.Equal(t, session.UserID, added.UserID)
+		assert.WithinDuration(t, time.Now().Add(ttl), added.ExpiresAt, time.Second)
+		assert.Equal(t, session.ExpiresAt, added.FamilyExpiresAt)
+	})
+
+	t.Run("error on adding token", func(t *testing.T) {
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			Return(errors.New("dummy error"))
+
+		token, err := refresh_token.New(ttl).Issue(ctx, session, repository)
+		assert.EqualError(t, err, "refresh token adding to repository error: dummy error")
+		assert.Empty(t, token)
+	})
+}
+
+func TestService_Rotate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockRefreshTokenRepository(ctrl)
+
+	var (
+		ctx      = context.Background()
+		userID   = int64(1)
+		tokenID  = int64(10)
+		familyID = "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b"
+		token    = "dummyRefreshToken"
+		now      = time.Now()
+	)
+
+	storedFactory := func() *domain.RefreshToken {
+		return &domain.RefreshToken{
+			ID:              tokenID,
+			TokenHash:       refresh_token.Hash(token),
+			FamilyID:        familyID,
+			UserID:          userID,
+			ExpiresAt:       now.Add(ttl),
+			FamilyExpiresAt: now.Add(maxLifetime),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				stored := storedFactory()
+
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(stored.TokenHash)).
+					Return(stored, nil)
+
+				repository.EXPECT().
+					MarkUsed(gomock.Eq(ctx), gomock.Eq(tokenID)).
+					Return(true, nil)
+
+				repository.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(_ context.Context, added *domain.RefreshToken) error {
+						assert.NotEqual(t, stored.TokenHash, added.TokenHash)
+						assert.Equal(t, familyID, added.FamilyID)
+						assert.Equal(t, userID, added.UserID)
+						assert.Equal(t, stored.FamilyExpiresAt, added.FamilyExpiresAt)
+						assert.WithinDuration(t, time.Now().Add(ttl), added.ExpiresAt, time.Second)
+						return nil
+					})
+			},
+			expErr: noError,
+		},
+		{
+			name: "token expiration is capped by family lifetime",
+			setup: func() {
+				stored := storedFactory()
+				stored.FamilyExpiresAt = now.Add(time.Minute)
+
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(stored.TokenHash)).
+					Return(stored, nil)
+
+				repository.EXPECT().
+					MarkUsed(gomock.Eq(ctx), gomock.Eq(tokenID)).
+					Return(true, nil)
+
+				repository.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(_ context.Context, added *domain.RefreshToken) error {
+						assert.Equal(t, stored.FamilyExpiresAt, added.ExpiresAt)
+						return nil
+					})
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on getting token",
+			setup: func() {
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(refresh_token.Hash(token))).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "refresh token getting from repository error: dummy error",
+		},
+		{
+			name: "token not found",
+			setup: func() {
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(refresh_token.Hash(token))).
+					Return(nil, nil)
+			},
+			expErr: dto.ErrInvalidRefreshToken.Error(),
+		},
+		{
+			name: "token revoked",
+			setup: func() {
+				stored := storedFactory()
+				stored.Used = true
+				stored.Revoked = true
+
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(stored.TokenHash)).
+					Return(stored, nil)
+			},
+			expErr: dto.ErrInvalidRefreshToken.Error(),
+		},
+		{
+			name: "token expired",
+			setup: func() {
+				stored := storedFactory()
+				stored.ExpiresAt = now.Add(-time.Minute)
+
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(stored.TokenHash)).
+					Return(stored, nil)
+			},
+			expErr: dto.ErrInvalidRefreshToken.Error(),
+		},
+		{
+			name: "token reused",
+			setup: func() {
+				stored := storedFactory()
+				stored.Used = true
+
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(stored.TokenHash)).
+					Return(stored, nil)
+
+				repository.EXPECT().
+					RevokeFamily(gomock.Eq(ctx), gomock.Eq(familyID)).
+					Return(nil)
+			},
+			expErr: dto.ErrReusedRefreshToken.Error(),
+		},
+		{
+			name: "error on revoking family of reused token",
+			setup: func() {
+				stored := storedFactory()
+				stored.Used = true
+
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(stored.TokenHash)).
+					Return(stored, nil)
+
+				repository.EXPECT().
+					RevokeFamily(gomock.Eq(ctx), gomock.Eq(familyID)).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "refresh token family revoking in repository error: dummy error",
+		},
+		{
+			name: "token used concurrently",
+			setup: func() {
+				stored := storedFactory()
+
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(stored.TokenHash)).
+					Return(stored, nil)
+
+				repository.EXPECT().
+					MarkUsed(gomock.Eq(ctx), gomock.Eq(tokenID)).
+					Return(false, nil)
+
+				repository.EXPECT().
+					RevokeFamily(gomock.Eq(ctx), gomock.Eq(familyID)).
+					Return(nil)
+			},
+			expErr: dto.ErrReusedRefreshToken.Error(),
+		},
+		{
+			name: "error on marking token as used",
+			setup: func() {
+				stored := storedFactory()
+
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(stored.TokenHash)).
+					Return(stored, nil)
+
+				repository.EXPECT().
+					MarkUsed(gomock.Eq(ctx), gomock.Eq(tokenID)).
+					Return(false, errors.New("dummy error"))
+			},
+			expErr: "refresh token marking as used in repository error: dummy error",
+		},
+		{
+			name: "error on adding new token",
+			setup: func() {
+				stored := storedFactory()
+
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(stored.TokenHash)).
+					Return(stored, nil)
+
+				repository.EXPECT().
+					MarkUsed(gomock.Eq(ctx), gomock.Eq(tokenID)).
+					Return(true, nil)
+
+				repository.EXPECT().
+					Add(gomock.Eq(ctx), gomock.Any()).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "refresh token adding to repository error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+
+			s := refresh_token.New(ttl)
+			object, newToken, err := s.Rotate(ctx, token, repository)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				assert.Equal(t, familyID, object.FamilyID)
+				assert.Equal(t, userID, object.UserID)
+				assert.NotEmpty(t, newToken)
+				assert.NotEqual(t, token, newToken)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+			assert.Nil(t, object)
+			assert.Empty(t, newToken)
+		})
+	}
+}
+
+func TestService_Revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockRefreshTokenRepository(ctrl)
+
+	var (
+		ctx      = context.Background()
+		userID   = int64(1)
+		familyID = "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b"
+		token    = "dummyRefreshToken"
+		stored   = &domain.RefreshToken{ID: 10, FamilyID: familyID, UserID: userID}
+	)
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(refresh_token.Hash(token))).
+					Return(stored, nil)
+
+				repository.EXPECT().
+					RevokeFamily(gomock.Eq(ctx), gomock.Eq(familyID)).
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "token not found",
+			setup: func() {
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(refresh_token.Hash(token))).
+					Return(nil, nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "token of another user",
+			setup: func() {
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(refresh_token.Hash(token))).
+					Return(&domain.RefreshToken{ID: 10, FamilyID: familyID, UserID: userID + 1}, nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on getting token",
+			setup: func() {
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(refresh_token.Hash(token))).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "refresh token getting from repository error: dummy error",
+		},
+		{
+			name: "error on revoking family",
+			setup: func() {
+				repository.EXPECT().
+					GetByHash(gomock.Eq(ctx), gomock.Eq(refresh_token.Hash(token))).
+					Return(stored, nil)
+
+				repository.EXPECT().
+					RevokeFamily(gomock.Eq(ctx), gomock.Eq(familyID)).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "refresh token family revoking in repository error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			err := refresh_token.New(ttl).Revoke(ctx, token, userID, repository)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
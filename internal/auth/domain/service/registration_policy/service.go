@@ -0,0 +1,131 @@
+package registration_policy
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const (
+	// ModeOpen lets anyone register.
+	ModeOpen = "open"
+	// ModeInvite requires an invite to register.
+	ModeInvite = "invite"
+	// ModeDomain allows registering only with the emails at Policy.AllowedDomains.
+	ModeDomain = "domain"
+)
+
+//go:embed disposable.txt
+var bundledDisposableList []byte
+
+// Policy is the rules of the registration.
+type Policy struct {
+	Mode string
+	// AllowedDomains are matched exactly, so their subdomains must be listed as well.
+	AllowedDomains []string
+}
+
+var DefaultPolicy = Policy{
+	Mode: ModeOpen,
+}
+
+type Service struct {
+	mode           string
+	allowedDomains map[string]struct{}
+	disposable     map[string]struct{}
+}
+
+// New creates the service with the disposable domain list, the bundled list is used when it's nil.
+// The list has a domain per line, empty lines and lines starting with # are skipped.
+func New(policy Policy, disposableList []byte) (*Service, error) {
+	switch policy.Mode {
+	case ModeOpen, ModeInvite:
+	case ModeDomain:
+		if len(policy.AllowedDomains) == 0 {
+			return nil, errors.New("allowed domains cannot be empty in domain mode")
+		}
+	default:
+		return nil, fmt.Errorf("unknown mode %q", policy.Mode)
+	}
+
+	if disposableList == nil {
+		disposableList = bundledDisposableList
+	}
+
+	disposable, err := parseDisposableList(disposableList)
+	if err != nil {
+		return nil, fmt.Errorf("disposable list parsing error: %w", err)
+	}
+
+	allowedDomains := make(map[string]struct{}, len(policy.AllowedDomains))
+	for _, domain := range policy.AllowedDomains {
+		allowedDomains[strings.ToLower(domain)] = struct{}{}
+	}
+
+	return &Service{
+		mode:           policy.Mode,
+		allowedDomains: allowedDomains,
+		disposable:     disposable,
+	}, nil
+}
+
+// CheckEmail returns dto.ErrDisposableEmail if the email is at a disposable domain or its subdomain,
+// and dto.ErrEmailDomainNotAllowed if the domain is not allowed in domain mode.
+func (s *Service) CheckEmail(email string) error {
+	_, domain, _ := strings.Cut(email, "@")
+	domain = strings.ToLower(domain)
+
+	if s.isDisposable(domain) {
+		return dto.ErrDisposableEmail
+	}
+
+	if s.mode == ModeDomain {
+		if _, ok := s.allowedDomains[domain]; !ok {
+			return dto.ErrEmailDomainNotAllowed
+		}
+	}
+
+	return nil
+}
+
+// InviteRequired reports whether the registration is invite-only.
+func (s *Service) InviteRequired() bool {
+	return s.mode == ModeInvite
+}
+
+func (s *Service) isDisposable(domain string) bool {
+	for domain != "" {
+		if _, ok := s.disposable[domain]; ok {
+			return true
+		}
+		_, domain, _ = strings.Cut(domain, ".")
+	}
+	return false
+}
+
+func parseDisposableList(data []byte) (map[string]struct{}, error) {
+	disposable := make(map[string]struct{})
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.ContainsAny(line, "@ \t") || strings.HasPrefix(line, ".") {
+			return nil, fmt.Errorf("line %d has invalid domain", n)
+		}
+		disposable[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return disposable, nil
+}
@@ -0,0 +1,138 @@
+package registration_policy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain/service/registration_policy"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+func TestService_CheckEmail(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy registration_policy.Policy
+		email  string
+		expErr error
+	}{
+		{
+			name:   "open mode",
+			policy: registration_policy.DefaultPolicy,
+			email:  "i.ivanov@example.com",
+		},
+		{
+			name:   "disposable domain",
+			policy: registration_policy.DefaultPolicy,
+			email:  "i.ivanov@Mailinator.com",
+			expErr: dto.ErrDisposableEmail,
+		},
+		{
+			name:   "subdomain of disposable domain",
+			policy: registration_policy.DefaultPolicy,
+			email:  "i.ivanov@eu.mailinator.com",
+			expErr: dto.ErrDisposableEmail,
+		},
+		{
+			name:   "domain ending like disposable domain",
+			policy: registration_policy.DefaultPolicy,
+			email:  "i.ivanov@notmailinator.com",
+		},
+		{
+			name:   "allowed domain",
+			policy: registration_policy.Policy{Mode: registration_policy.ModeDomain, AllowedDomains: []string{"Example.com"}},
+			email:  "i.ivanov@example.COM",
+		},
+		{
+			name:   "not allowed domain",
+			policy: registration_policy.Policy{Mode: registration_policy.ModeDomain, AllowedDomains: []string{"example.com"}},
+			email:  "i.ivanov@example.org",
+			expErr: dto.ErrEmailDomainNotAllowed,
+		},
+		{
+			name:   "subdomain of allowed domain",
+			policy: registration_policy.Policy{Mode: registration_policy.ModeDomain, AllowedDomains: []string{"example.com"}},
+			email:  "i.ivanov@mail.example.com",
+			expErr: dto.ErrEmailDomainNotAllowed,
+		},
+		{
+			name:   "disposable domain allowed",
+			policy: registration_policy.Policy{Mode: registration_policy.ModeDomain, AllowedDomains: []string{"yopmail.com"}},
+			email:  "i.ivanov@yopmail.com",
+			expErr: dto.ErrDisposableEmail,
+		},
+		{
+			name:   "invite mode",
+			policy: registration_policy.Policy{Mode: registration_policy.ModeInvite},
+			email:  "i.ivanov@example.org",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := registration_policy.New(tt.policy, nil)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expErr, s.CheckEmail(tt.email))
+		})
+	}
+}
+
+func TestService_CheckEmail_configuredDisposableList(t *testing.T) {
+	s, err := registration_policy.New(registration_policy.DefaultPolicy, []byte("# comment\n\nQuokkaMail.io\n"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, dto.ErrDisposableEmail, s.CheckEmail("i.ivanov@quokkamail.io"))
+
+	// the bundled list is not used
+	assert.NoError(t, s.CheckEmail("i.ivanov@mailinator.com"))
+}
+
+func TestService_InviteRequired(t *testing.T) {
+	tests := []struct {
+		mode string
+		exp  bool
+	}{
+		{mode: registration_policy.ModeOpen, exp: false},
+		{mode: registration_policy.ModeInvite, exp: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			s, err := registration_policy.New(registration_policy.Policy{Mode: tt.mode}, nil)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.exp, s.InviteRequired())
+		})
+	}
+}
+
+func TestNew_invalidPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy registration_policy.Policy
+		list   string
+		expErr string
+	}{
+		{
+			name:   "unknown mode",
+			policy: registration_policy.Policy{Mode: "closed"},
+			expErr: `unknown mode "closed"`,
+		},
+		{
+			name:   "domain mode without allowed domains",
+			policy: registration_policy.Policy{Mode: registration_policy.ModeDomain},
+			expErr: "allowed domains cannot be empty in domain mode",
+		},
+		{
+			name:   "email in disposable list",
+			policy: registration_policy.DefaultPolicy,
+			list:   "yopmail.com\nquokka@yopmail.com",
+			expErr: "disposable list parsing error: line 2 has invalid domain",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := registration_policy.New(tt.policy, []byte(tt.list))
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package revocation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/domain"
+)
+
+type Service struct {
+	tokenLifetime time.Duration
+	tokenLeeway   time.Duration
+}
+
+// New creates the service. Revocation entries are kept while the revoked
+// access tokens can pass validation, i.e. lifetime plus leeway after issuing.
+func New(tokenLifetime, tokenLeeway time.Duration) *Service {
+	return &Service{
+		tokenLifetime: tokenLifetime,
+		tokenLeeway:   tokenLeeway,
+	}
+}
+
+// Revoke invalidates the single access token.
+func (s *Service) Revoke(ctx context.Context, object *domain.AccessTokenObject, repository domain.AccessTokenRevocationRepository) error {
+	expiresAt := object.ExpirationTime.Add(s.tokenLeeway)
+
+	if err := repository.AddToken(ctx, object.ID, expiresAt); err != nil {
+		return fmt.Errorf("access token revocation adding to repository error: %w", err)
+	}
+	return nil
+}
+
+// RevokeUser invalidates all the sessions, access and refresh tokens issued to the user so far.
+func (s *Service) RevokeUser(ctx context.Context, userID int64, tx domain.TxCommitter) error {
+	revokedBefore := time.Now()
+	expiresAt := revokedBefore.Add(s.tokenLifetime + s.tokenLeeway)
+
+	if err := tx.AccessTokenRevocation().AddUser(ctx, userID, revokedBefore, expiresAt); err != nil {
+		return fmt.Errorf("user access tokens revocation adding to repository error: %w", err)
+	}
+
+	if err := tx.RefreshToken().RevokeUser(ctx, userID); err != nil {
+		return fmt.Errorf("user refresh tokens revoking in repository error: %w", err)
+	}
+
+	if err := tx.Session().RevokeUser(ctx, userID); err != nil {
+		return fmt.Errorf("user sessions revoking in repository error: %w", err)
+	}
+
+	return nil
+}
+
+// IsRevoked checks the user revocation for the tokens issued before sessions only.
+// The tokens with a session are revoked along with it, since the issued time has seconds precision
+// and can't tell the tokens issued right after the user revocation, e.g. on changing the password.
+func (s *Service) IsRevoked(ctx context.Context, object *domain.AccessTokenObject, repository domain.AccessTokenRevocationRepository) (bool, error) {
+	var (
+		revoked bool
+		err     error
+	)
+	if object.SessionID != "" {
+		revoked, err = repository.IsTokenRevoked(ctx, object.ID)
+	} else {
+		revoked, err = repository.IsRevoked(ctx, object.ID, object.UserID, object.IssuedAt)
+	}
+	if err != nil {
+		return false, fmt.Errorf("access token revocation checking in repository error: %w", err)
+	}
+	return revoked, nil
+}
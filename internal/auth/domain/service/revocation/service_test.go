@@ -0,0 +1,275 @@
+package revocation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	mockdomain "github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/domain/service/access_token"
+	"github.com/art-es/blog/internal/auth/domain/service/revocation"
+)
+
+const (
+	noError       = ""
+	tokenLifetime = 12 * time.Hour
+	tokenLeeway   = 2 * time.Hour
+)
+
+func TestService_Revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockAccessTokenRevocationRepository(ctrl)
+
+	var (
+		ctx    = context.Background()
+		now    = time.Now()
+		object = &domain.AccessTokenObject{ID: "dummyTokenID", UserID: 1, ExpirationTime: now}
+	)
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				repository.EXPECT().
+					AddToken(gomock.Eq(ctx), gomock.Eq(object.ID), gomock.Eq(now.Add(tokenLeeway))).
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on adding token",
+			setup: func() {
+				repository.EXPECT().
+					AddToken(gomock.Eq(ctx), gomock.Eq(object.ID), gomock.Eq(now.Add(tokenLeeway))).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "access token revocation adding to repository error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			err := revocation.New(tokenLifetime, tokenLeeway).Revoke(ctx, object, repository)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
+
+func TestService_RevokeUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tx := mockdomain.NewMockTxCommitter(ctrl)
+
+	var (
+		ctx    = context.Background()
+		userID = int64(1)
+	)
+
+	expectAddUser := func(err error) {
+		tx.EXPECT().
+			AccessTokenRevocation().
+			DoAndReturn(func() domain.AccessTokenRevocationRepository {
+				r := mockdomain.NewMockAccessTokenRevocationRepository(ctrl)
+				r.EXPECT().
+					AddUser(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ int64, revokedBefore, expiresAt time.Time) error {
+						assert.WithinDuration(t, time.Now(), revokedBefore, time.Second)
+						assert.Equal(t, tokenLifetime+tokenLeeway, expiresAt.Sub(revokedBefore))
+						return err
+					})
+				return r
+			})
+	}
+
+	expectRevokeRefreshTokens := func(err error) {
+		tx.EXPECT().
+			RefreshToken().
+			DoAndReturn(func() domain.RefreshTokenRepository {
+				r := mockdomain.NewMockRefreshTokenRepository(ctrl)
+				r.EXPECT().
+					RevokeUser(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(err)
+				return r
+			})
+	}
+
+	expectRevokeSessions := func(err error) {
+		tx.EXPECT().
+			Session().
+			DoAndReturn(func() domain.SessionRepository {
+				r := mockdomain.NewMockSessionRepository(ctrl)
+				r.EXPECT().
+					RevokeUser(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(err)
+				return r
+			})
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				expectAddUser(nil)
+				expectRevokeRefreshTokens(nil)
+				expectRevokeSessions(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on adding user",
+			setup: func() {
+				expectAddUser(errors.New("dummy error"))
+			},
+			expErr: "user access tokens revocation adding to repository error: dummy error",
+		},
+		{
+			name: "error on revoking refresh tokens",
+			setup: func() {
+				expectAddUser(nil)
+				expectRevokeRefreshTokens(errors.New("dummy error"))
+			},
+			expErr: "user refresh tokens revoking in repository error: dummy error",
+		},
+		{
+			name: "error on revoking sessions",
+			setup: func() {
+				expectAddUser(nil)
+				expectRevokeRefreshTokens(nil)
+				expectRevokeSessions(errors.New("dummy error"))
+			},
+			expErr: "user sessions revoking in repository error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			err := revocation.New(tokenLifetime, tokenLeeway).RevokeUser(ctx, userID, tx)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
+
+func TestService_IsRevoked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockAccessTokenRevocationRepository(ctrl)
+
+	var (
+		ctx    = context.Background()
+		object = &domain.AccessTokenObject{ID: "dummyTokenID", UserID: 1, IssuedAt: time.Now()}
+	)
+
+	t.Run("revoked", func(t *testing.T) {
+		repository.EXPECT().
+			IsRevoked(gomock.Eq(ctx), gomock.Eq(object.ID), gomock.Eq(object.UserID), gomock.Eq(object.IssuedAt)).
+			Return(true, nil)
+
+		revoked, err := revocation.New(tokenLifetime, tokenLeeway).IsRevoked(ctx, object, repository)
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+	})
+
+	t.Run("revoked with session", func(t *testing.T) {
+		object := &domain.AccessTokenObject{ID: "dummyTokenID", UserID: 1, SessionID: "dummySessionID", IssuedAt: time.Now()}
+
+		repository.EXPECT().
+			IsTokenRevoked(gomock.Eq(ctx), gomock.Eq(object.ID)).
+			Return(true, nil)
+
+		revoked, err := revocation.New(tokenLifetime, tokenLeeway).IsRevoked(ctx, object, repository)
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+	})
+
+	t.Run("error on checking", func(t *testing.T) {
+		repository.EXPECT().
+			IsRevoked(gomock.Eq(ctx), gomock.Eq(object.ID), gomock.Eq(object.UserID), gomock.Eq(object.IssuedAt)).
+			Return(false, errors.New("dummy error"))
+
+		revoked, err := revocation.New(tokenLifetime, tokenLeeway).IsRevoked(ctx, object, repository)
+		assert.EqualError(t, err, "access token revocation checking in repository error: dummy error")
+		assert.False(t, revoked)
+	})
+}
+
+func TestService_RevokeUser_sameSecond(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tx := mockdomain.NewMockTxCommitter(ctrl)
+	repository := mockdomain.NewMockAccessTokenRevocationRepository(ctrl)
+
+	key, err := access_token.GenerateKey("local")
+	assert.NoError(t, err)
+	issuer := access_token.New(key)
+
+	// the token without a session is issued and revoked within the same second
+	token, err := issuer.Sign(issuer.NewObject(1, "", nil))
+	assert.NoError(t, err)
+	object, err := issuer.ParseAndValidate(token)
+	assert.NoError(t, err)
+
+	var (
+		ctx           = context.Background()
+		revokedBefore time.Time
+	)
+
+	tx.EXPECT().AccessTokenRevocation().Return(repository)
+	repository.EXPECT().
+		AddUser(gomock.Eq(ctx), gomock.Eq(object.UserID), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int64, before, _ time.Time) error {
+			revokedBefore = before
+			return nil
+		})
+	tx.EXPECT().RefreshToken().DoAndReturn(func() domain.RefreshTokenRepository {
+		r := mockdomain.NewMockRefreshTokenRepository(ctrl)
+		r.EXPECT().RevokeUser(gomock.Eq(ctx), gomock.Eq(object.UserID)).Return(nil)
+		return r
+	})
+	tx.EXPECT().Session().DoAndReturn(func() domain.SessionRepository {
+		r := mockdomain.NewMockSessionRepository(ctrl)
+		r.EXPECT().RevokeUser(gomock.Eq(ctx), gomock.Eq(object.UserID)).Return(nil)
+		return r
+	})
+
+	service := revocation.New(tokenLifetime, tokenLeeway)
+	assert.NoError(t, service.RevokeUser(ctx, object.UserID, tx))
+
+	// the repository revokes the tokens issued not after the revocation time
+	repository.EXPECT().
+		IsRevoked(gomock.Eq(ctx), gomock.Eq(object.ID), gomock.Eq(object.UserID), gomock.Eq(object.IssuedAt)).
+		DoAndReturn(func(_ context.Context, _ string, _ int64, issuedAt time.Time) (bool, error) {
+			return !issuedAt.After(revokedBefore), nil
+		})
+
+	revoked, err := service.IsRevoked(ctx, object, repository)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
@@ -0,0 +1,77 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+// maxUserAgentLength limits the user agent kept in the repository, it's sent by the client.
+const maxUserAgentLength = 512
+
+type Service struct {
+	lifetime time.Duration
+}
+
+// New creates the service, lifetime limits the session regardless of its use.
+func New(lifetime time.Duration) *Service {
+	return &Service{
+		lifetime: lifetime,
+	}
+}
+
+// Start creates the session of the user signed in from the client.
+func (s *Service) Start(ctx context.Context, userID int64, userAgent, ip string, repository domain.SessionRepository) (*domain.Session, error) {
+	now := time.Now()
+
+	session := &domain.Session{
+		ID:         uuid.NewString(),
+		UserID:     userID,
+		UserAgent:  truncate(userAgent, maxUserAgentLength),
+		IP:         ip,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(s.lifetime),
+	}
+
+	if err := repository.Add(ctx, session); err != nil {
+		return nil, fmt.Errorf("session adding to repository error: %w", err)
+	}
+
+	return session, nil
+}
+
+// Revoke ends the session of the user together with its refresh tokens.
+// Sessions of other users and already ended ones are not found.
+func (s *Service) Revoke(ctx context.Context, id string, userID int64, tx domain.TxCommitter) error {
+	session, err := tx.Session().Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("session getting from repository error: %w", err)
+	}
+	if session == nil || session.UserID != userID || !session.IsActive(time.Now()) {
+		return dto.ErrSessionNotFound
+	}
+
+	if err = tx.Session().Revoke(ctx, id); err != nil {
+		return fmt.Errorf("session revoking in repository error: %w", err)
+	}
+
+	if err = tx.RefreshToken().RevokeFamily(ctx, id); err != nil {
+		return fmt.Errorf("refresh token family revoking in repository error: %w", err)
+	}
+
+	return nil
+}
+
+func truncate(s string, length int) string {
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+	return string(runes[:length])
+}
@@ -0,0 +1,226 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	mockdomain "github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/domain/service/session"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/common/testutil"
+)
+
+const (
+	noError  = ""
+	lifetime = 30 * 24 * time.Hour
+)
+
+func TestService_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockSessionRepository(ctrl)
+
+	var (
+		ctx       = context.Background()
+		userID    = int64(1)
+		userAgent = "Mozilla/5.0 (X11; Linux x86_64; rv:120.0) Gecko/20100101 Firefox/120.0"
+		ip        = "192.0.2.1"
+	)
+
+	t.Run("happy path", func(t *testing.T) {
+		var added *domain.Session
+
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			DoAndReturn(func(_ context.Context, s *domain.Session) error {
+				added = s
+				return nil
+			})
+
+		out, err := session.New(lifetime).Start(ctx, userID, userAgent, ip, repository)
+		assert.NoError(t, err)
+		assert.Equal(t, added, out)
+
+		assert.True(t, testutil.IsUUID().Matches(out.ID))
+		assert.Equal(t, userID, out.UserID)
+		assert.Equal(t, userAgent, out.UserAgent)
+		assert.Equal(t, ip, out.IP)
+		assert.WithinDuration(t, time.Now(), out.CreatedAt, time.Second)
+		assert.Equal(t, out.CreatedAt, out.LastUsedAt)
+		assert.Equal(t, out.CreatedAt.Add(lifetime), out.ExpiresAt)
+		assert.False(t, out.Revoked)
+	})
+
+	t.Run("happy path: long user agent", func(t *testing.T) {
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			Return(nil)
+
+		out, err := session.New(lifetime).Start(ctx, userID, strings.Repeat("ж", 1000), ip, repository)
+		assert.NoError(t, err)
+		assert.Equal(t, strings.Repeat("ж", 512), out.UserAgent)
+	})
+
+	t.Run("error on adding session", func(t *testing.T) {
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			Return(errors.New("dummy error"))
+
+		out, err := session.New(lifetime).Start(ctx, userID, userAgent, ip, repository)
+		assert.Nil(t, out)
+		assert.EqualError(t, err, "session adding to repository error: dummy error")
+	})
+}
+
+func TestService_Revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		tx                     = mockdomain.NewMockTxCommitter(ctrl)
+		sessionRepository      = mockdomain.NewMockSessionRepository(ctrl)
+		refreshTokenRepository = mockdomain.NewMockRefreshTokenRepository(ctrl)
+	)
+
+	var (
+		ctx       = context.Background()
+		userID    = int64(1)
+		sessionID = "8f2e3c4a-7b1d-4e5f-9a6b-0c1d2e3f4a5b"
+	)
+
+	storedFactory := func() *domain.Session {
+		return &domain.Session{
+			ID:        sessionID,
+			UserID:    userID,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	expectGetting := func(stored *domain.Session, err error) {
+		tx.EXPECT().
+			Session().
+			Return(sessionRepository)
+
+		sessionRepository.EXPECT().
+			Get(gomock.Eq(ctx), gomock.Eq(sessionID)).
+			Return(stored, err)
+	}
+
+	expectRevoking := func(err error) {
+		tx.EXPECT().
+			Session().
+			Return(sessionRepository)
+
+		sessionRepository.EXPECT().
+			Revoke(gomock.Eq(ctx), gomock.Eq(sessionID)).
+			Return(err)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				expectGetting(storedFactory(), nil)
+				expectRevoking(nil)
+
+				tx.EXPECT().
+					RefreshToken().
+					Return(refreshTokenRepository)
+
+				refreshTokenRepository.EXPECT().
+					RevokeFamily(gomock.Eq(ctx), gomock.Eq(sessionID)).
+					Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "error on getting session",
+			setup: func() {
+				expectGetting(nil, errors.New("dummy error"))
+			},
+			expErr: "session getting from repository error: dummy error",
+		},
+		{
+			name: "session not found",
+			setup: func() {
+				expectGetting(nil, nil)
+			},
+			expErr: dto.ErrSessionNotFound.Error(),
+		},
+		{
+			name: "session of another user",
+			setup: func() {
+				stored := storedFactory()
+				stored.UserID = userID + 1
+				expectGetting(stored, nil)
+			},
+			expErr: dto.ErrSessionNotFound.Error(),
+		},
+		{
+			name: "session already revoked",
+			setup: func() {
+				stored := storedFactory()
+				stored.Revoked = true
+				expectGetting(stored, nil)
+			},
+			expErr: dto.ErrSessionNotFound.Error(),
+		},
+		{
+			name: "session expired",
+			setup: func() {
+				stored := storedFactory()
+				stored.ExpiresAt = time.Now().Add(-time.Minute)
+				expectGetting(stored, nil)
+			},
+			expErr: dto.ErrSessionNotFound.Error(),
+		},
+		{
+			name: "error on revoking session",
+			setup: func() {
+				expectGetting(storedFactory(), nil)
+				expectRevoking(errors.New("dummy error"))
+			},
+			expErr: "session revoking in repository error: dummy error",
+		},
+		{
+			name: "error on revoking refresh tokens",
+			setup: func() {
+				expectGetting(storedFactory(), nil)
+				expectRevoking(nil)
+
+				tx.EXPECT().
+					RefreshToken().
+					Return(refreshTokenRepository)
+
+				refreshTokenRepository.EXPECT().
+					RevokeFamily(gomock.Eq(ctx), gomock.Eq(sessionID)).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "refresh token family revoking in repository error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			err := session.New(lifetime).Revoke(ctx, sessionID, userID, tx)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package two_factor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const (
+	challengeTokenSize = 32
+	// maxAttempts limits guessing the code for a single challenge or secret
+	maxAttempts = 5
+)
+
+type Service struct {
+	issuer       string
+	challengeTTL time.Duration
+}
+
+// New creates the service. The issuer is shown to the user in an authenticator app,
+// challengeTTL limits the time to enter the code after the password is accepted.
+func New(issuer string, challengeTTL time.Duration) *Service {
+	return &Service{
+		issuer:       issuer,
+		challengeTTL: challengeTTL,
+	}
+}
+
+// Enroll replaces the pending secret of the user with a new one.
+// Two-factor authentication is enabled after confirming the secret with a code.
+func (s *Service) Enroll(ctx context.Context, user *domain.User, repository domain.TwoFactorRepository) (*dto.TwoFactorEnrollOut, error) {
+	twoFactor, err := repository.Get(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("two-factor getting from repository error: %w", err)
+	}
+	if twoFactor != nil && twoFactor.Enabled {
+		return nil, dto.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("secret generation error: %w", err)
+	}
+
+	if err = repository.Save(ctx, &domain.TwoFactor{UserID: user.ID, Secret: secret}); err != nil {
+		return nil, fmt.Errorf("two-factor saving to repository error: %w", err)
+	}
+
+	return &dto.TwoFactorEnrollOut{
+		Secret: secret,
+		URI:    s.uri(secret, user.Email),
+	}, nil
+}
+
+// Enable confirms the pending secret with the code and returns new recovery codes.
+// Like the challenge, the pending secret is removed after too many failed attempts and has to be enrolled again.
+func (s *Service) Enable(ctx context.Context, userID int64, code string, repository domain.TwoFactorRepository) ([]string, error) {
+	twoFactor, err := repository.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("two-factor getting from repository error: %w", err)
+	}
+	if twoFactor == nil {
+		return nil, dto.ErrTwoFactorNotEnabled
+	}
+	if twoFactor.Enabled {
+		return nil, dto.ErrTwoFactorAlreadyEnabled
+	}
+
+	ok, err := verifyCode(ctx, twoFactor, code, repository)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, failEnable(ctx, userID, repository)
+	}
+
+	if err = repository.Enable(ctx, userID); err != nil {
+		return nil, fmt.Errorf("two-factor enabling in repository error: %w", err)
+	}
+
+	codes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("recovery codes generation error: %w", err)
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, c := range codes {
+		hashes = append(hashes, HashRecoveryCode(c))
+	}
+
+	if err = repository.RemoveRecoveryCodes(ctx, userID); err != nil {
+		return nil, fmt.Errorf("recovery codes removing from repository error: %w", err)
+	}
+
+	if err = repository.AddRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, fmt.Errorf("recovery codes adding to repository error: %w", err)
+	}
+
+	return codes, nil
+}
+
+// Disable removes the secret and the recovery codes, a valid code or a recovery code is required.
+// After too many failed attempts disabling is locked until the second factor is passed at sign-in.
+func (s *Service) Disable(ctx context.Context, userID int64, code string, repository domain.TwoFactorRepository) error {
+	twoFactor, err := repository.Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("two-factor getting from repository error: %w", err)
+	}
+	if twoFactor == nil || !twoFactor.Enabled {
+		return dto.ErrTwoFactorNotEnabled
+	}
+	if twoFactor.Attempts >= maxAttempts {
+		return dto.ErrTooManyTwoFactorAttempts
+	}
+
+	ok, err := verify(ctx, twoFactor, code, repository)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if _, err = repository.AddAttempt(ctx, userID); err != nil {
+			return fmt.Errorf("attempt adding to repository error: %w", err)
+		}
+		return dto.ErrInvalidTwoFactorCode
+	}
+
+	if err = repository.Remove(ctx, userID); err != nil {
+		return fmt.Errorf("two-factor removing from repository error: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) IsEnabled(ctx context.Context, userID int64, repository domain.TwoFactorRepository) (bool, error) {
+	twoFactor, err := repository.Get(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("two-factor getting from repository error: %w", err)
+	}
+	return twoFactor != nil && twoFactor.Enabled, nil
+}
+
+// IssueChallenge starts the pending authentication and returns its token.
+func (s *Service) IssueChallenge(ctx context.Context, userID int64, repository domain.TwoFactorChallengeRepository) (string, error) {
+	token, err := generateChallengeToken()
+	if err != nil {
+		return "", fmt.Errorf("challenge token generation error: %w", err)
+	}
+
+	challenge := &domain.TwoFactorChallenge{
+		TokenHash: HashChallengeToken(token),
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(s.challengeTTL),
+	}
+
+	if err = repository.Add(ctx, challenge); err != nil {
+		return "", fmt.Errorf("challenge adding to repository error: %w", err)
+	}
+
+	return token, nil
+}
+
+// GetChallenge returns the pending authentication of the token.
+func (s *Service) GetChallenge(ctx context.Context, token string, repository domain.TwoFactorChallengeRepository) (*domain.TwoFactorChallenge, error) {
+	challenge, err := repository.Get(ctx, HashChallengeToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("challenge getting from repository error: %w", err)
+	}
+	if challenge == nil || !time.Now().Before(challenge.ExpiresAt) || challenge.Attempts >= maxAttempts {
+		return nil, dto.ErrInvalidTwoFactorToken
+	}
+	return challenge, nil
+}
+
+// PassChallenge verifies the code or the recovery code for the pending authentication.
+// The challenge is removed after passing or too many failed attempts,
+// passing it resets the failed attempts of disabling two-factor authentication.
+func (s *Service) PassChallenge(
+	ctx context.Context,
+	challenge *domain.TwoFactorChallenge,
+	code string,
+	challengeRepository domain.TwoFactorChallengeRepository,
+	repository domain.TwoFactorRepository,
+) error {
+	twoFactor, err := repository.Get(ctx, challenge.UserID)
+	if err != nil {
+		return fmt.Errorf("two-factor getting from repository error: %w", err)
+	}
+	if twoFactor == nil || !twoFactor.Enabled {
+		// disabled after the challenge has been issued
+		return dto.ErrInvalidTwoFactorToken
+	}
+
+	ok, err := verify(ctx, twoFactor, code, repository)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return failChallenge(ctx, challenge, challengeRepository)
+	}
+
+	removed, err := challengeRepository.Remove(ctx, challenge.TokenHash)
+	if err != nil {
+		return fmt.Errorf("challenge removing from repository error: %w", err)
+	}
+	if !removed {
+		// the challenge has been passed concurrently
+		return dto.ErrInvalidTwoFactorToken
+	}
+
+	if twoFactor.Attempts > 0 {
+		if err = repository.ResetAttempts(ctx, challenge.UserID); err != nil {
+			return fmt.Errorf("attempts resetting in repository error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) uri(secret, accountName string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", s.issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(digits))
+	query.Set("period", strconv.Itoa(period))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + s.issuer + ":" + accountName,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+func failChallenge(ctx context.Context, challenge *domain.TwoFactorChallenge, repository domain.TwoFactorChallengeRepository) error {
+	attempts, err := repository.AddAttempt(ctx, challenge.TokenHash)
+	if err != nil {
+		return fmt.Errorf("challenge attempt adding to repository error: %w", err)
+	}
+
+	if attempts >= maxAttempts {
+		if _, err = repository.Remove(ctx, challenge.TokenHash); err != nil {
+			return fmt.Errorf("challenge removing from repository error: %w", err)
+		}
+	}
+
+	return dto.ErrInvalidTwoFactorCode
+}
+
+func failEnable(ctx context.Context, userID int64, repository domain.TwoFactorRepository) error {
+	attempts, err := repository.AddAttempt(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("attempt adding to repository error: %w", err)
+	}
+
+	if attempts >= maxAttempts {
+		if err = repository.Remove(ctx, userID); err != nil {
+			return fmt.Errorf("two-factor removing from repository error: %w", err)
+		}
+	}
+
+	return dto.ErrInvalidTwoFactorCode
+}
+
+// verify accepts either the code or the recovery code, a recovery code can be used once.
+func verify(ctx context.Context, twoFactor *domain.TwoFactor, code string, repository domain.TwoFactorRepository) (bool, error) {
+	if isCode(code) {
+		return verifyCode(ctx, twoFactor, code, repository)
+	}
+
+	taken, err := repository.TakeRecoveryCode(ctx, twoFactor.UserID, HashRecoveryCode(code))
+	if err != nil {
+		return false, fmt.Errorf("recovery code taking from repository error: %w", err)
+	}
+	return taken, nil
+}
+
+// verifyCode doesn't accept the code of the same or earlier time step twice.
+func verifyCode(ctx context.Context, twoFactor *domain.TwoFactor, code string, repository domain.TwoFactorRepository) (bool, error) {
+	step, ok := validateCode(twoFactor.Secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	used, err := repository.UseStep(ctx, twoFactor.UserID, step)
+	if err != nil {
+		return false, fmt.Errorf("time step using in repository error: %w", err)
+	}
+	return used, nil
+}
+
+// HashChallengeToken returns the representation of the challenge token kept in the repository.
+func HashChallengeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateChallengeToken() (string, error) {
+	b := make([]byte, challengeTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
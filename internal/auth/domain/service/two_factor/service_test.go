@@ -0,0 +1,757 @@
+package two_factor_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	mockdomain "github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/domain/service/two_factor"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const (
+	noError      = ""
+	issuer       = "Blog"
+	challengeTTL = 5 * time.Minute
+	secret       = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	recoveryCode = "abcde-fghij"
+)
+
+func validCode(t *testing.T) string {
+	code, err := two_factor.GenerateCode(secret, time.Now())
+	assert.NoError(t, err)
+	return code
+}
+
+func TestService_Enroll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockTwoFactorRepository(ctrl)
+
+	var (
+		ctx  = context.Background()
+		user = &domain.User{ID: 1, Email: "i.ivanov@example.com"}
+	)
+
+	tests := []struct {
+		name   string
+		setup  func()
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(nil, nil)
+
+				repository.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(_ context.Context, twoFactor *domain.TwoFactor) error {
+						assert.Equal(t, user.ID, twoFactor.UserID)
+						assert.Len(t, twoFactor.Secret, 32)
+						assert.False(t, twoFactor.Enabled)
+						return nil
+					})
+			},
+			expErr: noError,
+		},
+		{
+			name: "pending secret is replaced",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(&domain.TwoFactor{UserID: user.ID, Secret: secret}, nil)
+
+				repository.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(_ context.Context, twoFactor *domain.TwoFactor) error {
+						assert.NotEqual(t, secret, twoFactor.Secret)
+						return nil
+					})
+			},
+			expErr: noError,
+		},
+		{
+			name: "already enabled",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(&domain.TwoFactor{UserID: user.ID, Secret: secret, Enabled: true}, nil)
+			},
+			expErr: dto.ErrTwoFactorAlreadyEnabled.Error(),
+		},
+		{
+			name: "error on getting two-factor",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(nil, errors.New("dummy error"))
+			},
+			expErr: "two-factor getting from repository error: dummy error",
+		},
+		{
+			name: "error on saving two-factor",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(user.ID)).
+					Return(nil, nil)
+
+				repository.EXPECT().
+					Save(gomock.Eq(ctx), gomock.Any()).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "two-factor saving to repository error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			out, err := two_factor.New(issuer, challengeTTL).Enroll(ctx, user, repository)
+
+			if tt.expErr != noError {
+				assert.EqualError(t, err, tt.expErr)
+				assert.Nil(t, out)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			uri, err := url.Parse(out.URI)
+			assert.NoError(t, err)
+			assert.Equal(t, "otpauth", uri.Scheme)
+			assert.Equal(t, "totp", uri.Host)
+			assert.Equal(t, "/Blog:i.ivanov@example.com", uri.Path)
+			assert.Equal(t, out.Secret, uri.Query().Get("secret"))
+			assert.Equal(t, issuer, uri.Query().Get("issuer"))
+			assert.Equal(t, "6", uri.Query().Get("digits"))
+			assert.Equal(t, "30", uri.Query().Get("period"))
+		})
+	}
+}
+
+func TestService_Enable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockTwoFactorRepository(ctrl)
+
+	var (
+		ctx     = context.Background()
+		userID  = int64(1)
+		pending = &domain.TwoFactor{UserID: userID, Secret: secret}
+	)
+
+	tests := []struct {
+		name   string
+		setup  func()
+		code   func() string
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(pending, nil)
+				repository.EXPECT().UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).Return(true, nil)
+				repository.EXPECT().Enable(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil)
+				repository.EXPECT().RemoveRecoveryCodes(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil)
+				repository.EXPECT().
+					AddRecoveryCodes(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ int64, codeHashes []string) error {
+						assert.Len(t, codeHashes, 10)
+						return nil
+					})
+			},
+			expErr: noError,
+		},
+		{
+			name: "not enrolled",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil, nil)
+			},
+			expErr: dto.ErrTwoFactorNotEnabled.Error(),
+		},
+		{
+			name: "already enabled",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(&domain.TwoFactor{UserID: userID, Secret: secret, Enabled: true}, nil)
+			},
+			expErr: dto.ErrTwoFactorAlreadyEnabled.Error(),
+		},
+		{
+			name: "invalid code",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(pending, nil)
+				repository.EXPECT().AddAttempt(gomock.Eq(ctx), gomock.Eq(userID)).Return(1, nil)
+			},
+			code: func() string {
+				code, _ := two_factor.GenerateCode(secret, time.Now().Add(-time.Hour))
+				return code
+			},
+			expErr: dto.ErrInvalidTwoFactorCode.Error(),
+		},
+		{
+			name: "recovery code is not accepted",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(pending, nil)
+				repository.EXPECT().AddAttempt(gomock.Eq(ctx), gomock.Eq(userID)).Return(1, nil)
+			},
+			code:   func() string { return recoveryCode },
+			expErr: dto.ErrInvalidTwoFactorCode.Error(),
+		},
+		{
+			name: "code reused",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(pending, nil)
+				repository.EXPECT().UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).Return(false, nil)
+				repository.EXPECT().AddAttempt(gomock.Eq(ctx), gomock.Eq(userID)).Return(1, nil)
+			},
+			expErr: dto.ErrInvalidTwoFactorCode.Error(),
+		},
+		{
+			name: "too many attempts",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(pending, nil)
+				repository.EXPECT().AddAttempt(gomock.Eq(ctx), gomock.Eq(userID)).Return(5, nil)
+				// the pending secret has to be enrolled again
+				repository.EXPECT().Remove(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil)
+			},
+			code:   func() string { return "000000" },
+			expErr: dto.ErrInvalidTwoFactorCode.Error(),
+		},
+		{
+			name: "error on getting two-factor",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil, errors.New("dummy error"))
+			},
+			expErr: "two-factor getting from repository error: dummy error",
+		},
+		{
+			name: "error on using time step",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(pending, nil)
+				repository.EXPECT().
+					UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).
+					Return(false, errors.New("dummy error"))
+			},
+			expErr: "time step using in repository error: dummy error",
+		},
+		{
+			name: "error on adding attempt",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(pending, nil)
+				repository.EXPECT().AddAttempt(gomock.Eq(ctx), gomock.Eq(userID)).Return(0, errors.New("dummy error"))
+			},
+			code:   func() string { return "000000" },
+			expErr: "attempt adding to repository error: dummy error",
+		},
+		{
+			name: "error on removing pending secret",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(pending, nil)
+				repository.EXPECT().AddAttempt(gomock.Eq(ctx), gomock.Eq(userID)).Return(5, nil)
+				repository.EXPECT().Remove(gomock.Eq(ctx), gomock.Eq(userID)).Return(errors.New("dummy error"))
+			},
+			code:   func() string { return "000000" },
+			expErr: "two-factor removing from repository error: dummy error",
+		},
+		{
+			name: "error on enabling two-factor",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(pending, nil)
+				repository.EXPECT().UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).Return(true, nil)
+				repository.EXPECT().Enable(gomock.Eq(ctx), gomock.Eq(userID)).Return(errors.New("dummy error"))
+			},
+			expErr: "two-factor enabling in repository error: dummy error",
+		},
+		{
+			name: "error on removing recovery codes",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(pending, nil)
+				repository.EXPECT().UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).Return(true, nil)
+				repository.EXPECT().Enable(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil)
+				repository.EXPECT().
+					RemoveRecoveryCodes(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "recovery codes removing from repository error: dummy error",
+		},
+		{
+			name: "error on adding recovery codes",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(pending, nil)
+				repository.EXPECT().UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).Return(true, nil)
+				repository.EXPECT().Enable(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil)
+				repository.EXPECT().RemoveRecoveryCodes(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil)
+				repository.EXPECT().
+					AddRecoveryCodes(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).
+					Return(errors.New("dummy error"))
+			},
+			expErr: "recovery codes adding to repository error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			code := validCode(t)
+			if tt.code != nil {
+				code = tt.code()
+			}
+
+			codes, err := two_factor.New(issuer, challengeTTL).Enable(ctx, userID, code, repository)
+
+			if tt.expErr != noError {
+				assert.EqualError(t, err, tt.expErr)
+				assert.Nil(t, codes)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, codes, 10)
+			for _, c := range codes {
+				assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, c)
+			}
+		})
+	}
+}
+
+func TestService_Disable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockTwoFactorRepository(ctrl)
+
+	var (
+		ctx     = context.Background()
+		userID  = int64(1)
+		enabled = &domain.TwoFactor{UserID: userID, Secret: secret, Enabled: true}
+	)
+
+	tests := []struct {
+		name   string
+		setup  func()
+		code   string
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(enabled, nil)
+				repository.EXPECT().UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).Return(true, nil)
+				repository.EXPECT().Remove(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path with recovery code",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(enabled, nil)
+				repository.EXPECT().
+					TakeRecoveryCode(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(two_factor.HashRecoveryCode(recoveryCode))).
+					Return(true, nil)
+				repository.EXPECT().Remove(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil)
+			},
+			code:   recoveryCode,
+			expErr: noError,
+		},
+		{
+			name: "not enabled",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(&domain.TwoFactor{UserID: userID, Secret: secret}, nil)
+			},
+			expErr: dto.ErrTwoFactorNotEnabled.Error(),
+		},
+		{
+			name: "unknown recovery code",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(enabled, nil)
+				repository.EXPECT().
+					TakeRecoveryCode(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(two_factor.HashRecoveryCode(recoveryCode))).
+					Return(false, nil)
+				repository.EXPECT().AddAttempt(gomock.Eq(ctx), gomock.Eq(userID)).Return(1, nil)
+			},
+			code:   recoveryCode,
+			expErr: dto.ErrInvalidTwoFactorCode.Error(),
+		},
+		{
+			name: "too many attempts",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(&domain.TwoFactor{UserID: userID, Secret: secret, Enabled: true, Attempts: 5}, nil)
+			},
+			expErr: dto.ErrTooManyTwoFactorAttempts.Error(),
+		},
+		{
+			name: "error on getting two-factor",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil, errors.New("dummy error"))
+			},
+			expErr: "two-factor getting from repository error: dummy error",
+		},
+		{
+			name: "error on taking recovery code",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(enabled, nil)
+				repository.EXPECT().
+					TakeRecoveryCode(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(two_factor.HashRecoveryCode(recoveryCode))).
+					Return(false, errors.New("dummy error"))
+			},
+			code:   recoveryCode,
+			expErr: "recovery code taking from repository error: dummy error",
+		},
+		{
+			name: "error on adding attempt",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(enabled, nil)
+				repository.EXPECT().
+					TakeRecoveryCode(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(two_factor.HashRecoveryCode(recoveryCode))).
+					Return(false, nil)
+				repository.EXPECT().AddAttempt(gomock.Eq(ctx), gomock.Eq(userID)).Return(0, errors.New("dummy error"))
+			},
+			code:   recoveryCode,
+			expErr: "attempt adding to repository error: dummy error",
+		},
+		{
+			name: "error on removing two-factor",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(enabled, nil)
+				repository.EXPECT().UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).Return(true, nil)
+				repository.EXPECT().Remove(gomock.Eq(ctx), gomock.Eq(userID)).Return(errors.New("dummy error"))
+			},
+			expErr: "two-factor removing from repository error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			code := tt.code
+			if code == "" {
+				code = validCode(t)
+			}
+
+			err := two_factor.New(issuer, challengeTTL).Disable(ctx, userID, code, repository)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
+
+func TestService_IsEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockTwoFactorRepository(ctrl)
+
+	var (
+		ctx    = context.Background()
+		userID = int64(1)
+		s      = two_factor.New(issuer, challengeTTL)
+	)
+
+	repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil, nil)
+	enabled, err := s.IsEnabled(ctx, userID, repository)
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+
+	repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(&domain.TwoFactor{UserID: userID}, nil)
+	enabled, err = s.IsEnabled(ctx, userID, repository)
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+
+	repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(&domain.TwoFactor{UserID: userID, Enabled: true}, nil)
+	enabled, err = s.IsEnabled(ctx, userID, repository)
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+
+	repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil, errors.New("dummy error"))
+	_, err = s.IsEnabled(ctx, userID, repository)
+	assert.EqualError(t, err, "two-factor getting from repository error: dummy error")
+}
+
+func TestService_IssueChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockTwoFactorChallengeRepository(ctrl)
+
+	var (
+		ctx    = context.Background()
+		userID = int64(1)
+	)
+
+	t.Run("happy path", func(t *testing.T) {
+		var added *domain.TwoFactorChallenge
+
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			DoAndReturn(func(_ context.Context, challenge *domain.TwoFactorChallenge) error {
+				added = challenge
+				return nil
+			})
+
+		token, err := two_factor.New(issuer, challengeTTL).IssueChallenge(ctx, userID, repository)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token)
+
+		assert.Equal(t, two_factor.HashChallengeToken(token), added.TokenHash)
+		assert.Equal(t, userID, added.UserID)
+		assert.WithinDuration(t, time.Now().Add(challengeTTL), added.ExpiresAt, time.Second)
+	})
+
+	t.Run("error on adding challenge", func(t *testing.T) {
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			Return(errors.New("dummy error"))
+
+		token, err := two_factor.New(issuer, challengeTTL).IssueChallenge(ctx, userID, repository)
+		assert.EqualError(t, err, "challenge adding to repository error: dummy error")
+		assert.Empty(t, token)
+	})
+}
+
+func TestService_GetChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mockdomain.NewMockTwoFactorChallengeRepository(ctrl)
+
+	var (
+		ctx       = context.Background()
+		token     = "dummyTwoFactorToken"
+		tokenHash = two_factor.HashChallengeToken(token)
+		challenge = &domain.TwoFactorChallenge{TokenHash: tokenHash, UserID: 1, ExpiresAt: time.Now().Add(time.Minute)}
+	)
+
+	tests := []struct {
+		name         string
+		setup        func()
+		expChallenge *domain.TwoFactorChallenge
+		expErr       string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(challenge, nil)
+			},
+			expChallenge: challenge,
+			expErr:       noError,
+		},
+		{
+			name: "challenge not found",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(nil, nil)
+			},
+			expErr: dto.ErrInvalidTwoFactorToken.Error(),
+		},
+		{
+			name: "challenge expired",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(&domain.TwoFactorChallenge{TokenHash: tokenHash, UserID: 1, ExpiresAt: time.Now()}, nil)
+			},
+			expErr: dto.ErrInvalidTwoFactorToken.Error(),
+		},
+		{
+			name: "attempts exhausted",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(&domain.TwoFactorChallenge{TokenHash: tokenHash, UserID: 1, ExpiresAt: time.Now().Add(time.Minute), Attempts: 5}, nil)
+			},
+			expErr: dto.ErrInvalidTwoFactorToken.Error(),
+		},
+		{
+			name: "error on getting challenge",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(nil, errors.New("dummy error"))
+			},
+			expErr: "challenge getting from repository error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			challenge, err := two_factor.New(issuer, challengeTTL).GetChallenge(ctx, token, repository)
+
+			assert.Equal(t, tt.expChallenge, challenge)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
+
+func TestService_PassChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var (
+		challengeRepository = mockdomain.NewMockTwoFactorChallengeRepository(ctrl)
+		repository          = mockdomain.NewMockTwoFactorRepository(ctrl)
+	)
+
+	var (
+		ctx       = context.Background()
+		userID    = int64(1)
+		tokenHash = two_factor.HashChallengeToken("dummyTwoFactorToken")
+		challenge = &domain.TwoFactorChallenge{TokenHash: tokenHash, UserID: userID, ExpiresAt: time.Now().Add(time.Minute)}
+		enabled   = &domain.TwoFactor{UserID: userID, Secret: secret, Enabled: true}
+	)
+
+	tests := []struct {
+		name   string
+		setup  func()
+		code   string
+		expErr string
+	}{
+		{
+			name: "happy path",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(enabled, nil)
+				repository.EXPECT().UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).Return(true, nil)
+				challengeRepository.EXPECT().Remove(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(true, nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "happy path with recovery code",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(enabled, nil)
+				repository.EXPECT().
+					TakeRecoveryCode(gomock.Eq(ctx), gomock.Eq(userID), gomock.Eq(two_factor.HashRecoveryCode(recoveryCode))).
+					Return(true, nil)
+				challengeRepository.EXPECT().Remove(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(true, nil)
+			},
+			code:   recoveryCode,
+			expErr: noError,
+		},
+		{
+			name: "happy path: failed attempts of disabling reset",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(&domain.TwoFactor{UserID: userID, Secret: secret, Enabled: true, Attempts: 5}, nil)
+				repository.EXPECT().UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).Return(true, nil)
+				challengeRepository.EXPECT().Remove(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(true, nil)
+				repository.EXPECT().ResetAttempts(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil)
+			},
+			expErr: noError,
+		},
+		{
+			name: "two-factor disabled after issuing challenge",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil, nil)
+			},
+			expErr: dto.ErrInvalidTwoFactorToken.Error(),
+		},
+		{
+			name: "invalid code",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(enabled, nil)
+				repository.EXPECT().UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).Return(false, nil)
+				challengeRepository.EXPECT().AddAttempt(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(1, nil)
+			},
+			expErr: dto.ErrInvalidTwoFactorCode.Error(),
+		},
+		{
+			name: "invalid code on last attempt",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(enabled, nil)
+				repository.EXPECT().UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).Return(false, nil)
+				challengeRepository.EXPECT().AddAttempt(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(5, nil)
+				challengeRepository.EXPECT().Remove(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(true, nil)
+			},
+			expErr: dto.ErrInvalidTwoFactorCode.Error(),
+		},
+		{
+			name: "challenge passed concurrently",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(enabled, nil)
+				repository.EXPECT().UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).Return(true, nil)
+				challengeRepository.EXPECT().Remove(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(false, nil)
+			},
+			expErr: dto.ErrInvalidTwoFactorToken.Error(),
+		},
+		{
+			name: "error on getting two-factor",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(nil, errors.New("dummy error"))
+			},
+			expErr: "two-factor getting from repository error: dummy error",
+		},
+		{
+			name: "error on adding attempt",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(enabled, nil)
+				repository.EXPECT().UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).Return(false, nil)
+				challengeRepository.EXPECT().
+					AddAttempt(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(0, errors.New("dummy error"))
+			},
+			expErr: "challenge attempt adding to repository error: dummy error",
+		},
+		{
+			name: "error on removing challenge",
+			setup: func() {
+				repository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(userID)).Return(enabled, nil)
+				repository.EXPECT().UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).Return(true, nil)
+				challengeRepository.EXPECT().
+					Remove(gomock.Eq(ctx), gomock.Eq(tokenHash)).
+					Return(false, errors.New("dummy error"))
+			},
+			expErr: "challenge removing from repository error: dummy error",
+		},
+		{
+			name: "error on resetting attempts",
+			setup: func() {
+				repository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(userID)).
+					Return(&domain.TwoFactor{UserID: userID, Secret: secret, Enabled: true, Attempts: 1}, nil)
+				repository.EXPECT().UseStep(gomock.Eq(ctx), gomock.Eq(userID), gomock.Any()).Return(true, nil)
+				challengeRepository.EXPECT().Remove(gomock.Eq(ctx), gomock.Eq(tokenHash)).Return(true, nil)
+				repository.EXPECT().ResetAttempts(gomock.Eq(ctx), gomock.Eq(userID)).Return(errors.New("dummy error"))
+			},
+			expErr: "attempts resetting in repository error: dummy error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			code := tt.code
+			if code == "" {
+				code = validCode(t)
+			}
+
+			s := two_factor.New(issuer, challengeTTL)
+			err := s.PassChallenge(ctx, challenge, code, challengeRepository, repository)
+
+			if tt.expErr == noError {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expErr)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package two_factor
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TOTP parameters supported by the most of authenticator apps
+// https://datatracker.ietf.org/doc/html/rfc6238
+const (
+	secretSize = 20
+	digits     = 6
+	period     = 30
+	// skew is the number of time steps before and after the current one with acceptable codes
+	skew = 1
+)
+
+const (
+	recoveryCodeCount = 10
+	recoveryCodeSize  = 10
+	recoveryCodeGroup = 5
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateCode returns the TOTP code for the time.
+func GenerateCode(secret string, t time.Time) (string, error) {
+	key, err := secretEncoding.DecodeString(secret)
+	if err != nil {
+		return "", fmt.Errorf("secret decoding error: %w", err)
+	}
+	return code(key, timeStep(t)), nil
+}
+
+// validateCode returns the time step of the code if it is acceptable for the time.
+func validateCode(secret, input string, t time.Time) (int64, bool) {
+	key, err := secretEncoding.DecodeString(secret)
+	if err != nil || !isCode(input) {
+		return 0, false
+	}
+
+	current := timeStep(t)
+	for step := current - skew; step <= current+skew; step++ {
+		if hmac.Equal([]byte(code(key, step)), []byte(input)) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func isCode(input string) bool {
+	if len(input) != digits {
+		return false
+	}
+	for _, r := range input {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func timeStep(t time.Time) int64 {
+	return t.Unix() / period
+}
+
+// code implements HOTP with dynamic truncation https://datatracker.ietf.org/doc/html/rfc4226#section-5.3
+func code(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return secretEncoding.EncodeToString(b), nil
+}
+
+// generateRecoveryCodes returns codes formatted as "xxxxx-xxxxx".
+func generateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+
+		encoded := strings.ToLower(secretEncoding.EncodeToString(b))[:recoveryCodeSize]
+		codes = append(codes, encoded[:recoveryCodeGroup]+"-"+encoded[recoveryCodeGroup:])
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode returns the representation of the recovery code kept in the repository.
+// The code is case and separator insensitive.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
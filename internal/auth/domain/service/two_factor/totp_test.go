@@ -0,0 +1,41 @@
+package two_factor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain/service/two_factor"
+)
+
+func TestGenerateCode(t *testing.T) {
+	// test vectors of https://datatracker.ietf.org/doc/html/rfc6238#appendix-B truncated to 6 digits,
+	// the secret is base32 encoded "12345678901234567890"
+	const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	tests := []struct {
+		unix    int64
+		expCode string
+	}{
+		{unix: 59, expCode: "287082"},
+		{unix: 1111111109, expCode: "081804"},
+		{unix: 1111111111, expCode: "050471"},
+		{unix: 1234567890, expCode: "005924"},
+		{unix: 2000000000, expCode: "279037"},
+		{unix: 20000000000, expCode: "353130"},
+	}
+	for _, tt := range tests {
+		code, err := two_factor.GenerateCode(secret, time.Unix(tt.unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expCode, code)
+	}
+
+	_, err := two_factor.GenerateCode("not base32!", time.Now())
+	assert.Error(t, err)
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	assert.Equal(t, two_factor.HashRecoveryCode("abcde-fghij"), two_factor.HashRecoveryCode("ABCDE FGHIJ"))
+	assert.NotEqual(t, two_factor.HashRecoveryCode("abcde-fghij"), two_factor.HashRecoveryCode("abcde-fghik"))
+}
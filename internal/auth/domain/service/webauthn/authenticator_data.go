@@ -0,0 +1,83 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Flags of the authenticator data.
+const (
+	flagUserPresent            = 0x01
+	flagUserVerified           = 0x04
+	flagAttestedCredentialData = 0x40
+	flagExtensionData          = 0x80
+)
+
+const (
+	// minAuthenticatorDataSize is the size of RP ID hash, the flags and the signature counter.
+	minAuthenticatorDataSize = 37
+	// aaguidSize is the size of the authenticator model identifier, which is skipped as the attestation isn't verified.
+	aaguidSize = 16
+	// maxCredentialIDSize is set by the specification.
+	maxCredentialIDSize = 1023
+)
+
+// authenticatorData is the data signed by the authenticator https://www.w3.org/TR/webauthn-2/#sctn-authenticator-data.
+// The credential is set only by the registration.
+type authenticatorData struct {
+	rpIDHash     []byte
+	flags        byte
+	signCount    uint32
+	credentialID []byte
+	publicKey    []byte
+}
+
+func parseAuthenticatorData(data []byte) (*authenticatorData, error) {
+	if len(data) < minAuthenticatorDataSize {
+		return nil, errors.New("authenticator data is too short")
+	}
+
+	ad := &authenticatorData{
+		rpIDHash:  data[:32],
+		flags:     data[32],
+		signCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	rest := data[minAuthenticatorDataSize:]
+
+	if ad.flags&flagAttestedCredentialData != 0 {
+		if len(rest) < aaguidSize+2 {
+			return nil, errors.New("attested credential data is too short")
+		}
+
+		size := int(binary.BigEndian.Uint16(rest[aaguidSize:]))
+		rest = rest[aaguidSize+2:]
+		if size == 0 || size > maxCredentialIDSize || len(rest) < size {
+			return nil, errors.New("invalid credential ID")
+		}
+		ad.credentialID, rest = rest[:size], rest[size:]
+
+		_, after, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("public key decoding error: %w", err)
+		}
+		ad.publicKey, rest = rest[:len(rest)-len(after)], after
+	}
+
+	if ad.flags&flagExtensionData != 0 {
+		var err error
+		if _, rest, err = decodeCBOR(rest); err != nil {
+			return nil, fmt.Errorf("extensions decoding error: %w", err)
+		}
+	}
+
+	if len(rest) != 0 {
+		return nil, errors.New("trailing authenticator data")
+	}
+
+	return ad, nil
+}
+
+func (ad *authenticatorData) has(flag byte) bool {
+	return ad.flags&flag != 0
+}
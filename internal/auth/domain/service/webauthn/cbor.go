@@ -0,0 +1,137 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// maxCBORDepth keeps the malicious input from exhausting the stack.
+const maxCBORDepth = 16
+
+var errCBORUnexpectedEnd = errors.New("unexpected end of cbor data")
+
+// decodeCBOR decodes the first item of the data and returns the rest https://www.rfc-editor.org/rfc/rfc8949.
+// Only the definite-length items used by WebAuthn are supported: integers, byte and text strings,
+// arrays, maps keyed by integers or text strings, booleans and null.
+func decodeCBOR(data []byte) (any, []byte, error) {
+	return decodeCBORItem(data, 0)
+}
+
+func decodeCBORItem(data []byte, depth int) (any, []byte, error) {
+	if depth > maxCBORDepth {
+		return nil, nil, errors.New("cbor nesting is too deep")
+	}
+	if len(data) == 0 {
+		return nil, nil, errCBORUnexpectedEnd
+	}
+
+	major, info := data[0]>>5, data[0]&0x1f
+	arg, data, err := readCBORArgument(info, data[1:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case 0:
+		if arg > math.MaxInt64 {
+			return nil, nil, errors.New("cbor integer overflow")
+		}
+		return int64(arg), data, nil
+	case 1:
+		if arg > math.MaxInt64 {
+			return nil, nil, errors.New("cbor integer overflow")
+		}
+		return -1 - int64(arg), data, nil
+	case 2, 3:
+		if uint64(len(data)) < arg {
+			return nil, nil, errCBORUnexpectedEnd
+		}
+		if major == 3 {
+			return string(data[:arg]), data[arg:], nil
+		}
+		return data[:arg:arg], data[arg:], nil
+	case 4:
+		// every item takes a byte at least
+		if uint64(len(data)) < arg {
+			return nil, nil, errCBORUnexpectedEnd
+		}
+		items := make([]any, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			var item any
+			if item, data, err = decodeCBORItem(data, depth+1); err != nil {
+				return nil, nil, err
+			}
+			items = append(items, item)
+		}
+		return items, data, nil
+	case 5:
+		if uint64(len(data)) < 2*arg {
+			return nil, nil, errCBORUnexpectedEnd
+		}
+		items := make(map[any]any, arg)
+		for i := uint64(0); i < arg; i++ {
+			var key, value any
+			if key, data, err = decodeCBORItem(data, depth+1); err != nil {
+				return nil, nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, nil, fmt.Errorf("unsupported cbor map key %T", key)
+			}
+			if value, data, err = decodeCBORItem(data, depth+1); err != nil {
+				return nil, nil, err
+			}
+			items[key] = value
+		}
+		return items, data, nil
+	case 7:
+		switch info {
+		case 20:
+			return false, data, nil
+		case 21:
+			return true, data, nil
+		case 22:
+			return nil, data, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("unsupported cbor item %#x", major<<5|info)
+}
+
+func readCBORArgument(info byte, data []byte) (uint64, []byte, error) {
+	var size int
+	switch {
+	case info < 24:
+		return uint64(info), data, nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, nil, fmt.Errorf("unsupported cbor additional information %d", info)
+	}
+
+	if len(data) < size {
+		return 0, nil, errCBORUnexpectedEnd
+	}
+
+	var arg uint64
+	switch size {
+	case 1:
+		arg = uint64(data[0])
+	case 2:
+		arg = uint64(binary.BigEndian.Uint16(data))
+	case 4:
+		arg = uint64(binary.BigEndian.Uint32(data))
+	default:
+		arg = binary.BigEndian.Uint64(data)
+	}
+	return arg, data[size:], nil
+}
@@ -0,0 +1,133 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// COSE identifiers of the public key algorithms https://www.iana.org/assignments/cose/cose.xhtml
+const (
+	algES256 = -7
+	algEdDSA = -8
+	algRS256 = -257
+)
+
+// COSE key parameters and their values.
+const (
+	coseKeyType      = 1
+	coseKeyAlgorithm = 3
+	coseKeyCurve     = -1
+	coseKeyX         = -2
+	coseKeyY         = -3
+	coseKeyModulus   = -1
+	coseKeyExponent  = -2
+
+	coseKeyTypeOKP   = 1
+	coseKeyTypeEC2   = 2
+	coseKeyTypeRSA   = 3
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+)
+
+// minRSAKeySize rejects the keys which are too weak.
+const minRSAKeySize = 2048
+
+// supportedAlgorithms are offered to the authenticators, the preferred first.
+var supportedAlgorithms = []int{algES256, algEdDSA, algRS256}
+
+// parsePublicKey decodes COSE encoded public key https://www.rfc-editor.org/rfc/rfc9053.
+func parsePublicKey(data []byte) (crypto.PublicKey, error) {
+	item, rest, err := decodeCBOR(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after the key")
+	}
+
+	params, ok := item.(map[any]any)
+	if !ok {
+		return nil, errors.New("key is not a map")
+	}
+
+	keyType, _ := params[int64(coseKeyType)].(int64)
+	algorithm, _ := params[int64(coseKeyAlgorithm)].(int64)
+
+	switch {
+	case algorithm == algES256 && keyType == coseKeyTypeEC2:
+		return parseES256Key(params)
+	case algorithm == algEdDSA && keyType == coseKeyTypeOKP:
+		return parseEdDSAKey(params)
+	case algorithm == algRS256 && keyType == coseKeyTypeRSA:
+		return parseRS256Key(params)
+	}
+	return nil, fmt.Errorf("unsupported algorithm %d of key type %d", algorithm, keyType)
+}
+
+func parseES256Key(params map[any]any) (crypto.PublicKey, error) {
+	curve, _ := params[int64(coseKeyCurve)].(int64)
+	x, _ := params[int64(coseKeyX)].([]byte)
+	y, _ := params[int64(coseKeyY)].([]byte)
+	if curve != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+		return nil, errors.New("invalid P-256 key")
+	}
+
+	key := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+	if !key.Curve.IsOnCurve(key.X, key.Y) {
+		return nil, errors.New("point is not on P-256 curve")
+	}
+	return key, nil
+}
+
+func parseEdDSAKey(params map[any]any) (crypto.PublicKey, error) {
+	curve, _ := params[int64(coseKeyCurve)].(int64)
+	x, _ := params[int64(coseKeyX)].([]byte)
+	if curve != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid Ed25519 key")
+	}
+	return ed25519.PublicKey(x), nil
+}
+
+func parseRS256Key(params map[any]any) (crypto.PublicKey, error) {
+	n, _ := params[int64(coseKeyModulus)].([]byte)
+	e, _ := params[int64(coseKeyExponent)].([]byte)
+	if len(n)*8 < minRSAKeySize || len(e) == 0 || len(e) > 4 {
+		return nil, errors.New("invalid RSA key")
+	}
+
+	exponent := 0
+	for _, b := range e {
+		exponent = exponent<<8 | int(b)
+	}
+	if exponent < 3 || exponent%2 == 0 {
+		return nil, errors.New("invalid RSA exponent")
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exponent}, nil
+}
+
+// verifySignature reports whether the signature of the data is made by the key.
+func verifySignature(key crypto.PublicKey, data, signature []byte) bool {
+	digest := sha256.Sum256(data)
+
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], signature)
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, data, signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	}
+	return false
+}
@@ -0,0 +1,305 @@
+package webauthn
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const challengeSize = 32
+
+// Types of the client data.
+const (
+	clientDataTypeCreate = "webauthn.create"
+	clientDataTypeGet    = "webauthn.get"
+)
+
+// clientData is collected by the browser https://www.w3.org/TR/webauthn-2/#dictionary-client-data.
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+type Service struct {
+	rpID         string
+	rpName       string
+	origin       string
+	rpIDHash     [32]byte
+	challengeTTL time.Duration
+}
+
+// New creates the service of the relying party. The rpID is the domain the passkeys are bound to,
+// the origin is where the web application runs, e.g. "https://example.com", and challengeTTL limits the ceremonies.
+func New(rpID, rpName, origin string, challengeTTL time.Duration) *Service {
+	return &Service{
+		rpID:         rpID,
+		rpName:       rpName,
+		origin:       origin,
+		rpIDHash:     sha256.Sum256([]byte(rpID)),
+		challengeTTL: challengeTTL,
+	}
+}
+
+// BeginRegistration issues the challenge to register a new passkey of the user.
+// The credentials the user has are excluded, so an authenticator keeps one passkey of the user.
+func (s *Service) BeginRegistration(
+	ctx context.Context,
+	user *domain.User,
+	credentials []*domain.WebAuthnCredential,
+	repository domain.WebAuthnChallengeRepository,
+) (*dto.WebAuthnRegistrationBeginOut, error) {
+	challenge, err := s.issueChallenge(ctx, domain.WebAuthnCeremonyRegistration, user.ID, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	excludeCredentialIDs := make([]string, 0, len(credentials))
+	for _, credential := range credentials {
+		excludeCredentialIDs = append(excludeCredentialIDs, credential.ID)
+	}
+
+	return &dto.WebAuthnRegistrationBeginOut{
+		Challenge:            challenge,
+		RPID:                 s.rpID,
+		RPName:               s.rpName,
+		UserHandle:           userHandle(user.ID),
+		UserName:             user.Email,
+		UserDisplayName:      user.Name,
+		Algorithms:           append([]int(nil), supportedAlgorithms...),
+		ExcludeCredentialIDs: excludeCredentialIDs,
+		Timeout:              s.challengeTTL,
+	}, nil
+}
+
+// FinishRegistration verifies the response of the authenticator to the registration challenge of the user
+// and returns the new credential, which isn't added to the repository yet. The attestation isn't requested,
+// so its statement isn't verified. dto.ErrInvalidWebAuthnResponse is returned if the response is rejected.
+func (s *Service) FinishRegistration(
+	ctx context.Context,
+	userID int64,
+	clientDataJSON, attestationObject string,
+	repository domain.WebAuthnChallengeRepository,
+) (*domain.WebAuthnCredential, error) {
+	challenge, err := s.takeChallenge(ctx, clientDataJSON, clientDataTypeCreate, domain.WebAuthnCeremonyRegistration, repository)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.UserID != userID {
+		return nil, dto.ErrInvalidWebAuthnResponse
+	}
+
+	ad, err := decodeAttestationObject(attestationObject)
+	if err != nil || !s.checkAuthenticatorData(ad) || !ad.has(flagAttestedCredentialData) {
+		return nil, dto.ErrInvalidWebAuthnResponse
+	}
+	if _, err = parsePublicKey(ad.publicKey); err != nil {
+		return nil, dto.ErrInvalidWebAuthnResponse
+	}
+
+	return &domain.WebAuthnCredential{
+		ID:        base64.RawURLEncoding.EncodeToString(ad.credentialID),
+		UserID:    userID,
+		PublicKey: ad.publicKey,
+		SignCount: ad.signCount,
+	}, nil
+}
+
+// BeginLogin issues the challenge to sign in with any passkey of the relying party.
+func (s *Service) BeginLogin(ctx context.Context, repository domain.WebAuthnChallengeRepository) (*dto.WebAuthnLoginBeginOut, error) {
+	challenge, err := s.issueChallenge(ctx, domain.WebAuthnCeremonyLogin, 0, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.WebAuthnLoginBeginOut{
+		Challenge: challenge,
+		RPID:      s.rpID,
+		Timeout:   s.challengeTTL,
+	}, nil
+}
+
+// FinishLogin verifies the assertion of the authenticator to the login challenge and returns the credential
+// with the sign counter updated, and whether the authenticator has verified the user, e.g. with the biometrics.
+// dto.ErrInvalidWebAuthnResponse is returned if the assertion is rejected, including the counter going backwards,
+// which signals the authenticator is cloned.
+func (s *Service) FinishLogin(
+	ctx context.Context,
+	in *dto.WebAuthnLoginFinishIn,
+	tx domain.TxCommitter,
+) (*domain.WebAuthnCredential, bool, error) {
+	if _, err := s.takeChallenge(ctx, in.ClientDataJSON, clientDataTypeGet, domain.WebAuthnCeremonyLogin, tx.WebAuthnChallenge()); err != nil {
+		return nil, false, err
+	}
+
+	credential, err := tx.WebAuthnCredential().Get(ctx, trimPadding(in.CredentialID))
+	if err != nil {
+		return nil, false, fmt.Errorf("webauthn credential getting from repository error: %w", err)
+	}
+	if credential == nil || (in.UserHandle != "" && trimPadding(in.UserHandle) != userHandle(credential.UserID)) {
+		return nil, false, dto.ErrInvalidWebAuthnResponse
+	}
+
+	ad, ok := s.verifyAssertion(credential, in)
+	if !ok {
+		return nil, false, dto.ErrInvalidWebAuthnResponse
+	}
+	if (ad.signCount != 0 || credential.SignCount != 0) && ad.signCount <= credential.SignCount {
+		return nil, false, dto.ErrInvalidWebAuthnResponse
+	}
+
+	credential.SignCount = ad.signCount
+	credential.LastUsedAt = time.Now()
+	if err = tx.WebAuthnCredential().Touch(ctx, credential.ID, credential.SignCount, credential.LastUsedAt); err != nil {
+		return nil, false, fmt.Errorf("webauthn credential touching in repository error: %w", err)
+	}
+
+	return credential, ad.has(flagUserVerified), nil
+}
+
+func (s *Service) verifyAssertion(credential *domain.WebAuthnCredential, in *dto.WebAuthnLoginFinishIn) (*authenticatorData, bool) {
+	authenticatorData, err1 := decodeBase64(in.AuthenticatorData)
+	clientDataJSON, err2 := decodeBase64(in.ClientDataJSON)
+	signature, err3 := decodeBase64(in.Signature)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return nil, false
+	}
+
+	ad, err := parseAuthenticatorData(authenticatorData)
+	if err != nil || !s.checkAuthenticatorData(ad) {
+		return nil, false
+	}
+
+	key, err := parsePublicKey(credential.PublicKey)
+	if err != nil {
+		return nil, false
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	if !verifySignature(key, append(authenticatorData, clientDataHash[:]...), signature) {
+		return nil, false
+	}
+
+	return ad, true
+}
+
+func (s *Service) issueChallenge(ctx context.Context, ceremony string, userID int64, repository domain.WebAuthnChallengeRepository) (string, error) {
+	challenge, err := generate()
+	if err != nil {
+		return "", fmt.Errorf("webauthn challenge generation error: %w", err)
+	}
+
+	object := &domain.WebAuthnChallenge{
+		ChallengeHash: Hash(challenge),
+		Ceremony:      ceremony,
+		UserID:        userID,
+		ExpiresAt:     time.Now().Add(s.challengeTTL),
+	}
+
+	if err = repository.Add(ctx, object); err != nil {
+		return "", fmt.Errorf("webauthn challenge adding to repository error: %w", err)
+	}
+
+	return challenge, nil
+}
+
+// takeChallenge consumes the challenge the client data is signed for, so the response cannot be replayed.
+func (s *Service) takeChallenge(
+	ctx context.Context,
+	clientDataJSON, clientDataType, ceremony string,
+	repository domain.WebAuthnChallengeRepository,
+) (*domain.WebAuthnChallenge, error) {
+	raw, err := decodeBase64(clientDataJSON)
+	if err != nil {
+		return nil, dto.ErrInvalidWebAuthnResponse
+	}
+
+	var data clientData
+	if err = json.Unmarshal(raw, &data); err != nil || data.Challenge == "" {
+		return nil, dto.ErrInvalidWebAuthnResponse
+	}
+
+	challenge, err := repository.Take(ctx, Hash(trimPadding(data.Challenge)))
+	if err != nil {
+		return nil, fmt.Errorf("webauthn challenge taking from repository error: %w", err)
+	}
+	if challenge == nil || challenge.Ceremony != ceremony || !time.Now().Before(challenge.ExpiresAt) {
+		return nil, dto.ErrInvalidWebAuthnResponse
+	}
+
+	// the ceremonies embedded into other sites are not expected
+	if data.Type != clientDataType || data.Origin != s.origin || data.CrossOrigin {
+		return nil, dto.ErrInvalidWebAuthnResponse
+	}
+
+	return challenge, nil
+}
+
+// checkAuthenticatorData reports whether the data is made for the relying party with the user present.
+func (s *Service) checkAuthenticatorData(ad *authenticatorData) bool {
+	return bytes.Equal(ad.rpIDHash, s.rpIDHash[:]) && ad.has(flagUserPresent)
+}
+
+// decodeAttestationObject returns the authenticator data of the attestation, the format and the statement are ignored.
+func decodeAttestationObject(attestationObject string) (*authenticatorData, error) {
+	raw, err := decodeBase64(attestationObject)
+	if err != nil {
+		return nil, err
+	}
+
+	item, rest, err := decodeCBOR(raw)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := item.(map[any]any)
+	if !ok || len(rest) != 0 {
+		return nil, fmt.Errorf("invalid attestation object")
+	}
+
+	authData, ok := object["authData"].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("authenticator data is missing")
+	}
+
+	return parseAuthenticatorData(authData)
+}
+
+// Hash returns the representation of the challenge kept in the repository.
+func Hash(challenge string) string {
+	sum := sha256.Sum256([]byte(challenge))
+	return hex.EncodeToString(sum[:])
+}
+
+// userHandle identifies the user to the authenticators without disclosing the email.
+func userHandle(userID int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(userID, 10)))
+}
+
+// decodeBase64 accepts base64url with or without the padding, the browsers differ in it.
+func decodeBase64(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(trimPadding(s))
+}
+
+func trimPadding(s string) string {
+	return strings.TrimRight(s, "=")
+}
+
+func generate() (string, error) {
+	b := make([]byte, challengeSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
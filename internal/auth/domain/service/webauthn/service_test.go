@@ -0,0 +1,486 @@
+package webauthn_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	mockdomain "github.com/art-es/blog/internal/auth/domain/mock"
+	"github.com/art-es/blog/internal/auth/domain/service/webauthn"
+	"github.com/art-es/blog/internal/auth/domain/service/webauthn/webauthntest"
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const (
+	noError = ""
+	rpID    = "example.com"
+	rpName  = "Example"
+	origin  = "https://example.com"
+	ttl     = 5 * time.Minute
+)
+
+// expectChallenges makes the repository keep the challenges in memory.
+func expectChallenges(repository *mockdomain.MockWebAuthnChallengeRepository) map[string]*domain.WebAuthnChallenge {
+	challenges := map[string]*domain.WebAuthnChallenge{}
+
+	repository.EXPECT().
+		Add(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, challenge *domain.WebAuthnChallenge) error {
+			challenges[challenge.ChallengeHash] = challenge
+			return nil
+		}).
+		AnyTimes()
+
+	repository.EXPECT().
+		Take(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, challengeHash string) (*domain.WebAuthnChallenge, error) {
+			challenge := challenges[challengeHash]
+			delete(challenges, challengeHash)
+			return challenge, nil
+		}).
+		AnyTimes()
+
+	return challenges
+}
+
+func TestService_Registration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		ctx  = context.Background()
+		user = &domain.User{ID: 1, Name: "Dummy", Email: "dummy@example.com"}
+	)
+
+	service := webauthn.New(rpID, rpName, origin, ttl)
+
+	t.Run("begin", func(t *testing.T) {
+		repository := mockdomain.NewMockWebAuthnChallengeRepository(ctrl)
+		challenges := expectChallenges(repository)
+		credentials := []*domain.WebAuthnCredential{{ID: "dummyCredentialID"}}
+
+		out, err := service.BeginRegistration(ctx, user, credentials, repository)
+		assert.NoError(t, err)
+
+		assert.Len(t, out.Challenge, 43)
+		assert.Equal(t, rpID, out.RPID)
+		assert.Equal(t, rpName, out.RPName)
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString([]byte("1")), out.UserHandle)
+		assert.Equal(t, user.Email, out.UserName)
+		assert.Equal(t, user.Name, out.UserDisplayName)
+		assert.Equal(t, []int{-7, -8, -257}, out.Algorithms)
+		assert.Equal(t, []string{"dummyCredentialID"}, out.ExcludeCredentialIDs)
+		assert.Equal(t, ttl, out.Timeout)
+
+		challenge := challenges[webauthn.Hash(out.Challenge)]
+		assert.Equal(t, domain.WebAuthnCeremonyRegistration, challenge.Ceremony)
+		assert.Equal(t, user.ID, challenge.UserID)
+		assert.WithinDuration(t, time.Now().Add(ttl), challenge.ExpiresAt, time.Second)
+	})
+
+	t.Run("error on adding challenge", func(t *testing.T) {
+		repository := mockdomain.NewMockWebAuthnChallengeRepository(ctrl)
+		repository.EXPECT().
+			Add(gomock.Eq(ctx), gomock.Any()).
+			Return(errors.New("dummy error"))
+
+		out, err := service.BeginRegistration(ctx, user, nil, repository)
+		assert.Nil(t, out)
+		assert.EqualError(t, err, "webauthn challenge adding to repository error: dummy error")
+	})
+
+	for _, tt := range []struct {
+		name          string
+		prepare       func(a *webauthntest.Authenticator, challenges map[string]*domain.WebAuthnChallenge)
+		userID        int64
+		mutate        func(clientDataJSON, attestationObject string) (string, string)
+		expectedError string
+	}{
+		{
+			name:          "happy path",
+			userID:        user.ID,
+			expectedError: noError,
+		},
+		{
+			name: "wrong origin",
+			prepare: func(a *webauthntest.Authenticator, _ map[string]*domain.WebAuthnChallenge) {
+				a.Origin = "https://evil.example.com"
+			},
+			userID:        user.ID,
+			expectedError: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name: "wrong relying party",
+			prepare: func(a *webauthntest.Authenticator, _ map[string]*domain.WebAuthnChallenge) {
+				a.RPID = "evil.example.com"
+			},
+			userID:        user.ID,
+			expectedError: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name: "expired challenge",
+			prepare: func(_ *webauthntest.Authenticator, challenges map[string]*domain.WebAuthnChallenge) {
+				for _, challenge := range challenges {
+					challenge.ExpiresAt = time.Now().Add(-time.Second)
+				}
+			},
+			userID:        user.ID,
+			expectedError: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name: "login challenge",
+			prepare: func(_ *webauthntest.Authenticator, challenges map[string]*domain.WebAuthnChallenge) {
+				for _, challenge := range challenges {
+					challenge.Ceremony = domain.WebAuthnCeremonyLogin
+				}
+			},
+			userID:        user.ID,
+			expectedError: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name:          "another user",
+			userID:        2,
+			expectedError: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name:   "malformed attestation object",
+			userID: user.ID,
+			mutate: func(clientDataJSON, _ string) (string, string) {
+				return clientDataJSON, "AAAA"
+			},
+			expectedError: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name:   "malformed client data",
+			userID: user.ID,
+			mutate: func(_, attestationObject string) (string, string) {
+				return "!", attestationObject
+			},
+			expectedError: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+	} {
+		t.Run("finish: "+tt.name, func(t *testing.T) {
+			repository := mockdomain.NewMockWebAuthnChallengeRepository(ctrl)
+			challenges := expectChallenges(repository)
+			authenticator := webauthntest.New(origin)
+
+			options, err := service.BeginRegistration(ctx, user, nil, repository)
+			assert.NoError(t, err)
+
+			if tt.prepare != nil {
+				tt.prepare(authenticator, challenges)
+			}
+
+			clientDataJSON, attestationObject := authenticator.Create(options)
+			if tt.mutate != nil {
+				clientDataJSON, attestationObject = tt.mutate(clientDataJSON, attestationObject)
+			}
+
+			credential, err := service.FinishRegistration(ctx, tt.userID, clientDataJSON, attestationObject, repository)
+			if tt.expectedError != noError {
+				assert.Nil(t, credential)
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, authenticator.CredentialID(), credential.ID)
+			assert.Equal(t, user.ID, credential.UserID)
+			assert.NotEmpty(t, credential.PublicKey)
+			assert.Zero(t, credential.SignCount)
+
+			// the challenge is consumed
+			credential, err = service.FinishRegistration(ctx, tt.userID, clientDataJSON, attestationObject, repository)
+			assert.Nil(t, credential)
+			assert.ErrorIs(t, err, dto.ErrInvalidWebAuthnResponse)
+		})
+	}
+
+	t.Run("finish: error on taking challenge", func(t *testing.T) {
+		repository := mockdomain.NewMockWebAuthnChallengeRepository(ctrl)
+		expectChallenges(repository)
+		options, err := service.BeginRegistration(ctx, user, nil, repository)
+		assert.NoError(t, err)
+		clientDataJSON, attestationObject := webauthntest.New(origin).Create(options)
+
+		repository = mockdomain.NewMockWebAuthnChallengeRepository(ctrl)
+		repository.EXPECT().
+			Take(gomock.Eq(ctx), gomock.Eq(webauthn.Hash(options.Challenge))).
+			Return(nil, errors.New("dummy error"))
+
+		credential, err := service.FinishRegistration(ctx, user.ID, clientDataJSON, attestationObject, repository)
+		assert.Nil(t, credential)
+		assert.EqualError(t, err, "webauthn challenge taking from repository error: dummy error")
+	})
+}
+
+func TestService_Login(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		ctx  = context.Background()
+		user = &domain.User{ID: 1, Name: "Dummy", Email: "dummy@example.com"}
+	)
+
+	service := webauthn.New(rpID, rpName, origin, ttl)
+
+	// register returns the authenticator with its credential, as it's kept by the repository
+	register := func(t *testing.T) (*webauthntest.Authenticator, *domain.WebAuthnCredential) {
+		repository := mockdomain.NewMockWebAuthnChallengeRepository(ctrl)
+		expectChallenges(repository)
+		authenticator := webauthntest.New(origin)
+
+		options, err := service.BeginRegistration(ctx, user, nil, repository)
+		assert.NoError(t, err)
+		clientDataJSON, attestationObject := authenticator.Create(options)
+		credential, err := service.FinishRegistration(ctx, user.ID, clientDataJSON, attestationObject, repository)
+		assert.NoError(t, err)
+
+		return authenticator, credential
+	}
+
+	t.Run("begin", func(t *testing.T) {
+		repository := mockdomain.NewMockWebAuthnChallengeRepository(ctrl)
+		challenges := expectChallenges(repository)
+
+		out, err := service.BeginLogin(ctx, repository)
+		assert.NoError(t, err)
+
+		assert.Len(t, out.Challenge, 43)
+		assert.Equal(t, rpID, out.RPID)
+		assert.Equal(t, ttl, out.Timeout)
+
+		challenge := challenges[webauthn.Hash(out.Challenge)]
+		assert.Equal(t, domain.WebAuthnCeremonyLogin, challenge.Ceremony)
+		assert.Zero(t, challenge.UserID)
+	})
+
+	for _, tt := range []struct {
+		name           string
+		prepare        func(a *webauthntest.Authenticator, credential *domain.WebAuthnCredential)
+		mutate         func(in *dto.WebAuthnLoginFinishIn)
+		credentialMiss bool
+		touchErr       error
+		expectedUV     bool
+		expectedError  string
+	}{
+		{
+			name:          "happy path",
+			expectedUV:    true,
+			expectedError: noError,
+		},
+		{
+			name: "user isn't verified",
+			prepare: func(a *webauthntest.Authenticator, _ *domain.WebAuthnCredential) {
+				a.UserVerified = false
+			},
+			expectedUV:    false,
+			expectedError: noError,
+		},
+		{
+			name: "authenticator without counter",
+			prepare: func(a *webauthntest.Authenticator, _ *domain.WebAuthnCredential) {
+				a.Counting = false
+			},
+			expectedUV:    true,
+			expectedError: noError,
+		},
+		{
+			name: "counter going backwards",
+			prepare: func(a *webauthntest.Authenticator, credential *domain.WebAuthnCredential) {
+				credential.SignCount = 10
+				a.SignCount = 4
+			},
+			expectedError: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name: "counter stopped",
+			prepare: func(a *webauthntest.Authenticator, credential *domain.WebAuthnCredential) {
+				credential.SignCount = 10
+				a.SignCount = 10
+				a.Counting = false
+			},
+			expectedError: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name: "key of another credential",
+			prepare: func(_ *webauthntest.Authenticator, credential *domain.WebAuthnCredential) {
+				_, other := register(t)
+				credential.PublicKey = other.PublicKey
+			},
+			expectedError: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name: "wrong relying party",
+			prepare: func(a *webauthntest.Authenticator, _ *domain.WebAuthnCredential) {
+				a.RPID = "evil.example.com"
+			},
+			expectedError: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name: "tampered authenticator data",
+			mutate: func(in *dto.WebAuthnLoginFinishIn) {
+				data, _ := base64.RawURLEncoding.DecodeString(in.AuthenticatorData)
+				data[32] |= 0x40
+				in.AuthenticatorData = base64.RawURLEncoding.EncodeToString(data)
+			},
+			expectedError: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name: "another user handle",
+			mutate: func(in *dto.WebAuthnLoginFinishIn) {
+				in.UserHandle = base64.RawURLEncoding.EncodeToString([]byte("2"))
+			},
+			expectedError: dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name:           "credential not found",
+			credentialMiss: true,
+			expectedError:  dto.ErrInvalidWebAuthnResponse.Error(),
+		},
+		{
+			name:          "error on touching credential",
+			touchErr:      errors.New("dummy error"),
+			expectedError: "webauthn credential touching in repository error: dummy error",
+		},
+	} {
+		t.Run("finish: "+tt.name, func(t *testing.T) {
+			var (
+				tx                   = mockdomain.NewMockTxCommitter(ctrl)
+				challengeRepository  = mockdomain.NewMockWebAuthnChallengeRepository(ctrl)
+				credentialRepository = mockdomain.NewMockWebAuthnCredentialRepository(ctrl)
+			)
+
+			expectChallenges(challengeRepository)
+			tx.EXPECT().WebAuthnChallenge().Return(challengeRepository).AnyTimes()
+			tx.EXPECT().WebAuthnCredential().Return(credentialRepository).AnyTimes()
+
+			authenticator, credential := register(t)
+			if tt.prepare != nil {
+				tt.prepare(authenticator, credential)
+			}
+			storedSignCount := credential.SignCount
+
+			options, err := service.BeginLogin(ctx, challengeRepository)
+			assert.NoError(t, err)
+			in := authenticator.Get(options)
+			if tt.mutate != nil {
+				tt.mutate(in)
+			}
+
+			if tt.credentialMiss {
+				credentialRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(credential.ID)).
+					Return(nil, nil)
+			} else {
+				credentialRepository.EXPECT().
+					Get(gomock.Eq(ctx), gomock.Eq(credential.ID)).
+					Return(credential, nil)
+			}
+
+			if tt.expectedError == noError || tt.touchErr != nil {
+				credentialRepository.EXPECT().
+					Touch(gomock.Eq(ctx), gomock.Eq(credential.ID), gomock.Eq(authenticator.SignCount), gomock.Any()).
+					Return(tt.touchErr)
+			}
+
+			out, userVerified, err := service.FinishLogin(ctx, in, tx)
+			if tt.expectedError != noError {
+				assert.Nil(t, out)
+				assert.False(t, userVerified)
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, credential.ID, out.ID)
+			assert.Equal(t, authenticator.SignCount, out.SignCount)
+			assert.WithinDuration(t, time.Now(), out.LastUsedAt, time.Second)
+			assert.Equal(t, tt.expectedUV, userVerified)
+			if authenticator.Counting {
+				assert.Greater(t, out.SignCount, storedSignCount)
+			}
+		})
+	}
+
+	t.Run("finish: replayed assertion", func(t *testing.T) {
+		var (
+			tx                   = mockdomain.NewMockTxCommitter(ctrl)
+			challengeRepository  = mockdomain.NewMockWebAuthnChallengeRepository(ctrl)
+			credentialRepository = mockdomain.NewMockWebAuthnCredentialRepository(ctrl)
+		)
+
+		expectChallenges(challengeRepository)
+		tx.EXPECT().WebAuthnChallenge().Return(challengeRepository).AnyTimes()
+		tx.EXPECT().WebAuthnCredential().Return(credentialRepository).AnyTimes()
+
+		authenticator, credential := register(t)
+		options, err := service.BeginLogin(ctx, challengeRepository)
+		assert.NoError(t, err)
+		in := authenticator.Get(options)
+
+		credentialRepository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(credential.ID)).Return(credential, nil)
+		credentialRepository.EXPECT().Touch(gomock.Eq(ctx), gomock.Eq(credential.ID), gomock.Eq(uint32(1)), gomock.Any()).Return(nil)
+
+		_, _, err = service.FinishLogin(ctx, in, tx)
+		assert.NoError(t, err)
+
+		out, _, err := service.FinishLogin(ctx, in, tx)
+		assert.Nil(t, out)
+		assert.ErrorIs(t, err, dto.ErrInvalidWebAuthnResponse)
+	})
+}
+
+func TestService_Algorithms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		ctx  = context.Background()
+		user = &domain.User{ID: 1, Name: "Dummy", Email: "dummy@example.com"}
+	)
+
+	service := webauthn.New(rpID, rpName, origin, ttl)
+
+	for name, newAuthenticator := range map[string]func(origin string) *webauthntest.Authenticator{
+		"ES256": webauthntest.New,
+		"EdDSA": webauthntest.NewEdDSA,
+		"RS256": webauthntest.NewRS256,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var (
+				tx                   = mockdomain.NewMockTxCommitter(ctrl)
+				challengeRepository  = mockdomain.NewMockWebAuthnChallengeRepository(ctrl)
+				credentialRepository = mockdomain.NewMockWebAuthnCredentialRepository(ctrl)
+			)
+
+			expectChallenges(challengeRepository)
+			tx.EXPECT().WebAuthnChallenge().Return(challengeRepository).AnyTimes()
+			tx.EXPECT().WebAuthnCredential().Return(credentialRepository).AnyTimes()
+			authenticator := newAuthenticator(origin)
+
+			registrationOptions, err := service.BeginRegistration(ctx, user, nil, challengeRepository)
+			assert.NoError(t, err)
+			clientDataJSON, attestationObject := authenticator.Create(registrationOptions)
+			credential, err := service.FinishRegistration(ctx, user.ID, clientDataJSON, attestationObject, challengeRepository)
+			assert.NoError(t, err)
+
+			credentialRepository.EXPECT().Get(gomock.Eq(ctx), gomock.Eq(credential.ID)).Return(credential, nil)
+			credentialRepository.EXPECT().Touch(gomock.Eq(ctx), gomock.Eq(credential.ID), gomock.Eq(uint32(1)), gomock.Any()).Return(nil)
+
+			loginOptions, err := service.BeginLogin(ctx, challengeRepository)
+			assert.NoError(t, err)
+			out, userVerified, err := service.FinishLogin(ctx, authenticator.Get(loginOptions), tx)
+			assert.NoError(t, err)
+			assert.Equal(t, user.ID, out.UserID)
+			assert.True(t, userVerified)
+		})
+	}
+}
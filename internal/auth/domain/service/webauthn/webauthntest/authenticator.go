@@ -0,0 +1,206 @@
+// Package webauthntest provides the software authenticator to run the WebAuthn ceremonies without a browser.
+package webauthntest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+const (
+	credentialIDSize = 16
+	rsaKeySize       = 2048
+)
+
+// Authenticator acts as the browser with a platform authenticator holding a single passkey.
+// The fields can be changed between the ceremonies to simulate the authenticators misbehaving.
+type Authenticator struct {
+	// Origin is put into the client data.
+	Origin string
+	// RPID overrides the relying party the authenticator signs for, the one of the options is used if empty.
+	RPID string
+	// UserVerified sets the flag of the user verified, e.g. with the biometrics.
+	UserVerified bool
+	// SignCount is put into the authenticator data, it's incremented by each assertion if Counting is set.
+	SignCount uint32
+	Counting  bool
+
+	credentialID []byte
+	userHandle   string
+	key          crypto.Signer
+	publicKey    []byte
+}
+
+// New creates the authenticator with a new ES256 credential.
+func New(origin string) *Authenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	return newAuthenticator(origin, key, encodeCBOR([]pair{
+		{1, 2},  // the key type is EC2
+		{3, -7}, // the algorithm is ES256
+		{-1, 1}, // the curve is P-256
+		{-2, key.X.FillBytes(make([]byte, 32))},
+		{-3, key.Y.FillBytes(make([]byte, 32))},
+	}))
+}
+
+// NewEdDSA creates the authenticator with a new Ed25519 credential.
+func NewEdDSA(origin string) *Authenticator {
+	public, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	return newAuthenticator(origin, key, encodeCBOR([]pair{
+		{1, 1},  // the key type is OKP
+		{3, -8}, // the algorithm is EdDSA
+		{-1, 6}, // the curve is Ed25519
+		{-2, []byte(public)},
+	}))
+}
+
+// NewRS256 creates the authenticator with a new RSA credential, as Windows Hello does.
+func NewRS256(origin string) *Authenticator {
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeySize)
+	if err != nil {
+		panic(err)
+	}
+
+	return newAuthenticator(origin, key, encodeCBOR([]pair{
+		{1, 3},    // the key type is RSA
+		{3, -257}, // the algorithm is RS256
+		{-1, key.N.Bytes()},
+		{-2, big.NewInt(int64(key.E)).Bytes()},
+	}))
+}
+
+func newAuthenticator(origin string, key crypto.Signer, publicKey []byte) *Authenticator {
+	credentialID := make([]byte, credentialIDSize)
+	if _, err := rand.Read(credentialID); err != nil {
+		panic(err)
+	}
+
+	return &Authenticator{
+		Origin:       origin,
+		UserVerified: true,
+		Counting:     true,
+		credentialID: credentialID,
+		key:          key,
+		publicKey:    publicKey,
+	}
+}
+
+// CredentialID returns the base64url encoded ID of the credential.
+func (a *Authenticator) CredentialID() string {
+	return base64.RawURLEncoding.EncodeToString(a.credentialID)
+}
+
+// Create responds to the registration options like navigator.credentials.create.
+// It returns the base64url encoded client data and attestation object with the "none" attestation.
+func (a *Authenticator) Create(options *dto.WebAuthnRegistrationBeginOut) (string, string) {
+	a.userHandle = options.UserHandle
+
+	clientDataJSON := a.clientData("webauthn.create", options.Challenge)
+
+	// the model of the authenticator is left zero as for the "none" attestation
+	attested := make([]byte, 16)
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.credentialID)))
+	attested = append(attested, a.credentialID...)
+	attested = append(attested, a.publicKey...)
+
+	authData := append(a.authenticatorData(options.RPID, 0x40), attested...)
+	attestationObject := encodeCBOR([]pair{
+		{"fmt", "none"},
+		{"attStmt", []pair{}},
+		{"authData", authData},
+	})
+
+	return encode(clientDataJSON), encode(attestationObject)
+}
+
+// Get responds to the login options like navigator.credentials.get.
+func (a *Authenticator) Get(options *dto.WebAuthnLoginBeginOut) *dto.WebAuthnLoginFinishIn {
+	if a.Counting {
+		a.SignCount++
+	}
+
+	clientDataJSON := a.clientData("webauthn.get", options.Challenge)
+	authData := a.authenticatorData(options.RPID, 0)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signature := a.sign(append(append([]byte(nil), authData...), clientDataHash[:]...))
+
+	return &dto.WebAuthnLoginFinishIn{
+		CredentialID:      a.CredentialID(),
+		ClientDataJSON:    encode(clientDataJSON),
+		AuthenticatorData: encode(authData),
+		Signature:         encode(signature),
+		UserHandle:        a.userHandle,
+	}
+}
+
+// sign signs the data as the algorithm of the credential requires, Ed25519 signs the message itself.
+func (a *Authenticator) sign(data []byte) []byte {
+	var (
+		signature []byte
+		err       error
+	)
+
+	if _, ok := a.key.(ed25519.PrivateKey); ok {
+		signature, err = a.key.Sign(rand.Reader, data, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(data)
+		signature, err = a.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	return signature
+}
+
+func (a *Authenticator) clientData(typ, challenge string) []byte {
+	data, err := json.Marshal(map[string]any{
+		"type":        typ,
+		"challenge":   challenge,
+		"origin":      a.Origin,
+		"crossOrigin": false,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func (a *Authenticator) authenticatorData(rpID string, flags byte) []byte {
+	if a.RPID != "" {
+		rpID = a.RPID
+	}
+
+	// the user is always present
+	flags |= 0x01
+	if a.UserVerified {
+		flags |= 0x04
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(data, a.SignCount)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,53 @@
+package webauthntest
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// pair is an entry of the CBOR map, the maps are encoded in the order of their entries.
+type pair struct {
+	key   any
+	value any
+}
+
+// encodeCBOR encodes the subset of CBOR the authenticators use https://www.rfc-editor.org/rfc/rfc8949.
+func encodeCBOR(item any) []byte {
+	switch v := item.(type) {
+	case int:
+		if v < 0 {
+			return cborHead(1, uint64(-1-v))
+		}
+		return cborHead(0, uint64(v))
+	case []byte:
+		return append(cborHead(2, uint64(len(v))), v...)
+	case string:
+		return append(cborHead(3, uint64(len(v))), v...)
+	case []pair:
+		out := cborHead(5, uint64(len(v)))
+		for _, p := range v {
+			out = append(out, encodeCBOR(p.key)...)
+			out = append(out, encodeCBOR(p.value)...)
+		}
+		return out
+	default:
+		panic(fmt.Sprintf("unsupported CBOR item %T", item))
+	}
+}
+
+func cborHead(major byte, argument uint64) []byte {
+	major <<= 5
+
+	switch {
+	case argument < 24:
+		return []byte{major | byte(argument)}
+	case argument <= 0xff:
+		return []byte{major | 24, byte(argument)}
+	case argument <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major | 25}, uint16(argument))
+	case argument <= 0xffffffff:
+		return binary.BigEndian.AppendUint32([]byte{major | 26}, uint32(argument))
+	default:
+		return binary.BigEndian.AppendUint64([]byte{major | 27}, argument)
+	}
+}
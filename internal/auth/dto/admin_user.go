@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// AdminUser is the account seen by the admins.
+type AdminUser struct {
+	ID     int64
+	Name   string
+	Email  string
+	Active bool
+}
+
+type UserSuspension struct {
+	Reason string
+	// Until is nil if the user is banned.
+	Until       *time.Time
+	SuspendedBy int64
+	CreatedAt   time.Time
+}
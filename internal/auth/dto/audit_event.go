@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+type AuditEvent struct {
+	ID int64
+	// Type is one of the domain.AuditEvent* constants.
+	Type string
+	// ActorID is 0 if the actor is unknown.
+	ActorID int64
+	// TargetID is 0 if the action is made on the actor.
+	TargetID  int64
+	IP        string
+	UserAgent string
+	Outcome   string
+	Details   string
+	CreatedAt time.Time
+}
@@ -0,0 +1,14 @@
+package dto
+
+type EmailChangeCodeEmailMessage struct {
+	Email string `json:"email"`
+	Code  string `json:"code"`
+}
+
+// EmailChangeNoticeEmailMessage tells the old email about the change,
+// UndoToken is set once the change is confirmed.
+type EmailChangeNoticeEmailMessage struct {
+	Email     string `json:"email"`
+	NewEmail  string `json:"newEmail"`
+	UndoToken string `json:"undoToken,omitempty"`
+}
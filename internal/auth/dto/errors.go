@@ -1,12 +1,73 @@
 package dto
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
 
 var (
-	ErrEmailIsBusy                = errors.New("busy email")
-	ErrUserNotFound               = errors.New("auth not found")
-	ErrUserActivationCodeNotFound = errors.New("activation code not found")
-	ErrExpiredUserActivationCode  = errors.New("expired user activation code")
-	ErrIncorrectPassword          = errors.New("incorrect password")
-	ErrInvalidAccessToken         = errors.New("invalid access token")
+	ErrEmailIsBusy                 = errors.New("busy email")
+	ErrInviteRequired              = errors.New("invite required")
+	ErrInvalidInvite               = errors.New("invalid invite")
+	ErrEmailDomainNotAllowed       = errors.New("email domain not allowed")
+	ErrDisposableEmail             = errors.New("disposable email")
+	ErrUserNotFound                = errors.New("auth not found")
+	ErrUserActivationCodeNotFound  = errors.New("activation code not found")
+	ErrExpiredUserActivationCode   = errors.New("expired user activation code")
+	ErrActivationCodeResendLimit   = errors.New("activation code resend limit")
+	ErrUserNotActivated            = errors.New("user not activated")
+	ErrUserSuspended               = errors.New("user suspended")
+	ErrIncorrectPassword           = errors.New("incorrect password")
+	ErrInvalidAccessToken          = errors.New("invalid access token")
+	ErrInvalidPasswordResetToken   = errors.New("invalid password reset token")
+	ErrInvalidMagicLinkToken       = errors.New("invalid magic link token")
+	ErrInvalidRefreshToken         = errors.New("invalid refresh token")
+	ErrInvalidEmailChangeCode      = errors.New("invalid email change code")
+	ErrInvalidEmailChangeUndoToken = errors.New("invalid email change undo token")
+	ErrReusedRefreshToken          = errors.New("reused refresh token")
+	ErrTwoFactorAlreadyEnabled     = errors.New("two-factor already enabled")
+	ErrTwoFactorNotEnabled         = errors.New("two-factor not enabled")
+	ErrInvalidTwoFactorCode        = errors.New("invalid two-factor code")
+	ErrInvalidTwoFactorToken       = errors.New("invalid two-factor token")
+	ErrTooManyTwoFactorAttempts    = errors.New("too many two-factor attempts")
+	ErrOIDCProviderNotFound        = errors.New("oidc provider not found")
+	ErrInvalidOIDCState            = errors.New("invalid oidc state")
+	ErrOIDCAuthenticationFailed    = errors.New("oidc authentication failed")
+	ErrExternalEmailNotVerified    = errors.New("external email not verified")
+	ErrInvalidWebAuthnResponse     = errors.New("invalid webauthn response")
+	ErrWebAuthnCredentialExists    = errors.New("webauthn credential exists")
+	ErrSessionNotFound             = errors.New("session not found")
+	ErrScopeNotGranted             = errors.New("scope not granted")
+	ErrPersonalAccessTokenNotFound = errors.New("personal access token not found")
+	ErrHandleIsBusy                = errors.New("busy handle")
+	ErrProfileNotFound             = errors.New("profile not found")
 )
+
+// LoginThrottledError rejects authentication after too many failed attempts.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("login throttled, retry after %s", e.RetryAfter)
+}
+
+// Rules of the password policy.
+const (
+	PasswordRuleMinLength   = "min_length"
+	PasswordRuleCharClasses = "char_classes"
+	PasswordRuleNotName     = "not_name"
+	PasswordRuleNotEmail    = "not_email"
+	PasswordRuleNotBreached = "not_breached"
+)
+
+// PasswordPolicyError rejects the password which violates the rules of the password policy.
+type PasswordPolicyError struct {
+	Violations []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return fmt.Sprintf("password policy violated: %s", strings.Join(e.Violations, ", "))
+}
@@ -0,0 +1,16 @@
+package dto
+
+// JSONWebKey is a public key of the access token signature
+// https://datatracker.ietf.org/doc/html/rfc7517#section-4
+type JSONWebKey struct {
+	KeyType   string
+	KeyID     string
+	Use       string
+	Algorithm string
+	// RSA keys
+	Modulus  string
+	Exponent string
+	// Ed25519 keys
+	Curve string
+	X     string
+}
@@ -0,0 +1,6 @@
+package dto
+
+type MagicLinkEmailMessage struct {
+	Email string `json:"email"`
+	Token string `json:"token"`
+}
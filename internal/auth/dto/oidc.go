@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// OIDCRequest is the parameters of the authorization request binding its response to the browser.
+type OIDCRequest struct {
+	State         string
+	Nonce         string
+	CodeChallenge string
+	ExpiresAt     time.Time
+}
+
+// OIDCIdentity is the user identity asserted by the ID token of the provider.
+type OIDCIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
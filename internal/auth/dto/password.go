@@ -0,0 +1,10 @@
+package dto
+
+type PasswordResetEmailMessage struct {
+	Email string `json:"email"`
+	Token string `json:"token"`
+}
+
+type PasswordChangedEmailMessage struct {
+	Email string `json:"email"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// PersonalAccessToken describes the token of the user, the token itself is shown only on creation.
+type PersonalAccessToken struct {
+	ID        string
+	Name      string
+	Scopes    []string
+	CreatedAt time.Time
+	ExpiresAt *time.Time
+}
@@ -0,0 +1,22 @@
+package dto
+
+// UserProfile is the profile seen by the user oneself.
+type UserProfile struct {
+	ID          int64
+	Name        string
+	Email       string
+	Handle      string
+	DisplayName string
+	Bio         string
+	Website     string
+	AvatarURL   string
+}
+
+// PublicProfile is the profile seen by everyone.
+type PublicProfile struct {
+	Handle      string
+	DisplayName string
+	Bio         string
+	Website     string
+	AvatarURL   string
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+// Session is the sign-in of the user on a device, Current marks the session of the request.
+type Session struct {
+	ID         string
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	Current    bool
+}
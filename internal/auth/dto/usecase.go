@@ -1,30 +1,211 @@
 package dto
 
+import "time"
+
 type UserRegisterIn struct {
 	Name     string
 	Email    string
 	Password string
+	// InviteCode is required if the registration is invite-only.
+	InviteCode string
+	IP         string
+	UserAgent  string
+}
+
+type UserRegisterOut struct {
+	// Activated is true if the invite has activated the user, so no activation code is sent.
+	Activated bool
 }
 
 type UserActivateIn struct {
-	Code string
+	Code      string
+	IP        string
+	UserAgent string
+}
+
+type UserActivationResendIn struct {
+	Email string
 }
 
 type UserAuthenticateIn struct {
-	Email    string
-	Password string
+	Email     string
+	Password  string
+	IP        string
+	UserAgent string
 }
 
+// UserAuthenticateOut has only TwoFactorToken if the user has two-factor authentication enabled.
 type UserAuthenticateOut struct {
-	AccessToken string
+	AccessToken    string
+	RefreshToken   string
+	TwoFactorToken string
+}
+
+type UserAuthenticateTwoFactorIn struct {
+	TwoFactorToken string
+	Code           string
+	IP             string
+	UserAgent      string
+}
+
+type TwoFactorEnrollIn struct {
+	UserID int64
+}
+
+type TwoFactorEnrollOut struct {
+	Secret string
+	URI    string
+}
+
+type TwoFactorConfirmIn struct {
+	UserID int64
+	Code   string
+}
+
+type TwoFactorConfirmOut struct {
+	RecoveryCodes []string
+}
+
+type TwoFactorDisableIn struct {
+	UserID int64
+	Code   string
+}
+
+type OIDCAuthorizeIn struct {
+	Provider string
+}
+
+// OIDCAuthorizeOut has the state to bind the sign-in to the browser until StateExpiresAt.
+type OIDCAuthorizeOut struct {
+	AuthorizationURL string
+	State            string
+	StateExpiresAt   time.Time
+}
+
+type OIDCAuthenticateIn struct {
+	Provider  string
+	Code      string
+	State     string
+	IP        string
+	UserAgent string
+}
+
+type WebAuthnRegistrationBeginIn struct {
+	UserID int64
+}
+
+// WebAuthnRegistrationBeginOut is the options of navigator.credentials.create(), the binary values are base64url encoded.
+type WebAuthnRegistrationBeginOut struct {
+	Challenge       string
+	RPID            string
+	RPName          string
+	UserHandle      string
+	UserName        string
+	UserDisplayName string
+	// Algorithms are COSE identifiers of the supported public key algorithms, preferred first.
+	Algorithms []int
+	// ExcludeCredentialIDs keeps the authenticators from registering the same user twice.
+	ExcludeCredentialIDs []string
+	Timeout              time.Duration
+}
+
+// WebAuthnRegistrationFinishIn carries the response of the authenticator, the binary values are base64url encoded.
+type WebAuthnRegistrationFinishIn struct {
+	UserID            int64
+	Name              string
+	ClientDataJSON    string
+	AttestationObject string
+	IP                string
+	UserAgent         string
+}
+
+type WebAuthnRegistrationFinishOut struct {
+	CredentialID string
+}
+
+// WebAuthnLoginBeginOut is the options of navigator.credentials.get(). The credentials are not listed,
+// so the authenticator offers the passkeys it keeps for the relying party.
+type WebAuthnLoginBeginOut struct {
+	Challenge string
+	RPID      string
+	Timeout   time.Duration
+}
+
+// WebAuthnLoginFinishIn carries the assertion of the authenticator, the binary values are base64url encoded.
+type WebAuthnLoginFinishIn struct {
+	CredentialID      string
+	ClientDataJSON    string
+	AuthenticatorData string
+	Signature         string
+	// UserHandle is optional, it's checked against the owner of the credential if it's set.
+	UserHandle string
+	IP         string
+	UserAgent  string
+}
+
+type MagicLinkSendIn struct {
+	Email string
+}
+
+type MagicLinkConsumeIn struct {
+	Token     string
+	IP        string
+	UserAgent string
+}
+
+type EmailChangeIn struct {
+	UserID    int64
+	NewEmail  string
+	IP        string
+	UserAgent string
+}
+
+type EmailChangeConfirmIn struct {
+	UserID    int64
+	Code      string
+	IP        string
+	UserAgent string
+}
+
+type EmailChangeUndoIn struct {
+	Token     string
+	IP        string
+	UserAgent string
+}
+
+type PasswordForgotIn struct {
+	Email string
+}
+
+type PasswordResetIn struct {
+	Token     string
+	Password  string
+	IP        string
+	UserAgent string
+}
+
+type PasswordChangeIn struct {
+	UserID          int64
+	CurrentPassword string
+	NewPassword     string
+	IP              string
+	UserAgent       string
+}
+
+type PasswordChangeOut struct {
+	AccessToken  string
+	RefreshToken string
 }
 
 type AccessTokenRefreshIn struct {
-	AccessToken string
+	RefreshToken string
+	IP           string
+	UserAgent    string
 }
 
 type AccessTokenRefreshOut struct {
-	AccessToken string
+	AccessToken  string
+	RefreshToken string
 }
 
 type AccessTokenParseIn struct {
@@ -32,5 +213,170 @@ type AccessTokenParseIn struct {
 }
 
 type ParseTokenOut struct {
+	UserID              int64
+	SessionID           string
+	Roles               []string
+	Permissions         []string
+	PersonalAccessToken bool
+}
+
+type UserLogoutIn struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+type UserLogoutEverywhereIn struct {
+	AccessToken string
+}
+
+type SessionListIn struct {
+	UserID    int64
+	SessionID string
+}
+
+type SessionListOut struct {
+	Sessions []Session
+}
+
+type SessionRevokeIn struct {
+	UserID int64
+	ID     string
+}
+
+type PersonalAccessTokenCreateIn struct {
+	UserID int64
+	// Permissions are granted to the request, the scopes of the token are limited by them.
+	Permissions []string
+	Name        string
+	Scopes      []string
+	ExpiresAt   *time.Time
+}
+
+type PersonalAccessTokenCreateOut struct {
+	ID    string
+	Token string
+}
+
+type PersonalAccessTokenListIn struct {
+	UserID int64
+}
+
+type PersonalAccessTokenListOut struct {
+	Tokens []PersonalAccessToken
+}
+
+type PersonalAccessTokenRevokeIn struct {
+	UserID int64
+	ID     string
+}
+
+type JSONWebKeySetGetOut struct {
+	Keys []JSONWebKey
+}
+
+type UserProfileGetIn struct {
+	UserID int64
+}
+
+type UserProfileGetOut struct {
+	Profile UserProfile
+}
+
+// UserProfileUpdateIn changes only the fields which are not nil, an empty value clears the field.
+type UserProfileUpdateIn struct {
+	UserID      int64
+	Handle      *string
+	DisplayName *string
+	Bio         *string
+	Website     *string
+	AvatarURL   *string
+}
+
+type UserProfileUpdateOut struct {
+	Profile UserProfile
+}
+
+type PublicProfileGetIn struct {
+	Handle string
+}
+
+type PublicProfileGetOut struct {
+	Profile PublicProfile
+}
+
+// AuditEventListIn filters the events by the non-zero fields.
+type AuditEventListIn struct {
+	ActorID  int64
+	TargetID int64
+	Type     string
+	Outcome  string
+	From     *time.Time
+	To       *time.Time
+	// Before is NextBefore of the previous page.
+	Before int64
+	Limit  int
+}
+
+type AuditEventListOut struct {
+	Events []AuditEvent
+	// NextBefore is 0 on the last page.
+	NextBefore int64
+}
+
+// AdminUserListIn searches the users by the non-zero fields.
+type AdminUserListIn struct {
+	Query string
+	// Before is NextBefore of the previous page.
+	Before int64
+	Limit  int
+}
+
+type AdminUserListOut struct {
+	Users []AdminUser
+	// NextBefore is 0 on the last page.
+	NextBefore int64
+}
+
+type AdminUserGetIn struct {
 	UserID int64
 }
+
+type AdminUserGetOut struct {
+	User  AdminUser
+	Roles []string
+	// Suspension is nil if the user is not suspended at the moment.
+	Suspension *UserSuspension
+}
+
+// AdminUserActionIn is the action of the admin on the user.
+type AdminUserActionIn struct {
+	AdminID   int64
+	UserID    int64
+	IP        string
+	UserAgent string
+}
+
+// AdminUserSuspendIn bans the user if Until is nil.
+type AdminUserSuspendIn struct {
+	AdminID   int64
+	UserID    int64
+	Reason    string
+	Until     *time.Time
+	IP        string
+	UserAgent string
+}
+
+type InviteCreateIn struct {
+	AdminID        int64
+	MaxUses        int
+	SkipActivation bool
+	ExpiresAt      time.Time
+	IP             string
+	UserAgent      string
+}
+
+type InviteCreateOut struct {
+	ID int64
+	// Code is returned only on the creation.
+	Code string
+}
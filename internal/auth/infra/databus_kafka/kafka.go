@@ -11,7 +11,12 @@ import (
 )
 
 type Client struct {
-	activationEmailWriter *kafka.Writer
+	activationEmailWriter        *kafka.Writer
+	passwordResetEmailWriter     *kafka.Writer
+	passwordChangedEmailWriter   *kafka.Writer
+	magicLinkEmailWriter         *kafka.Writer
+	emailChangeCodeEmailWriter   *kafka.Writer
+	emailChangeNoticeEmailWriter *kafka.Writer
 }
 
 func New(kafkaURL string) *Client {
@@ -21,6 +26,31 @@ func New(kafkaURL string) *Client {
 			Topic:    "auth.activation_codes",
 			Balancer: &kafka.LeastBytes{},
 		},
+		passwordResetEmailWriter: &kafka.Writer{
+			Addr:     kafka.TCP(kafkaURL),
+			Topic:    "auth.password_reset_tokens",
+			Balancer: &kafka.LeastBytes{},
+		},
+		passwordChangedEmailWriter: &kafka.Writer{
+			Addr:     kafka.TCP(kafkaURL),
+			Topic:    "auth.password_changes",
+			Balancer: &kafka.LeastBytes{},
+		},
+		magicLinkEmailWriter: &kafka.Writer{
+			Addr:     kafka.TCP(kafkaURL),
+			Topic:    "auth.magic_link_tokens",
+			Balancer: &kafka.LeastBytes{},
+		},
+		emailChangeCodeEmailWriter: &kafka.Writer{
+			Addr:     kafka.TCP(kafkaURL),
+			Topic:    "auth.email_change_codes",
+			Balancer: &kafka.LeastBytes{},
+		},
+		emailChangeNoticeEmailWriter: &kafka.Writer{
+			Addr:     kafka.TCP(kafkaURL),
+			Topic:    "auth.email_change_notices",
+			Balancer: &kafka.LeastBytes{},
+		},
 	}
 }
 
@@ -40,3 +70,88 @@ func (c *Client) ProduceActivationEmail(ctx context.Context, msg *dto.UserActiva
 
 	return nil
 }
+
+func (c *Client) ProducePasswordResetEmail(ctx context.Context, msg *dto.PasswordResetEmailMessage) error {
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("json marshal error: %w", err)
+	}
+
+	err = c.passwordResetEmailWriter.WriteMessages(ctx, kafka.Message{
+		Key:   []byte("send_email"),
+		Value: value,
+	})
+	if err != nil {
+		return fmt.Errorf("write message to kafka error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) ProducePasswordChangedEmail(ctx context.Context, msg *dto.PasswordChangedEmailMessage) error {
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("json marshal error: %w", err)
+	}
+
+	err = c.passwordChangedEmailWriter.WriteMessages(ctx, kafka.Message{
+		Key:   []byte("send_email"),
+		Value: value,
+	})
+	if err != nil {
+		return fmt.Errorf("write message to kafka error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) ProduceMagicLinkEmail(ctx context.Context, msg *dto.MagicLinkEmailMessage) error {
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("json marshal error: %w", err)
+	}
+
+	err = c.magicLinkEmailWriter.WriteMessages(ctx, kafka.Message{
+		Key:   []byte("send_email"),
+		Value: value,
+	})
+	if err != nil {
+		return fmt.Errorf("write message to kafka error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) ProduceEmailChangeCodeEmail(ctx context.Context, msg *dto.EmailChangeCodeEmailMessage) error {
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("json marshal error: %w", err)
+	}
+
+	err = c.emailChangeCodeEmailWriter.WriteMessages(ctx, kafka.Message{
+		Key:   []byte("send_email"),
+		Value: value,
+	})
+	if err != nil {
+		return fmt.Errorf("write message to kafka error: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) ProduceEmailChangeNoticeEmail(ctx context.Context, msg *dto.EmailChangeNoticeEmailMessage) error {
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("json marshal error: %w", err)
+	}
+
+	err = c.emailChangeNoticeEmailWriter.WriteMessages(ctx, kafka.Message{
+		Key:   []byte("send_email"),
+		Value: value,
+	})
+	if err != nil {
+		return fmt.Errorf("write message to kafka error: %w", err)
+	}
+
+	return nil
+}
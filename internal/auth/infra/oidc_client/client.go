@@ -0,0 +1,119 @@
+package oidc_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/art-es/blog/internal/auth/dto"
+)
+
+// ProviderConfig is the client registered at the OpenID Connect provider.
+type ProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Client signs in users at OpenID Connect providers with the authorization code flow and PKCE
+// https://openid.net/specs/openid-connect-core-1_0.html#CodeFlowAuth
+type Client struct {
+	providers map[string]*provider
+}
+
+// New creates the client. The provider metadata and keys are fetched on the first use.
+func New(httpClient *http.Client, configs ...ProviderConfig) *Client {
+	providers := make(map[string]*provider, len(configs))
+	for _, config := range configs {
+		providers[config.Name] = newProvider(httpClient, config)
+	}
+
+	return &Client{
+		providers: providers,
+	}
+}
+
+func (c *Client) HasProvider(name string) bool {
+	_, ok := c.providers[name]
+	return ok
+}
+
+// AuthorizationURL returns the URL to sign in at the provider.
+func (c *Client) AuthorizationURL(ctx context.Context, name string, request *dto.OIDCRequest) (string, error) {
+	p, ok := c.providers[name]
+	if !ok {
+		return "", dto.ErrOIDCProviderNotFound
+	}
+
+	metadata, err := p.getMetadata(ctx)
+	if err != nil {
+		return "", fmt.Errorf("provider metadata getting error: %w", err)
+	}
+
+	authorizationURL, err := url.Parse(metadata.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("authorization endpoint parsing error: %w", err)
+	}
+
+	query := authorizationURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", request.State)
+	query.Set("nonce", request.Nonce)
+	query.Set("code_challenge", request.CodeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authorizationURL.RawQuery = query.Encode()
+
+	return authorizationURL.String(), nil
+}
+
+// Exchange redeems the code for the ID token and returns the identity asserted by the token.
+// dto.ErrOIDCAuthenticationFailed is returned if the provider rejects the code,
+// the token fails verification or is issued for another authorization request.
+func (c *Client) Exchange(ctx context.Context, name, code, codeVerifier, nonce string) (*dto.OIDCIdentity, error) {
+	p, ok := c.providers[name]
+	if !ok {
+		return nil, dto.ErrOIDCProviderNotFound
+	}
+
+	metadata, err := p.getMetadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("provider metadata getting error: %w", err)
+	}
+
+	idToken, err := p.redeemCode(ctx, metadata.TokenEndpoint, code, codeVerifier)
+	if err != nil {
+		if errors.Is(err, errCodeRejected) {
+			return nil, dto.ErrOIDCAuthenticationFailed
+		}
+		return nil, fmt.Errorf("code redeeming error: %w", err)
+	}
+
+	claims, err := p.verifyIDToken(ctx, metadata.JWKSURI, idToken)
+	if err != nil {
+		if errors.Is(err, errInvalidIDToken) {
+			return nil, dto.ErrOIDCAuthenticationFailed
+		}
+		return nil, fmt.Errorf("id token verification error: %w", err)
+	}
+
+	// the nonce binds the token to the authorization request, so a token issued for another request is rejected
+	if claims.Nonce != nonce {
+		return nil, dto.ErrOIDCAuthenticationFailed
+	}
+
+	return &dto.OIDCIdentity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
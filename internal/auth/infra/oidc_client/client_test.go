@@ -0,0 +1,228 @@
+package oidc_client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain/service/oidc_state"
+	"github.com/art-es/blog/internal/auth/dto"
+	"github.com/art-es/blog/internal/auth/infra/oidc_client"
+)
+
+const (
+	providerName = "example"
+	codeVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	nonce        = "dummyNonce"
+)
+
+var request = &dto.OIDCRequest{
+	State:         "dummyState",
+	Nonce:         nonce,
+	CodeChallenge: oidc_state.CodeChallenge(codeVerifier),
+}
+
+func newClient(p *standInProvider, secret string) *oidc_client.Client {
+	return oidc_client.New(http.DefaultClient, oidc_client.ProviderConfig{
+		Name:         providerName,
+		Issuer:       p.issuer(),
+		ClientID:     clientID,
+		ClientSecret: secret,
+		RedirectURL:  redirectURL,
+		Scopes:       []string{"openid", "email", "profile"},
+	})
+}
+
+func TestClient_AuthorizationURL(t *testing.T) {
+	ctx := context.Background()
+	p := newStandInProvider(t, jwt.SigningMethodRS256)
+	c := newClient(p, clientSecret)
+
+	assert.True(t, c.HasProvider(providerName))
+	assert.False(t, c.HasProvider("unknown"))
+
+	authorizationURL, err := c.AuthorizationURL(ctx, providerName, request)
+	assert.NoError(t, err)
+	assert.Equal(t, p.issuer()+"/authorize"+
+		"?client_id=dummyClientID"+
+		"&code_challenge=E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"+
+		"&code_challenge_method=S256"+
+		"&nonce=dummyNonce"+
+		"&redirect_uri=https%3A%2F%2Fblog.example.com%2Foidc%2Fcallback"+
+		"&response_type=code"+
+		"&scope=openid+email+profile"+
+		"&state=dummyState", authorizationURL)
+
+	_, err = c.AuthorizationURL(ctx, "unknown", request)
+	assert.Equal(t, dto.ErrOIDCProviderNotFound, err)
+}
+
+func TestClient_AuthorizationURL_issuerMismatch(t *testing.T) {
+	p := newStandInProvider(t, jwt.SigningMethodRS256)
+	c := oidc_client.New(http.DefaultClient, oidc_client.ProviderConfig{
+		Name:     providerName,
+		Issuer:   p.issuer() + "/",
+		ClientID: clientID,
+	})
+
+	_, err := c.AuthorizationURL(context.Background(), providerName, request)
+	assert.EqualError(t, err, `provider metadata getting error: unexpected issuer: "`+p.issuer()+`"`)
+}
+
+func TestClient_Exchange(t *testing.T) {
+	ctx := context.Background()
+
+	claims := jwt.MapClaims{
+		"sub":            "dummySubject",
+		"email":          "i.ivanov@example.com",
+		"email_verified": true,
+		"name":           "Ivan Ivanov",
+	}
+	expIdentity := &dto.OIDCIdentity{
+		Subject:       "dummySubject",
+		Email:         "i.ivanov@example.com",
+		EmailVerified: true,
+		Name:          "Ivan Ivanov",
+	}
+
+	authorize := func(p *standInProvider, c *oidc_client.Client, claims jwt.MapClaims) string {
+		authorizationURL, err := c.AuthorizationURL(ctx, providerName, request)
+		assert.NoError(t, err)
+		return p.authorize(authorizationURL, claims)
+	}
+
+	for _, method := range []jwt.SigningMethod{jwt.SigningMethodRS256, jwt.SigningMethodES256} {
+		t.Run("happy path: "+method.Alg(), func(t *testing.T) {
+			p := newStandInProvider(t, method)
+			c := newClient(p, clientSecret)
+			code := authorize(p, c, claims)
+
+			identity, err := c.Exchange(ctx, providerName, code, codeVerifier, nonce)
+			assert.NoError(t, err)
+			assert.Equal(t, expIdentity, identity)
+		})
+	}
+
+	t.Run("happy path: email verified as string", func(t *testing.T) {
+		p := newStandInProvider(t, jwt.SigningMethodRS256)
+		c := newClient(p, clientSecret)
+		code := authorize(p, c, jwt.MapClaims{"sub": "dummySubject", "email": "i.ivanov@example.com", "email_verified": "true"})
+
+		identity, err := c.Exchange(ctx, providerName, code, codeVerifier, nonce)
+		assert.NoError(t, err)
+		assert.Equal(t, &dto.OIDCIdentity{Subject: "dummySubject", Email: "i.ivanov@example.com", EmailVerified: true}, identity)
+	})
+
+	t.Run("provider not found", func(t *testing.T) {
+		p := newStandInProvider(t, jwt.SigningMethodRS256)
+		c := newClient(p, clientSecret)
+
+		identity, err := c.Exchange(ctx, "unknown", "dummyCode", codeVerifier, nonce)
+		assert.Nil(t, identity)
+		assert.Equal(t, dto.ErrOIDCProviderNotFound, err)
+	})
+
+	t.Run("wrong code verifier", func(t *testing.T) {
+		p := newStandInProvider(t, jwt.SigningMethodRS256)
+		c := newClient(p, clientSecret)
+		code := authorize(p, c, claims)
+
+		identity, err := c.Exchange(ctx, providerName, code, "wrongCodeVerifier", nonce)
+		assert.Nil(t, identity)
+		assert.Equal(t, dto.ErrOIDCAuthenticationFailed, err)
+	})
+
+	t.Run("reused code", func(t *testing.T) {
+		p := newStandInProvider(t, jwt.SigningMethodRS256)
+		c := newClient(p, clientSecret)
+		code := authorize(p, c, claims)
+
+		_, err := c.Exchange(ctx, providerName, code, codeVerifier, nonce)
+		assert.NoError(t, err)
+
+		identity, err := c.Exchange(ctx, providerName, code, codeVerifier, nonce)
+		assert.Nil(t, identity)
+		assert.Equal(t, dto.ErrOIDCAuthenticationFailed, err)
+	})
+
+	t.Run("wrong nonce", func(t *testing.T) {
+		p := newStandInProvider(t, jwt.SigningMethodRS256)
+		c := newClient(p, clientSecret)
+		code := authorize(p, c, claims)
+
+		identity, err := c.Exchange(ctx, providerName, code, codeVerifier, "anotherNonce")
+		assert.Nil(t, identity)
+		assert.Equal(t, dto.ErrOIDCAuthenticationFailed, err)
+	})
+
+	t.Run("wrong client secret", func(t *testing.T) {
+		p := newStandInProvider(t, jwt.SigningMethodRS256)
+		c := newClient(p, "wrongClientSecret")
+		code := authorize(p, c, claims)
+
+		identity, err := c.Exchange(ctx, providerName, code, codeVerifier, nonce)
+		assert.Nil(t, identity)
+		assert.Equal(t, dto.ErrOIDCAuthenticationFailed, err)
+	})
+
+	t.Run("tampered token", func(t *testing.T) {
+		p := newStandInProvider(t, jwt.SigningMethodRS256)
+		c := newClient(p, clientSecret)
+		p.tamperTokens()
+		code := authorize(p, c, claims)
+
+		identity, err := c.Exchange(ctx, providerName, code, codeVerifier, nonce)
+		assert.Nil(t, identity)
+		assert.Equal(t, dto.ErrOIDCAuthenticationFailed, err)
+	})
+
+	t.Run("token for another client", func(t *testing.T) {
+		p := newStandInProvider(t, jwt.SigningMethodRS256)
+		c := newClient(p, clientSecret)
+		code := authorize(p, c, jwt.MapClaims{"sub": "dummySubject", "aud": "anotherClientID"})
+
+		identity, err := c.Exchange(ctx, providerName, code, codeVerifier, nonce)
+		assert.Nil(t, identity)
+		assert.Equal(t, dto.ErrOIDCAuthenticationFailed, err)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		p := newStandInProvider(t, jwt.SigningMethodRS256)
+		c := newClient(p, clientSecret)
+		code := authorize(p, c, jwt.MapClaims{"sub": "dummySubject", "exp": 1})
+
+		identity, err := c.Exchange(ctx, providerName, code, codeVerifier, nonce)
+		assert.Nil(t, identity)
+		assert.Equal(t, dto.ErrOIDCAuthenticationFailed, err)
+	})
+
+	t.Run("token without subject", func(t *testing.T) {
+		p := newStandInProvider(t, jwt.SigningMethodRS256)
+		c := newClient(p, clientSecret)
+		code := authorize(p, c, jwt.MapClaims{"email": "i.ivanov@example.com"})
+
+		identity, err := c.Exchange(ctx, providerName, code, codeVerifier, nonce)
+		assert.Nil(t, identity)
+		assert.Equal(t, dto.ErrOIDCAuthenticationFailed, err)
+	})
+
+	t.Run("token signed with unknown key", func(t *testing.T) {
+		p := newStandInProvider(t, jwt.SigningMethodRS256)
+		c := newClient(p, clientSecret)
+
+		code := authorize(p, c, claims)
+		_, err := c.Exchange(ctx, providerName, code, codeVerifier, nonce)
+		assert.NoError(t, err)
+
+		// the keys were fetched recently, so they aren't refetched
+		p.rotateKey("key-2")
+		code = authorize(p, c, claims)
+
+		identity, err := c.Exchange(ctx, providerName, code, codeVerifier, nonce)
+		assert.Nil(t, identity)
+		assert.Equal(t, dto.ErrOIDCAuthenticationFailed, err)
+	})
+}
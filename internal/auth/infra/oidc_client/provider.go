@@ -0,0 +1,339 @@
+package oidc_client
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// keysRefreshInterval limits refetching the keys on tokens with unknown key ids.
+	keysRefreshInterval = time.Minute
+	// leeway tolerates the clock skew with the provider.
+	leeway = time.Minute
+	// maxResponseSize limits reading the provider responses.
+	maxResponseSize = 1 << 20
+)
+
+var (
+	errCodeRejected   = errors.New("code rejected")
+	errInvalidIDToken = errors.New("invalid id token")
+)
+
+// metadata is the provider configuration
+// https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IDToken string `json:"id_token"`
+}
+
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string   `json:"nonce"`
+	Email         string   `json:"email"`
+	EmailVerified flexBool `json:"email_verified"`
+	Name          string   `json:"name"`
+}
+
+// flexBool accepts booleans encoded as strings, which some providers do for email_verified.
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case `true`, `"true"`:
+		*b = true
+	case `false`, `"false"`, `null`:
+		*b = false
+	default:
+		return fmt.Errorf("unexpected boolean: %s", data)
+	}
+	return nil
+}
+
+type provider struct {
+	config     ProviderConfig
+	httpClient *http.Client
+	parserOpts []jwt.ParserOption
+
+	mu            sync.Mutex
+	metadata      *metadata
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+func newProvider(httpClient *http.Client, config ProviderConfig) *provider {
+	return &provider{
+		config:     config,
+		httpClient: httpClient,
+		parserOpts: []jwt.ParserOption{
+			jwt.WithExpirationRequired(),
+			jwt.WithIssuedAt(),
+			jwt.WithLeeway(leeway),
+			jwt.WithAudience(config.ClientID),
+			jwt.WithIssuer(config.Issuer),
+			jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+		},
+	}
+}
+
+// getMetadata fetches the metadata once, it must be issued by the configured issuer.
+func (p *provider) getMetadata(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	var m metadata
+	discoveryURL := strings.TrimSuffix(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := p.getJSON(ctx, discoveryURL, &m); err != nil {
+		return nil, err
+	}
+	if m.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("unexpected issuer: %q", m.Issuer)
+	}
+	if m.AuthorizationEndpoint == "" || m.TokenEndpoint == "" || m.JWKSURI == "" {
+		return nil, errors.New("endpoints are missing")
+	}
+
+	p.metadata = &m
+	return p.metadata, nil
+}
+
+// redeemCode returns the ID token, errCodeRejected is returned if the provider rejects the request with 4xx status.
+func (p *provider) redeemCode(ctx context.Context, tokenEndpoint, code, codeVerifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", p.config.ClientID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		// https://datatracker.ietf.org/doc/html/rfc6749#section-2.3.1
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return "", fmt.Errorf("response reading error: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp tokenErrorResponse
+		json.Unmarshal(body, &errResp)
+		err = fmt.Errorf("unexpected status %d: %s %s", resp.StatusCode, errResp.Error, errResp.ErrorDescription)
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+			return "", fmt.Errorf("%w: %v", errCodeRejected, err)
+		}
+		return "", err
+	}
+
+	var tokenResp tokenResponse
+	if err = json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("response decoding error: %w", err)
+	}
+	if tokenResp.IDToken == "" {
+		return "", errors.New("id token is missing")
+	}
+
+	return tokenResp.IDToken, nil
+}
+
+// verifyIDToken returns errInvalidIDToken if the token is rejected, e.g. is expired or is signed with an unknown key.
+// Failing to fetch the keys is not the token fault, so the error is returned as is.
+func (p *provider) verifyIDToken(ctx context.Context, jwksURI, idToken string) (*idTokenClaims, error) {
+	claims := &idTokenClaims{}
+
+	var fetchErr error
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := p.getKey(ctx, jwksURI, kid)
+		if err != nil && !errors.Is(err, errInvalidIDToken) {
+			fetchErr = err
+		}
+		return key, err
+	}
+
+	if _, err := jwt.ParseWithClaims(idToken, claims, keyFunc, p.parserOpts...); err != nil {
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+		return nil, fmt.Errorf("%w: %v", errInvalidIDToken, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: subject is empty", errInvalidIDToken)
+	}
+
+	return claims, nil
+}
+
+// getKey returns the key the token is signed with.
+// The keys are refetched on an unknown key id, so the keys rotation at the provider is followed.
+func (p *provider) getKey(ctx context.Context, jwksURI, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.findKey(kid); ok {
+		return key, nil
+	}
+
+	if time.Since(p.keysFetchedAt) < keysRefreshInterval {
+		return nil, fmt.Errorf("%w: unknown key id: %q", errInvalidIDToken, kid)
+	}
+
+	var set jsonWebKeySet
+	if err := p.getJSON(ctx, jwksURI, &set); err != nil {
+		return nil, fmt.Errorf("keys fetching error: %w", err)
+	}
+
+	p.keys = make(map[string]crypto.PublicKey, len(set.Keys))
+	p.keysFetchedAt = time.Now()
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		// unsupported keys are skipped, the provider may publish keys for other clients
+		if key, err := jwk.publicKey(); err == nil {
+			p.keys[jwk.KeyID] = key
+		}
+	}
+
+	if key, ok := p.findKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown key id: %q", errInvalidIDToken, kid)
+}
+
+// findKey allows tokens without the key id if the provider has the only key.
+func (p *provider) findKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+func (p *provider) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v); err != nil {
+		return fmt.Errorf("response decoding error: %w", err)
+	}
+	return nil
+}
+
+// publicKey supports RSA and P-256 keys, which are used with RS256 and ES256.
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() {
+			return nil, errors.New("exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Curve != "P-256" {
+			return nil, fmt.Errorf("unsupported curve: %s", k.Curve)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return nil, errors.New("point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", k.KeyType)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,220 @@
+package oidc_client_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	clientID     = "dummyClientID"
+	clientSecret = "dummyClientSecret"
+	redirectURL  = "https://blog.example.com/oidc/callback"
+)
+
+// standInProvider is a local OpenID Connect provider. The user signs in at it with authorize,
+// the rest of the flow goes over HTTP.
+type standInProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	method jwt.SigningMethod
+	keyID  string
+	key    crypto.Signer
+
+	mu     sync.Mutex
+	grants map[string]*grant
+	tamper bool
+}
+
+type grant struct {
+	codeChallenge string
+	claims        jwt.MapClaims
+}
+
+func newStandInProvider(t *testing.T, method jwt.SigningMethod) *standInProvider {
+	p := &standInProvider{
+		t:      t,
+		method: method,
+		grants: make(map[string]*grant),
+	}
+	p.rotateKey("key-1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.handleDiscovery)
+	mux.HandleFunc("/token", p.handleToken)
+	mux.HandleFunc("/jwks", p.handleKeys)
+
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	return p
+}
+
+func (p *standInProvider) issuer() string {
+	return p.server.URL
+}
+
+// rotateKey replaces the signing key, the previous key isn't published anymore.
+func (p *standInProvider) rotateKey(keyID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var err error
+	switch p.method {
+	case jwt.SigningMethodRS256:
+		p.key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case jwt.SigningMethodES256:
+		p.key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	}
+	assert.NoError(p.t, err)
+	p.keyID = keyID
+}
+
+// tamperTokens makes the provider replace the claims of the issued ID tokens keeping the signature.
+func (p *standInProvider) tamperTokens() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tamper = true
+}
+
+// authorize signs in the user following the authorization URL and returns the code.
+func (p *standInProvider) authorize(authorizationURL string, claims jwt.MapClaims) string {
+	u, err := url.Parse(authorizationURL)
+	assert.NoError(p.t, err)
+
+	query := u.Query()
+	assert.Equal(p.t, p.server.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(p.t, "code", query.Get("response_type"))
+	assert.Equal(p.t, clientID, query.Get("client_id"))
+	assert.Equal(p.t, redirectURL, query.Get("redirect_uri"))
+	assert.Equal(p.t, "S256", query.Get("code_challenge_method"))
+
+	idClaims := jwt.MapClaims{
+		"iss":   p.issuer(),
+		"aud":   clientID,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nonce": query.Get("nonce"),
+	}
+	for k, v := range claims {
+		idClaims[k] = v
+	}
+
+	code := randomString(p.t)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.grants[code] = &grant{
+		codeChallenge: query.Get("code_challenge"),
+		claims:        idClaims,
+	}
+
+	return code
+}
+
+func (p *standInProvider) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 p.issuer(),
+		"authorization_endpoint": p.server.URL + "/authorize",
+		"token_endpoint":         p.server.URL + "/token",
+		"jwks_uri":               p.server.URL + "/jwks",
+	})
+}
+
+func (p *standInProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != clientID || secret != clientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	if r.Method != http.MethodPost ||
+		r.PostFormValue("grant_type") != "authorization_code" ||
+		r.PostFormValue("redirect_uri") != redirectURL {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// the code is single-use even if the verifier is wrong
+	g, ok := p.grants[r.PostFormValue("code")]
+	delete(p.grants, r.PostFormValue("code"))
+
+	sum := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != g.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	token := jwt.NewWithClaims(p.method, g.claims)
+	token.Header["kid"] = p.keyID
+	idToken, err := token.SignedString(p.key)
+	assert.NoError(p.t, err)
+
+	if p.tamper {
+		parts := strings.Split(idToken, ".")
+		g.claims["sub"] = "anotherSubject"
+		payload, err := json.Marshal(g.claims)
+		assert.NoError(p.t, err)
+		parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+		idToken = strings.Join(parts, ".")
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": "dummyAccessToken",
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func (p *standInProvider) handleKeys(w http.ResponseWriter, _ *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	jwk := map[string]string{"kid": p.keyID, "use": "sig", "alg": p.method.Alg()}
+	switch key := p.key.Public().(type) {
+	case *rsa.PublicKey:
+		jwk["kty"] = "RSA"
+		jwk["n"] = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+		jwk["e"] = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	case *ecdsa.PublicKey:
+		jwk["kty"] = "EC"
+		jwk["crv"] = "P-256"
+		jwk["x"] = base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32)))
+		jwk["y"] = base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32)))
+	}
+
+	encryptionKey := map[string]string{"kid": "enc", "use": "enc", "kty": "RSA"}
+	writeJSON(w, http.StatusOK, map[string]any{"keys": []any{encryptionKey, jwk}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func randomString(t *testing.T) string {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	assert.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,79 @@
+package repository_memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/domain"
+)
+
+// minPurgeSize keeps small maps from being purged on every failure.
+const minPurgeSize = 1024
+
+// LoginAttemptRepository keeps the attempts in the process memory,
+// so they are neither shared between instances nor survive restarts.
+type LoginAttemptRepository struct {
+	mu        sync.Mutex
+	attempts  map[string]domain.LoginAttempts
+	purgeSize int
+}
+
+func NewLoginAttemptRepository() *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		attempts:  make(map[string]domain.LoginAttempts),
+		purgeSize: minPurgeSize,
+	}
+}
+
+// AddFailure increments the failures. Forgotten entries are purged whenever the map doubles in size.
+func (r *LoginAttemptRepository) AddFailure(_ context.Context, key string, failedAt, resetBefore time.Time) (*domain.LoginAttempts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempts, ok := r.attempts[key]
+	if !ok || attempts.LastFailedAt.Before(resetBefore) {
+		attempts = domain.LoginAttempts{Key: key}
+	}
+	attempts.Failures++
+	attempts.LastFailedAt = failedAt
+	r.attempts[key] = attempts
+
+	if len(r.attempts) >= r.purgeSize {
+		r.purge(resetBefore)
+	}
+
+	return &attempts, nil
+}
+
+func (r *LoginAttemptRepository) Get(_ context.Context, key string) (*domain.LoginAttempts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempts, ok := r.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+	return &attempts, nil
+}
+
+func (r *LoginAttemptRepository) Remove(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
+
+func (r *LoginAttemptRepository) purge(resetBefore time.Time) {
+	for key, attempts := range r.attempts {
+		if attempts.LastFailedAt.Before(resetBefore) {
+			delete(r.attempts, key)
+		}
+	}
+
+	r.purgeSize = 2 * len(r.attempts)
+	if r.purgeSize < minPurgeSize {
+		r.purgeSize = minPurgeSize
+	}
+}
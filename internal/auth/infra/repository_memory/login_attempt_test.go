@@ -0,0 +1,50 @@
+package repository_memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/auth/infra/repository_memory"
+)
+
+func TestLoginAttemptRepository(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		key         = "account:i.ivanov@example.com"
+		now         = time.Now()
+		resetBefore = now.Add(-time.Hour)
+	)
+
+	r := repository_memory.NewLoginAttemptRepository()
+
+	attempts, err := r.Get(ctx, key)
+	assert.NoError(t, err)
+	assert.Nil(t, attempts)
+
+	attempts, err = r.AddFailure(ctx, key, now.Add(-2*time.Hour), resetBefore.Add(-2*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.LoginAttempts{Key: key, Failures: 1, LastFailedAt: now.Add(-2 * time.Hour)}, attempts)
+
+	// the previous failure is forgotten
+	attempts, err = r.AddFailure(ctx, key, now.Add(-time.Minute), resetBefore)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.LoginAttempts{Key: key, Failures: 1, LastFailedAt: now.Add(-time.Minute)}, attempts)
+
+	attempts, err = r.AddFailure(ctx, key, now, resetBefore)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.LoginAttempts{Key: key, Failures: 2, LastFailedAt: now}, attempts)
+
+	attempts, err = r.Get(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.LoginAttempts{Key: key, Failures: 2, LastFailedAt: now}, attempts)
+
+	assert.NoError(t, r.Remove(ctx, key))
+
+	attempts, err = r.Get(ctx, key)
+	assert.NoError(t, err)
+	assert.Nil(t, attempts)
+}
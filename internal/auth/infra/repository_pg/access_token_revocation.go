@@ -0,0 +1,53 @@
+package repository_pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type accessTokenRevocationRepository struct {
+	conn pg.Conn
+}
+
+func newAccessTokenRevocationRepository(conn pg.Conn) *accessTokenRevocationRepository {
+	return &accessTokenRevocationRepository{conn: conn}
+}
+
+// AddToken revokes the single token. Expired entries are purged on the way.
+func (r *accessTokenRevocationRepository) AddToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	const query = `WITH purged AS (DELETE FROM revoked_access_token WHERE expires_at <= now())
+		INSERT INTO revoked_access_token (token_id, expires_at) VALUES ($1, $2)
+		ON CONFLICT (token_id) DO NOTHING`
+	_, err := r.conn.ExecContext(ctx, query, tokenID, expiresAt)
+	return err
+}
+
+// AddUser revokes all the user tokens issued before the time. Expired entries are purged on the way.
+func (r *accessTokenRevocationRepository) AddUser(ctx context.Context, userID int64, revokedBefore, expiresAt time.Time) error {
+	const query = `WITH purged AS (DELETE FROM revoked_user_access_token WHERE expires_at <= now())
+		INSERT INTO revoked_user_access_token (user_id, revoked_before, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET revoked_before=EXCLUDED.revoked_before, expires_at=EXCLUDED.expires_at`
+	_, err := r.conn.ExecContext(ctx, query, userID, revokedBefore, expiresAt)
+	return err
+}
+
+// IsRevoked checks both the token and the user revocations.
+// The token issued within the second of the user revocation is revoked too, since the issued time has seconds precision.
+func (r *accessTokenRevocationRepository) IsRevoked(ctx context.Context, tokenID string, userID int64, issuedAt time.Time) (bool, error) {
+	const query = `SELECT 
+		EXISTS(SELECT 1 FROM revoked_access_token WHERE token_id=$1 AND expires_at > now()) 
+		OR EXISTS(SELECT 1 FROM revoked_user_access_token 
+			WHERE user_id=$2 AND revoked_before >= $3 AND expires_at > now())`
+	var revoked bool
+	err := r.conn.QueryRowContext(ctx, query, tokenID, userID, issuedAt).Scan(&revoked)
+	return revoked, err
+}
+
+func (r *accessTokenRevocationRepository) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	const query = `SELECT EXISTS(SELECT 1 FROM revoked_access_token WHERE token_id=$1 AND expires_at > now())`
+	var revoked bool
+	err := r.conn.QueryRowContext(ctx, query, tokenID).Scan(&revoked)
+	return revoked, err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/art-es/blog/internal/auth/domain"
 	"github.com/art-es/blog/internal/common/repository/pg"
 )
 
@@ -21,15 +22,27 @@ func (r *activationCodeRepository) Add(ctx context.Context, code string, userID
 	return err
 }
 
-func (r *activationCodeRepository) GetUserID(ctx context.Context, code string) (int64, error) {
-	const query = `SELECT user_id FROM activation_code WHERE code=$1`
-	var userID int64
+func (r *activationCodeRepository) Get(ctx context.Context, code string) (*domain.ActivationCode, error) {
+	const query = `SELECT code, user_id, created_at FROM activation_code WHERE code=$1`
+	activationCode := &domain.ActivationCode{}
 	err := r.conn.QueryRowContext(ctx, query, code).
-		Scan(&userID)
+		Scan(&activationCode.Code, &activationCode.UserID, &activationCode.CreatedAt)
 	if err == sql.ErrNoRows {
-		return 0, nil
+		return nil, nil
 	}
-	return userID, err
+	return activationCode, err
+}
+
+func (r *activationCodeRepository) GetLatest(ctx context.Context, userID int64) (*domain.ActivationCode, error) {
+	const query = `SELECT code, user_id, created_at FROM activation_code 
+		WHERE user_id=$1 ORDER BY created_at DESC LIMIT 1`
+	activationCode := &domain.ActivationCode{}
+	err := r.conn.QueryRowContext(ctx, query, userID).
+		Scan(&activationCode.Code, &activationCode.UserID, &activationCode.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return activationCode, err
 }
 
 func (r *activationCodeRepository) RemoveCodes(ctx context.Context, userID int64) error {
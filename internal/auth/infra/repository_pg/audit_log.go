@@ -0,0 +1,59 @@
+package repository_pg
+
+import (
+	"context"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type auditLogRepository struct {
+	conn pg.Conn
+}
+
+func newAuditLogRepository(conn pg.Conn) *auditLogRepository {
+	return &auditLogRepository{conn: conn}
+}
+
+// Add stores unknown actors and targets as NULL.
+func (r *auditLogRepository) Add(ctx context.Context, event *domain.AuditEvent) error {
+	const query = `INSERT INTO audit_event (type, actor_id, target_id, ip, user_agent, outcome, details) 
+		VALUES ($1, NULLIF($2, 0), NULLIF($3, 0), $4, $5, $6, $7) RETURNING id, created_at`
+	return r.conn.QueryRowContext(ctx, query,
+		event.Type, event.ActorID, event.TargetID, event.IP, event.UserAgent, event.Outcome, event.Details).
+		Scan(&event.ID, &event.CreatedAt)
+}
+
+// Find skips the conditions of the zero fields of the filter.
+func (r *auditLogRepository) Find(ctx context.Context, filter *domain.AuditEventFilter) ([]*domain.AuditEvent, error) {
+	const query = `SELECT id, type, COALESCE(actor_id, 0), COALESCE(target_id, 0), ip, user_agent, outcome, details, created_at 
+		FROM audit_event 
+		WHERE ($1 = 0 OR actor_id = $1) 
+			AND ($2 = 0 OR target_id = $2) 
+			AND ($3 = '' OR type = $3) 
+			AND ($4 = '' OR outcome = $4) 
+			AND ($5::TIMESTAMPTZ IS NULL OR created_at >= $5) 
+			AND ($6::TIMESTAMPTZ IS NULL OR created_at < $6) 
+			AND ($7 = 0 OR id < $7) 
+		ORDER BY id DESC 
+		LIMIT $8`
+	rows, err := r.conn.QueryContext(ctx, query,
+		filter.ActorID, filter.TargetID, filter.Type, filter.Outcome, filter.From, filter.To, filter.BeforeID, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*domain.AuditEvent
+	for rows.Next() {
+		event := &domain.AuditEvent{}
+		err = rows.Scan(&event.ID, &event.Type, &event.ActorID, &event.TargetID, &event.IP, &event.UserAgent,
+			&event.Outcome, &event.Details, &event.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
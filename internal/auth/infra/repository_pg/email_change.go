@@ -0,0 +1,69 @@
+package repository_pg
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type emailChangeRepository struct {
+	conn pg.Conn
+}
+
+func newEmailChangeRepository(conn pg.Conn) *emailChangeRepository {
+	return &emailChangeRepository{conn: conn}
+}
+
+func (r *emailChangeRepository) Add(ctx context.Context, change *domain.EmailChange) error {
+	const query = `INSERT INTO email_change (user_id, old_email, new_email, code_hash, expires_at) 
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	return r.conn.QueryRowContext(ctx, query,
+		change.UserID, change.OldEmail, change.NewEmail, change.CodeHash, change.ExpiresAt).
+		Scan(&change.ID)
+}
+
+func (r *emailChangeRepository) GetPending(ctx context.Context, userID int64, codeHash string) (*domain.EmailChange, error) {
+	const query = `SELECT id, user_id, old_email, new_email, code_hash, expires_at FROM email_change 
+		WHERE user_id=$1 AND code_hash=$2 AND undo_token_hash IS NULL`
+	change := &domain.EmailChange{}
+	err := r.conn.QueryRowContext(ctx, query, userID, codeHash).
+		Scan(&change.ID, &change.UserID, &change.OldEmail, &change.NewEmail, &change.CodeHash, &change.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return change, err
+}
+
+func (r *emailChangeRepository) RemovePending(ctx context.Context, userID int64) error {
+	const query = `DELETE FROM email_change WHERE user_id=$1 AND undo_token_hash IS NULL`
+	_, err := r.conn.ExecContext(ctx, query, userID)
+	return err
+}
+
+func (r *emailChangeRepository) Confirm(ctx context.Context, id int64, undoTokenHash string, undoExpiresAt time.Time) error {
+	const query = `UPDATE email_change SET undo_token_hash=$2, undo_expires_at=$3 WHERE id=$1`
+	_, err := r.conn.ExecContext(ctx, query, id, undoTokenHash, undoExpiresAt)
+	return err
+}
+
+func (r *emailChangeRepository) TakeConfirmed(ctx context.Context, undoTokenHash string) (*domain.EmailChange, error) {
+	const query = `DELETE FROM email_change WHERE undo_token_hash=$1 
+		RETURNING id, user_id, old_email, new_email, code_hash, expires_at, undo_token_hash, undo_expires_at`
+	change := &domain.EmailChange{}
+	err := r.conn.QueryRowContext(ctx, query, undoTokenHash).
+		Scan(&change.ID, &change.UserID, &change.OldEmail, &change.NewEmail, &change.CodeHash, &change.ExpiresAt,
+			&change.UndoTokenHash, &change.UndoExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return change, err
+}
+
+func (r *emailChangeRepository) RemoveUserChanges(ctx context.Context, userID int64) error {
+	const query = `DELETE FROM email_change WHERE user_id=$1`
+	_, err := r.conn.ExecContext(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,34 @@
+package repository_pg
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type externalIdentityRepository struct {
+	conn pg.Conn
+}
+
+func newExternalIdentityRepository(conn pg.Conn) *externalIdentityRepository {
+	return &externalIdentityRepository{conn: conn}
+}
+
+func (r *externalIdentityRepository) Add(ctx context.Context, identity *domain.ExternalIdentity) error {
+	const query = `INSERT INTO user_external_identity (provider, subject, user_id) VALUES ($1, $2, $3)`
+	_, err := r.conn.ExecContext(ctx, query, identity.Provider, identity.Subject, identity.UserID)
+	return err
+}
+
+func (r *externalIdentityRepository) Get(ctx context.Context, provider, subject string) (*domain.ExternalIdentity, error) {
+	const query = `SELECT provider, subject, user_id FROM user_external_identity WHERE provider=$1 AND subject=$2`
+	identity := &domain.ExternalIdentity{}
+	err := r.conn.QueryRowContext(ctx, query, provider, subject).
+		Scan(&identity.Provider, &identity.Subject, &identity.UserID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return identity, err
+}
@@ -0,0 +1,37 @@
+package repository_pg
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type inviteRepository struct {
+	conn pg.Conn
+}
+
+func newInviteRepository(conn pg.Conn) *inviteRepository {
+	return &inviteRepository{conn: conn}
+}
+
+func (r *inviteRepository) Add(ctx context.Context, invite *domain.Invite) error {
+	const query = `INSERT INTO invite (code_hash, max_uses, skip_activation, expires_at, created_by) 
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	return r.conn.QueryRowContext(ctx, query,
+		invite.CodeHash, invite.MaxUses, invite.SkipActivation, invite.ExpiresAt, invite.CreatedBy).
+		Scan(&invite.ID)
+}
+
+func (r *inviteRepository) Use(ctx context.Context, codeHash string) (*domain.Invite, error) {
+	const query = `UPDATE invite SET uses=uses+1 WHERE code_hash=$1 AND uses<max_uses 
+		RETURNING id, code_hash, max_uses, uses, skip_activation, expires_at, created_by`
+	invite := &domain.Invite{}
+	err := r.conn.QueryRowContext(ctx, query, codeHash).
+		Scan(&invite.ID, &invite.CodeHash, &invite.MaxUses, &invite.Uses, &invite.SkipActivation, &invite.ExpiresAt, &invite.CreatedBy)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return invite, err
+}
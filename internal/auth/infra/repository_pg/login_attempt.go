@@ -0,0 +1,52 @@
+package repository_pg
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type loginAttemptRepository struct {
+	conn pg.Conn
+}
+
+func newLoginAttemptRepository(conn pg.Conn) *loginAttemptRepository {
+	return &loginAttemptRepository{conn: conn}
+}
+
+// AddFailure increments the failures atomically. Forgotten entries are purged on the way.
+func (r *loginAttemptRepository) AddFailure(ctx context.Context, key string, failedAt, resetBefore time.Time) (*domain.LoginAttempts, error) {
+	const query = `WITH purged AS (DELETE FROM login_attempt WHERE last_failed_at < $3 AND key <> $1)
+		INSERT INTO login_attempt (key, failures, last_failed_at) VALUES ($1, 1, $2)
+		ON CONFLICT (key) DO UPDATE SET 
+			failures=CASE WHEN login_attempt.last_failed_at < $3 THEN 1 ELSE login_attempt.failures + 1 END,
+			last_failed_at=EXCLUDED.last_failed_at
+		RETURNING key, failures, last_failed_at`
+	attempts := &domain.LoginAttempts{}
+	err := r.conn.QueryRowContext(ctx, query, key, failedAt, resetBefore).
+		Scan(&attempts.Key, &attempts.Failures, &attempts.LastFailedAt)
+	if err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+func (r *loginAttemptRepository) Get(ctx context.Context, key string) (*domain.LoginAttempts, error) {
+	const query = `SELECT key, failures, last_failed_at FROM login_attempt WHERE key=$1`
+	attempts := &domain.LoginAttempts{}
+	err := r.conn.QueryRowContext(ctx, query, key).
+		Scan(&attempts.Key, &attempts.Failures, &attempts.LastFailedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return attempts, err
+}
+
+func (r *loginAttemptRepository) Remove(ctx context.Context, key string) error {
+	const query = `DELETE FROM login_attempt WHERE key=$1`
+	_, err := r.conn.ExecContext(ctx, query, key)
+	return err
+}
@@ -0,0 +1,41 @@
+package repository_pg
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type magicLinkTokenRepository struct {
+	conn pg.Conn
+}
+
+func newMagicLinkTokenRepository(conn pg.Conn) *magicLinkTokenRepository {
+	return &magicLinkTokenRepository{conn: conn}
+}
+
+func (r *magicLinkTokenRepository) Add(ctx context.Context, token *domain.MagicLinkToken) error {
+	const query = `INSERT INTO magic_link_token (token_hash, user_id, email, expires_at) VALUES ($1, $2, $3, $4)`
+	_, err := r.conn.ExecContext(ctx, query, token.TokenHash, token.UserID, token.Email, token.ExpiresAt)
+	return err
+}
+
+func (r *magicLinkTokenRepository) Take(ctx context.Context, tokenHash string) (*domain.MagicLinkToken, error) {
+	const query = `DELETE FROM magic_link_token WHERE token_hash=$1 
+		RETURNING token_hash, user_id, email, expires_at`
+	token := &domain.MagicLinkToken{}
+	err := r.conn.QueryRowContext(ctx, query, tokenHash).
+		Scan(&token.TokenHash, &token.UserID, &token.Email, &token.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return token, err
+}
+
+func (r *magicLinkTokenRepository) RemoveUserTokens(ctx context.Context, userID int64) error {
+	const query = `DELETE FROM magic_link_token WHERE user_id=$1`
+	_, err := r.conn.ExecContext(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,37 @@
+package repository_pg
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type oidcStateRepository struct {
+	conn pg.Conn
+}
+
+func newOIDCStateRepository(conn pg.Conn) *oidcStateRepository {
+	return &oidcStateRepository{conn: conn}
+}
+
+// Add adds the state. Expired states are purged on the way.
+func (r *oidcStateRepository) Add(ctx context.Context, state *domain.OIDCState) error {
+	const query = `WITH purged AS (DELETE FROM oidc_state WHERE expires_at <= now())
+		INSERT INTO oidc_state (state_hash, provider, code_verifier, nonce, expires_at) VALUES ($1, $2, $3, $4, $5)`
+	_, err := r.conn.ExecContext(ctx, query, state.StateHash, state.Provider, state.CodeVerifier, state.Nonce, state.ExpiresAt)
+	return err
+}
+
+func (r *oidcStateRepository) Take(ctx context.Context, stateHash string) (*domain.OIDCState, error) {
+	const query = `DELETE FROM oidc_state WHERE state_hash=$1 
+		RETURNING state_hash, provider, code_verifier, nonce, expires_at`
+	state := &domain.OIDCState{}
+	err := r.conn.QueryRowContext(ctx, query, stateHash).
+		Scan(&state.StateHash, &state.Provider, &state.CodeVerifier, &state.Nonce, &state.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return state, err
+}
@@ -0,0 +1,41 @@
+package repository_pg
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type passwordResetTokenRepository struct {
+	conn pg.Conn
+}
+
+func newPasswordResetTokenRepository(conn pg.Conn) *passwordResetTokenRepository {
+	return &passwordResetTokenRepository{conn: conn}
+}
+
+func (r *passwordResetTokenRepository) Add(ctx context.Context, token *domain.PasswordResetToken) error {
+	const query = `INSERT INTO password_reset_token (token_hash, user_id, expires_at) VALUES ($1, $2, $3)`
+	_, err := r.conn.ExecContext(ctx, query, token.TokenHash, token.UserID, token.ExpiresAt)
+	return err
+}
+
+func (r *passwordResetTokenRepository) Take(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	const query = `DELETE FROM password_reset_token WHERE token_hash=$1 
+		RETURNING token_hash, user_id, expires_at`
+	token := &domain.PasswordResetToken{}
+	err := r.conn.QueryRowContext(ctx, query, tokenHash).
+		Scan(&token.TokenHash, &token.UserID, &token.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return token, err
+}
+
+func (r *passwordResetTokenRepository) RemoveUserTokens(ctx context.Context, userID int64) error {
+	const query = `DELETE FROM password_reset_token WHERE user_id=$1`
+	_, err := r.conn.ExecContext(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,106 @@
+package repository_pg
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+// scopesSeparator joins the scopes in a single column like the scope parameter of OAuth 2.0.
+const scopesSeparator = " "
+
+type personalAccessTokenRepository struct {
+	conn pg.Conn
+}
+
+func newPersonalAccessTokenRepository(conn pg.Conn) *personalAccessTokenRepository {
+	return &personalAccessTokenRepository{conn: conn}
+}
+
+func (r *personalAccessTokenRepository) Add(ctx context.Context, token *domain.PersonalAccessToken) error {
+	const query = `INSERT INTO personal_access_token (id, user_id, name, token_hash, scopes, created_at, expires_at) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	var expiresAt sql.NullTime
+	if token.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: *token.ExpiresAt, Valid: true}
+	}
+	_, err := r.conn.ExecContext(ctx, query,
+		token.ID, token.UserID, token.Name, token.TokenHash,
+		strings.Join(token.Scopes, scopesSeparator), token.CreatedAt, expiresAt)
+	return err
+}
+
+func (r *personalAccessTokenRepository) Get(ctx context.Context, id string) (*domain.PersonalAccessToken, error) {
+	const query = `SELECT id, user_id, name, token_hash, scopes, created_at, expires_at, revoked 
+		FROM personal_access_token WHERE id=$1`
+	return r.getOne(ctx, query, id)
+}
+
+func (r *personalAccessTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	const query = `SELECT id, user_id, name, token_hash, scopes, created_at, expires_at, revoked 
+		FROM personal_access_token WHERE token_hash=$1`
+	return r.getOne(ctx, query, tokenHash)
+}
+
+func (r *personalAccessTokenRepository) GetActive(ctx context.Context, userID int64) ([]*domain.PersonalAccessToken, error) {
+	const query = `SELECT id, user_id, name, token_hash, scopes, created_at, expires_at, revoked 
+		FROM personal_access_token 
+		WHERE user_id=$1 AND revoked=FALSE AND (expires_at IS NULL OR expires_at > now()) 
+		ORDER BY created_at DESC`
+	rows, err := r.conn.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*domain.PersonalAccessToken
+	for rows.Next() {
+		token, err := scanPersonalAccessToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
+func (r *personalAccessTokenRepository) Revoke(ctx context.Context, id string) error {
+	const query = `UPDATE personal_access_token SET revoked=TRUE WHERE id=$1`
+	_, err := r.conn.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *personalAccessTokenRepository) getOne(ctx context.Context, query string, arg any) (*domain.PersonalAccessToken, error) {
+	token, err := scanPersonalAccessToken(r.conn.QueryRowContext(ctx, query, arg))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return token, err
+}
+
+func scanPersonalAccessToken(row interface{ Scan(dest ...any) error }) (*domain.PersonalAccessToken, error) {
+	var (
+		token     domain.PersonalAccessToken
+		scopes    string
+		expiresAt sql.NullTime
+	)
+
+	err := row.Scan(&token.ID, &token.UserID, &token.Name, &token.TokenHash,
+		&scopes, &token.CreatedAt, &expiresAt, &token.Revoked)
+	if err != nil {
+		return nil, err
+	}
+
+	if scopes != "" {
+		token.Scopes = strings.Split(scopes, scopesSeparator)
+	}
+	if expiresAt.Valid {
+		token.ExpiresAt = &expiresAt.Time
+	}
+
+	return &token, nil
+}
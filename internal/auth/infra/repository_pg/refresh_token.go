@@ -0,0 +1,65 @@
+package repository_pg
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type refreshTokenRepository struct {
+	conn pg.Conn
+}
+
+func newRefreshTokenRepository(conn pg.Conn) *refreshTokenRepository {
+	return &refreshTokenRepository{conn: conn}
+}
+
+func (r *refreshTokenRepository) Add(ctx context.Context, token *domain.RefreshToken) error {
+	const query = `INSERT INTO refresh_token (token_hash, family_id, user_id, expires_at, family_expires_at) 
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	return r.conn.QueryRowContext(ctx, query,
+		token.TokenHash, token.FamilyID, token.UserID, token.ExpiresAt, token.FamilyExpiresAt).
+		Scan(&token.ID)
+}
+
+func (r *refreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	const query = `SELECT id, token_hash, family_id, user_id, expires_at, family_expires_at, used, revoked 
+		FROM refresh_token WHERE token_hash=$1`
+	token := &domain.RefreshToken{}
+	err := r.conn.QueryRowContext(ctx, query, tokenHash).
+		Scan(&token.ID, &token.TokenHash, &token.FamilyID, &token.UserID,
+			&token.ExpiresAt, &token.FamilyExpiresAt, &token.Used, &token.Revoked)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return token, err
+}
+
+func (r *refreshTokenRepository) MarkUsed(ctx context.Context, id int64) (bool, error) {
+	const query = `UPDATE refresh_token SET used=TRUE WHERE id=$1 AND used=FALSE`
+	result, err := r.conn.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	const query = `UPDATE refresh_token SET revoked=TRUE WHERE family_id=$1`
+	_, err := r.conn.ExecContext(ctx, query, familyID)
+	return err
+}
+
+func (r *refreshTokenRepository) RevokeUser(ctx context.Context, userID int64) error {
+	const query = `UPDATE refresh_token SET revoked=TRUE WHERE user_id=$1 AND revoked=FALSE`
+	_, err := r.conn.ExecContext(ctx, query, userID)
+	return err
+}
@@ -1,6 +1,7 @@
 package repository_pg
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/art-es/blog/internal/auth/domain"
@@ -27,6 +28,94 @@ func (r *Repository) User() domain.UserRepository {
 	return newUserRepository(r.Conn())
 }
 
+func (r *Repository) Role() domain.RoleRepository {
+	return newRoleRepository(r.Conn())
+}
+
+func (r *Repository) Suspension() domain.SuspensionRepository {
+	return newSuspensionRepository(r.Conn())
+}
+
+func (r *Repository) Invite() domain.InviteRepository {
+	return newInviteRepository(r.Conn())
+}
+
 func (r *Repository) ActivationCode() domain.ActivationCodeRepository {
 	return newActivationCodeRepository(r.Conn())
 }
+
+func (r *Repository) PasswordResetToken() domain.PasswordResetTokenRepository {
+	return newPasswordResetTokenRepository(r.Conn())
+}
+
+func (r *Repository) MagicLinkToken() domain.MagicLinkTokenRepository {
+	return newMagicLinkTokenRepository(r.Conn())
+}
+
+func (r *Repository) EmailChange() domain.EmailChangeRepository {
+	return newEmailChangeRepository(r.Conn())
+}
+
+func (r *Repository) TwoFactor() domain.TwoFactorRepository {
+	return newTwoFactorRepository(r.Conn())
+}
+
+func (r *Repository) TwoFactorChallenge() domain.TwoFactorChallengeRepository {
+	return newTwoFactorChallengeRepository(r.Conn())
+}
+
+func (r *Repository) ExternalIdentity() domain.ExternalIdentityRepository {
+	return newExternalIdentityRepository(r.Conn())
+}
+
+func (r *Repository) OIDCState() domain.OIDCStateRepository {
+	return newOIDCStateRepository(r.Conn())
+}
+
+func (r *Repository) WebAuthnCredential() domain.WebAuthnCredentialRepository {
+	return newWebAuthnCredentialRepository(r.Conn())
+}
+
+func (r *Repository) WebAuthnChallenge() domain.WebAuthnChallengeRepository {
+	return newWebAuthnChallengeRepository(r.Conn())
+}
+
+func (r *Repository) Session() domain.SessionRepository {
+	return newSessionRepository(r.Conn())
+}
+
+func (r *Repository) PersonalAccessToken() domain.PersonalAccessTokenRepository {
+	return newPersonalAccessTokenRepository(r.Conn())
+}
+
+func (r *Repository) RefreshToken() domain.RefreshTokenRepository {
+	return newRefreshTokenRepository(r.Conn())
+}
+
+func (r *Repository) AccessTokenRevocation() domain.AccessTokenRevocationRepository {
+	return newAccessTokenRevocationRepository(r.Conn())
+}
+
+func (r *Repository) AuditLog() domain.AuditLogRepository {
+	return newAuditLogRepository(r.Conn())
+}
+
+// LoginAttempt works outside of transactions, so it isn't a part of domain.TxCommitter.
+func (r *Repository) LoginAttempt() domain.LoginAttemptRepository {
+	return newLoginAttemptRepository(r.Conn())
+}
+
+// execAffected reports whether the query has affected any rows.
+func execAffected(ctx context.Context, conn pg.Conn, query string, args ...any) (bool, error) {
+	result, err := conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
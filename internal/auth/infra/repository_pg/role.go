@@ -0,0 +1,62 @@
+package repository_pg
+
+import (
+	"context"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type roleRepository struct {
+	conn pg.Conn
+}
+
+func newRoleRepository(conn pg.Conn) *roleRepository {
+	return &roleRepository{conn: conn}
+}
+
+func (r *roleRepository) GetUserAccess(ctx context.Context, userID int64) (*domain.UserAccess, error) {
+	const rolesQuery = `SELECT role FROM user_role WHERE user_id=$1 ORDER BY role`
+	roles, err := r.queryStrings(ctx, rolesQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	const permissionsQuery = `SELECT DISTINCT rp.permission 
+		FROM role_permission rp JOIN user_role ur ON ur.role=rp.role 
+		WHERE ur.user_id=$1 ORDER BY rp.permission`
+	permissions, err := r.queryStrings(ctx, permissionsQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserAccess{
+		Roles:       roles,
+		Permissions: permissions,
+	}, nil
+}
+
+func (r *roleRepository) AddUserRole(ctx context.Context, userID int64, role string) error {
+	const query = `INSERT INTO user_role (user_id, role) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	_, err := r.conn.ExecContext(ctx, query, userID, role)
+	return err
+}
+
+func (r *roleRepository) queryStrings(ctx context.Context, query string, args ...any) ([]string, error) {
+	rows, err := r.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err = rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, rows.Err()
+}
@@ -0,0 +1,81 @@
+package repository_pg
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type sessionRepository struct {
+	conn pg.Conn
+}
+
+func newSessionRepository(conn pg.Conn) *sessionRepository {
+	return &sessionRepository{conn: conn}
+}
+
+func (r *sessionRepository) Add(ctx context.Context, session *domain.Session) error {
+	const query = `INSERT INTO session (id, user_id, user_agent, ip, created_at, last_used_at, expires_at) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := r.conn.ExecContext(ctx, query,
+		session.ID, session.UserID, session.UserAgent, session.IP,
+		session.CreatedAt, session.LastUsedAt, session.ExpiresAt)
+	return err
+}
+
+func (r *sessionRepository) Get(ctx context.Context, id string) (*domain.Session, error) {
+	const query = `SELECT id, user_id, user_agent, ip, created_at, last_used_at, expires_at, revoked 
+		FROM session WHERE id=$1`
+	session := &domain.Session{}
+	err := r.conn.QueryRowContext(ctx, query, id).
+		Scan(&session.ID, &session.UserID, &session.UserAgent, &session.IP,
+			&session.CreatedAt, &session.LastUsedAt, &session.ExpiresAt, &session.Revoked)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return session, err
+}
+
+func (r *sessionRepository) GetActive(ctx context.Context, userID int64) ([]*domain.Session, error) {
+	const query = `SELECT id, user_id, user_agent, ip, created_at, last_used_at, expires_at, revoked 
+		FROM session WHERE user_id=$1 AND revoked=FALSE AND expires_at > now() ORDER BY last_used_at DESC`
+	rows, err := r.conn.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*domain.Session
+	for rows.Next() {
+		session := &domain.Session{}
+		err = rows.Scan(&session.ID, &session.UserID, &session.UserAgent, &session.IP,
+			&session.CreatedAt, &session.LastUsedAt, &session.ExpiresAt, &session.Revoked)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, rows.Err()
+}
+
+func (r *sessionRepository) Touch(ctx context.Context, id string, usedAt time.Time) error {
+	const query = `UPDATE session SET last_used_at=$2 WHERE id=$1`
+	_, err := r.conn.ExecContext(ctx, query, id, usedAt)
+	return err
+}
+
+func (r *sessionRepository) Revoke(ctx context.Context, id string) error {
+	const query = `UPDATE session SET revoked=TRUE WHERE id=$1`
+	_, err := r.conn.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *sessionRepository) RevokeUser(ctx context.Context, userID int64) error {
+	const query = `UPDATE session SET revoked=TRUE WHERE user_id=$1 AND revoked=FALSE`
+	_, err := r.conn.ExecContext(ctx, query, userID)
+	return err
+}
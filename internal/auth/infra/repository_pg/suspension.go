@@ -0,0 +1,57 @@
+package repository_pg
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type suspensionRepository struct {
+	conn pg.Conn
+}
+
+func newSuspensionRepository(conn pg.Conn) *suspensionRepository {
+	return &suspensionRepository{conn: conn}
+}
+
+func (r *suspensionRepository) Get(ctx context.Context, userID int64) (*domain.Suspension, error) {
+	const query = `SELECT user_id, reason, until, suspended_by, created_at FROM user_suspension WHERE user_id=$1`
+	var (
+		suspension domain.Suspension
+		until      sql.NullTime
+	)
+	err := r.conn.QueryRowContext(ctx, query, userID).
+		Scan(&suspension.UserID, &suspension.Reason, &until, &suspension.SuspendedBy, &suspension.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if until.Valid {
+		suspension.Until = until.Time
+	}
+	return &suspension, nil
+}
+
+// Save replaces the previous suspension of the user, a ban is stored with NULL until.
+func (r *suspensionRepository) Save(ctx context.Context, suspension *domain.Suspension) error {
+	const query = `INSERT INTO user_suspension (user_id, reason, until, suspended_by) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET 
+			reason=EXCLUDED.reason, until=EXCLUDED.until, suspended_by=EXCLUDED.suspended_by, created_at=now()`
+	var until sql.NullTime
+	if !suspension.Until.IsZero() {
+		until = sql.NullTime{Time: suspension.Until, Valid: true}
+	}
+	_, err := r.conn.ExecContext(ctx, query, suspension.UserID, suspension.Reason, until, suspension.SuspendedBy)
+	return err
+}
+
+func (r *suspensionRepository) Remove(ctx context.Context, userID int64) error {
+	const query = `DELETE FROM user_suspension WHERE user_id=$1`
+	_, err := r.conn.ExecContext(ctx, query, userID)
+	return err
+}
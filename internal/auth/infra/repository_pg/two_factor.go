@@ -0,0 +1,91 @@
+package repository_pg
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type twoFactorRepository struct {
+	conn pg.Conn
+}
+
+func newTwoFactorRepository(conn pg.Conn) *twoFactorRepository {
+	return &twoFactorRepository{conn: conn}
+}
+
+func (r *twoFactorRepository) Save(ctx context.Context, twoFactor *domain.TwoFactor) error {
+	const query = `INSERT INTO user_two_factor (user_id, secret, enabled, last_used_step, attempts) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE SET 
+			secret=EXCLUDED.secret, enabled=EXCLUDED.enabled, last_used_step=EXCLUDED.last_used_step, attempts=EXCLUDED.attempts`
+	_, err := r.conn.ExecContext(ctx, query,
+		twoFactor.UserID, twoFactor.Secret, twoFactor.Enabled, twoFactor.LastUsedStep, twoFactor.Attempts)
+	return err
+}
+
+func (r *twoFactorRepository) Get(ctx context.Context, userID int64) (*domain.TwoFactor, error) {
+	const query = `SELECT user_id, secret, enabled, last_used_step, attempts FROM user_two_factor WHERE user_id=$1`
+	twoFactor := &domain.TwoFactor{}
+	err := r.conn.QueryRowContext(ctx, query, userID).
+		Scan(&twoFactor.UserID, &twoFactor.Secret, &twoFactor.Enabled, &twoFactor.LastUsedStep, &twoFactor.Attempts)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return twoFactor, err
+}
+
+func (r *twoFactorRepository) Enable(ctx context.Context, userID int64) error {
+	const query = `UPDATE user_two_factor SET enabled=TRUE WHERE user_id=$1`
+	_, err := r.conn.ExecContext(ctx, query, userID)
+	return err
+}
+
+func (r *twoFactorRepository) UseStep(ctx context.Context, userID int64, step int64) (bool, error) {
+	const query = `UPDATE user_two_factor SET last_used_step=$2 WHERE user_id=$1 AND last_used_step < $2`
+	return execAffected(ctx, r.conn, query, userID, step)
+}
+
+func (r *twoFactorRepository) AddAttempt(ctx context.Context, userID int64) (int, error) {
+	const query = `UPDATE user_two_factor SET attempts=attempts + 1 WHERE user_id=$1 RETURNING attempts`
+	var attempts int
+	err := r.conn.QueryRowContext(ctx, query, userID).Scan(&attempts)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return attempts, err
+}
+
+func (r *twoFactorRepository) ResetAttempts(ctx context.Context, userID int64) error {
+	const query = `UPDATE user_two_factor SET attempts=0 WHERE user_id=$1`
+	_, err := r.conn.ExecContext(ctx, query, userID)
+	return err
+}
+
+func (r *twoFactorRepository) Remove(ctx context.Context, userID int64) error {
+	const query = `DELETE FROM user_two_factor WHERE user_id=$1`
+	_, err := r.conn.ExecContext(ctx, query, userID)
+	return err
+}
+
+func (r *twoFactorRepository) AddRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error {
+	const query = `INSERT INTO user_recovery_code (user_id, code_hash) VALUES ($1, $2)`
+	for _, codeHash := range codeHashes {
+		if _, err := r.conn.ExecContext(ctx, query, userID, codeHash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *twoFactorRepository) TakeRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	const query = `DELETE FROM user_recovery_code WHERE user_id=$1 AND code_hash=$2`
+	return execAffected(ctx, r.conn, query, userID, codeHash)
+}
+
+func (r *twoFactorRepository) RemoveRecoveryCodes(ctx context.Context, userID int64) error {
+	const query = `DELETE FROM user_recovery_code WHERE user_id=$1`
+	_, err := r.conn.ExecContext(ctx, query, userID)
+	return err
+}
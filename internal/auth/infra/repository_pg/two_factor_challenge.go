@@ -0,0 +1,51 @@
+package repository_pg
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type twoFactorChallengeRepository struct {
+	conn pg.Conn
+}
+
+func newTwoFactorChallengeRepository(conn pg.Conn) *twoFactorChallengeRepository {
+	return &twoFactorChallengeRepository{conn: conn}
+}
+
+// Add adds the challenge. Expired challenges are purged on the way.
+func (r *twoFactorChallengeRepository) Add(ctx context.Context, challenge *domain.TwoFactorChallenge) error {
+	const query = `WITH purged AS (DELETE FROM two_factor_challenge WHERE expires_at <= now())
+		INSERT INTO two_factor_challenge (token_hash, user_id, expires_at) VALUES ($1, $2, $3)`
+	_, err := r.conn.ExecContext(ctx, query, challenge.TokenHash, challenge.UserID, challenge.ExpiresAt)
+	return err
+}
+
+func (r *twoFactorChallengeRepository) Get(ctx context.Context, tokenHash string) (*domain.TwoFactorChallenge, error) {
+	const query = `SELECT token_hash, user_id, expires_at, attempts FROM two_factor_challenge WHERE token_hash=$1`
+	challenge := &domain.TwoFactorChallenge{}
+	err := r.conn.QueryRowContext(ctx, query, tokenHash).
+		Scan(&challenge.TokenHash, &challenge.UserID, &challenge.ExpiresAt, &challenge.Attempts)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return challenge, err
+}
+
+func (r *twoFactorChallengeRepository) AddAttempt(ctx context.Context, tokenHash string) (int, error) {
+	const query = `UPDATE two_factor_challenge SET attempts=attempts + 1 WHERE token_hash=$1 RETURNING attempts`
+	var attempts int
+	err := r.conn.QueryRowContext(ctx, query, tokenHash).Scan(&attempts)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return attempts, err
+}
+
+func (r *twoFactorChallengeRepository) Remove(ctx context.Context, tokenHash string) (bool, error) {
+	const query = `DELETE FROM two_factor_challenge WHERE token_hash=$1`
+	return execAffected(ctx, r.conn, query, tokenHash)
+}
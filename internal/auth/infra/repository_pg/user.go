@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository"
 	"github.com/art-es/blog/internal/common/repository/pg"
 )
 
@@ -32,29 +33,31 @@ func (r *userRepository) Activate(ctx context.Context, id int64) (bool, error) {
 }
 
 func (r *userRepository) Get(ctx context.Context, id int64) (*domain.User, error) {
-	const query = `SELECT id, name, password_hash FROM auth WHERE id=$1`
+	const query = `SELECT id, name, email, password_hash, activate FROM auth WHERE id=$1`
 	user := &domain.User{}
 	err := r.conn.QueryRowContext(ctx, query, id).
-		Scan(&user.ID, &user.Name, &user.PasswordHash)
+		Scan(&user.ID, &user.Name, &user.Email, &user.PasswordHash, &user.Active)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	return user, err
 }
 
+// GetByEmail matches the email case-insensitively.
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	const query = `SELECT id, name, password_hash FROM auth WHERE email=$1`
+	const query = `SELECT id, name, email, password_hash, activate FROM auth WHERE lower(email)=lower($1)`
 	user := &domain.User{}
 	err := r.conn.QueryRowContext(ctx, query, email).
-		Scan(&user.ID, &user.Name, &user.Email)
+		Scan(&user.ID, &user.Name, &user.Email, &user.PasswordHash, &user.Active)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	return user, err
 }
 
+// EmailExists matches the email case-insensitively.
 func (r *userRepository) EmailExists(ctx context.Context, email string) (bool, error) {
-	const query = `SELECT EXISTS(SELECT 1 FROM auth WHERE email=$1)`
+	const query = `SELECT EXISTS(SELECT 1 FROM auth WHERE lower(email)=lower($1))`
 	var exists bool
 	err := r.conn.QueryRowContext(ctx, query, email).Scan(&exists)
 	return exists, err
@@ -67,17 +70,52 @@ func (r *userRepository) Exists(ctx context.Context, id int64) (bool, error) {
 	return exists, err
 }
 
+// Find matches the query against the email and the name case-insensitively,
+// the users are sorted from the newest to the oldest.
+func (r *userRepository) Find(ctx context.Context, filter *domain.UserFilter) ([]*domain.User, error) {
+	const query = `SELECT id, name, email, password_hash, activate 
+		FROM auth 
+		WHERE ($1 = '' OR strpos(lower(email), lower($1)) > 0 OR strpos(lower(name), lower($1)) > 0) 
+			AND ($2 = 0 OR id < $2) 
+		ORDER BY id DESC 
+		LIMIT $3`
+	rows, err := r.conn.QueryContext(ctx, query, filter.Query, filter.BeforeID, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		user := &domain.User{}
+		if err = rows.Scan(&user.ID, &user.Name, &user.Email, &user.PasswordHash, &user.Active); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// Save returns repository.ErrUniqueViolation if the email is taken by another user.
 func (r *userRepository) Save(ctx context.Context, user *domain.User) error {
+	var err error
 	if user.ID == 0 {
-		return r.insert(ctx, user)
+		err = r.insert(ctx, user)
+	} else {
+		err = r.update(ctx, user)
+	}
+
+	if pg.IsUniqueViolation(err) {
+		return repository.ErrUniqueViolation
 	}
-	return r.update(ctx, user)
+	return err
 }
 
 func (r *userRepository) insert(ctx context.Context, user *domain.User) error {
-	const query = `INSERT INTO auth (name, email, password_hash) 
-		VALUES ($1, $2, $3) RETURNING id`
-	return r.conn.QueryRowContext(ctx, query, user.Name, user.Email, user.PasswordHash).
+	const query = `INSERT INTO auth (name, email, password_hash, activate) 
+		VALUES ($1, $2, $3, $4) RETURNING id`
+	return r.conn.QueryRowContext(ctx, query, user.Name, user.Email, user.PasswordHash, user.Active).
 		Scan(&user.ID)
 }
 
@@ -86,3 +124,35 @@ func (r *userRepository) update(ctx context.Context, user *domain.User) error {
 	_, err := r.conn.ExecContext(ctx, query, user.ID, user.Name, user.Email, user.PasswordHash)
 	return err
 }
+
+func (r *userRepository) GetProfile(ctx context.Context, userID int64) (*domain.Profile, error) {
+	const query = `SELECT id, COALESCE(handle, ''), display_name, bio, website, avatar_url FROM auth WHERE id=$1`
+	return r.getProfile(ctx, query, userID)
+}
+
+func (r *userRepository) GetProfileByHandle(ctx context.Context, handle string) (*domain.Profile, error) {
+	const query = `SELECT id, handle, display_name, bio, website, avatar_url FROM auth WHERE handle=$1`
+	return r.getProfile(ctx, query, handle)
+}
+
+func (r *userRepository) getProfile(ctx context.Context, query string, args ...any) (*domain.Profile, error) {
+	profile := &domain.Profile{}
+	err := r.conn.QueryRowContext(ctx, query, args...).
+		Scan(&profile.UserID, &profile.Handle, &profile.DisplayName, &profile.Bio, &profile.Website, &profile.AvatarURL)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return profile, err
+}
+
+// SaveProfile stores an empty handle as NULL, so the users without handles don't violate its uniqueness.
+// repository.ErrUniqueViolation is returned if the handle is taken by another user.
+func (r *userRepository) SaveProfile(ctx context.Context, profile *domain.Profile) error {
+	const query = `UPDATE auth SET handle=NULLIF($2, ''), display_name=$3, bio=$4, website=$5, avatar_url=$6 WHERE id=$1`
+	_, err := r.conn.ExecContext(ctx, query,
+		profile.UserID, profile.Handle, profile.DisplayName, profile.Bio, profile.Website, profile.AvatarURL)
+	if pg.IsUniqueViolation(err) {
+		return repository.ErrUniqueViolation
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+package repository_pg
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type webAuthnChallengeRepository struct {
+	conn pg.Conn
+}
+
+func newWebAuthnChallengeRepository(conn pg.Conn) *webAuthnChallengeRepository {
+	return &webAuthnChallengeRepository{conn: conn}
+}
+
+// Add adds the challenge. Expired challenges are purged on the way.
+func (r *webAuthnChallengeRepository) Add(ctx context.Context, challenge *domain.WebAuthnChallenge) error {
+	const query = `WITH purged AS (DELETE FROM webauthn_challenge WHERE expires_at <= now())
+		INSERT INTO webauthn_challenge (challenge_hash, ceremony, user_id, expires_at) VALUES ($1, $2, $3, $4)`
+	var userID sql.NullInt64
+	if challenge.UserID != 0 {
+		userID = sql.NullInt64{Int64: challenge.UserID, Valid: true}
+	}
+	_, err := r.conn.ExecContext(ctx, query, challenge.ChallengeHash, challenge.Ceremony, userID, challenge.ExpiresAt)
+	return err
+}
+
+func (r *webAuthnChallengeRepository) Take(ctx context.Context, challengeHash string) (*domain.WebAuthnChallenge, error) {
+	const query = `DELETE FROM webauthn_challenge WHERE challenge_hash=$1 
+		RETURNING challenge_hash, ceremony, user_id, expires_at`
+	var (
+		challenge domain.WebAuthnChallenge
+		userID    sql.NullInt64
+	)
+	err := r.conn.QueryRowContext(ctx, query, challengeHash).
+		Scan(&challenge.ChallengeHash, &challenge.Ceremony, &userID, &challenge.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	challenge.UserID = userID.Int64
+	return &challenge, err
+}
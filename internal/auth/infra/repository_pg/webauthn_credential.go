@@ -0,0 +1,88 @@
+package repository_pg
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/repository"
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type webAuthnCredentialRepository struct {
+	conn pg.Conn
+}
+
+func newWebAuthnCredentialRepository(conn pg.Conn) *webAuthnCredentialRepository {
+	return &webAuthnCredentialRepository{conn: conn}
+}
+
+// Add returns repository.ErrUniqueViolation if the credential is registered already.
+func (r *webAuthnCredentialRepository) Add(ctx context.Context, credential *domain.WebAuthnCredential) error {
+	const query = `INSERT INTO webauthn_credential (id, user_id, name, public_key, sign_count) 
+		VALUES ($1, $2, $3, $4, $5) RETURNING created_at`
+	err := r.conn.QueryRowContext(ctx, query,
+		credential.ID, credential.UserID, credential.Name, credential.PublicKey, int64(credential.SignCount)).
+		Scan(&credential.CreatedAt)
+	if pg.IsUniqueViolation(err) {
+		return repository.ErrUniqueViolation
+	}
+	return err
+}
+
+func (r *webAuthnCredentialRepository) Get(ctx context.Context, id string) (*domain.WebAuthnCredential, error) {
+	const query = `SELECT id, user_id, name, public_key, sign_count, created_at, last_used_at 
+		FROM webauthn_credential WHERE id=$1`
+	credential, err := scanWebAuthnCredential(r.conn.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return credential, err
+}
+
+func (r *webAuthnCredentialRepository) GetByUser(ctx context.Context, userID int64) ([]*domain.WebAuthnCredential, error) {
+	const query = `SELECT id, user_id, name, public_key, sign_count, created_at, last_used_at 
+		FROM webauthn_credential WHERE user_id=$1 ORDER BY created_at`
+	rows, err := r.conn.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var credentials []*domain.WebAuthnCredential
+	for rows.Next() {
+		credential, err := scanWebAuthnCredential(rows)
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, credential)
+	}
+
+	return credentials, rows.Err()
+}
+
+func (r *webAuthnCredentialRepository) Touch(ctx context.Context, id string, signCount uint32, usedAt time.Time) error {
+	const query = `UPDATE webauthn_credential SET sign_count=$2, last_used_at=$3 WHERE id=$1`
+	_, err := r.conn.ExecContext(ctx, query, id, int64(signCount), usedAt)
+	return err
+}
+
+func scanWebAuthnCredential(row interface{ Scan(dest ...any) error }) (*domain.WebAuthnCredential, error) {
+	var (
+		credential domain.WebAuthnCredential
+		signCount  int64
+		lastUsedAt sql.NullTime
+	)
+
+	err := row.Scan(&credential.ID, &credential.UserID, &credential.Name, &credential.PublicKey,
+		&signCount, &credential.CreatedAt, &lastUsedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	credential.SignCount = uint32(signCount)
+	credential.LastUsedAt = lastUsedAt.Time
+
+	return &credential, nil
+}
@@ -2,7 +2,13 @@ package api
 
 import "github.com/gin-gonic/gin"
 
-const userIDContextKey = "user_id"
+const (
+	userIDContextKey       = "user_id"
+	sessionIDContextKey    = "session_id"
+	rolesContextKey        = "roles"
+	permissionsContextKey  = "permissions"
+	personalAccessTokenKey = "personal_access_token"
+)
 
 func SetUserID(ctx *gin.Context, value int64) {
 	ctx.Set(userIDContextKey, value)
@@ -11,3 +17,46 @@ func SetUserID(ctx *gin.Context, value int64) {
 func GetUserID(ctx *gin.Context) int64 {
 	return ctx.GetInt64(userIDContextKey)
 }
+
+func SetSessionID(ctx *gin.Context, value string) {
+	ctx.Set(sessionIDContextKey, value)
+}
+
+func GetSessionID(ctx *gin.Context) string {
+	return ctx.GetString(sessionIDContextKey)
+}
+
+func SetRoles(ctx *gin.Context, value []string) {
+	ctx.Set(rolesContextKey, value)
+}
+
+func GetRoles(ctx *gin.Context) []string {
+	return ctx.GetStringSlice(rolesContextKey)
+}
+
+func SetPermissions(ctx *gin.Context, value []string) {
+	ctx.Set(permissionsContextKey, value)
+}
+
+func GetPermissions(ctx *gin.Context) []string {
+	return ctx.GetStringSlice(permissionsContextKey)
+}
+
+func SetPersonalAccessToken(ctx *gin.Context, value bool) {
+	ctx.Set(personalAccessTokenKey, value)
+}
+
+// IsPersonalAccessToken reports whether the user is authenticated with a personal access token, not a session.
+func IsPersonalAccessToken(ctx *gin.Context) bool {
+	return ctx.GetBool(personalAccessTokenKey)
+}
+
+// HasPermission reports whether the authenticated user is granted the permission.
+func HasPermission(ctx *gin.Context, permission string) bool {
+	for _, p := range GetPermissions(ctx) {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
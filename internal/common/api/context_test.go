@@ -19,3 +19,21 @@ func TestGetSetUserID(t *testing.T) {
 	api.SetUserID(ctx, notEmpty)
 	assert.Equal(t, notEmpty, api.GetUserID(ctx), "userID is not empty")
 }
+
+func TestGetSetRoles(t *testing.T) {
+	ctx := &gin.Context{}
+	assert.Empty(t, api.GetRoles(ctx), "roles are empty")
+
+	api.SetRoles(ctx, []string{"admin"})
+	assert.Equal(t, []string{"admin"}, api.GetRoles(ctx), "roles are not empty")
+}
+
+func TestHasPermission(t *testing.T) {
+	ctx := &gin.Context{}
+	assert.False(t, api.HasPermission(ctx, "post:write"), "permissions are empty")
+
+	api.SetPermissions(ctx, []string{"post:read", "post:write"})
+	assert.Equal(t, []string{"post:read", "post:write"}, api.GetPermissions(ctx))
+	assert.True(t, api.HasPermission(ctx, "post:write"), "permission is granted")
+	assert.False(t, api.HasPermission(ctx, "user:manage"), "permission is not granted")
+}
@@ -1,7 +1,23 @@
 package api
 
-import "github.com/gin-gonic/gin"
+import (
+	"strings"
 
+	"github.com/gin-gonic/gin"
+)
+
+const bearerPrefix = "bearer "
+
+// AccessTokenHeader reads X-Access-Token, falling back to the standard Authorization header with the Bearer scheme.
 func AccessTokenHeader(ctx *gin.Context) string {
-	return ctx.GetHeader("X-Access-Token")
+	if token := ctx.GetHeader("X-Access-Token"); token != "" {
+		return token
+	}
+
+	authorization := ctx.GetHeader("Authorization")
+	// the scheme is case-insensitive
+	if len(authorization) > len(bearerPrefix) && strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return strings.TrimSpace(authorization[len(bearerPrefix):])
+	}
+	return ""
 }
@@ -0,0 +1,61 @@
+package api_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/common/api"
+)
+
+func TestAccessTokenHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expToken string
+	}{
+		{
+			name:     "X-Access-Token",
+			headers:  map[string]string{"X-Access-Token": "foo"},
+			expToken: "foo",
+		},
+		{
+			name:     "bearer token",
+			headers:  map[string]string{"Authorization": "Bearer foo"},
+			expToken: "foo",
+		},
+		{
+			name:     "bearer scheme in lower case",
+			headers:  map[string]string{"Authorization": "bearer foo"},
+			expToken: "foo",
+		},
+		{
+			name:     "X-Access-Token takes precedence",
+			headers:  map[string]string{"X-Access-Token": "foo", "Authorization": "Bearer bar"},
+			expToken: "foo",
+		},
+		{
+			name:     "other scheme",
+			headers:  map[string]string{"Authorization": "Basic Zm9vOmJhcg=="},
+			expToken: "",
+		},
+		{
+			name:     "no token",
+			headers:  map[string]string{"Authorization": "Bearer "},
+			expToken: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest("GET", "/", nil)
+			for k, v := range tt.headers {
+				ctx.Request.Header.Set(k, v)
+			}
+
+			assert.Equal(t, tt.expToken, api.AccessTokenHeader(ctx))
+		})
+	}
+}
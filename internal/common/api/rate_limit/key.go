@@ -0,0 +1,61 @@
+package rate_limit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/common/api"
+)
+
+// maxKeyBodySize keeps large bodies from being buffered only to find the key.
+const maxKeyBodySize = 1 << 20
+
+// KeyFunc finds the key the requests are limited by, the requests with an empty key are not limited.
+type KeyFunc func(ctx *gin.Context) string
+
+// ByIP limits the requests by the client IP.
+func ByIP(ctx *gin.Context) string {
+	return "ip:" + ctx.ClientIP()
+}
+
+// ByUserID limits the requests by ID of the authenticated user, falling back to the client IP.
+// It must follow the middleware which sets ID of the authenticated user to the context.
+func ByUserID(ctx *gin.Context) string {
+	if userID := api.GetUserID(ctx); userID != 0 {
+		return "user:" + strconv.FormatInt(userID, 10)
+	}
+	return ByIP(ctx)
+}
+
+// ByJSONField limits the requests by the string field of the JSON body, e.g. the email normalized by domain.NormalizeEmail.
+// The values are compared case-insensitively after normalizing, the body is restored for the handler.
+func ByJSONField(field string, normalize func(string) string) KeyFunc {
+	return func(ctx *gin.Context) string {
+		if ctx.Request.Body == nil {
+			return ""
+		}
+
+		body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxKeyBodySize))
+		if err != nil {
+			return ""
+		}
+		ctx.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), ctx.Request.Body))
+
+		var fields map[string]json.RawMessage
+		var value string
+		if json.Unmarshal(body, &fields) != nil || json.Unmarshal(fields[field], &value) != nil {
+			return ""
+		}
+
+		value = strings.ToLower(normalize(strings.TrimSpace(value)))
+		if value == "" {
+			return ""
+		}
+		return field + ":" + value
+	}
+}
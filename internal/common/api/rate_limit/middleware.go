@@ -0,0 +1,72 @@
+package rate_limit
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/log"
+)
+
+type Middleware struct {
+	name   string
+	policy Policy
+	key    KeyFunc
+	store  Store
+	logger log.Logger
+}
+
+// New creates the middleware limiting the requests by the policy per key. The name separates the keys
+// of different limits in the store, the routes sharing the name share the limit.
+func New(name string, policy Policy, key KeyFunc, store Store, logger log.Logger) *Middleware {
+	return &Middleware{
+		name:   name,
+		policy: policy,
+		key:    key,
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (m *Middleware) Handle(ctx *gin.Context) {
+	key := m.key(ctx)
+	if key == "" {
+		ctx.Next()
+		return
+	}
+
+	var result Result
+	err := m.store.Update(ctx, m.name+":"+key, m.policy.TTL(), func(state *State) {
+		result = m.policy.Take(state, time.Now())
+	})
+	if err != nil {
+		// the service must stay available while the store is not
+		m.logger.Error("rate limit store error",
+			log.String("limit", m.name),
+			log.Error(err),
+		)
+		ctx.Next()
+		return
+	}
+
+	ctx.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	ctx.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	ctx.Header("RateLimit-Reset", seconds(result.Reset))
+
+	if !result.Allowed {
+		ctx.Header("Retry-After", seconds(result.RetryAfter))
+		api.TooManyRequestsResponse(ctx)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Next()
+}
+
+// seconds rounds up, so the clients don't come back too early.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
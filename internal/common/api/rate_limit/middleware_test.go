@@ -0,0 +1,215 @@
+package rate_limit_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/auth/domain"
+	"github.com/art-es/blog/internal/common/api"
+	"github.com/art-es/blog/internal/common/api/rate_limit"
+	"github.com/art-es/blog/internal/common/api/rate_limit/mock"
+	mock_log "github.com/art-es/blog/internal/common/log/mock"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const method = http.MethodPost
+	const path = "/"
+
+	var (
+		store      = mock.NewMockStore(ctrl)
+		logger     = mock_log.NewMockLogger(ctrl)
+		dummyError = errors.New("dummy error")
+	)
+
+	tests := []struct {
+		name       string
+		middleware func() gin.HandlerFunc
+		requests   int
+		expCode    int
+		expHeaders map[string]string
+		expBody    string
+	}{
+		{
+			name: "allowed",
+			middleware: func() gin.HandlerFunc {
+				return rate_limit.New("register", rate_limit.NewTokenBucket(2, time.Minute), rate_limit.ByIP, rate_limit.NewMemoryStore(), logger).Handle
+			},
+			requests: 2,
+			expCode:  200,
+			expHeaders: map[string]string{
+				"RateLimit-Limit":     "2",
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "120",
+				"Retry-After":         "",
+			},
+			expBody: `{"message":"OK"}`,
+		},
+		{
+			name: "too many requests",
+			middleware: func() gin.HandlerFunc {
+				return rate_limit.New("register", rate_limit.NewTokenBucket(2, time.Minute), rate_limit.ByIP, rate_limit.NewMemoryStore(), logger).Handle
+			},
+			requests: 3,
+			expCode:  429,
+			expHeaders: map[string]string{
+				"RateLimit-Limit":     "2",
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "120",
+				"Retry-After":         "60",
+			},
+			expBody: `{"message":"Too many requests, please try again later."}`,
+		},
+		{
+			name: "no key",
+			middleware: func() gin.HandlerFunc {
+				return rate_limit.New("password_forgot", rate_limit.NewTokenBucket(1, time.Minute), rate_limit.ByJSONField("email", domain.NormalizeEmail), store, logger).Handle
+			},
+			requests: 2,
+			expCode:  200,
+			expHeaders: map[string]string{
+				"RateLimit-Limit": "",
+				"Retry-After":     "",
+			},
+			expBody: `{"message":"OK"}`,
+		},
+		{
+			name: "store error",
+			middleware: func() gin.HandlerFunc {
+				store.EXPECT().
+					Update(gomock.Any(), gomock.Eq("register:ip:192.0.2.1"), gomock.Eq(time.Minute), gomock.Any()).
+					Return(dummyError)
+
+				logger.EXPECT().
+					Error(gomock.Eq("rate limit store error"), gomock.Any())
+
+				return rate_limit.New("register", rate_limit.NewTokenBucket(1, time.Minute), rate_limit.ByIP, store, logger).Handle
+			},
+			requests: 1,
+			expCode:  200,
+			expHeaders: map[string]string{
+				"RateLimit-Limit": "",
+				"Retry-After":     "",
+			},
+			expBody: `{"message":"OK"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, err := api.NewRouter(nil)
+			assert.NoError(t, err)
+			router.Handle(method, path, tt.middleware(), func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"message": "OK"})
+			})
+
+			var w *httptest.ResponseRecorder
+			for i := 0; i < tt.requests; i++ {
+				r := httptest.NewRequest(method, path, nil)
+				// a new X-Forwarded-For on every request doesn't get around the limit by IP
+				r.Header.Set("X-Forwarded-For", "203.0.113."+strconv.Itoa(i+1))
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, r)
+			}
+
+			assert.Equal(t, tt.expCode, w.Code)
+			for header, value := range tt.expHeaders {
+				assert.Equal(t, value, w.Header().Get(header), header)
+			}
+			assert.JSONEq(t, tt.expBody, w.Body.String())
+		})
+	}
+}
+
+func TestKeyFunc(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     rate_limit.KeyFunc
+		userID  int64
+		body    string
+		expKey  string
+		expBody string
+	}{
+		{
+			name:   "IP",
+			key:    rate_limit.ByIP,
+			expKey: "ip:192.0.2.1",
+		},
+		{
+			name:   "user ID",
+			key:    rate_limit.ByUserID,
+			userID: 1,
+			expKey: "user:1",
+		},
+		{
+			name:   "user ID of unauthenticated request",
+			key:    rate_limit.ByUserID,
+			expKey: "ip:192.0.2.1",
+		},
+		{
+			name:    "JSON field",
+			key:     rate_limit.ByJSONField("email", domain.NormalizeEmail),
+			body:    `{"email":" I.Ivanov@Example.com"}`,
+			expKey:  "email:i.ivanov@example.com",
+			expBody: `{"email":" I.Ivanov@Example.com"}`,
+		},
+		{
+			name:    "internationalized email",
+			key:     rate_limit.ByJSONField("email", domain.NormalizeEmail),
+			body:    `{"email":"ivan@Пример.рф"}`,
+			expKey:  "email:ivan@xn--e1afmkfd.xn--p1ai",
+			expBody: `{"email":"ivan@Пример.рф"}`,
+		},
+		{
+			name:    "missing JSON field",
+			key:     rate_limit.ByJSONField("email", domain.NormalizeEmail),
+			body:    `{"name":"Ivan Ivanov"}`,
+			expKey:  "",
+			expBody: `{"name":"Ivan Ivanov"}`,
+		},
+		{
+			name:    "JSON field of another type",
+			key:     rate_limit.ByJSONField("email", domain.NormalizeEmail),
+			body:    `{"email":1}`,
+			expKey:  "",
+			expBody: `{"email":1}`,
+		},
+		{
+			name:    "malformed JSON",
+			key:     rate_limit.ByJSONField("email", domain.NormalizeEmail),
+			body:    `{"email":`,
+			expKey:  "",
+			expBody: `{"email":`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.body))
+			if tt.userID != 0 {
+				api.SetUserID(ctx, tt.userID)
+			}
+
+			assert.Equal(t, tt.expKey, tt.key(ctx))
+
+			// the body is left for the handler
+			body, err := io.ReadAll(ctx.Request.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expBody, string(body))
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package rate_limit
+
+import (
+	"math"
+	"time"
+)
+
+// Policy decides whether a request is allowed by the state of its key.
+type Policy interface {
+	// Take spends a request of the state at now, the state is left as is for the denied requests.
+	Take(state *State, now time.Time) Result
+	// TTL is how long the untouched state takes to become as good as a new one.
+	TTL() time.Duration
+}
+
+// State is kept by the store per key, the new state is zero.
+type State struct {
+	// Count is the tokens spent by the token bucket or the requests in the current window of the sliding window.
+	Count float64
+	// PrevCount is the requests in the previous window of the sliding window.
+	PrevCount float64
+	// Time is when the token bucket was refilled or when the current window of the sliding window has started.
+	Time time.Time
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time left until the quota is fully restored.
+	Reset time.Duration
+	// RetryAfter is the time left until the denied request can be retried.
+	RetryAfter time.Duration
+}
+
+type tokenBucket struct {
+	capacity int
+	interval time.Duration
+}
+
+// NewTokenBucket allows bursts of the capacity, the spent tokens are refilled one per interval.
+func NewTokenBucket(capacity int, interval time.Duration) Policy {
+	return &tokenBucket{
+		capacity: capacity,
+		interval: interval,
+	}
+}
+
+func (p *tokenBucket) Take(state *State, now time.Time) Result {
+	refilled := float64(now.Sub(state.Time)) / float64(p.interval)
+	state.Count = math.Max(0, state.Count-refilled)
+	state.Time = now
+
+	result := Result{Limit: p.capacity}
+	if state.Count+1 <= float64(p.capacity) {
+		state.Count++
+		result.Allowed = true
+	} else {
+		result.RetryAfter = p.duration(state.Count + 1 - float64(p.capacity))
+	}
+	result.Remaining = int(float64(p.capacity) - state.Count)
+	result.Reset = p.duration(state.Count)
+	return result
+}
+
+func (p *tokenBucket) TTL() time.Duration {
+	return time.Duration(p.capacity) * p.interval
+}
+
+func (p *tokenBucket) duration(tokens float64) time.Duration {
+	return time.Duration(math.Round(tokens * float64(p.interval)))
+}
+
+type slidingWindow struct {
+	limit  int
+	window time.Duration
+}
+
+// NewSlidingWindow allows the limit of requests within any window. The requests of the previous window
+// are counted in proportion to its part overlapping the window ending now.
+func NewSlidingWindow(limit int, window time.Duration) Policy {
+	return &slidingWindow{
+		limit:  limit,
+		window: window,
+	}
+}
+
+func (p *slidingWindow) Take(state *State, now time.Time) Result {
+	start := now.Truncate(p.window)
+	switch {
+	case state.Time.Equal(start):
+	case state.Time.Add(p.window).Equal(start):
+		state.PrevCount, state.Count = state.Count, 0
+		state.Time = start
+	default:
+		state.PrevCount, state.Count = 0, 0
+		state.Time = start
+	}
+
+	elapsed := now.Sub(start)
+	prevWeight := 1 - float64(elapsed)/float64(p.window)
+	count := state.PrevCount*prevWeight + state.Count
+
+	result := Result{
+		Limit: p.limit,
+		Reset: p.window - elapsed,
+	}
+	if count+1 <= float64(p.limit) {
+		state.Count++
+		count++
+		result.Allowed = true
+	} else {
+		result.RetryAfter = p.retryAfter(state, elapsed)
+	}
+	result.Remaining = int(math.Max(0, float64(p.limit)-count))
+	return result
+}
+
+func (p *slidingWindow) TTL() time.Duration {
+	return 2 * p.window
+}
+
+// retryAfter finds when the previous requests fade enough to leave room for one more.
+func (p *slidingWindow) retryAfter(state *State, elapsed time.Duration) time.Duration {
+	room := float64(p.limit) - 1 - state.Count
+	if room >= 0 {
+		// the previous window requests have to fade until they fit the room
+		return p.fraction(1-room/state.PrevCount) - elapsed
+	}
+
+	// the current window requests have to become the previous ones and fade
+	return p.window - elapsed + p.fraction(1-float64(p.limit-1)/state.Count)
+}
+
+func (p *slidingWindow) fraction(f float64) time.Duration {
+	return time.Duration(math.Round(f * float64(p.window)))
+}
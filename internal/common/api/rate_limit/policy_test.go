@@ -0,0 +1,69 @@
+package rate_limit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/common/api/rate_limit"
+)
+
+func TestTokenBucket(t *testing.T) {
+	var (
+		policy = rate_limit.NewTokenBucket(2, 10*time.Second)
+		state  = &rate_limit.State{}
+		now    = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	assert.Equal(t, 20*time.Second, policy.TTL())
+
+	result := policy.Take(state, now)
+	assert.Equal(t, rate_limit.Result{Allowed: true, Limit: 2, Remaining: 1, Reset: 10 * time.Second}, result)
+
+	result = policy.Take(state, now)
+	assert.Equal(t, rate_limit.Result{Allowed: true, Limit: 2, Remaining: 0, Reset: 20 * time.Second}, result)
+
+	// the bucket is empty
+	result = policy.Take(state, now.Add(4*time.Second))
+	assert.Equal(t, rate_limit.Result{Limit: 2, Remaining: 0, Reset: 16 * time.Second, RetryAfter: 6 * time.Second}, result)
+
+	// a token is refilled
+	result = policy.Take(state, now.Add(10*time.Second))
+	assert.Equal(t, rate_limit.Result{Allowed: true, Limit: 2, Remaining: 0, Reset: 20 * time.Second}, result)
+
+	// the bucket is full again
+	result = policy.Take(state, now.Add(time.Hour))
+	assert.Equal(t, rate_limit.Result{Allowed: true, Limit: 2, Remaining: 1, Reset: 10 * time.Second}, result)
+}
+
+func TestSlidingWindow(t *testing.T) {
+	var (
+		policy = rate_limit.NewSlidingWindow(2, time.Minute)
+		state  = &rate_limit.State{}
+		now    = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	assert.Equal(t, 2*time.Minute, policy.TTL())
+
+	result := policy.Take(state, now.Add(30*time.Second))
+	assert.Equal(t, rate_limit.Result{Allowed: true, Limit: 2, Remaining: 1, Reset: 30 * time.Second}, result)
+
+	result = policy.Take(state, now.Add(45*time.Second))
+	assert.Equal(t, rate_limit.Result{Allowed: true, Limit: 2, Remaining: 0, Reset: 15 * time.Second}, result)
+
+	// the current window is full, a request fits once the previous one counts for a half
+	result = policy.Take(state, now.Add(50*time.Second))
+	assert.Equal(t, rate_limit.Result{Limit: 2, Remaining: 0, Reset: 10 * time.Second, RetryAfter: 40 * time.Second}, result)
+
+	// the previous window counts for two thirds
+	result = policy.Take(state, now.Add(80*time.Second))
+	assert.Equal(t, rate_limit.Result{Limit: 2, Remaining: 0, Reset: 40 * time.Second, RetryAfter: 10 * time.Second}, result)
+
+	result = policy.Take(state, now.Add(90*time.Second))
+	assert.Equal(t, rate_limit.Result{Allowed: true, Limit: 2, Remaining: 0, Reset: 30 * time.Second}, result)
+
+	// the requests of the windows before the previous one are forgotten
+	result = policy.Take(state, now.Add(time.Hour))
+	assert.Equal(t, rate_limit.Result{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Minute}, result)
+}
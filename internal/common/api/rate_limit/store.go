@@ -0,0 +1,70 @@
+//go:generate mockgen -source=store.go -destination=mock/store.go -package=mock
+package rate_limit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// minPurgeSize keeps small maps from being purged on every update.
+const minPurgeSize = 1024
+
+// Store keeps the states of the keys. Update must apply fn atomically and forget the state after ttl.
+type Store interface {
+	Update(ctx context.Context, key string, ttl time.Duration, fn func(state *State)) error
+}
+
+type memoryEntry struct {
+	state     State
+	expiresAt time.Time
+}
+
+// MemoryStore keeps the states in the process memory,
+// so they are neither shared between instances nor survive restarts.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	purgeSize int
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries:   make(map[string]memoryEntry),
+		purgeSize: minPurgeSize,
+	}
+}
+
+// Update applies fn to the state of the key. Expired entries are purged whenever the map doubles in size.
+func (s *MemoryStore) Update(_ context.Context, key string, ttl time.Duration, fn func(state *State)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	entry, ok := s.entries[key]
+	if !ok || !now.Before(entry.expiresAt) {
+		entry = memoryEntry{}
+	}
+	fn(&entry.state)
+	entry.expiresAt = now.Add(ttl)
+	s.entries[key] = entry
+
+	if len(s.entries) >= s.purgeSize {
+		s.purge(now)
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) purge(now time.Time) {
+	for key, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+
+	s.purgeSize = 2 * len(s.entries)
+	if s.purgeSize < minPurgeSize {
+		s.purgeSize = minPurgeSize
+	}
+}
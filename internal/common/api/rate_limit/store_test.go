@@ -0,0 +1,44 @@
+package rate_limit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/common/api/rate_limit"
+)
+
+func TestMemoryStore(t *testing.T) {
+	var (
+		ctx = context.Background()
+		key = "register:ip:192.0.2.1"
+	)
+
+	s := rate_limit.NewMemoryStore()
+	increment := func(state *rate_limit.State) {
+		state.Count++
+	}
+
+	var count float64
+	assert.NoError(t, s.Update(ctx, key, time.Hour, increment))
+	assert.NoError(t, s.Update(ctx, key, time.Hour, func(state *rate_limit.State) {
+		increment(state)
+		count = state.Count
+	}))
+	assert.Equal(t, float64(2), count)
+
+	// the keys don't share the state
+	assert.NoError(t, s.Update(ctx, "register:ip:192.0.2.2", time.Hour, func(state *rate_limit.State) {
+		count = state.Count
+	}))
+	assert.Equal(t, float64(0), count)
+
+	// the state is forgotten after ttl
+	assert.NoError(t, s.Update(ctx, key, 0, increment))
+	assert.NoError(t, s.Update(ctx, key, time.Hour, func(state *rate_limit.State) {
+		count = state.Count
+	}))
+	assert.Equal(t, float64(0), count)
+}
@@ -36,6 +36,11 @@ func UnauthorizedResponse(ctx *gin.Context) {
 	ctx.JSON(http.StatusUnauthorized, &ErrorResponse{Message: message})
 }
 
+func TooManyRequestsResponse(ctx *gin.Context) {
+	const message = "Too many requests, please try again later."
+	ctx.JSON(http.StatusTooManyRequests, &ErrorResponse{Message: message})
+}
+
 func InternalServerErrorResponse(ctx *gin.Context) {
 	const message = "Something went wrong, please try again later."
 	ctx.JSON(http.StatusInternalServerError, &ErrorResponse{Message: message})
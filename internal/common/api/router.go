@@ -2,6 +2,17 @@ package api
 
 import "github.com/gin-gonic/gin"
 
+// NewRouter creates the router which takes the client IP from the X-Forwarded-For header
+// only if the request comes from one of the trusted proxies, none are trusted by default.
+// Otherwise, any client could spoof its IP and get around the limits by IP.
+func NewRouter(trustedProxies []string) (*gin.Engine, error) {
+	r := gin.Default()
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
 type EndpointHandler interface {
 	Method() string
 	Endpoint() string
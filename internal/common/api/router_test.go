@@ -0,0 +1,65 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/common/api"
+)
+
+func TestNewRouter_clientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		expIP          string
+	}{
+		{
+			name:       "no trusted proxies",
+			remoteAddr: "192.0.2.1:1234",
+			expIP:      "192.0.2.1",
+		},
+		{
+			name:           "untrusted proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "192.0.2.1:1234",
+			expIP:          "192.0.2.1",
+		},
+		{
+			name:           "trusted proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.1:1234",
+			expIP:          "203.0.113.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, err := api.NewRouter(tt.trustedProxies)
+			assert.NoError(t, err)
+
+			var ip string
+			router.GET("/", func(ctx *gin.Context) {
+				ip = ctx.ClientIP()
+			})
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			r.Header.Set("X-Forwarded-For", "203.0.113.7")
+			router.ServeHTTP(httptest.NewRecorder(), r)
+
+			assert.Equal(t, tt.expIP, ip)
+		})
+	}
+}
+
+func TestNewRouter_invalidProxy(t *testing.T) {
+	_, err := api.NewRouter([]string{"not an IP"})
+	assert.Error(t, err)
+}
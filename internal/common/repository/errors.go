@@ -0,0 +1,7 @@
+package repository
+
+import "errors"
+
+// ErrUniqueViolation is returned by the repositories,
+// when the saved entity conflicts with another one by a unique field.
+var ErrUniqueViolation = errors.New("unique violation")
@@ -0,0 +1,16 @@
+package pg
+
+import "errors"
+
+const uniqueViolationCode = "23505"
+
+// sqlStateError is implemented by the errors of both lib/pq and pgx, so neither has to be imported.
+type sqlStateError interface {
+	SQLState() string
+}
+
+// IsUniqueViolation reports whether the query is failed because of a unique constraint.
+func IsUniqueViolation(err error) bool {
+	var stateErr sqlStateError
+	return errors.As(err, &stateErr) && stateErr.SQLState() == uniqueViolationCode
+}
@@ -0,0 +1,37 @@
+package pg_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/art-es/blog/internal/common/repository/pg"
+)
+
+type dummyDriverError struct {
+	code string
+}
+
+func (e *dummyDriverError) Error() string    { return "dummy driver error" }
+func (e *dummyDriverError) SQLState() string { return e.code }
+
+func TestIsUniqueViolation(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		expRes bool
+	}{
+		{name: "unique violation", err: &dummyDriverError{code: "23505"}, expRes: true},
+		{name: "wrapped unique violation", err: fmt.Errorf("wrapped: %w", &dummyDriverError{code: "23505"}), expRes: true},
+		{name: "foreign key violation", err: &dummyDriverError{code: "23503"}, expRes: false},
+		{name: "not a driver error", err: errors.New("dummy error"), expRes: false},
+		{name: "no error", err: nil, expRes: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expRes, pg.IsUniqueViolation(tt.err))
+		})
+	}
+}
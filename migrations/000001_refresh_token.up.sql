@@ -0,0 +1,13 @@
+CREATE TABLE refresh_token (
+    id                BIGSERIAL PRIMARY KEY,
+    token_hash        TEXT        NOT NULL UNIQUE,
+    family_id         UUID        NOT NULL,
+    user_id           BIGINT      NOT NULL REFERENCES auth (id) ON DELETE CASCADE,
+    expires_at        TIMESTAMPTZ NOT NULL,
+    family_expires_at TIMESTAMPTZ NOT NULL,
+    used              BOOLEAN     NOT NULL DEFAULT FALSE,
+    revoked           BOOLEAN     NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX refresh_token_family_id_idx ON refresh_token (family_id);
+CREATE INDEX refresh_token_user_id_idx ON refresh_token (user_id);
@@ -0,0 +1,2 @@
+DROP TABLE revoked_user_access_token;
+DROP TABLE revoked_access_token;
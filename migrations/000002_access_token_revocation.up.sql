@@ -0,0 +1,14 @@
+CREATE TABLE revoked_access_token (
+    token_id   UUID PRIMARY KEY,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX revoked_access_token_expires_at_idx ON revoked_access_token (expires_at);
+
+CREATE TABLE revoked_user_access_token (
+    user_id        BIGINT PRIMARY KEY REFERENCES auth (id) ON DELETE CASCADE,
+    revoked_before TIMESTAMPTZ NOT NULL,
+    expires_at     TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX revoked_user_access_token_expires_at_idx ON revoked_user_access_token (expires_at);
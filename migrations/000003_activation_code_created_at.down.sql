@@ -0,0 +1,3 @@
+DROP INDEX activation_code_user_id_created_at_idx;
+
+ALTER TABLE activation_code DROP COLUMN created_at;
@@ -0,0 +1,3 @@
+ALTER TABLE activation_code ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX activation_code_user_id_created_at_idx ON activation_code (user_id, created_at);
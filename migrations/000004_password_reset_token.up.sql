@@ -0,0 +1,7 @@
+CREATE TABLE password_reset_token (
+    token_hash TEXT PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES auth (id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX password_reset_token_user_id_idx ON password_reset_token (user_id);
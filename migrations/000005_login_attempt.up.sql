@@ -0,0 +1,7 @@
+CREATE TABLE login_attempt (
+    key            VARCHAR(300) PRIMARY KEY,
+    failures       INT         NOT NULL,
+    last_failed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX login_attempt_last_failed_at_idx ON login_attempt (last_failed_at);
@@ -0,0 +1,3 @@
+DROP TABLE two_factor_challenge;
+DROP TABLE user_recovery_code;
+DROP TABLE user_two_factor;
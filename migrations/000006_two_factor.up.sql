@@ -0,0 +1,21 @@
+CREATE TABLE user_two_factor (
+    user_id        BIGINT PRIMARY KEY REFERENCES auth (id) ON DELETE CASCADE,
+    secret         TEXT    NOT NULL,
+    enabled        BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_step BIGINT  NOT NULL DEFAULT 0
+);
+
+CREATE TABLE user_recovery_code (
+    user_id   BIGINT NOT NULL REFERENCES user_two_factor (user_id) ON DELETE CASCADE,
+    code_hash TEXT   NOT NULL,
+    PRIMARY KEY (user_id, code_hash)
+);
+
+CREATE TABLE two_factor_challenge (
+    token_hash TEXT PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES auth (id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    attempts   INT         NOT NULL DEFAULT 0
+);
+
+CREATE INDEX two_factor_challenge_expires_at_idx ON two_factor_challenge (expires_at);
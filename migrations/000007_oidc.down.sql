@@ -0,0 +1,2 @@
+DROP TABLE oidc_state;
+DROP TABLE user_external_identity;
@@ -0,0 +1,18 @@
+CREATE TABLE user_external_identity (
+    provider TEXT   NOT NULL,
+    subject  TEXT   NOT NULL,
+    user_id  BIGINT NOT NULL REFERENCES auth (id) ON DELETE CASCADE,
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX user_external_identity_user_id_idx ON user_external_identity (user_id);
+
+CREATE TABLE oidc_state (
+    state_hash    TEXT PRIMARY KEY,
+    provider      TEXT        NOT NULL,
+    code_verifier TEXT        NOT NULL,
+    nonce         TEXT        NOT NULL,
+    expires_at    TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX oidc_state_expires_at_idx ON oidc_state (expires_at);
@@ -0,0 +1,19 @@
+CREATE TABLE session (
+    id           UUID PRIMARY KEY,
+    user_id      BIGINT      NOT NULL REFERENCES auth (id) ON DELETE CASCADE,
+    user_agent   TEXT        NOT NULL,
+    ip           TEXT        NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    last_used_at TIMESTAMPTZ NOT NULL,
+    expires_at   TIMESTAMPTZ NOT NULL,
+    revoked      BOOLEAN     NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX session_user_id_idx ON session (user_id);
+
+-- token families issued before sessions keep working as sessions of an unknown device
+INSERT INTO session (id, user_id, user_agent, ip, created_at, last_used_at, expires_at)
+SELECT family_id, user_id, '', '', now(), now(), MAX(family_expires_at)
+FROM refresh_token
+WHERE revoked = FALSE
+GROUP BY family_id, user_id;
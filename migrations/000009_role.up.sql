@@ -0,0 +1,28 @@
+CREATE TABLE role_permission (
+    role       TEXT NOT NULL,
+    permission TEXT NOT NULL,
+    PRIMARY KEY (role, permission)
+);
+
+INSERT INTO role_permission (role, permission)
+VALUES ('reader', 'post:read'),
+       ('reader', 'comment:write'),
+       ('author', 'post:read'),
+       ('author', 'post:write'),
+       ('author', 'comment:write'),
+       ('admin', 'post:read'),
+       ('admin', 'post:write'),
+       ('admin', 'post:moderate'),
+       ('admin', 'comment:write'),
+       ('admin', 'user:manage');
+
+CREATE TABLE user_role (
+    user_id BIGINT NOT NULL REFERENCES auth (id) ON DELETE CASCADE,
+    role    TEXT   NOT NULL,
+    PRIMARY KEY (user_id, role)
+);
+
+-- the existing users get the default role
+INSERT INTO user_role (user_id, role)
+SELECT id, 'reader'
+FROM auth;
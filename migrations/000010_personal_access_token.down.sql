@@ -0,0 +1 @@
+DROP TABLE personal_access_token;
@@ -0,0 +1,12 @@
+CREATE TABLE personal_access_token (
+    id         UUID PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES auth (id) ON DELETE CASCADE,
+    name       TEXT        NOT NULL,
+    token_hash TEXT        NOT NULL UNIQUE,
+    scopes     TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ,
+    revoked    BOOLEAN     NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX personal_access_token_user_id_idx ON personal_access_token (user_id);
@@ -0,0 +1,6 @@
+ALTER TABLE auth
+    DROP COLUMN handle,
+    DROP COLUMN display_name,
+    DROP COLUMN bio,
+    DROP COLUMN website,
+    DROP COLUMN avatar_url;
@@ -0,0 +1,6 @@
+ALTER TABLE auth
+    ADD COLUMN handle       TEXT UNIQUE,
+    ADD COLUMN display_name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN bio          TEXT NOT NULL DEFAULT '',
+    ADD COLUMN website      TEXT NOT NULL DEFAULT '',
+    ADD COLUMN avatar_url   TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+DELETE FROM role_permission WHERE permission = 'audit:read';
+
+DROP TABLE audit_event;
@@ -0,0 +1,21 @@
+-- actor_id has no foreign key, so the events outlive the users
+CREATE TABLE audit_event (
+    id         BIGSERIAL PRIMARY KEY,
+    type       TEXT        NOT NULL,
+    actor_id   BIGINT,
+    ip         TEXT        NOT NULL,
+    user_agent TEXT        NOT NULL,
+    outcome    TEXT        NOT NULL,
+    details    TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX audit_event_actor_id_idx ON audit_event (actor_id);
+CREATE INDEX audit_event_created_at_idx ON audit_event (created_at);
+
+-- the log is append-only
+CREATE RULE audit_event_no_update AS ON UPDATE TO audit_event DO INSTEAD NOTHING;
+CREATE RULE audit_event_no_delete AS ON DELETE TO audit_event DO INSTEAD NOTHING;
+
+INSERT INTO role_permission (role, permission)
+VALUES ('admin', 'audit:read');
@@ -0,0 +1 @@
+DROP INDEX auth_email_lower_idx;
@@ -0,0 +1,7 @@
+-- the emails are normalized by the service from now on, the stored ones get the domains lower-cased,
+-- the accounts differing only in the case of the email have to be merged manually before the migration
+UPDATE auth
+SET email = trim(substring(email FROM '^(.*)@')) || '@' || lower(trim(substring(email FROM '@([^@]*)$')))
+WHERE email LIKE '%@%';
+
+CREATE UNIQUE INDEX auth_email_lower_idx ON auth (lower(email));
@@ -0,0 +1,8 @@
+CREATE TABLE magic_link_token (
+    token_hash TEXT PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES auth (id) ON DELETE CASCADE,
+    email      TEXT        NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX magic_link_token_user_id_idx ON magic_link_token (user_id);
@@ -0,0 +1,12 @@
+CREATE TABLE email_change (
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         BIGINT      NOT NULL REFERENCES auth (id) ON DELETE CASCADE,
+    old_email       TEXT        NOT NULL,
+    new_email       TEXT        NOT NULL,
+    code_hash       TEXT        NOT NULL,
+    expires_at      TIMESTAMPTZ NOT NULL,
+    undo_token_hash TEXT UNIQUE,
+    undo_expires_at TIMESTAMPTZ
+);
+
+CREATE INDEX email_change_user_id_idx ON email_change (user_id);
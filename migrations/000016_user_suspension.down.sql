@@ -0,0 +1,5 @@
+DROP INDEX audit_event_target_id_idx;
+
+ALTER TABLE audit_event DROP COLUMN target_id;
+
+DROP TABLE user_suspension;
@@ -0,0 +1,14 @@
+-- suspended_by has no foreign key, so the suspensions outlive the admins;
+-- until is NULL for the bans
+CREATE TABLE user_suspension (
+    user_id      BIGINT PRIMARY KEY REFERENCES auth (id) ON DELETE CASCADE,
+    reason       TEXT        NOT NULL,
+    until        TIMESTAMPTZ,
+    suspended_by BIGINT      NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- target_id is the user affected by the event of another actor
+ALTER TABLE audit_event ADD COLUMN target_id BIGINT;
+
+CREATE INDEX audit_event_target_id_idx ON audit_event (target_id);
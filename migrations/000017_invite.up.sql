@@ -0,0 +1,11 @@
+-- created_by has no foreign key, so the invites outlive the admins
+CREATE TABLE invite (
+    id              BIGSERIAL PRIMARY KEY,
+    code_hash       TEXT UNIQUE NOT NULL,
+    max_uses        INT         NOT NULL,
+    uses            INT         NOT NULL DEFAULT 0,
+    skip_activation BOOLEAN     NOT NULL,
+    expires_at      TIMESTAMPTZ NOT NULL,
+    created_by      BIGINT      NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,2 @@
+DROP TABLE webauthn_challenge;
+DROP TABLE webauthn_credential;
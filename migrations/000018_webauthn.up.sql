@@ -0,0 +1,22 @@
+-- id is the base64url encoded credential ID chosen by the authenticator
+CREATE TABLE webauthn_credential (
+    id           TEXT PRIMARY KEY,
+    user_id      BIGINT      NOT NULL REFERENCES auth (id) ON DELETE CASCADE,
+    name         TEXT        NOT NULL,
+    public_key   BYTEA       NOT NULL,
+    sign_count   BIGINT      NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at TIMESTAMPTZ
+);
+
+CREATE INDEX webauthn_credential_user_id_idx ON webauthn_credential (user_id);
+
+-- user_id is set only for the registration, the login challenges are not bound to a user
+CREATE TABLE webauthn_challenge (
+    challenge_hash TEXT PRIMARY KEY,
+    ceremony       TEXT        NOT NULL,
+    user_id        BIGINT REFERENCES auth (id) ON DELETE CASCADE,
+    expires_at     TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX webauthn_challenge_expires_at_idx ON webauthn_challenge (expires_at);
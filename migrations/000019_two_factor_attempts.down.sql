@@ -0,0 +1 @@
+ALTER TABLE user_two_factor DROP COLUMN attempts;
@@ -0,0 +1 @@
+ALTER TABLE user_two_factor ADD COLUMN attempts INT NOT NULL DEFAULT 0;